@@ -0,0 +1,119 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	domainAPIKey "github.com/aldinokemal/go-whatsapp-web-multidevice/domains/apikey"
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+)
+
+var apiKeyCreateRequest domainAPIKey.CreateKeyRequest
+var apiKeyCreateScopes []string
+
+var apiKeyCmd = &cobra.Command{
+	Use:   "apikey",
+	Short: "Manage REST API keys",
+	Long:  `Create, list and revoke the scoped API keys accepted by the REST server through the X-Api-Key or Authorization: Bearer header.`,
+}
+
+var apiKeyCreateCmd = &cobra.Command{
+	Use:   "create",
+	Short: "Create a new API key",
+	Run:   apiKeyCreate,
+}
+
+var apiKeyListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List API keys",
+	Run:   apiKeyList,
+}
+
+var apiKeyRevokeCmd = &cobra.Command{
+	Use:   "revoke <id>",
+	Short: "Revoke an API key",
+	Args:  cobra.ExactArgs(1),
+	Run:   apiKeyRevoke,
+}
+
+func init() {
+	scopes := make([]string, 0, len(domainAPIKey.Scopes))
+	for _, scope := range domainAPIKey.Scopes {
+		scopes = append(scopes, string(scope))
+	}
+
+	apiKeyCreateCmd.Flags().StringVar(&apiKeyCreateRequest.Name, "name", "", `name of the key --name <string> | example: --name="crm"`)
+	apiKeyCreateCmd.Flags().StringSliceVar(&apiKeyCreateScopes, "scope", nil,
+		fmt.Sprintf(`scopes granted to the key (%s) --scope <string> | example: --scope=send,read-chats`, strings.Join(scopes, ", ")))
+	apiKeyCreateCmd.Flags().StringSliceVar(&apiKeyCreateRequest.AllowedRecipients, "allowed-recipient", nil,
+		`glob patterns of recipients the key may send to --allowed-recipient <string> | example: --allowed-recipient="62812*"`)
+	apiKeyCreateCmd.Flags().IntVar(&apiKeyCreateRequest.RateLimit, "rate-limit", 0,
+		`maximum requests per minute, 0 for unlimited --rate-limit <number> | example: --rate-limit=60`)
+	apiKeyCreateCmd.Flags().StringVar(&apiKeyCreateRequest.ExpiresAt, "expires-at", "",
+		`RFC3339 expiry of the key --expires-at <string> | example: --expires-at="2026-12-31T23:59:59Z"`)
+
+	apiKeyCmd.AddCommand(apiKeyCreateCmd, apiKeyListCmd, apiKeyRevokeCmd)
+	rootCmd.AddCommand(apiKeyCmd)
+}
+
+func apiKeyCreate(_ *cobra.Command, _ []string) {
+	for _, scope := range apiKeyCreateScopes {
+		apiKeyCreateRequest.Scopes = append(apiKeyCreateRequest.Scopes, domainAPIKey.Scope(scope))
+	}
+
+	response, err := apiKeyUsecase.CreateKey(context.Background(), apiKeyCreateRequest)
+	if err != nil {
+		logrus.Fatalf("Failed to create API key: %v", err)
+	}
+
+	fmt.Printf("ID:     %s\n", response.ID)
+	fmt.Printf("Name:   %s\n", response.Name)
+	fmt.Printf("Scopes: %s\n", joinScopes(response.Scopes))
+	fmt.Printf("Secret: %s\n", response.Secret)
+	fmt.Println("Store the secret now, it will not be shown again.")
+}
+
+func apiKeyList(_ *cobra.Command, _ []string) {
+	keys, err := apiKeyUsecase.ListKeys(context.Background())
+	if err != nil {
+		logrus.Fatalf("Failed to list API keys: %v", err)
+	}
+
+	writer := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(writer, "ID\tNAME\tPREFIX\tSCOPES\tRATE LIMIT\tEXPIRES\tSTATUS")
+	for _, key := range keys {
+		expires := "-"
+		if key.ExpiresAt != nil {
+			expires = key.ExpiresAt.Format(time.RFC3339)
+		}
+		status := "active"
+		if key.RevokedAt != nil {
+			status = "revoked"
+		} else if key.ExpiresAt != nil && key.ExpiresAt.Before(time.Now()) {
+			status = "expired"
+		}
+		fmt.Fprintf(writer, "%s\t%s\t%s\t%s\t%d\t%s\t%s\n",
+			key.ID, key.Name, key.Prefix, joinScopes(key.Scopes), key.RateLimit, expires, status)
+	}
+	writer.Flush()
+}
+
+func apiKeyRevoke(_ *cobra.Command, args []string) {
+	if err := apiKeyUsecase.RevokeKey(context.Background(), domainAPIKey.RevokeKeyRequest{ID: args[0]}); err != nil {
+		logrus.Fatalf("Failed to revoke API key: %v", err)
+	}
+	fmt.Printf("API key %s revoked\n", args[0])
+}
+
+func joinScopes(scopes []domainAPIKey.Scope) string {
+	values := make([]string, 0, len(scopes))
+	for _, scope := range scopes {
+		values = append(values, string(scope))
+	}
+	return strings.Join(values, ",")
+}
@@ -1,13 +1,21 @@
 package cmd
 
 import (
+	"context"
+	"errors"
 	"fmt"
+	"net/http"
+	"time"
 
 	"github.com/sirupsen/logrus"
 
 	"github.com/aldinokemal/go-whatsapp-web-multidevice/config"
+	domainAudit "github.com/aldinokemal/go-whatsapp-web-multidevice/domains/audit"
+	"github.com/aldinokemal/go-whatsapp-web-multidevice/infrastructure/whatsapp"
+	"github.com/aldinokemal/go-whatsapp-web-multidevice/ui/command"
 	"github.com/aldinokemal/go-whatsapp-web-multidevice/ui/mcp"
 	"github.com/aldinokemal/go-whatsapp-web-multidevice/ui/rest/helpers"
+	mcpgo "github.com/mark3labs/mcp-go/mcp"
 	"github.com/mark3labs/mcp-go/server"
 	"github.com/spf13/cobra"
 )
@@ -26,11 +34,19 @@ func init() {
 	mcpCmd.Flags().StringVar(&config.McpHost, "host", "localhost", "Host for the SSE MCP server")
 }
 
-func mcpServer(_ *cobra.Command, _ []string) {
+func mcpServer(cmd *cobra.Command, _ []string) {
 	// Set auto reconnect to whatsapp server after booting
 	go helpers.SetAutoConnectAfterBooting(appUsecase)
-	// Set auto reconnect checking
-	go helpers.SetAutoReconnectChecking(whatsappCli)
+	// Supervise the connection of the current client, reconnecting and alerting when it is lost
+	go whatsapp.RunConnectionSupervisor(cmd.Context())
+	// Bulk jobs do not survive a restart, finish the ones the previous run left behind
+	if err := groupUsecase.FailInterruptedBulkJobs(); err != nil {
+		logrus.Errorf("Failed to finish interrupted bulk jobs: %v", err)
+	}
+	// Send the commands published to the command topic of the event broker
+	if commandConsumer != nil {
+		go command.Consume(cmd.Context(), commandConsumer, command.InitCommandHandler(sendUsecase, messageUsecase))
+	}
 
 	// Create MCP server with capabilities
 	mcpServer := server.NewMCPServer(
@@ -38,6 +54,7 @@ func mcpServer(_ *cobra.Command, _ []string) {
 		config.AppVersion,
 		server.WithToolCapabilities(true),
 		server.WithResourceCapabilities(true, true),
+		server.WithToolHandlerMiddleware(auditOriginMiddleware),
 	)
 
 	// Add all WhatsApp tools
@@ -47,12 +64,30 @@ func mcpServer(_ *cobra.Command, _ []string) {
 	queryHandler := mcp.InitMcpQuery(chatUsecase, userUsecase, messageUsecase)
 	queryHandler.AddQueryTools(mcpServer)
 
+	chatHandler := mcp.InitMcpChat(chatUsecase)
+	chatHandler.AddChatTools(mcpServer)
+
+	labelHandler := mcp.InitMcpLabel(labelUsecase)
+	labelHandler.AddLabelTools(mcpServer)
+
+	callHandler := mcp.InitMcpCall(callUsecase)
+	callHandler.AddCallTools(mcpServer)
+
+	userHandler := mcp.InitMcpUser(userUsecase)
+	userHandler.AddUserTools(mcpServer)
+
 	appHandler := mcp.InitMcpApp(appUsecase)
 	appHandler.AddAppTools(mcpServer)
 
 	groupHandler := mcp.InitMcpGroup(groupUsecase)
 	groupHandler.AddGroupTools(mcpServer)
 
+	statusHandler := mcp.InitMcpStatus(statusUsecase)
+	statusHandler.AddStatusTools(mcpServer)
+
+	newsletterHandler := mcp.InitMcpNewsletter(newsletterUsecase)
+	newsletterHandler.AddNewsletterTools(mcpServer)
+
 	// Create SSE server
 	sseServer := server.NewSSEServer(
 		mcpServer,
@@ -66,7 +101,27 @@ func mcpServer(_ *cobra.Command, _ []string) {
 	logrus.Printf("SSE endpoint: http://%s:%s/sse", config.McpHost, config.McpPort)
 	logrus.Printf("Message endpoint: http://%s:%s/message", config.McpHost, config.McpPort)
 
-	if err := sseServer.Start(addr); err != nil {
+	go func() {
+		<-cmd.Context().Done()
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+		if err := sseServer.Shutdown(shutdownCtx); err != nil {
+			logrus.Errorf("Failed to shut down the SSE server: %v", err)
+		}
+	}()
+
+	if err := sseServer.Start(addr); err != nil && !errors.Is(err, http.ErrServerClosed) {
 		logrus.Fatalf("Failed to start SSE server: %v", err)
 	}
 }
+
+// auditOriginMiddleware tags tool calls so the audit log shows which MCP tool triggered an action
+func auditOriginMiddleware(next server.ToolHandlerFunc) server.ToolHandlerFunc {
+	return func(ctx context.Context, request mcpgo.CallToolRequest) (*mcpgo.CallToolResult, error) {
+		ctx = domainAudit.ContextWithOrigin(ctx, domainAudit.Origin{
+			Actor:    "mcp",
+			Endpoint: "tool " + request.Params.Name,
+		})
+		return next(ctx, request)
+	}
+}
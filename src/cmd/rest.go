@@ -4,15 +4,17 @@ import (
 	"fmt"
 	"net/http"
 	"strings"
+	"time"
 
 	"github.com/aldinokemal/go-whatsapp-web-multidevice/config"
+	"github.com/aldinokemal/go-whatsapp-web-multidevice/infrastructure/whatsapp"
+	"github.com/aldinokemal/go-whatsapp-web-multidevice/ui/command"
 	"github.com/aldinokemal/go-whatsapp-web-multidevice/ui/rest"
 	"github.com/aldinokemal/go-whatsapp-web-multidevice/ui/rest/helpers"
 	"github.com/aldinokemal/go-whatsapp-web-multidevice/ui/rest/middleware"
 	"github.com/aldinokemal/go-whatsapp-web-multidevice/ui/websocket"
 	"github.com/dustin/go-humanize"
 	"github.com/gofiber/fiber/v2"
-	"github.com/gofiber/fiber/v2/middleware/basicauth"
 	"github.com/gofiber/fiber/v2/middleware/cors"
 	"github.com/gofiber/fiber/v2/middleware/filesystem"
 	"github.com/gofiber/fiber/v2/middleware/logger"
@@ -32,7 +34,7 @@ var restCmd = &cobra.Command{
 func init() {
 	rootCmd.AddCommand(restCmd)
 }
-func restServer(_ *cobra.Command, _ []string) {
+func restServer(cmd *cobra.Command, _ []string) {
 	engine := html.NewFileSystem(http.FS(EmbedIndex), ".html")
 	engine.AddFunc("isEnableBasicAuth", func(token any) bool {
 		return token != nil
@@ -63,23 +65,18 @@ func restServer(_ *cobra.Command, _ []string) {
 	}
 	app.Use(cors.New(cors.Config{
 		AllowOrigins: "*",
-		AllowHeaders: "Origin, Content-Type, Accept",
+		AllowHeaders: "Origin, Content-Type, Accept, Authorization, X-Api-Key, Idempotency-Key",
 	}))
 
-	if len(config.AppBasicAuthCredential) > 0 {
-		account := make(map[string]string)
-		for _, basicAuth := range config.AppBasicAuthCredential {
-			ba := strings.Split(basicAuth, ":")
-			if len(ba) != 2 {
-				logrus.Fatalln("Basic auth is not valid, please this following format <user>:<secret>")
-			}
-			account[ba[0]] = ba[1]
+	account := make(map[string]string)
+	for _, basicAuth := range config.AppBasicAuthCredential {
+		ba := strings.Split(basicAuth, ":")
+		if len(ba) != 2 {
+			logrus.Fatalln("Basic auth is not valid, please this following format <user>:<secret>")
 		}
-
-		app.Use(basicauth.New(basicauth.Config{
-			Users: account,
-		}))
+		account[ba[0]] = ba[1]
 	}
+	app.Use(middleware.Auth(apiKeyUsecase, account))
 
 	// Create base path group or use app directly
 	var apiGroup fiber.Router = app
@@ -95,6 +92,16 @@ func restServer(_ *cobra.Command, _ []string) {
 	rest.InitRestMessage(apiGroup, messageUsecase)
 	rest.InitRestGroup(apiGroup, groupUsecase)
 	rest.InitRestNewsletter(apiGroup, newsletterUsecase)
+	rest.InitRestStatus(apiGroup, statusUsecase)
+	rest.InitRestModeration(apiGroup, moderationUsecase)
+	rest.InitRestAPIKey(apiGroup, apiKeyUsecase)
+	rest.InitRestAudit(apiGroup, auditUsecase)
+	rest.InitRestLabel(apiGroup, labelUsecase)
+	rest.InitRestCall(apiGroup, callUsecase)
+	rest.InitRestMetrics(apiGroup)
+	rest.InitRestHealth(apiGroup)
+	rest.InitRestWebhookMedia(apiGroup)
+	whatsapp.RegisterMetrics(chatStorageRepo)
 
 	// Initialize OtomaX REST endpoints if enabled
 	if config.OtomaxEnabled && otomaxUsecase != nil {
@@ -118,8 +125,23 @@ func restServer(_ *cobra.Command, _ []string) {
 
 	// Set auto reconnect to whatsapp server after booting
 	go helpers.SetAutoConnectAfterBooting(appUsecase)
-	// Set auto reconnect checking
-	go helpers.SetAutoReconnectChecking(whatsappCli)
+	// Supervise the connection of the current client, reconnecting and alerting when it is lost
+	go whatsapp.RunConnectionSupervisor(cmd.Context())
+	// Bulk jobs do not survive a restart, finish the ones the previous run left behind
+	if err := groupUsecase.FailInterruptedBulkJobs(); err != nil {
+		logrus.Errorf("Failed to finish interrupted bulk jobs: %v", err)
+	}
+	// Send the commands published to the command topic of the event broker
+	if commandConsumer != nil {
+		go command.Consume(cmd.Context(), commandConsumer, command.InitCommandHandler(sendUsecase, messageUsecase))
+	}
+
+	go func() {
+		<-cmd.Context().Done()
+		if err := app.ShutdownWithTimeout(10 * time.Second); err != nil {
+			logrus.Errorf("Failed to shut down the REST server: %v", err)
+		}
+	}()
 
 	if err := app.Listen(":" + config.AppPort); err != nil {
 		logrus.Fatalln("Failed to start: ", err.Error())
@@ -7,22 +7,33 @@ import (
 	"fmt"
 	"go.mau.fi/whatsmeow/store/sqlstore"
 	"os"
+	"os/signal"
 	"strings"
+	"syscall"
 	"time"
 
 	"github.com/aldinokemal/go-whatsapp-web-multidevice/config"
+	domainAPIKey "github.com/aldinokemal/go-whatsapp-web-multidevice/domains/apikey"
 	domainApp "github.com/aldinokemal/go-whatsapp-web-multidevice/domains/app"
+	domainAudit "github.com/aldinokemal/go-whatsapp-web-multidevice/domains/audit"
+	domainCall "github.com/aldinokemal/go-whatsapp-web-multidevice/domains/call"
 	domainChat "github.com/aldinokemal/go-whatsapp-web-multidevice/domains/chat"
 	domainChatStorage "github.com/aldinokemal/go-whatsapp-web-multidevice/domains/chatstorage"
 	domainGroup "github.com/aldinokemal/go-whatsapp-web-multidevice/domains/group"
+	domainLabel "github.com/aldinokemal/go-whatsapp-web-multidevice/domains/label"
 	domainMessage "github.com/aldinokemal/go-whatsapp-web-multidevice/domains/message"
+	domainModeration "github.com/aldinokemal/go-whatsapp-web-multidevice/domains/moderation"
 	domainNewsletter "github.com/aldinokemal/go-whatsapp-web-multidevice/domains/newsletter"
 	domainOtomax "github.com/aldinokemal/go-whatsapp-web-multidevice/domains/otomax"
 	domainSend "github.com/aldinokemal/go-whatsapp-web-multidevice/domains/send"
+	domainStatus "github.com/aldinokemal/go-whatsapp-web-multidevice/domains/status"
 	domainUser "github.com/aldinokemal/go-whatsapp-web-multidevice/domains/user"
+	"github.com/aldinokemal/go-whatsapp-web-multidevice/infrastructure/broker"
 	"github.com/aldinokemal/go-whatsapp-web-multidevice/infrastructure/chatstorage"
 	"github.com/aldinokemal/go-whatsapp-web-multidevice/infrastructure/otomax"
 	"github.com/aldinokemal/go-whatsapp-web-multidevice/infrastructure/whatsapp"
+	"github.com/aldinokemal/go-whatsapp-web-multidevice/pkg/mediastorage"
+	"github.com/aldinokemal/go-whatsapp-web-multidevice/pkg/tracing"
 	"github.com/aldinokemal/go-whatsapp-web-multidevice/pkg/utils"
 	"github.com/aldinokemal/go-whatsapp-web-multidevice/usecase"
 	_ "github.com/lib/pq"
@@ -30,20 +41,23 @@ import (
 	"github.com/sirupsen/logrus"
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
-	"go.mau.fi/whatsmeow"
 )
 
 var (
 	EmbedIndex embed.FS
 	EmbedViews embed.FS
 
-	// Whatsapp
-	whatsappCli *whatsmeow.Client
-
 	// Chat Storage
 	chatStorageDB   *sql.DB
 	chatStorageRepo domainChatStorage.IChatStorageRepository
 
+	// Events published to and commands read from the event broker, nil when disabled
+	eventPublisher  broker.Publisher
+	commandConsumer broker.Consumer
+
+	// Flushes the pending spans before the process exits
+	shutdownTracing tracing.ShutdownFunc = func(context.Context) error { return nil }
+
 	// Usecase
 	appUsecase        domainApp.IAppUsecase
 	chatUsecase       domainChat.IChatUsecase
@@ -53,6 +67,12 @@ var (
 	groupUsecase      domainGroup.IGroupUsecase
 	newsletterUsecase domainNewsletter.INewsletterUsecase
 	otomaxUsecase     domainOtomax.IOtomaxUsecase
+	statusUsecase     domainStatus.IStatusUsecase
+	moderationUsecase domainModeration.IModerationUsecase
+	apiKeyUsecase     domainAPIKey.IAPIKeyUsecase
+	auditUsecase      domainAudit.IAuditUsecase
+	labelUsecase      domainLabel.ILabelUsecase
+	callUsecase       domainCall.ICallUsecase
 )
 
 // rootCmd represents the base command when called without any subcommands
@@ -97,6 +117,9 @@ func initEnvConfig() {
 	if envBasePath := viper.GetString("app_base_path"); envBasePath != "" {
 		config.AppBasePath = envBasePath
 	}
+	if viper.IsSet("app_idempotency_window") {
+		config.AppIdempotencyWindow = viper.GetDuration("app_idempotency_window")
+	}
 
 	// Database settings
 	if envDBURI := viper.GetString("db_uri"); envDBURI != "" {
@@ -113,6 +136,15 @@ func initEnvConfig() {
 	if viper.IsSet("whatsapp_auto_mark_read") {
 		config.WhatsappAutoMarkRead = viper.GetBool("whatsapp_auto_mark_read")
 	}
+	if viper.IsSet("whatsapp_call_reject") {
+		config.WhatsappCallReject = viper.GetBool("whatsapp_call_reject")
+	}
+	if envCallRejectMessage := viper.GetString("whatsapp_call_reject_message"); envCallRejectMessage != "" {
+		config.WhatsappCallRejectMessage = envCallRejectMessage
+	}
+	if viper.IsSet("whatsapp_ignore_blocked") {
+		config.WhatsappIgnoreBlocked = viper.GetBool("whatsapp_ignore_blocked")
+	}
 	if envWebhook := viper.GetString("whatsapp_webhook"); envWebhook != "" {
 		webhook := strings.Split(envWebhook, ",")
 		config.WhatsappWebhook = webhook
@@ -120,9 +152,84 @@ func initEnvConfig() {
 	if envWebhookSecret := viper.GetString("whatsapp_webhook_secret"); envWebhookSecret != "" {
 		config.WhatsappWebhookSecret = envWebhookSecret
 	}
+	if viper.IsSet("whatsapp_webhook_status") {
+		config.WhatsappWebhookStatus = viper.GetBool("whatsapp_webhook_status")
+	}
+	if envWebhookSchema := viper.GetString("whatsapp_webhook_schema"); envWebhookSchema != "" {
+		config.WhatsappWebhookSchema = strings.Split(envWebhookSchema, ",")
+	}
+	if envWebhookMediaBaseURL := viper.GetString("whatsapp_webhook_media_base_url"); envWebhookMediaBaseURL != "" {
+		config.WhatsappWebhookMediaBaseURL = envWebhookMediaBaseURL
+	}
+	if viper.IsSet("whatsapp_webhook_media_inline_max_size") {
+		config.WhatsappWebhookMediaInlineMaxSize = viper.GetInt64("whatsapp_webhook_media_inline_max_size")
+	}
 	if viper.IsSet("whatsapp_account_validation") {
 		config.WhatsappAccountValidation = viper.GetBool("whatsapp_account_validation")
 	}
+	if envAlertWebhook := viper.GetString("whatsapp_alert_webhook"); envAlertWebhook != "" {
+		config.WhatsappAlertWebhook = strings.Split(envAlertWebhook, ",")
+	}
+	if envAlertPhone := viper.GetString("whatsapp_alert_phone"); envAlertPhone != "" {
+		config.WhatsappAlertPhone = envAlertPhone
+	}
+	if viper.IsSet("whatsapp_history_sync_dump") {
+		config.WhatsappHistorySyncDump = viper.GetBool("whatsapp_history_sync_dump")
+	}
+	if viper.IsSet("whatsapp_history_sync_dump_max_files") {
+		config.WhatsappHistorySyncDumpMaxFiles = viper.GetInt("whatsapp_history_sync_dump_max_files")
+	}
+
+	// OpenTelemetry settings
+	if envOtelExporter := viper.GetString("otel_exporter"); envOtelExporter != "" {
+		config.OtelExporter = envOtelExporter
+	}
+	if envOtelEndpoint := viper.GetString("otel_endpoint"); envOtelEndpoint != "" {
+		config.OtelEndpoint = envOtelEndpoint
+	}
+	if envOtelServiceName := viper.GetString("otel_service_name"); envOtelServiceName != "" {
+		config.OtelServiceName = envOtelServiceName
+	}
+
+	// Media storage settings
+	if envMediaStorage := viper.GetString("media_storage"); envMediaStorage != "" {
+		config.MediaStorage = envMediaStorage
+	}
+	if envMediaS3Endpoint := viper.GetString("media_s3_endpoint"); envMediaS3Endpoint != "" {
+		config.MediaS3Endpoint = envMediaS3Endpoint
+	}
+	if envMediaS3Bucket := viper.GetString("media_s3_bucket"); envMediaS3Bucket != "" {
+		config.MediaS3Bucket = envMediaS3Bucket
+	}
+	if envMediaS3Region := viper.GetString("media_s3_region"); envMediaS3Region != "" {
+		config.MediaS3Region = envMediaS3Region
+	}
+	if envMediaS3AccessKey := viper.GetString("media_s3_access_key"); envMediaS3AccessKey != "" {
+		config.MediaS3AccessKey = envMediaS3AccessKey
+	}
+	if envMediaS3SecretKey := viper.GetString("media_s3_secret_key"); envMediaS3SecretKey != "" {
+		config.MediaS3SecretKey = envMediaS3SecretKey
+	}
+	if viper.IsSet("media_s3_use_ssl") {
+		config.MediaS3UseSSL = viper.GetBool("media_s3_use_ssl")
+	}
+	if viper.IsSet("media_url_expiry") {
+		config.MediaURLExpiry = viper.GetDuration("media_url_expiry")
+	}
+
+	// Event broker settings
+	if envEventBroker := viper.GetString("event_broker"); envEventBroker != "" {
+		config.EventBroker = envEventBroker
+	}
+	if envEventBrokerURL := viper.GetString("event_broker_url"); envEventBrokerURL != "" {
+		config.EventBrokerURL = envEventBrokerURL
+	}
+	if envEventBrokerTopic := viper.GetString("event_broker_topic"); envEventBrokerTopic != "" {
+		config.EventBrokerTopic = envEventBrokerTopic
+	}
+	if envEventBrokerCommandTopic := viper.GetString("event_broker_command_topic"); envEventBrokerCommandTopic != "" {
+		config.EventBrokerCommandTopic = envEventBrokerCommandTopic
+	}
 
 	// OtomaX settings
 	if viper.IsSet("otomax_enabled") {
@@ -193,6 +300,12 @@ func initFlags() {
 		config.AppBasePath,
 		`base path for subpath deployment --base-path <string> | example: --base-path="/gowa"`,
 	)
+	rootCmd.PersistentFlags().DurationVarP(
+		&config.AppIdempotencyWindow,
+		"idempotency-window", "",
+		config.AppIdempotencyWindow,
+		`how long send results are kept for retries with the same Idempotency-Key --idempotency-window <duration> | example: --idempotency-window=1h`,
+	)
 
 	// Database flags
 	rootCmd.PersistentFlags().StringVarP(
@@ -221,6 +334,24 @@ func initFlags() {
 		config.WhatsappAutoMarkRead,
 		`auto mark incoming messages as read --auto-mark-read <true/false> | example: --auto-mark-read=true`,
 	)
+	rootCmd.PersistentFlags().BoolVarP(
+		&config.WhatsappCallReject,
+		"call-reject", "",
+		config.WhatsappCallReject,
+		`reject incoming calls automatically --call-reject <true/false> | example: --call-reject=true`,
+	)
+	rootCmd.PersistentFlags().StringVarP(
+		&config.WhatsappCallRejectMessage,
+		"call-reject-message", "",
+		config.WhatsappCallRejectMessage,
+		`message sent to the caller of a rejected call, {type} is replaced by voice or video --call-reject-message <string> | example: --call-reject-message="Sorry, we can't take {type} calls. Please send a message."`,
+	)
+	rootCmd.PersistentFlags().BoolVarP(
+		&config.WhatsappIgnoreBlocked,
+		"ignore-blocked", "",
+		config.WhatsappIgnoreBlocked,
+		`keep messages of blocked contacts away from auto-reply and OtomaX --ignore-blocked <true/false> | example: --ignore-blocked=true`,
+	)
 	rootCmd.PersistentFlags().StringSliceVarP(
 		&config.WhatsappWebhook,
 		"webhook", "w",
@@ -233,12 +364,156 @@ func initFlags() {
 		config.WhatsappWebhookSecret,
 		`secure webhook request --webhook-secret <string> | example: --webhook-secret="super-secret-key"`,
 	)
+	rootCmd.PersistentFlags().BoolVarP(
+		&config.WhatsappWebhookStatus,
+		"webhook-status", "",
+		config.WhatsappWebhookStatus,
+		`forward contacts' status updates to webhook --webhook-status <true/false> | example: --webhook-status=true`,
+	)
+	rootCmd.PersistentFlags().StringSliceVarP(
+		&config.WhatsappWebhookSchema,
+		"webhook-schema", "",
+		config.WhatsappWebhookSchema,
+		`payload schema of the webhooks, per URL or as default --webhook-schema <string> | example: --webhook-schema="https://yourcallback.com/callback=v2"`,
+	)
+	rootCmd.PersistentFlags().StringVarP(
+		&config.WhatsappWebhookMediaBaseURL,
+		"webhook-media-base-url", "",
+		config.WhatsappWebhookMediaBaseURL,
+		`public URL of this app used for media URLs in v2 webhooks --webhook-media-base-url <string> | example: --webhook-media-base-url="https://gowa.example.com"`,
+	)
+	rootCmd.PersistentFlags().Int64VarP(
+		&config.WhatsappWebhookMediaInlineMaxSize,
+		"webhook-media-inline-max-size", "",
+		config.WhatsappWebhookMediaInlineMaxSize,
+		`inline media up to this many bytes as base64 in v2 webhooks, 0 disables it --webhook-media-inline-max-size <int> | example: --webhook-media-inline-max-size=1048576`,
+	)
 	rootCmd.PersistentFlags().BoolVarP(
 		&config.WhatsappAccountValidation,
 		"account-validation", "",
 		config.WhatsappAccountValidation,
 		`enable or disable account validation --account-validation <true/false> | example: --account-validation=true`,
 	)
+	rootCmd.PersistentFlags().StringSliceVarP(
+		&config.WhatsappAlertWebhook,
+		"alert-webhook", "",
+		config.WhatsappAlertWebhook,
+		`forward connection alerts to this webhook --alert-webhook <string> | example: --alert-webhook="https://yourcallback.com/alert"`,
+	)
+	rootCmd.PersistentFlags().StringVarP(
+		&config.WhatsappAlertPhone,
+		"alert-phone", "",
+		config.WhatsappAlertPhone,
+		`send connection alerts to this admin phone number --alert-phone <string> | example: --alert-phone="6281234567890"`,
+	)
+	rootCmd.PersistentFlags().BoolVarP(
+		&config.WhatsappHistorySyncDump,
+		"history-sync-dump", "",
+		config.WhatsappHistorySyncDump,
+		`keep the raw history sync data in storages/ as gzipped JSON for debugging --history-sync-dump <true/false> | example: --history-sync-dump=true`,
+	)
+	rootCmd.PersistentFlags().IntVarP(
+		&config.WhatsappHistorySyncDumpMaxFiles,
+		"history-sync-dump-max-files", "",
+		config.WhatsappHistorySyncDumpMaxFiles,
+		`number of history sync dumps kept, older ones are removed --history-sync-dump-max-files <int> | example: --history-sync-dump-max-files=5`,
+	)
+
+	// OpenTelemetry flags
+	rootCmd.PersistentFlags().StringVarP(
+		&config.OtelExporter,
+		"otel-exporter", "",
+		config.OtelExporter,
+		`trace exporter: none, otlp or stdout --otel-exporter <string> | example: --otel-exporter=otlp`,
+	)
+	rootCmd.PersistentFlags().StringVarP(
+		&config.OtelEndpoint,
+		"otel-endpoint", "",
+		config.OtelEndpoint,
+		`OTLP/HTTP endpoint for the otlp exporter --otel-endpoint <string> | example: --otel-endpoint="http://localhost:4318"`,
+	)
+	rootCmd.PersistentFlags().StringVarP(
+		&config.OtelServiceName,
+		"otel-service-name", "",
+		config.OtelServiceName,
+		`service name reported in traces --otel-service-name <string> | example: --otel-service-name="gowa"`,
+	)
+
+	// Media storage flags
+	rootCmd.PersistentFlags().StringVarP(
+		&config.MediaStorage,
+		"media-storage", "",
+		config.MediaStorage,
+		`where received media is stored: local or s3 --media-storage <string> | example: --media-storage=s3`,
+	)
+	rootCmd.PersistentFlags().StringVarP(
+		&config.MediaS3Endpoint,
+		"media-s3-endpoint", "",
+		config.MediaS3Endpoint,
+		`S3-compatible endpoint, without scheme --media-s3-endpoint <string> | example: --media-s3-endpoint="minio:9000"`,
+	)
+	rootCmd.PersistentFlags().StringVarP(
+		&config.MediaS3Bucket,
+		"media-s3-bucket", "",
+		config.MediaS3Bucket,
+		`S3 bucket for media --media-s3-bucket <string> | example: --media-s3-bucket="whatsapp-media"`,
+	)
+	rootCmd.PersistentFlags().StringVarP(
+		&config.MediaS3Region,
+		"media-s3-region", "",
+		config.MediaS3Region,
+		`S3 region --media-s3-region <string> | example: --media-s3-region="ap-southeast-1"`,
+	)
+	rootCmd.PersistentFlags().StringVarP(
+		&config.MediaS3AccessKey,
+		"media-s3-access-key", "",
+		config.MediaS3AccessKey,
+		`S3 access key --media-s3-access-key <string> | example: --media-s3-access-key="minioadmin"`,
+	)
+	rootCmd.PersistentFlags().StringVarP(
+		&config.MediaS3SecretKey,
+		"media-s3-secret-key", "",
+		config.MediaS3SecretKey,
+		`S3 secret key --media-s3-secret-key <string> | example: --media-s3-secret-key="minioadmin"`,
+	)
+	rootCmd.PersistentFlags().BoolVarP(
+		&config.MediaS3UseSSL,
+		"media-s3-use-ssl", "",
+		config.MediaS3UseSSL,
+		`connect to the S3 endpoint over HTTPS --media-s3-use-ssl <true/false> | example: --media-s3-use-ssl=false`,
+	)
+	rootCmd.PersistentFlags().DurationVarP(
+		&config.MediaURLExpiry,
+		"media-url-expiry", "",
+		config.MediaURLExpiry,
+		`validity of signed media URLs --media-url-expiry <duration> | example: --media-url-expiry=6h`,
+	)
+
+	// Event broker flags
+	rootCmd.PersistentFlags().StringVarP(
+		&config.EventBroker,
+		"event-broker", "",
+		config.EventBroker,
+		`publish webhook events to a message broker: none, nats, kafka, redis or amqp --event-broker <string> | example: --event-broker=nats`,
+	)
+	rootCmd.PersistentFlags().StringVarP(
+		&config.EventBrokerURL,
+		"event-broker-url", "",
+		config.EventBrokerURL,
+		`message broker URL --event-broker-url <string> | example: --event-broker-url="nats://localhost:4222"`,
+	)
+	rootCmd.PersistentFlags().StringVarP(
+		&config.EventBrokerTopic,
+		"event-broker-topic", "",
+		config.EventBrokerTopic,
+		`NATS subject prefix, Kafka topic, Redis stream or AMQP exchange --event-broker-topic <string> | example: --event-broker-topic="gowa.events"`,
+	)
+	rootCmd.PersistentFlags().StringVarP(
+		&config.EventBrokerCommandTopic,
+		"event-broker-command-topic", "",
+		config.EventBrokerCommandTopic,
+		`read send, react and revoke commands from this NATS subject, Redis stream or AMQP queue --event-broker-command-topic <string> | example: --event-broker-command-topic="gowa.commands"`,
+	)
 
 	// OtomaX flags
 	rootCmd.PersistentFlags().BoolVarP(
@@ -340,13 +615,32 @@ func initApp() {
 	}
 
 	//preparing folder if not exist
-	err := utils.CreateFolder(config.PathQrCode, config.PathSendItems, config.PathStorages, config.PathMedia)
+	err := utils.CreateFolder(config.PathQrCode, config.PathSendItems, config.PathStorages, config.PathMedia, config.PathStatuses)
 	if err != nil {
 		logrus.Errorln(err)
 	}
 
 	ctx := context.Background()
 
+	if shutdownTracing, err = tracing.Init(ctx); err != nil {
+		logrus.Fatalf("failed to initialize tracing: %v", err)
+	}
+
+	if err := mediastorage.Init(ctx); err != nil {
+		logrus.Fatalf("failed to initialize media storage: %v", err)
+	}
+
+	eventPublisher, err = broker.New(config.EventBroker, config.EventBrokerURL, config.EventBrokerTopic)
+	if err != nil {
+		logrus.Fatalf("failed to initialize event broker: %v", err)
+	}
+	whatsapp.SetEventPublisher(eventPublisher)
+
+	commandConsumer, err = broker.NewConsumer(config.EventBroker, config.EventBrokerURL, config.EventBrokerCommandTopic)
+	if err != nil {
+		logrus.Fatalf("failed to initialize command consumer: %v", err)
+	}
+
 	chatStorageDB, err = initChatStorage()
 	if err != nil {
 		// Terminate the application if chat storage fails to initialize to avoid nil pointer panics later.
@@ -368,10 +662,17 @@ func initApp() {
 	appUsecase = usecase.NewAppService(chatStorageRepo)
 	chatUsecase = usecase.NewChatService(chatStorageRepo)
 	sendUsecase = usecase.NewSendService(appUsecase, chatStorageRepo)
-	userUsecase = usecase.NewUserService()
+	userUsecase = usecase.NewUserService(chatStorageRepo)
 	messageUsecase = usecase.NewMessageService(chatStorageRepo)
-	groupUsecase = usecase.NewGroupService()
-	newsletterUsecase = usecase.NewNewsletterService()
+	groupUsecase = usecase.NewGroupService(chatStorageRepo)
+	newsletterUsecase = usecase.NewNewsletterService(chatStorageRepo)
+	statusUsecase = usecase.NewStatusService(chatStorageRepo)
+	moderationUsecase = usecase.NewModerationService(chatStorageRepo, groupUsecase, messageUsecase, sendUsecase)
+	whatsapp.SetModerationService(moderationUsecase)
+	apiKeyUsecase = usecase.NewAPIKeyService(chatStorageRepo)
+	auditUsecase = usecase.NewAuditService(chatStorageRepo)
+	labelUsecase = usecase.NewLabelService(chatStorageRepo)
+	callUsecase = usecase.NewCallService(chatStorageRepo)
 
 	// Initialize OtomaX service if enabled
 	if config.OtomaxEnabled {
@@ -391,7 +692,67 @@ func initApp() {
 func Execute(embedIndex embed.FS, embedViews embed.FS) {
 	EmbedIndex = embedIndex
 	EmbedViews = embedViews
-	if err := rootCmd.Execute(); err != nil {
+
+	// The first SIGINT or SIGTERM cancels the context of the command so the servers shut down gracefully,
+	// a second one stops the process right away
+	ctx, cancel := context.WithCancel(context.Background())
+	signals := make(chan os.Signal, 1)
+	stoppedBy := make(chan os.Signal, 1)
+	signal.Notify(signals, os.Interrupt, syscall.SIGTERM)
+	go func() {
+		sig := <-signals
+		signal.Stop(signals)
+		logrus.Infof("Received %s, shutting down", sig)
+		stoppedBy <- sig
+		cancel()
+	}()
+
+	err := rootCmd.ExecuteContext(ctx)
+	cancel()
+	shutdown()
+
+	select {
+	case sig := <-stoppedBy:
+		// Keep the exit status of a process stopped by the signal
+		if signum, ok := sig.(syscall.Signal); ok {
+			os.Exit(128 + int(signum))
+		}
+		os.Exit(1)
+	default:
+	}
+	if err != nil {
 		os.Exit(1)
 	}
 }
+
+// shutdown releases what initApp opened once the command returned, and flushes the spans last
+func shutdown() {
+	if client := whatsapp.GetClient(); client != nil {
+		client.Disconnect()
+	}
+	if commandConsumer != nil {
+		if err := commandConsumer.Close(); err != nil {
+			logrus.Warnf("failed to close command consumer: %v", err)
+		}
+	}
+	if eventPublisher != nil {
+		if err := eventPublisher.Close(); err != nil {
+			logrus.Warnf("failed to close event publisher: %v", err)
+		}
+	}
+	if chatStorageDB != nil {
+		if err := chatStorageDB.Close(); err != nil {
+			logrus.Warnf("failed to close chat storage: %v", err)
+		}
+	}
+	flushTracing()
+}
+
+// flushTracing exports the spans that are still buffered, waiting at most a few seconds
+func flushTracing() {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := shutdownTracing(ctx); err != nil {
+		logrus.Warnf("failed to shut down tracing: %v", err)
+	}
+}
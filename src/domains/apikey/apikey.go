@@ -0,0 +1,54 @@
+package apikey
+
+import "time"
+
+// Scope limits which group of REST routes a key can call
+type Scope string
+
+const (
+	ScopeSend      Scope = "send"
+	ScopeReadChats Scope = "read-chats"
+	ScopeGroups    Scope = "groups"
+	ScopeAdmin     Scope = "admin"
+	ScopeOtomax    Scope = "otomax"
+	ScopeMetrics   Scope = "metrics"
+)
+
+// Scopes lists every scope a key can be granted
+var Scopes = []Scope{ScopeSend, ScopeReadChats, ScopeGroups, ScopeAdmin, ScopeOtomax, ScopeMetrics}
+
+// Key is an API key without its secret. Zero values of the restriction fields mean unrestricted.
+type Key struct {
+	ID                string     `json:"id"`
+	Name              string     `json:"name"`
+	Prefix            string     `json:"prefix"`
+	Scopes            []Scope    `json:"scopes"`
+	AllowedRecipients []string   `json:"allowed_recipients"`
+	RateLimit         int        `json:"rate_limit"`
+	ExpiresAt         *time.Time `json:"expires_at,omitempty"`
+	LastUsedAt        *time.Time `json:"last_used_at,omitempty"`
+	RevokedAt         *time.Time `json:"revoked_at,omitempty"`
+	CreatedAt         time.Time  `json:"created_at"`
+}
+
+type CreateKeyRequest struct {
+	Name string `json:"name" form:"name"`
+	// Scopes granted to the key, see Scopes
+	Scopes []Scope `json:"scopes" form:"scopes"`
+	// AllowedRecipients are glob patterns matched against the phone or JID of send requests, e.g. 62812* or *@g.us
+	AllowedRecipients []string `json:"allowed_recipients" form:"allowed_recipients"`
+	// RateLimit is the maximum number of requests per minute, 0 for unlimited
+	RateLimit int `json:"rate_limit" form:"rate_limit"`
+	// ExpiresAt is an optional RFC3339 timestamp after which the key stops working
+	ExpiresAt string `json:"expires_at" form:"expires_at"`
+}
+
+type CreateKeyResponse struct {
+	Key
+	// Secret is the full API key. It is only returned once and cannot be recovered later.
+	Secret string `json:"secret"`
+}
+
+type RevokeKeyRequest struct {
+	ID string `json:"id" uri:"id"`
+}
@@ -0,0 +1,28 @@
+package apikey
+
+import (
+	"context"
+)
+
+// IAPIKeyManagement handles creating, listing and revoking API keys
+type IAPIKeyManagement interface {
+	CreateKey(ctx context.Context, request CreateKeyRequest) (response CreateKeyResponse, err error)
+	ListKeys(ctx context.Context) (response []Key, err error)
+	RevokeKey(ctx context.Context, request RevokeKeyRequest) (err error)
+}
+
+// IAPIKeyAuthorizer checks API keys presented by REST clients
+type IAPIKeyAuthorizer interface {
+	// HasActiveKeys reports whether at least one usable key exists, which makes authentication mandatory
+	HasActiveKeys(ctx context.Context) (bool, error)
+	// Authenticate resolves a raw key and consumes one request from its rate limit
+	Authenticate(ctx context.Context, rawKey string) (key Key, err error)
+	// Authorize checks the scope and, when given, the recipient against the key restrictions
+	Authorize(key Key, scope Scope, recipient string) error
+}
+
+// IAPIKeyUsecase combines all API key interfaces
+type IAPIKeyUsecase interface {
+	IAPIKeyManagement
+	IAPIKeyAuthorizer
+}
@@ -0,0 +1,135 @@
+package audit
+
+import (
+	"context"
+	"time"
+)
+
+// Results recorded for an audited action
+const (
+	ResultSuccess = "success"
+	ResultFailed  = "failed"
+)
+
+// Actor used when an action is not triggered by an authenticated request, e.g. moderation or CLI
+const ActorSystem = "system"
+
+// Origin identifies who triggered an action and through which endpoint
+type Origin struct {
+	Actor    string
+	Endpoint string
+}
+
+type originKey struct{}
+
+// ContextWithOrigin attaches the origin of a request to its context
+func ContextWithOrigin(ctx context.Context, origin Origin) context.Context {
+	return context.WithValue(ctx, originKey{}, origin)
+}
+
+// OriginFromContext returns the origin attached to the context, or the system actor when there is none
+func OriginFromContext(ctx context.Context) Origin {
+	if origin, ok := ctx.Value(originKey{}).(Origin); ok {
+		return origin
+	}
+	return Origin{Actor: ActorSystem}
+}
+
+type Entry struct {
+	ID        int64     `json:"id"`
+	Actor     string    `json:"actor"`
+	Endpoint  string    `json:"endpoint"`
+	Action    string    `json:"action"`
+	TargetJID string    `json:"target_jid"`
+	MessageID string    `json:"message_id"`
+	Details   string    `json:"details"`
+	Result    string    `json:"result"`
+	Error     string    `json:"error"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type ListRequest struct {
+	Actor     string `json:"actor" query:"actor"`
+	Action    string `json:"action" query:"action"`
+	TargetJID string `json:"target_jid" query:"target_jid"`
+	Result    string `json:"result" query:"result"`
+	// From and To are optional RFC3339 timestamps limiting the time range
+	From   string `json:"from" query:"from"`
+	To     string `json:"to" query:"to"`
+	Limit  int    `json:"limit" query:"limit"`
+	Offset int    `json:"offset" query:"offset"`
+}
+
+type ListResponse struct {
+	Data       []Entry            `json:"data"`
+	Pagination PaginationResponse `json:"pagination"`
+}
+
+type PaginationResponse struct {
+	Limit  int `json:"limit"`
+	Offset int `json:"offset"`
+	Total  int `json:"total"`
+}
+
+// Audited actions
+const (
+	ActionSendText         = "send.text"
+	ActionSendImage        = "send.image"
+	ActionSendFile         = "send.file"
+	ActionSendVideo        = "send.video"
+	ActionSendContact      = "send.contact"
+	ActionSendLink         = "send.link"
+	ActionSendLocation     = "send.location"
+	ActionSendAudio        = "send.audio"
+	ActionSendPoll         = "send.poll"
+	ActionSendPresence     = "send.presence"
+	ActionSendChatPresence = "send.chat_presence"
+	ActionSendSticker      = "send.sticker"
+
+	ActionMessageRead   = "message.read"
+	ActionMessageReact  = "message.react"
+	ActionMessageRevoke = "message.revoke"
+	ActionMessageDelete = "message.delete"
+	ActionMessageUpdate = "message.update"
+	ActionMessageStar   = "message.star"
+
+	ActionChatPin          = "chat.pin"
+	ActionChatArchive      = "chat.archive"
+	ActionChatMute         = "chat.mute"
+	ActionChatRead         = "chat.read"
+	ActionChatDisappearing = "chat.disappearing"
+	ActionChatHistory      = "chat.history"
+	ActionChatClear        = "chat.clear"
+	ActionChatDelete       = "chat.delete"
+
+	ActionLabelCreate  = "label.create"
+	ActionLabelUpdate  = "label.update"
+	ActionLabelDelete  = "label.delete"
+	ActionLabelChat    = "label.chat"
+	ActionLabelMessage = "label.message"
+
+	ActionGroupJoin                = "group.join"
+	ActionGroupLeave               = "group.leave"
+	ActionGroupCreate              = "group.create"
+	ActionGroupParticipants        = "group.participants"
+	ActionGroupParticipantRequests = "group.participant_requests"
+	ActionGroupBulkParticipants    = "group.bulk_participants"
+	ActionGroupPhoto               = "group.photo"
+	ActionGroupName                = "group.name"
+	ActionGroupLocked              = "group.locked"
+	ActionGroupAnnounce            = "group.announce"
+	ActionGroupTopic               = "group.topic"
+	ActionGroupResetInviteLink     = "group.reset_invite_link"
+	ActionCommunityCreate          = "community.create"
+	ActionCommunityLink            = "community.link"
+	ActionCommunityUnlink          = "community.unlink"
+	ActionCommunityAnnouncement    = "community.announce"
+
+	ActionUserAvatar       = "user.avatar"
+	ActionUserPushName     = "user.pushname"
+	ActionUserAbout        = "user.about"
+	ActionUserPrivacy      = "user.privacy"
+	ActionUserDisappearing = "user.disappearing"
+	ActionUserBlock        = "user.block"
+	ActionUserUnblock      = "user.unblock"
+)
@@ -0,0 +1,10 @@
+package audit
+
+import (
+	"context"
+)
+
+// IAuditUsecase reads the audit log written by the state-changing usecases
+type IAuditUsecase interface {
+	List(ctx context.Context, request ListRequest) (response ListResponse, err error)
+}
@@ -0,0 +1,31 @@
+package call
+
+type ListCallsRequest struct {
+	Phone   string `json:"phone" query:"phone"`
+	Outcome string `json:"outcome" query:"outcome"`
+	Limit   int    `json:"limit" query:"limit"`
+	Offset  int    `json:"offset" query:"offset"`
+}
+
+type ListCallsResponse struct {
+	Data       []CallInfo         `json:"data"`
+	Pagination PaginationResponse `json:"pagination"`
+}
+
+type PaginationResponse struct {
+	Limit  int `json:"limit"`
+	Offset int `json:"offset"`
+	Total  int `json:"total"`
+}
+
+type CallInfo struct {
+	ID        string `json:"id"`
+	From      string `json:"from"`
+	GroupJID  string `json:"group_jid,omitempty"`
+	IsVideo   bool   `json:"is_video"`
+	IsGroup   bool   `json:"is_group"`
+	Outcome   string `json:"outcome"`
+	Reason    string `json:"reason,omitempty"`
+	Timestamp string `json:"timestamp"`
+	UpdatedAt string `json:"updated_at"`
+}
@@ -0,0 +1,10 @@
+package call
+
+import (
+	"context"
+)
+
+// ICallUsecase reads the log of incoming calls
+type ICallUsecase interface {
+	ListCalls(ctx context.Context, request ListCallsRequest) (response ListCallsResponse, err error)
+}
@@ -7,6 +7,11 @@ type ListChatsRequest struct {
 	Offset   int    `json:"offset" query:"offset"`
 	Search   string `json:"search" query:"search"`
 	HasMedia bool   `json:"has_media" query:"has_media"`
+	Pinned   *bool  `json:"pinned" query:"pinned"`
+	Archived *bool  `json:"archived" query:"archived"`
+	Muted    *bool  `json:"muted" query:"muted"`
+	Unread   *bool  `json:"unread" query:"unread"`
+	LabelID  string `json:"label_id" query:"label_id"`
 }
 
 type ListChatsResponse struct {
@@ -23,6 +28,8 @@ type GetChatMessagesRequest struct {
 	MediaOnly bool    `json:"media_only" query:"media_only"`
 	IsFromMe  *bool   `json:"is_from_me" query:"is_from_me"`
 	Search    string  `json:"search" query:"search"`
+	// IncludeEdits adds the revision history of edited messages
+	IncludeEdits bool `json:"include_edits" query:"include_edits"`
 }
 
 type GetChatMessagesResponse struct {
@@ -44,13 +51,116 @@ type PinChatResponse struct {
 	Pinned  bool   `json:"pinned"`
 }
 
-type ChatInfo struct {
-	JID                 string `json:"jid"`
-	Name                string `json:"name"`
-	LastMessageTime     string `json:"last_message_time"`
+// Archive Chat operations
+type ArchiveChatRequest struct {
+	ChatJID  string `json:"chat_jid" uri:"chat_jid"`
+	Archived bool   `json:"archived"`
+}
+
+type ArchiveChatResponse struct {
+	Status   string `json:"status"`
+	Message  string `json:"message"`
+	ChatJID  string `json:"chat_jid"`
+	Archived bool   `json:"archived"`
+}
+
+// Mute Chat operations
+type MuteChatRequest struct {
+	ChatJID string `json:"chat_jid" uri:"chat_jid"`
+	Muted   bool   `json:"muted"`
+	// Duration is the mute duration in seconds, 0 mutes the chat until it is unmuted
+	Duration int64 `json:"duration"`
+}
+
+type MuteChatResponse struct {
+	Status     string `json:"status"`
+	Message    string `json:"message"`
+	ChatJID    string `json:"chat_jid"`
+	Muted      bool   `json:"muted"`
+	MutedUntil string `json:"muted_until,omitempty"`
+}
+
+// Mark Chat Read operations
+type MarkChatReadRequest struct {
+	ChatJID string `json:"chat_jid" uri:"chat_jid"`
+	Read    bool   `json:"read"`
+}
+
+type MarkChatReadResponse struct {
+	Status  string `json:"status"`
+	Message string `json:"message"`
+	ChatJID string `json:"chat_jid"`
+	Unread  bool   `json:"unread"`
+}
+
+// Disappearing messages operations
+type SetDisappearingTimerRequest struct {
+	ChatJID string `json:"chat_jid" uri:"chat_jid"`
+	// Timer is one of off, 24h, 7d or 90d
+	Timer string `json:"timer"`
+}
+
+type SetDisappearingTimerResponse struct {
+	Status  string `json:"status"`
+	Message string `json:"message"`
+	ChatJID string `json:"chat_jid"`
+	Timer   string `json:"timer"`
+	// EphemeralExpiration is the timer in seconds, 0 when disappearing messages are off
 	EphemeralExpiration uint32 `json:"ephemeral_expiration"`
-	CreatedAt           string `json:"created_at"`
-	UpdatedAt           string `json:"updated_at"`
+}
+
+// History backfill operations
+const (
+	DefaultHistoryRequestCount = 50
+	MaxHistoryRequestCount     = 100
+)
+
+type RequestHistoryRequest struct {
+	ChatJID string `json:"chat_jid" uri:"chat_jid"`
+	// Count is how many messages older than the oldest stored one are requested, 50 when empty
+	Count int `json:"count"`
+}
+
+type RequestHistoryResponse struct {
+	Status  string `json:"status"`
+	Message string `json:"message"`
+	ChatJID string `json:"chat_jid"`
+	// OldestMessageID is the stored message the older history is requested before
+	OldestMessageID string `json:"oldest_message_id"`
+	OldestTimestamp string `json:"oldest_timestamp"`
+	Count           int    `json:"count"`
+}
+
+// Clear Chat operations
+type ClearChatRequest struct {
+	ChatJID       string `json:"chat_jid" uri:"chat_jid"`
+	DeleteStarred bool   `json:"delete_starred"`
+}
+
+// Delete Chat operations
+type DeleteChatRequest struct {
+	ChatJID string `json:"chat_jid" uri:"chat_jid"`
+}
+
+type ChatActionResponse struct {
+	Status  string `json:"status"`
+	Message string `json:"message"`
+	ChatJID string `json:"chat_jid"`
+}
+
+type ChatInfo struct {
+	JID                 string   `json:"jid"`
+	Name                string   `json:"name"`
+	LastMessageTime     string   `json:"last_message_time"`
+	EphemeralExpiration uint32   `json:"ephemeral_expiration"`
+	Pinned              bool     `json:"pinned"`
+	Archived            bool     `json:"archived"`
+	Muted               bool     `json:"muted"`
+	MutedUntil          string   `json:"muted_until,omitempty"`
+	Unread              bool     `json:"unread"`
+	LabelIDs            []string `json:"label_ids"`
+	CreatedAt           string   `json:"created_at"`
+	UpdatedAt           string   `json:"updated_at"`
 }
 
 type MessageInfo struct {
@@ -64,8 +174,51 @@ type MessageInfo struct {
 	Filename   string `json:"filename"`
 	URL        string `json:"url"`
 	FileLength uint64 `json:"file_length"`
-	CreatedAt  string `json:"created_at"`
-	UpdatedAt  string `json:"updated_at"`
+	Starred    bool   `json:"starred"`
+	EditedAt   string `json:"edited_at,omitempty"`
+	Revoked    bool   `json:"revoked"`
+	RevokedAt  string `json:"revoked_at,omitempty"`
+	// Edits lists every revision from the original to the current content, only when requested
+	Edits []MessageEdit `json:"edits,omitempty"`
+	// ReplyTo is the message this one replies to, its sender and content are empty when it is not stored
+	ReplyTo   *MessageReference `json:"reply_to,omitempty"`
+	Mentions  []string          `json:"mentions,omitempty"`
+	Reactions []MessageReaction `json:"reactions,omitempty"`
+	CreatedAt string            `json:"created_at"`
+	UpdatedAt string            `json:"updated_at"`
+}
+
+type MessageEdit struct {
+	Content  string `json:"content"`
+	EditedAt string `json:"edited_at"`
+}
+
+type MessageReference struct {
+	ID        string `json:"id"`
+	SenderJID string `json:"sender_jid,omitempty"`
+	Content   string `json:"content,omitempty"`
+}
+
+type MessageReaction struct {
+	SenderJID string `json:"sender_jid"`
+	Emoji     string `json:"emoji"`
+	Timestamp string `json:"timestamp"`
+}
+
+// Message thread operations
+type GetMessageThreadRequest struct {
+	MessageID string `json:"message_id" uri:"message_id"`
+	// ChatJID narrows the lookup down to one chat, message IDs are only unique within a chat
+	ChatJID string `json:"chat_jid" query:"chat_jid"`
+}
+
+type GetMessageThreadResponse struct {
+	ChatJID   string `json:"chat_jid"`
+	MessageID string `json:"message_id"`
+	// RootID is the first message of the reply chain the message belongs to
+	RootID string `json:"root_id"`
+	// Data holds the reply chain from the root to the message followed by all replies to it, oldest first
+	Data []MessageInfo `json:"data"`
 }
 
 type PaginationResponse struct {
@@ -8,5 +8,13 @@ import (
 type IChatUsecase interface {
 	ListChats(ctx context.Context, request ListChatsRequest) (response ListChatsResponse, err error)
 	GetChatMessages(ctx context.Context, request GetChatMessagesRequest) (response GetChatMessagesResponse, err error)
+	GetMessageThread(ctx context.Context, request GetMessageThreadRequest) (response GetMessageThreadResponse, err error)
 	PinChat(ctx context.Context, request PinChatRequest) (response PinChatResponse, err error)
+	ArchiveChat(ctx context.Context, request ArchiveChatRequest) (response ArchiveChatResponse, err error)
+	MuteChat(ctx context.Context, request MuteChatRequest) (response MuteChatResponse, err error)
+	MarkChatRead(ctx context.Context, request MarkChatReadRequest) (response MarkChatReadResponse, err error)
+	SetDisappearingTimer(ctx context.Context, request SetDisappearingTimerRequest) (response SetDisappearingTimerResponse, err error)
+	RequestHistory(ctx context.Context, request RequestHistoryRequest) (response RequestHistoryResponse, err error)
+	ClearChat(ctx context.Context, request ClearChatRequest) (response ChatActionResponse, err error)
+	DeleteChat(ctx context.Context, request DeleteChatRequest) (response ChatActionResponse, err error)
 }
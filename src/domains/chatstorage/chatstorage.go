@@ -8,10 +8,33 @@ type Chat struct {
 	Name                string    `db:"name"`
 	LastMessageTime     time.Time `db:"last_message_time"`
 	EphemeralExpiration uint32    `db:"ephemeral_expiration"`
+	Pinned              bool      `db:"pinned"`
+	Archived            bool      `db:"archived"`
+	MutedUntil          int64     `db:"muted_until"` // Unix seconds, 0 when not muted and MutedForever when muted without end
+	Unread              bool      `db:"unread"`
+	LabelIDs            []string  `db:"-"`
 	CreatedAt           time.Time `db:"created_at"`
 	UpdatedAt           time.Time `db:"updated_at"`
 }
 
+// MutedForever is the MutedUntil value of a chat muted without an end time
+const MutedForever int64 = -1
+
+// IsMuted reports whether the chat is muted at now
+func (c *Chat) IsMuted(now time.Time) bool {
+	return c.MutedUntil == MutedForever || c.MutedUntil > now.Unix()
+}
+
+// ChatStateUpdate holds the app state of a chat to persist. Nil fields are left unchanged.
+type ChatStateUpdate struct {
+	Pinned     *bool
+	Archived   *bool
+	MutedUntil *int64
+	Unread     *bool
+	// EphemeralExpiration is the disappearing messages timer in seconds, 0 turns it off
+	EphemeralExpiration *uint32
+}
+
 // Message represents a WhatsApp message
 type Message struct {
 	ID            string    `db:"id"`
@@ -27,8 +50,46 @@ type Message struct {
 	FileSHA256    []byte    `db:"file_sha256"`
 	FileEncSHA256 []byte    `db:"file_enc_sha256"`
 	FileLength    uint64    `db:"file_length"`
-	CreatedAt     time.Time `db:"created_at"`
-	UpdatedAt     time.Time `db:"updated_at"`
+	Starred       bool      `db:"starred"`
+	// EditedAt is the time of the latest edit, nil when the message was never edited
+	EditedAt *time.Time `db:"edited_at"`
+	// Revoked messages were deleted for everyone, their content and media are cleared
+	Revoked   bool       `db:"revoked"`
+	RevokedAt *time.Time `db:"revoked_at"`
+	// QuotedMessageID is the ID of the message this one replies to
+	QuotedMessageID string `db:"quoted_message_id"`
+	// Mentions lists the JIDs mentioned in the message
+	Mentions  []string  `db:"mentions"`
+	CreatedAt time.Time `db:"created_at"`
+	UpdatedAt time.Time `db:"updated_at"`
+}
+
+// MessageReaction is the latest reaction of a user to a message
+type MessageReaction struct {
+	ChatJID   string    `db:"chat_jid"`
+	MessageID string    `db:"message_id"`
+	SenderJID string    `db:"sender_jid"`
+	Emoji     string    `db:"emoji"`
+	Timestamp time.Time `db:"timestamp"`
+}
+
+// MessageEdit is one revision of an edited message. The first revision holds the original content.
+type MessageEdit struct {
+	ChatJID   string    `db:"chat_jid"`
+	MessageID string    `db:"message_id"`
+	EditID    string    `db:"edit_id"`
+	Content   string    `db:"content"`
+	EditedAt  time.Time `db:"edited_at"`
+}
+
+// Label represents a WhatsApp Business label that can be applied to chats and messages
+type Label struct {
+	ID           string    `db:"id"`
+	Name         string    `db:"name"`
+	Color        int32     `db:"color"`
+	PredefinedID int32     `db:"predefined_id"`
+	CreatedAt    time.Time `db:"created_at"`
+	UpdatedAt    time.Time `db:"updated_at"`
 }
 
 // MediaInfo represents downloadable media information
@@ -61,4 +122,189 @@ type ChatFilter struct {
 	Offset     int
 	SearchName string
 	HasMedia   bool
+	Pinned     *bool
+	Archived   *bool
+	Muted      *bool
+	Unread     *bool
+	LabelID    string
+}
+
+// Status represents a WhatsApp status (story) update
+type Status struct {
+	ID        string    `db:"id"`
+	Sender    string    `db:"sender"`
+	PushName  string    `db:"pushname"`
+	Content   string    `db:"content"`
+	MediaType string    `db:"media_type"`
+	MediaPath string    `db:"media_path"`
+	MimeType  string    `db:"mime_type"`
+	IsFromMe  bool      `db:"is_from_me"`
+	Timestamp time.Time `db:"timestamp"`
+	ExpiresAt time.Time `db:"expires_at"`
+	CreatedAt time.Time `db:"created_at"`
+}
+
+// StatusFilter represents query filters for status updates
+type StatusFilter struct {
+	Sender         string
+	IncludeExpired bool
+	Limit          int
+	Offset         int
+}
+
+// Outcomes of a call received by the account
+const (
+	CallOutcomeRinging  = "ringing"
+	CallOutcomeAccepted = "accepted"
+	CallOutcomeRejected = "rejected"
+	CallOutcomeMissed   = "missed"
+	CallOutcomeEnded    = "ended"
+)
+
+// Call represents an incoming voice or video call and how it ended
+type Call struct {
+	ID        string    `db:"id"`
+	CallerJID string    `db:"caller_jid"`
+	GroupJID  string    `db:"group_jid"`
+	IsVideo   bool      `db:"is_video"`
+	IsGroup   bool      `db:"is_group"`
+	Outcome   string    `db:"outcome"`
+	Reason    string    `db:"reason"`
+	Timestamp time.Time `db:"timestamp"`
+	UpdatedAt time.Time `db:"updated_at"`
+}
+
+// CallFilter represents query filters for the call log
+type CallFilter struct {
+	CallerJID string
+	Outcome   string
+	Limit     int
+	Offset    int
+}
+
+// BlockedContact represents a contact on the account's blocklist
+type BlockedContact struct {
+	JID       string    `db:"jid"`
+	BlockedAt time.Time `db:"blocked_at"`
+}
+
+// PushName is the profile name a contact has set for themselves
+type PushName struct {
+	JID       string    `db:"jid"`
+	PushName  string    `db:"push_name"`
+	UpdatedAt time.Time `db:"updated_at"`
+}
+
+// GroupMetadata represents the details of a group as received from history sync
+type GroupMetadata struct {
+	JID          string              `db:"jid"`
+	Name         string              `db:"name"`
+	Topic        string              `db:"topic"`
+	CreatorJID   string              `db:"creator_jid"`
+	CreatedAt    time.Time           `db:"group_created_at"` // When the group was created on WhatsApp, zero when unknown
+	Participants []*GroupParticipant `db:"-"`
+	UpdatedAt    time.Time           `db:"updated_at"`
+}
+
+// GroupParticipant represents a member of a group with metadata
+type GroupParticipant struct {
+	JID          string `db:"jid"`
+	IsAdmin      bool   `db:"is_admin"`
+	IsSuperAdmin bool   `db:"is_super_admin"`
+}
+
+// GroupModeration represents the moderation settings of a group
+type GroupModeration struct {
+	GroupJID           string    `db:"group_jid"`
+	WelcomeMessage     string    `db:"welcome_message"`
+	GoodbyeMessage     string    `db:"goodbye_message"`
+	AntiLink           bool      `db:"anti_link"`
+	BannedWords        []string  `db:"banned_words"`
+	FloodLimit         int       `db:"flood_limit"`
+	FloodWindowSeconds int       `db:"flood_window_seconds"`
+	MaxWarnings        int       `db:"max_warnings"`
+	CreatedAt          time.Time `db:"created_at"`
+	UpdatedAt          time.Time `db:"updated_at"`
+}
+
+// GroupBulkJob represents a bulk group participant job imported from CSV
+type GroupBulkJob struct {
+	ID           string     `db:"id"`
+	Status       string     `db:"status"`
+	BatchSize    int        `db:"batch_size"`
+	DelaySeconds int        `db:"delay_seconds"`
+	Total        int        `db:"total"`
+	Processed    int        `db:"processed"`
+	Succeeded    int        `db:"succeeded"`
+	Failed       int        `db:"failed"`
+	Invited      int        `db:"invited"`
+	CreatedAt    time.Time  `db:"created_at"`
+	UpdatedAt    time.Time  `db:"updated_at"`
+	FinishedAt   *time.Time `db:"finished_at"`
+}
+
+// GroupBulkJobRow represents a single CSV row of a bulk group participant job
+type GroupBulkJobRow struct {
+	JobID       string     `db:"job_id"`
+	RowNumber   int        `db:"row_number"`
+	GroupJID    string     `db:"group_jid"`
+	Phone       string     `db:"phone"`
+	Action      string     `db:"action"`
+	Status      string     `db:"status"`
+	Message     string     `db:"message"`
+	ProcessedAt *time.Time `db:"processed_at"`
+}
+
+// APIKey represents a stored REST API key. Only the SHA-256 hash of the secret is kept.
+type APIKey struct {
+	ID                string     `db:"id"`
+	Name              string     `db:"name"`
+	KeyHash           string     `db:"key_hash"`
+	Prefix            string     `db:"prefix"`
+	Scopes            []string   `db:"scopes"`
+	AllowedRecipients []string   `db:"allowed_recipients"`
+	RateLimit         int        `db:"rate_limit"`
+	ExpiresAt         *time.Time `db:"expires_at"`
+	LastUsedAt        *time.Time `db:"last_used_at"`
+	RevokedAt         *time.Time `db:"revoked_at"`
+	CreatedAt         time.Time  `db:"created_at"`
+}
+
+// AuditLog represents an append-only record of a state-changing action
+type AuditLog struct {
+	ID        int64     `db:"id"`
+	Actor     string    `db:"actor"`
+	Endpoint  string    `db:"endpoint"`
+	Action    string    `db:"action"`
+	TargetJID string    `db:"target_jid"`
+	MessageID string    `db:"message_id"`
+	Details   string    `db:"details"`
+	Result    string    `db:"result"`
+	Error     string    `db:"error"`
+	CreatedAt time.Time `db:"created_at"`
+}
+
+// IdempotencyKey records the result of a send request made with an idempotency key, so retries with the
+// same key return it instead of sending again. CompletedAt is nil while the first request is in progress.
+type IdempotencyKey struct {
+	Actor       string     `db:"actor"`
+	Key         string     `db:"idempotency_key"`
+	Action      string     `db:"action"`
+	Recipient   string     `db:"recipient"`
+	MessageID   string     `db:"message_id"`
+	Status      string     `db:"status"`
+	CreatedAt   time.Time  `db:"created_at"`
+	CompletedAt *time.Time `db:"completed_at"`
+}
+
+// AuditLogFilter represents query filters for the audit log
+type AuditLogFilter struct {
+	Actor     string
+	Action    string
+	TargetJID string
+	Result    string
+	From      *time.Time
+	To        *time.Time
+	Limit     int
+	Offset    int
 }
@@ -15,6 +15,8 @@ type IChatStorageRepository interface {
 	GetChat(jid string) (*Chat, error)
 	GetChats(filter *ChatFilter) ([]*Chat, error)
 	DeleteChat(jid string) error
+	UpdateChatState(jid string, update *ChatStateUpdate) error
+	ClearChatMessages(jid string) error
 
 	// Message operations
 	StoreMessage(message *Message) error
@@ -23,7 +25,81 @@ type IChatStorageRepository interface {
 	GetMessages(filter *MessageFilter) ([]*Message, error)
 	SearchMessages(chatJID, searchText string, limit int) ([]*Message, error) // Database-level search
 	DeleteMessage(id, chatJID string) error
-	StoreSentMessageWithContext(ctx context.Context, messageID string, senderJID string, recipientJID string, content string, timestamp time.Time) error
+	SetMessageStarred(id, chatJID string, starred bool) error
+	EditMessage(chatJID, messageID, editID, content string, editedAt time.Time) error
+	RevokeMessage(chatJID, messageID string, revokedAt time.Time) error
+	GetMessageEdits(chatJID, messageID string) ([]*MessageEdit, error)
+	GetMessageReplies(chatJID, messageID string) ([]*Message, error)
+	GetOldestMessage(chatJID string) (*Message, error)
+
+	// Reaction operations
+	StoreReaction(reaction *MessageReaction) error
+	GetMessageReactions(chatJID string, messageIDs []string) (map[string][]*MessageReaction, error)
+	StoreSentMessageWithContext(ctx context.Context, messageID string, senderJID string, recipientJID string, content string, timestamp time.Time, quotedMessageID string, mentions []string) error
+
+	// Label operations
+	StoreLabel(label *Label) error
+	GetLabel(id string) (*Label, error)
+	GetLabels() ([]*Label, error)
+	DeleteLabel(id string) error
+	GetHighestLabelID() (int, error)
+	SetChatLabel(chatJID, labelID string, labeled bool) error
+	SetMessageLabel(chatJID, messageID, labelID string, labeled bool) error
+
+	// Status operations
+	StoreStatus(status *Status) error
+	GetStatuses(filter *StatusFilter) ([]*Status, error)
+	DeleteStatus(id, sender string) error
+
+	// Call log operations
+	StoreCall(call *Call) error
+	GetCall(id string) (*Call, error)
+	GetCalls(filter *CallFilter) ([]*Call, error)
+	CountCalls(filter *CallFilter) (int64, error)
+
+	// Blocklist operations
+	ReplaceBlocklist(jids []string) error
+	SetContactBlocked(jid string, blocked bool) error
+	GetBlocklist() ([]*BlockedContact, error)
+	IsContactBlocked(jid string) (bool, error)
+
+	// Contact and group metadata operations
+	StorePushNames(pushNames []*PushName) error
+	GetPushName(jid string) (string, error)
+	StoreGroupMetadata(metadata *GroupMetadata) error
+	GetGroupMetadata(jid string) (*GroupMetadata, error)
+
+	// Group moderation operations
+	StoreGroupModeration(moderation *GroupModeration) error
+	GetGroupModeration(groupJID string) (*GroupModeration, error)
+	GetGroupModerations() ([]*GroupModeration, error)
+	DeleteGroupModeration(groupJID string) error
+
+	// Group bulk job operations
+	CreateGroupBulkJob(job *GroupBulkJob, rows []*GroupBulkJobRow) error
+	UpdateGroupBulkJob(job *GroupBulkJob) error
+	UpdateGroupBulkJobRow(row *GroupBulkJobRow) error
+	GetGroupBulkJob(id string) (*GroupBulkJob, error)
+	GetUnfinishedGroupBulkJobs() ([]*GroupBulkJob, error)
+	GetGroupBulkJobRows(jobID string) ([]*GroupBulkJobRow, error)
+
+	// API key operations
+	StoreAPIKey(key *APIKey) error
+	GetAPIKeyByHash(keyHash string) (*APIKey, error)
+	GetAPIKeys() ([]*APIKey, error)
+	RevokeAPIKey(id string, revokedAt time.Time) (bool, error)
+	UpdateAPIKeyLastUsed(id string, lastUsedAt time.Time) error
+	CountActiveAPIKeys(now time.Time) (int64, error)
+
+	// Audit log operations (append-only)
+	StoreAuditLog(entry *AuditLog) error
+	GetAuditLogs(filter *AuditLogFilter) ([]*AuditLog, error)
+	CountAuditLogs(filter *AuditLogFilter) (int64, error)
+
+	// Idempotency key operations
+	ClaimIdempotencyKey(key *IdempotencyKey, expiredBefore time.Time) (*IdempotencyKey, error)
+	CompleteIdempotencyKey(actor, key, messageID, status string, completedAt time.Time) error
+	DeleteIdempotencyKey(actor, key string) error
 
 	// Statistics
 	GetChatMessageCount(chatJID string) (int64, error)
@@ -31,6 +107,7 @@ type IChatStorageRepository interface {
 	GetTotalChatCount() (int64, error)
 	GetChatNameWithPushName(jid types.JID, chatJID string, senderUser string, pushName string) string
 	GetStorageStatistics() (chatCount int64, messageCount int64, err error)
+	GetDatabaseSize() (int64, error)
 
 	// Cleanup operations
 	TruncateAllChats() error
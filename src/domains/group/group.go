@@ -5,6 +5,7 @@ import (
 	"time"
 
 	"go.mau.fi/whatsmeow"
+	"go.mau.fi/whatsmeow/types"
 )
 
 // NOTE: IGroupUsecase is now defined in interfaces.go with proper segregation
@@ -131,3 +132,91 @@ type GetGroupInviteLinkResponse struct {
 type GroupInfoResponse struct {
 	Data any `json:"data"`
 }
+
+// GroupInfoData is the group information returned by WhatsApp extended with its community membership
+type GroupInfoData struct {
+	types.GroupInfo
+	Community CommunityMembership `json:"Community"`
+}
+
+type CommunityMembership struct {
+	IsCommunity         bool   `json:"is_community"`
+	CommunityID         string `json:"community_id,omitempty"`
+	IsAnnouncementGroup bool   `json:"is_announcement_group"`
+}
+
+type CreateCommunityRequest struct {
+	Name        string `json:"name" form:"name"`
+	Description string `json:"description" form:"description"`
+}
+
+type LinkGroupRequest struct {
+	CommunityID string `json:"community_id" form:"community_id"`
+	GroupID     string `json:"group_id" form:"group_id"`
+}
+
+type UnlinkGroupRequest struct {
+	CommunityID string `json:"community_id" form:"community_id"`
+	GroupID     string `json:"group_id" form:"group_id"`
+}
+
+type GetLinkedGroupsRequest struct {
+	CommunityID string `json:"community_id" query:"community_id"`
+}
+
+type LinkedGroup struct {
+	JID                 string `json:"jid"`
+	Name                string `json:"name"`
+	IsAnnouncementGroup bool   `json:"is_announcement_group"`
+}
+
+type GetLinkedGroupsResponse struct {
+	CommunityID string        `json:"community_id"`
+	Groups      []LinkedGroup `json:"groups"`
+}
+
+type CommunityAnnouncementRequest struct {
+	CommunityID string `json:"community_id" form:"community_id"`
+	Message     string `json:"message" form:"message"`
+}
+
+type CommunityAnnouncementResponse struct {
+	MessageID string `json:"message_id"`
+	GroupID   string `json:"group_id"`
+	Status    string `json:"status"`
+}
+
+type BulkParticipantJobRequest struct {
+	File         *multipart.FileHeader `json:"file" form:"file"`
+	BatchSize    int                   `json:"batch_size" form:"batch_size"`
+	DelaySeconds int                   `json:"delay_seconds" form:"delay_seconds"`
+}
+
+type GetBulkParticipantJobRequest struct {
+	JobID string `json:"job_id" uri:"job_id"`
+}
+
+type BulkParticipantJobResponse struct {
+	JobID        string     `json:"job_id"`
+	Status       string     `json:"status"`
+	BatchSize    int        `json:"batch_size"`
+	DelaySeconds int        `json:"delay_seconds"`
+	Total        int        `json:"total"`
+	Processed    int        `json:"processed"`
+	Succeeded    int        `json:"succeeded"`
+	Failed       int        `json:"failed"`
+	Invited      int        `json:"invited"`
+	CreatedAt    time.Time  `json:"created_at"`
+	UpdatedAt    time.Time  `json:"updated_at"`
+	FinishedAt   *time.Time `json:"finished_at,omitempty"`
+}
+
+type BulkParticipantJobRow struct {
+	Row         int        `json:"row"`
+	GroupID     string     `json:"group_id"`
+	Phone       string     `json:"phone"`
+	Action      string     `json:"action"`
+	Status      string     `json:"status"`
+	Message     string     `json:"message"`
+	ProcessedAt *time.Time `json:"processed_at,omitempty"`
+}
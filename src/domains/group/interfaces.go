@@ -31,9 +31,29 @@ type IGroupSettings interface {
 	SetGroupTopic(ctx context.Context, request SetGroupTopicRequest) (err error)
 }
 
+// IGroupCommunity handles WhatsApp Community operations
+type IGroupCommunity interface {
+	CreateCommunity(ctx context.Context, request CreateCommunityRequest) (communityID string, err error)
+	LinkGroup(ctx context.Context, request LinkGroupRequest) (err error)
+	UnlinkGroup(ctx context.Context, request UnlinkGroupRequest) (err error)
+	GetLinkedGroups(ctx context.Context, request GetLinkedGroupsRequest) (response GetLinkedGroupsResponse, err error)
+	SendCommunityAnnouncement(ctx context.Context, request CommunityAnnouncementRequest) (response CommunityAnnouncementResponse, err error)
+}
+
+// IGroupBulk handles bulk participant jobs imported from CSV
+type IGroupBulk interface {
+	StartBulkParticipantJob(ctx context.Context, request BulkParticipantJobRequest) (response BulkParticipantJobResponse, err error)
+	GetBulkParticipantJob(ctx context.Context, request GetBulkParticipantJobRequest) (response BulkParticipantJobResponse, err error)
+	GetBulkParticipantJobRows(ctx context.Context, request GetBulkParticipantJobRequest) (rows []BulkParticipantJobRow, err error)
+	// FailInterruptedBulkJobs finishes the jobs a previous run left pending or running, call it once at startup
+	FailInterruptedBulkJobs() error
+}
+
 // IGroupUsecase combines all group interfaces for backward compatibility
 type IGroupUsecase interface {
 	IGroupManagement
 	IGroupParticipants
 	IGroupSettings
+	IGroupCommunity
+	IGroupBulk
 }
@@ -0,0 +1,15 @@
+package label
+
+import (
+	"context"
+)
+
+// ILabelUsecase manages WhatsApp Business labels and their assignment to chats and messages
+type ILabelUsecase interface {
+	ListLabels(ctx context.Context) (response ListLabelsResponse, err error)
+	CreateLabel(ctx context.Context, request CreateLabelRequest) (response Label, err error)
+	UpdateLabel(ctx context.Context, request UpdateLabelRequest) (response Label, err error)
+	DeleteLabel(ctx context.Context, request DeleteLabelRequest) (err error)
+	LabelChat(ctx context.Context, request LabelChatRequest) (response LabelChatResponse, err error)
+	LabelMessage(ctx context.Context, request LabelMessageRequest) (err error)
+}
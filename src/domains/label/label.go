@@ -0,0 +1,51 @@
+package label
+
+// Label is a WhatsApp Business label that can be applied to chats and messages
+type Label struct {
+	ID           string `json:"id"`
+	Name         string `json:"name"`
+	Color        int32  `json:"color"`
+	PredefinedID int32  `json:"predefined_id,omitempty"`
+	UpdatedAt    string `json:"updated_at"`
+}
+
+type ListLabelsResponse struct {
+	Data []Label `json:"data"`
+}
+
+type CreateLabelRequest struct {
+	Name string `json:"name" form:"name"`
+	// Color is the index of the label color in the WhatsApp palette, from 0 to 19
+	Color int32 `json:"color" form:"color"`
+}
+
+type UpdateLabelRequest struct {
+	LabelID string `json:"label_id" uri:"label_id"`
+	Name    string `json:"name" form:"name"`
+	Color   int32  `json:"color" form:"color"`
+}
+
+type DeleteLabelRequest struct {
+	LabelID string `json:"label_id" uri:"label_id"`
+}
+
+type LabelChatRequest struct {
+	ChatJID string `json:"chat_jid" uri:"chat_jid"`
+	LabelID string `json:"label_id" form:"label_id"`
+	Labeled bool   `json:"labeled" form:"labeled"`
+}
+
+type LabelChatResponse struct {
+	Status  string `json:"status"`
+	Message string `json:"message"`
+	ChatJID string `json:"chat_jid"`
+	LabelID string `json:"label_id"`
+	Labeled bool   `json:"labeled"`
+}
+
+type LabelMessageRequest struct {
+	MessageID string `json:"message_id" uri:"message_id"`
+	Phone     string `json:"phone" form:"phone"`
+	LabelID   string `json:"label_id" form:"label_id"`
+	Labeled   bool   `json:"labeled" form:"labeled"`
+}
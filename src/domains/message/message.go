@@ -8,6 +8,8 @@ type GenericResponse struct {
 type RevokeRequest struct {
 	MessageID string `json:"message_id" uri:"message_id"`
 	Phone     string `json:"phone" form:"phone"`
+	// Sender is the author of the message when revoking someone else's message as a group admin
+	Sender string `json:"sender,omitempty" form:"sender"`
 }
 
 type DeleteRequest struct {
@@ -49,5 +51,6 @@ type DownloadMediaResponse struct {
 	MediaType string `json:"media_type"`
 	Filename  string `json:"filename"`
 	FilePath  string `json:"file_path"`
+	FileURL   string `json:"file_url,omitempty"`
 	FileSize  int64  `json:"file_size"`
 }
@@ -0,0 +1,27 @@
+package moderation
+
+import (
+	"context"
+
+	"go.mau.fi/whatsmeow/types/events"
+)
+
+// IModerationSettings handles the per-group moderation configuration
+type IModerationSettings interface {
+	GetSettings(ctx context.Context, request GetSettingsRequest) (response Settings, err error)
+	ListSettings(ctx context.Context) (response []Settings, err error)
+	SetSettings(ctx context.Context, request Settings) (response Settings, err error)
+	DeleteSettings(ctx context.Context, request DeleteSettingsRequest) (err error)
+}
+
+// IModerationEnforcer applies the moderation rules to incoming group events
+type IModerationEnforcer interface {
+	HandleGroupMessage(ctx context.Context, evt *events.Message)
+	HandleParticipantsChange(ctx context.Context, evt *events.GroupInfo)
+}
+
+// IModerationUsecase combines all moderation interfaces
+type IModerationUsecase interface {
+	IModerationSettings
+	IModerationEnforcer
+}
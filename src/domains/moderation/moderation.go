@@ -0,0 +1,28 @@
+package moderation
+
+// Placeholders supported in welcome and goodbye templates
+const (
+	PlaceholderUser  = "{user}"
+	PlaceholderGroup = "{group}"
+)
+
+// Settings is the moderation configuration of a single group. Zero values disable the matching rule.
+type Settings struct {
+	GroupID            string   `json:"group_id" form:"group_id"`
+	WelcomeMessage     string   `json:"welcome_message" form:"welcome_message"`
+	GoodbyeMessage     string   `json:"goodbye_message" form:"goodbye_message"`
+	AntiLink           bool     `json:"anti_link" form:"anti_link"`
+	BannedWords        []string `json:"banned_words" form:"banned_words"`
+	FloodLimit         int      `json:"flood_limit" form:"flood_limit"`
+	FloodWindowSeconds int      `json:"flood_window_seconds" form:"flood_window_seconds"`
+	MaxWarnings        int      `json:"max_warnings" form:"max_warnings"`
+	UpdatedAt          string   `json:"updated_at,omitempty"`
+}
+
+type GetSettingsRequest struct {
+	GroupID string `json:"group_id" query:"group_id"`
+}
+
+type DeleteSettingsRequest struct {
+	GroupID string `json:"group_id" form:"group_id"`
+}
@@ -0,0 +1,33 @@
+package newsletter
+
+import "context"
+
+// INewsletterManagement handles creating, following and inspecting newsletters (channels)
+type INewsletterManagement interface {
+	Create(ctx context.Context, request CreateNewsletterRequest) (response NewsletterInfo, err error)
+	Follow(ctx context.Context, request FollowRequest) (err error)
+	Unfollow(ctx context.Context, request UnfollowRequest) (err error)
+	GetInfo(ctx context.Context, request GetInfoRequest) (response NewsletterInfo, err error)
+	GetInfoFromLink(ctx context.Context, request GetInfoFromLinkRequest) (response NewsletterInfo, err error)
+}
+
+// INewsletterSettings handles newsletter metadata and viewer settings
+type INewsletterSettings interface {
+	Update(ctx context.Context, request UpdateNewsletterRequest) (response NewsletterInfo, err error)
+	Mute(ctx context.Context, request MuteRequest) (err error)
+}
+
+// INewsletterMessages handles posting updates and reading recent posts
+type INewsletterMessages interface {
+	PostText(ctx context.Context, request PostTextRequest) (response PostResponse, err error)
+	PostImage(ctx context.Context, request PostImageRequest) (response PostResponse, err error)
+	PostVideo(ctx context.Context, request PostVideoRequest) (response PostResponse, err error)
+	GetMessages(ctx context.Context, request GetMessagesRequest) (response GetMessagesResponse, err error)
+}
+
+// INewsletterUsecase combines all newsletter interfaces
+type INewsletterUsecase interface {
+	INewsletterManagement
+	INewsletterSettings
+	INewsletterMessages
+}
@@ -1,11 +1,99 @@
 package newsletter
 
-import "context"
+import "mime/multipart"
 
-type INewsletterUsecase interface {
-	Unfollow(ctx context.Context, request UnfollowRequest) (err error)
+type CreateNewsletterRequest struct {
+	Name        string                `json:"name" form:"name"`
+	Description string                `json:"description" form:"description"`
+	Picture     *multipart.FileHeader `json:"picture" form:"picture"`
+}
+
+type FollowRequest struct {
+	NewsletterID string `json:"newsletter_id" form:"newsletter_id"`
 }
 
 type UnfollowRequest struct {
 	NewsletterID string `json:"newsletter_id" form:"newsletter_id"`
 }
+
+type GetInfoRequest struct {
+	NewsletterID string `json:"newsletter_id" query:"newsletter_id"`
+}
+
+type GetInfoFromLinkRequest struct {
+	Link string `json:"link" query:"link"`
+}
+
+// UpdateNewsletterRequest changes the channel metadata; nil fields are left untouched
+type UpdateNewsletterRequest struct {
+	NewsletterID string                `json:"newsletter_id" form:"newsletter_id"`
+	Name         *string               `json:"name" form:"name"`
+	Description  *string               `json:"description" form:"description"`
+	Picture      *multipart.FileHeader `json:"picture" form:"picture"`
+}
+
+type MuteRequest struct {
+	NewsletterID string `json:"newsletter_id" form:"newsletter_id"`
+	Mute         bool   `json:"mute" form:"mute"`
+}
+
+type NewsletterInfo struct {
+	ID              string `json:"id"`
+	Name            string `json:"name"`
+	Description     string `json:"description"`
+	InviteCode      string `json:"invite_code"`
+	InviteLink      string `json:"invite_link"`
+	SubscriberCount int    `json:"subscriber_count"`
+	Verification    string `json:"verification"`
+	State           string `json:"state"`
+	PictureURL      string `json:"picture_url,omitempty"`
+	Role            string `json:"role,omitempty"`
+	Mute            string `json:"mute,omitempty"`
+	CreatedAt       string `json:"created_at"`
+}
+
+type PostTextRequest struct {
+	NewsletterID string `json:"newsletter_id" form:"newsletter_id"`
+	Message      string `json:"message" form:"message"`
+}
+
+type PostImageRequest struct {
+	NewsletterID string                `json:"newsletter_id" form:"newsletter_id"`
+	Caption      string                `json:"caption" form:"caption"`
+	Image        *multipart.FileHeader `json:"image" form:"image"`
+	ImageURL     *string               `json:"image_url" form:"image_url"`
+}
+
+type PostVideoRequest struct {
+	NewsletterID string                `json:"newsletter_id" form:"newsletter_id"`
+	Caption      string                `json:"caption" form:"caption"`
+	Video        *multipart.FileHeader `json:"video" form:"video"`
+	VideoURL     *string               `json:"video_url" form:"video_url"`
+}
+
+type PostResponse struct {
+	MessageID string `json:"message_id"`
+	ServerID  int    `json:"server_id"`
+	Status    string `json:"status"`
+}
+
+type GetMessagesRequest struct {
+	NewsletterID string `json:"newsletter_id" query:"newsletter_id"`
+	Count        int    `json:"count" query:"count"`
+	Before       int    `json:"before" query:"before"`
+}
+
+type GetMessagesResponse struct {
+	Data []NewsletterMessage `json:"data"`
+}
+
+type NewsletterMessage struct {
+	ServerID  int            `json:"server_id"`
+	MessageID string         `json:"message_id"`
+	Type      string         `json:"type"`
+	Content   string         `json:"content"`
+	MediaType string         `json:"media_type,omitempty"`
+	Views     int            `json:"views"`
+	Reactions map[string]int `json:"reactions"`
+	Timestamp string         `json:"timestamp"`
+}
@@ -1,7 +1,8 @@
 package send
 
 type BaseRequest struct {
-	Phone       string `json:"phone" form:"phone"`
-	Duration    *int   `json:"duration,omitempty" form:"duration"`
-	IsForwarded bool   `json:"is_forwarded,omitempty" form:"is_forwarded"`
+	Phone          string `json:"phone" form:"phone"`
+	Duration       *int   `json:"duration,omitempty" form:"duration"`
+	IsForwarded    bool   `json:"is_forwarded,omitempty" form:"is_forwarded"`
+	IdempotencyKey string `json:"idempotency_key,omitempty" form:"idempotency_key"` // Retries with the same key return the first result
 }
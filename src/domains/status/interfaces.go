@@ -0,0 +1,22 @@
+package status
+
+import "context"
+
+// IStatusPublisher handles posting status (stories) updates
+type IStatusPublisher interface {
+	PostText(ctx context.Context, request TextStatusRequest) (response StatusResponse, err error)
+	PostImage(ctx context.Context, request ImageStatusRequest) (response StatusResponse, err error)
+	PostVideo(ctx context.Context, request VideoStatusRequest) (response StatusResponse, err error)
+}
+
+// IStatusViewer handles reading stored status updates and audience settings
+type IStatusViewer interface {
+	ListStatuses(ctx context.Context, request ListStatusesRequest) (response ListStatusesResponse, err error)
+	GetPrivacy(ctx context.Context) (response []StatusPrivacyResponse, err error)
+}
+
+// IStatusUsecase combines all status interfaces
+type IStatusUsecase interface {
+	IStatusPublisher
+	IStatusViewer
+}
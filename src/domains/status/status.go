@@ -0,0 +1,84 @@
+package status
+
+import (
+	"mime/multipart"
+	"time"
+)
+
+// Lifetime is how long WhatsApp keeps a status update visible
+const Lifetime = 24 * time.Hour
+
+// Status audiences, matching the status privacy lists stored on the WhatsApp account
+const (
+	AudienceContacts       = "contacts"
+	AudienceContactsExcept = "contacts-except"
+	AudienceOnlyShareWith  = "only-share-with"
+)
+
+// BaseStatusRequest holds the fields shared by every status post.
+// Audience is optional and defaults to the account's default status audience. WhatsApp delivers
+// status posts to that default audience only, so a different audience is rejected.
+type BaseStatusRequest struct {
+	Audience string `json:"audience" form:"audience"`
+}
+
+type TextStatusRequest struct {
+	BaseStatusRequest
+	Message         string `json:"message" form:"message"`
+	BackgroundColor string `json:"background_color" form:"background_color"`
+	Font            int    `json:"font" form:"font"`
+}
+
+type ImageStatusRequest struct {
+	BaseStatusRequest
+	Caption  string                `json:"caption" form:"caption"`
+	Image    *multipart.FileHeader `json:"image" form:"image"`
+	ImageURL *string               `json:"image_url" form:"image_url"`
+}
+
+type VideoStatusRequest struct {
+	BaseStatusRequest
+	Caption  string                `json:"caption" form:"caption"`
+	Video    *multipart.FileHeader `json:"video" form:"video"`
+	VideoURL *string               `json:"video_url" form:"video_url"`
+}
+
+type StatusResponse struct {
+	MessageID string `json:"message_id"`
+	Status    string `json:"status"`
+	// Privacy is the audience the post reached, one of the Audience constants
+	Privacy string `json:"privacy"`
+}
+
+type StatusPrivacyResponse struct {
+	Type      string   `json:"type"`
+	List      []string `json:"list"`
+	IsDefault bool     `json:"is_default"`
+}
+
+type ListStatusesRequest struct {
+	Sender         string `json:"sender" query:"sender"`
+	IncludeExpired bool   `json:"include_expired" query:"include_expired"`
+	Limit          int    `json:"limit" query:"limit"`
+	Offset         int    `json:"offset" query:"offset"`
+}
+
+type ListStatusesResponse struct {
+	Data   []StatusInfo `json:"data"`
+	Limit  int          `json:"limit"`
+	Offset int          `json:"offset"`
+}
+
+type StatusInfo struct {
+	ID        string `json:"id"`
+	Sender    string `json:"sender"`
+	PushName  string `json:"pushname"`
+	Content   string `json:"content"`
+	MediaType string `json:"media_type"`
+	MediaPath string `json:"media_path"`
+	MediaURL  string `json:"media_url,omitempty"`
+	MimeType  string `json:"mime_type"`
+	IsFromMe  bool   `json:"is_from_me"`
+	Timestamp string `json:"timestamp"`
+	ExpiresAt string `json:"expires_at"`
+}
@@ -47,6 +47,33 @@ type MyPrivacySettingResponse struct {
 	Status       string `json:"status"`
 	Profile      string `json:"profile"`
 	ReadReceipts string `json:"read_receipts"`
+	Online       string `json:"online"`
+	CallAdd      string `json:"call_add"`
+}
+
+// Privacy settings that can be changed, "about" is reported as "status" by MyPrivacySettingResponse
+const (
+	PrivacySettingLastSeen     = "last_seen"
+	PrivacySettingOnline       = "online"
+	PrivacySettingProfile      = "profile"
+	PrivacySettingAbout        = "about"
+	PrivacySettingGroupAdd     = "group_add"
+	PrivacySettingReadReceipts = "read_receipts"
+	PrivacySettingCallAdd      = "call_add"
+)
+
+type ChangePrivacySettingRequest struct {
+	Setting string `json:"setting" form:"setting"`
+	Value   string `json:"value" form:"value"`
+}
+
+type ChangeAboutRequest struct {
+	About string `json:"about" form:"about"`
+}
+
+type ChangeDisappearingTimerRequest struct {
+	// Timer is one of off, 24h, 7d or 90d
+	Timer string `json:"timer" form:"timer"`
 }
 
 type MyListGroupsResponse struct {
@@ -107,3 +134,16 @@ type BusinessProfileResponse struct {
 	BusinessHoursTimeZone string                       `json:"business_hours_timezone"`
 	BusinessHours         []BusinessProfileHoursConfig `json:"business_hours"`
 }
+
+type BlockRequest struct {
+	Phone string `json:"phone" form:"phone"`
+}
+
+type BlocklistResponse struct {
+	Data []BlockedContact `json:"data"`
+}
+
+type BlockedContact struct {
+	JID       string `json:"jid"`
+	BlockedAt string `json:"blocked_at"`
+}
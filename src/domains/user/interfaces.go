@@ -16,6 +16,7 @@ type IUserProfile interface {
 	Avatar(ctx context.Context, request AvatarRequest) (response AvatarResponse, err error)
 	ChangeAvatar(ctx context.Context, request ChangeAvatarRequest) (err error)
 	ChangePushName(ctx context.Context, request ChangePushNameRequest) (err error)
+	ChangeAbout(ctx context.Context, request ChangeAboutRequest) (err error)
 }
 
 // IUserListing handles user listing operations
@@ -28,6 +29,15 @@ type IUserListing interface {
 // IUserPrivacy handles user privacy operations
 type IUserPrivacy interface {
 	MyPrivacySetting(ctx context.Context) (response MyPrivacySettingResponse, err error)
+	ChangePrivacySetting(ctx context.Context, request ChangePrivacySettingRequest) (response MyPrivacySettingResponse, err error)
+	ChangeDefaultDisappearingTimer(ctx context.Context, request ChangeDisappearingTimerRequest) (err error)
+}
+
+// IUserBlocklist handles blocking and unblocking contacts
+type IUserBlocklist interface {
+	Block(ctx context.Context, request BlockRequest) (response BlocklistResponse, err error)
+	Unblock(ctx context.Context, request BlockRequest) (response BlocklistResponse, err error)
+	Blocklist(ctx context.Context) (response BlocklistResponse, err error)
 }
 
 // IUserUsecase combines all user interfaces for backward compatibility
@@ -36,4 +46,5 @@ type IUserUsecase interface {
 	IUserProfile
 	IUserListing
 	IUserPrivacy
+	IUserBlocklist
 }
@@ -0,0 +1,94 @@
+package webhook
+
+import "time"
+
+// Payload schemas a webhook can be configured to receive
+const (
+	SchemaV1 = "v1"
+	SchemaV2 = "v2"
+)
+
+// SchemaVersion is the schema_version of every v2 payload
+const SchemaVersion = "2"
+
+// Event types of v2 message payloads. Other events keep the type of their v1 "event" field.
+const (
+	EventMessage        = "message"
+	EventMessageRevoked = "message.revoked"
+	EventMessageEdited  = "message.edited"
+)
+
+// Event is a v2 webhook payload. Message events carry Message, the other events carry their v1 body in Data.
+type Event struct {
+	SchemaVersion string         `json:"schema_version" jsonschema:"enum=2"`
+	ID            string         `json:"id"`
+	Event         string         `json:"event"`
+	Device        string         `json:"device"`
+	Timestamp     time.Time      `json:"timestamp"`
+	Message       *Message       `json:"message,omitempty"`
+	Data          map[string]any `json:"data,omitempty"`
+}
+
+// Message is a message received or sent by the device
+type Message struct {
+	ID        string    `json:"id"`
+	ChatJID   string    `json:"chat_jid"`
+	SenderJID string    `json:"sender_jid"`
+	SenderLID string    `json:"sender_lid,omitempty"`
+	PushName  string    `json:"pushname,omitempty"`
+	IsFromMe  bool      `json:"is_from_me"`
+	IsGroup   bool      `json:"is_group"`
+	Type      string    `json:"type" jsonschema:"enum=text,enum=image,enum=video,enum=audio,enum=document,enum=sticker,enum=contact,enum=location,enum=poll,enum=reaction,enum=protocol,enum=other,enum=unknown"`
+	Text      string    `json:"text,omitempty"`
+	Timestamp time.Time `json:"timestamp"`
+	Forwarded bool      `json:"forwarded,omitempty"`
+	ViewOnce  bool      `json:"view_once,omitempty"`
+	// TargetMessageID is the message revoked or edited by a message.revoked or message.edited event
+	TargetMessageID string    `json:"target_message_id,omitempty"`
+	ServerID        int       `json:"server_id,omitempty"`
+	Quoted          *Quoted   `json:"quoted,omitempty"`
+	Media           *Media    `json:"media,omitempty"`
+	Reaction        *Reaction `json:"reaction,omitempty"`
+	Location        *Location `json:"location,omitempty"`
+	Contacts        []Contact `json:"contacts,omitempty"`
+}
+
+// Quoted is the message a message replies to
+type Quoted struct {
+	MessageID string `json:"message_id"`
+	SenderJID string `json:"sender_jid,omitempty"`
+	Text      string `json:"text,omitempty"`
+}
+
+// Media is delivered as a URL, inline as base64 when small enough, or both. Error is set instead when the
+// download failed, the rest of the message is still delivered.
+type Media struct {
+	MimeType string `json:"mime_type"`
+	FileName string `json:"file_name,omitempty"`
+	FileSize int64  `json:"file_size,omitempty"`
+	URL      string `json:"url,omitempty"`
+	Base64   string `json:"base64,omitempty"`
+	Error    string `json:"error,omitempty"`
+}
+
+// Reaction is a reaction to another message
+type Reaction struct {
+	MessageID string `json:"message_id"`
+	// Emoji is empty when the reaction was removed
+	Emoji string `json:"emoji"`
+}
+
+// Location is a shared location, Live when it is updated by the sender
+type Location struct {
+	Latitude  float64 `json:"latitude"`
+	Longitude float64 `json:"longitude"`
+	Name      string  `json:"name,omitempty"`
+	Address   string  `json:"address,omitempty"`
+	Live      bool    `json:"live,omitempty"`
+}
+
+// Contact is a shared contact card
+type Contact struct {
+	DisplayName string `json:"display_name"`
+	VCard       string `json:"vcard"`
+}
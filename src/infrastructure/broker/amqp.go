@@ -0,0 +1,167 @@
+package broker
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	amqp "github.com/rabbitmq/amqp091-go"
+	"github.com/sirupsen/logrus"
+)
+
+// amqpPublisher publishes persistent messages to a durable topic exchange with the event type
+// as routing key, and waits for the broker to confirm each of them
+type amqpPublisher struct {
+	mu       sync.Mutex
+	url      string
+	exchange string
+	conn     *amqp.Connection
+	channel  *amqp.Channel
+}
+
+func newAMQPPublisher(url, exchange string) (*amqpPublisher, error) {
+	p := &amqpPublisher{url: url, exchange: exchange}
+	if err := p.connect(); err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+// connect opens the connection and channel. Callers other than the constructor must hold p.mu.
+func (p *amqpPublisher) connect() error {
+	conn, err := amqp.Dial(p.url)
+	if err != nil {
+		return fmt.Errorf("failed to connect to AMQP broker: %w", err)
+	}
+	channel, err := conn.Channel()
+	if err != nil {
+		_ = conn.Close()
+		return fmt.Errorf("failed to open AMQP channel: %w", err)
+	}
+	if err := channel.ExchangeDeclare(p.exchange, amqp.ExchangeTopic, true, false, false, false, nil); err != nil {
+		_ = conn.Close()
+		return fmt.Errorf("failed to declare AMQP exchange %s: %w", p.exchange, err)
+	}
+	if err := channel.Confirm(false); err != nil {
+		_ = conn.Close()
+		return fmt.Errorf("failed to enable AMQP publisher confirms: %w", err)
+	}
+
+	p.conn = conn
+	p.channel = channel
+	return nil
+}
+
+func (p *amqpPublisher) Publish(ctx context.Context, event Envelope) error {
+	data, err := marshal(event)
+	if err != nil {
+		return err
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	// The client does not reconnect by itself, so open a new connection after the broker went away
+	if p.channel == nil || p.channel.IsClosed() {
+		if p.conn != nil {
+			_ = p.conn.Close()
+		}
+		if err := p.connect(); err != nil {
+			return err
+		}
+	}
+
+	confirmation, err := p.channel.PublishWithDeferredConfirmWithContext(ctx, p.exchange, event.Type, false, false, amqp.Publishing{
+		ContentType:  "application/json",
+		DeliveryMode: amqp.Persistent,
+		MessageId:    event.ID,
+		Type:         event.Type,
+		Timestamp:    event.Timestamp,
+		Body:         data,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to publish to AMQP exchange %s: %w", p.exchange, err)
+	}
+
+	acked, err := confirmation.WaitContext(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to confirm AMQP publish to exchange %s: %w", p.exchange, err)
+	}
+	if !acked {
+		return fmt.Errorf("AMQP broker rejected the event %s", event.ID)
+	}
+	return nil
+}
+
+func (p *amqpPublisher) Close() error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.conn == nil {
+		return nil
+	}
+	return p.conn.Close()
+}
+
+// amqpConsumer reads commands from a durable queue one at a time, reconnecting when the broker goes away
+type amqpConsumer struct {
+	url   string
+	queue string
+}
+
+func newAMQPConsumer(url, queue string) *amqpConsumer {
+	return &amqpConsumer{url: url, queue: queue}
+}
+
+func (c *amqpConsumer) Consume(ctx context.Context, handle HandleFunc) error {
+	for {
+		err := c.consume(ctx, handle)
+		if ctx.Err() != nil {
+			return nil
+		}
+		logrus.Warnf("[BROKER] AMQP command queue %s stopped, reconnecting in %s: %v", c.queue, redeliveryDelay, err)
+		if !sleep(ctx, redeliveryDelay) {
+			return nil
+		}
+	}
+}
+
+func (c *amqpConsumer) consume(ctx context.Context, handle HandleFunc) error {
+	conn, err := amqp.Dial(c.url)
+	if err != nil {
+		return fmt.Errorf("failed to connect to AMQP broker: %w", err)
+	}
+	defer conn.Close()
+
+	channel, err := conn.Channel()
+	if err != nil {
+		return fmt.Errorf("failed to open AMQP channel: %w", err)
+	}
+	if _, err := channel.QueueDeclare(c.queue, true, false, false, false, nil); err != nil {
+		return fmt.Errorf("failed to declare AMQP queue %s: %w", c.queue, err)
+	}
+	if err := channel.Qos(1, 0, false); err != nil {
+		return fmt.Errorf("failed to set AMQP prefetch: %w", err)
+	}
+	deliveries, err := channel.ConsumeWithContext(ctx, c.queue, "", false, false, false, false, nil)
+	if err != nil {
+		return fmt.Errorf("failed to consume AMQP queue %s: %w", c.queue, err)
+	}
+
+	for delivery := range deliveries {
+		if err := handle(ctx, delivery.Body); err != nil {
+			// Requeueing at once would hand the command straight back, wait before returning it
+			sleep(ctx, redeliveryDelay)
+			_ = delivery.Nack(false, true)
+			continue
+		}
+		if err := delivery.Ack(false); err != nil {
+			logrus.Warnf("[BROKER] Failed to acknowledge AMQP command: %v", err)
+		}
+	}
+	return fmt.Errorf("delivery channel closed")
+}
+
+func (c *amqpConsumer) Close() error {
+	return nil
+}
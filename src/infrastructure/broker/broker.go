@@ -0,0 +1,154 @@
+package broker
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	KindNone  = "none"
+	KindNATS  = "nats"
+	KindKafka = "kafka"
+	KindRedis = "redis"
+	KindAMQP  = "amqp"
+)
+
+// Envelope wraps every published event. Payload holds the same body that is sent to webhooks.
+type Envelope struct {
+	ID        string         `json:"id"`
+	Type      string         `json:"type"`
+	Device    string         `json:"device"`
+	Timestamp time.Time      `json:"timestamp"`
+	Payload   map[string]any `json:"payload"`
+}
+
+// Publisher delivers events to a message broker
+type Publisher interface {
+	// Publish returns once the broker accepted the event, or with the error that prevented it
+	Publish(ctx context.Context, event Envelope) error
+	Close() error
+}
+
+// HandleFunc processes one command. Returning an error leaves the command unacknowledged so it is
+// delivered again after redeliveryDelay.
+type HandleFunc func(ctx context.Context, data []byte) error
+
+// Consumer reads commands from a message broker
+type Consumer interface {
+	// Consume calls handle for every command until ctx is done, acknowledging a command once handle returned nil
+	Consume(ctx context.Context, handle HandleFunc) error
+	Close() error
+}
+
+// redeliveryDelay is how long a command that could not be handled waits before it is delivered again
+const redeliveryDelay = 10 * time.Second
+
+// NewEnvelope wraps payload in an envelope with a new unique ID
+func NewEnvelope(eventType, device string, payload map[string]any) Envelope {
+	return Envelope{
+		ID:        uuid.NewString(),
+		Type:      eventType,
+		Device:    device,
+		Timestamp: time.Now().UTC(),
+		Payload:   payload,
+	}
+}
+
+// New connects to the broker of the given kind. It returns a nil publisher when kind is empty or none.
+//
+// The topic is the NATS subject prefix, the Kafka topic, the Redis stream key or the AMQP topic exchange.
+func New(kind, url, topic string) (Publisher, error) {
+	if kind == "" || kind == KindNone {
+		return nil, nil
+	}
+	if url == "" {
+		return nil, fmt.Errorf("event broker %s needs a URL", kind)
+	}
+	if topic == "" {
+		return nil, fmt.Errorf("event broker %s needs a topic", kind)
+	}
+
+	switch kind {
+	case KindNATS:
+		return newNATSPublisher(url, topic)
+	case KindKafka:
+		return newKafkaPublisher(url, topic), nil
+	case KindRedis:
+		return newRedisPublisher(url, topic)
+	case KindAMQP:
+		return newAMQPPublisher(url, topic)
+	default:
+		return nil, fmt.Errorf("unknown event broker %q, use none, nats, kafka, redis or amqp", kind)
+	}
+}
+
+// NewConsumer connects to the broker of the given kind to read commands from topic, which is the NATS subject,
+// the Redis stream or the AMQP queue. It returns a nil consumer when kind is none or topic is empty.
+func NewConsumer(kind, url, topic string) (Consumer, error) {
+	if kind == "" || kind == KindNone || topic == "" {
+		return nil, nil
+	}
+	if url == "" {
+		return nil, fmt.Errorf("event broker %s needs a URL", kind)
+	}
+
+	switch kind {
+	case KindNATS:
+		return newNATSConsumer(url, topic)
+	case KindRedis:
+		return newRedisConsumer(url, topic)
+	case KindAMQP:
+		return newAMQPConsumer(url, topic), nil
+	case KindKafka:
+		return nil, fmt.Errorf("commands are not supported with kafka, use nats, redis or amqp")
+	default:
+		return nil, fmt.Errorf("unknown event broker %q, use nats, redis or amqp", kind)
+	}
+}
+
+// sleep waits for d, returning false when ctx is done first
+func sleep(ctx context.Context, d time.Duration) bool {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return false
+	case <-timer.C:
+		return true
+	}
+}
+
+// routingKey returns the NATS subject of an event, e.g. gowa.events.message.ack
+func routingKey(topic string, event Envelope) string {
+	return topic + "." + event.Type
+}
+
+// splitAddresses turns "kafka://host1:9092,host2:9092" into a list of host:port addresses
+func splitAddresses(url string) []string {
+	_, hosts, found := strings.Cut(url, "://")
+	if !found {
+		hosts = url
+	}
+
+	var addresses []string
+	for _, address := range strings.Split(hosts, ",") {
+		if address = strings.TrimSpace(address); address != "" {
+			addresses = append(addresses, address)
+		}
+	}
+	return addresses
+}
+
+func marshal(event Envelope) ([]byte, error) {
+	data, err := json.Marshal(event)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal event %s: %w", event.ID, err)
+	}
+	return data, nil
+}
@@ -0,0 +1,89 @@
+package broker
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNew(t *testing.T) {
+	tests := []struct {
+		name    string
+		kind    string
+		url     string
+		topic   string
+		wantErr string
+	}{
+		{name: "empty kind disables publishing", kind: ""},
+		{name: "none disables publishing", kind: KindNone},
+		{name: "missing url", kind: KindNATS, topic: "gowa.events", wantErr: "needs a URL"},
+		{name: "missing topic", kind: KindKafka, url: "localhost:9092", wantErr: "needs a topic"},
+		{name: "unknown kind", kind: "mqtt", url: "mqtt://localhost", topic: "gowa.events", wantErr: "unknown event broker"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			publisher, err := New(tt.kind, tt.url, tt.topic)
+			if tt.wantErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Nil(t, publisher)
+		})
+	}
+}
+
+func TestNewEnvelope(t *testing.T) {
+	payload := map[string]any{"event": "message.ack"}
+
+	first := NewEnvelope("message.ack", "6281234567890:1@s.whatsapp.net", payload)
+	second := NewEnvelope("message.ack", "6281234567890:1@s.whatsapp.net", payload)
+
+	assert.NotEmpty(t, first.ID)
+	assert.NotEqual(t, first.ID, second.ID)
+	assert.Equal(t, "message.ack", first.Type)
+	assert.Equal(t, "6281234567890:1@s.whatsapp.net", first.Device)
+	assert.False(t, first.Timestamp.IsZero())
+	assert.Equal(t, payload, first.Payload)
+}
+
+func TestRoutingKey(t *testing.T) {
+	assert.Equal(t, "gowa.events.message.ack", routingKey("gowa.events", Envelope{Type: "message.ack"}))
+}
+
+func TestSplitAddresses(t *testing.T) {
+	assert.Equal(t, []string{"localhost:9092"}, splitAddresses("localhost:9092"))
+	assert.Equal(t, []string{"kafka1:9092", "kafka2:9092"}, splitAddresses("kafka://kafka1:9092, kafka2:9092"))
+}
+
+func TestNewConsumer(t *testing.T) {
+	tests := []struct {
+		name    string
+		kind    string
+		url     string
+		topic   string
+		wantErr string
+	}{
+		{name: "no broker disables commands", kind: KindNone, topic: "gowa.commands"},
+		{name: "no topic disables commands", kind: KindNATS, url: "nats://localhost:4222"},
+		{name: "missing url", kind: KindRedis, topic: "gowa.commands", wantErr: "needs a URL"},
+		{name: "kafka is not supported", kind: KindKafka, url: "localhost:9092", topic: "gowa.commands", wantErr: "not supported with kafka"},
+		{name: "unknown kind", kind: "mqtt", url: "mqtt://localhost", topic: "gowa.commands", wantErr: "unknown event broker"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			consumer, err := NewConsumer(tt.kind, tt.url, tt.topic)
+			if tt.wantErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Nil(t, consumer)
+		})
+	}
+}
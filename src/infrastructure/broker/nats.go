@@ -0,0 +1,133 @@
+package broker
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/nats-io/nats.go"
+	"github.com/nats-io/nats.go/jetstream"
+	"github.com/sirupsen/logrus"
+)
+
+// natsPublisher publishes to JetStream, so events are kept until consumers read them.
+// A stream capturing "<topic>.>" must exist on the server.
+type natsPublisher struct {
+	conn  *nats.Conn
+	js    jetstream.JetStream
+	topic string
+}
+
+func newNATSPublisher(url, topic string) (*natsPublisher, error) {
+	conn, err := nats.Connect(url, nats.Name("gowa"), nats.MaxReconnects(-1))
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to NATS: %w", err)
+	}
+	js, err := jetstream.New(conn)
+	if err != nil {
+		conn.Close()
+		return nil, fmt.Errorf("failed to open JetStream: %w", err)
+	}
+	return &natsPublisher{conn: conn, js: js, topic: topic}, nil
+}
+
+func (p *natsPublisher) Publish(ctx context.Context, event Envelope) error {
+	data, err := marshal(event)
+	if err != nil {
+		return err
+	}
+
+	msg := nats.NewMsg(routingKey(p.topic, event))
+	msg.Data = data
+	msg.Header.Set("Content-Type", "application/json")
+
+	// The message ID lets JetStream drop duplicates of a retried publish
+	if _, err := p.js.PublishMsg(ctx, msg, jetstream.WithMsgID(event.ID)); err != nil {
+		return fmt.Errorf("failed to publish to NATS subject %s: %w", msg.Subject, err)
+	}
+	return nil
+}
+
+func (p *natsPublisher) Close() error {
+	return p.conn.Drain()
+}
+
+const (
+	natsCommandConsumer = "gowa-commands"
+	// natsCommandAckWait leaves room for uploading large media before JetStream delivers the command again
+	natsCommandAckWait = 5 * time.Minute
+)
+
+// natsConsumer reads commands with a durable JetStream consumer.
+// A stream capturing the command subject must exist on the server.
+type natsConsumer struct {
+	conn    *nats.Conn
+	js      jetstream.JetStream
+	subject string
+}
+
+func newNATSConsumer(url, subject string) (*natsConsumer, error) {
+	conn, err := nats.Connect(url, nats.Name("gowa"), nats.MaxReconnects(-1))
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to NATS: %w", err)
+	}
+	js, err := jetstream.New(conn)
+	if err != nil {
+		conn.Close()
+		return nil, fmt.Errorf("failed to open JetStream: %w", err)
+	}
+	return &natsConsumer{conn: conn, js: js, subject: subject}, nil
+}
+
+func (c *natsConsumer) Consume(ctx context.Context, handle HandleFunc) error {
+	streamName, err := c.js.StreamNameBySubject(ctx, c.subject)
+	if err != nil {
+		return fmt.Errorf("no JetStream stream captures subject %s: %w", c.subject, err)
+	}
+	stream, err := c.js.Stream(ctx, streamName)
+	if err != nil {
+		return fmt.Errorf("failed to open JetStream stream %s: %w", streamName, err)
+	}
+	consumer, err := stream.CreateOrUpdateConsumer(ctx, jetstream.ConsumerConfig{
+		Durable:       natsCommandConsumer,
+		FilterSubject: c.subject,
+		AckPolicy:     jetstream.AckExplicitPolicy,
+		AckWait:       natsCommandAckWait,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create JetStream consumer on %s: %w", streamName, err)
+	}
+
+	messages, err := consumer.Messages()
+	if err != nil {
+		return fmt.Errorf("failed to read JetStream consumer %s: %w", natsCommandConsumer, err)
+	}
+	go func() {
+		<-ctx.Done()
+		messages.Stop()
+	}()
+
+	for {
+		msg, err := messages.Next()
+		if errors.Is(err, jetstream.ErrMsgIteratorClosed) {
+			return nil
+		}
+		if err != nil {
+			logrus.Warnf("[BROKER] Failed to read command from NATS: %v", err)
+			continue
+		}
+
+		if err := handle(ctx, msg.Data()); err != nil {
+			_ = msg.NakWithDelay(redeliveryDelay)
+			continue
+		}
+		if err := msg.Ack(); err != nil {
+			logrus.Warnf("[BROKER] Failed to acknowledge NATS command: %v", err)
+		}
+	}
+}
+
+func (c *natsConsumer) Close() error {
+	return c.conn.Drain()
+}
@@ -0,0 +1,146 @@
+package broker
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/sirupsen/logrus"
+)
+
+// redisPublisher appends events to a Redis stream, to be read with XREAD or consumer groups
+type redisPublisher struct {
+	client *redis.Client
+	stream string
+}
+
+func newRedisPublisher(url, stream string) (*redisPublisher, error) {
+	options, err := redis.ParseURL(url)
+	if err != nil {
+		return nil, fmt.Errorf("invalid Redis URL: %w", err)
+	}
+
+	client := redis.NewClient(options)
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	if err := client.Ping(ctx).Err(); err != nil {
+		_ = client.Close()
+		return nil, fmt.Errorf("failed to connect to Redis: %w", err)
+	}
+	return &redisPublisher{client: client, stream: stream}, nil
+}
+
+func (p *redisPublisher) Publish(ctx context.Context, event Envelope) error {
+	data, err := marshal(event)
+	if err != nil {
+		return err
+	}
+
+	err = p.client.XAdd(ctx, &redis.XAddArgs{
+		Stream: p.stream,
+		Values: map[string]any{
+			"id":    event.ID,
+			"type":  event.Type,
+			"event": data,
+		},
+	}).Err()
+	if err != nil {
+		return fmt.Errorf("failed to publish to Redis stream %s: %w", p.stream, err)
+	}
+	return nil
+}
+
+func (p *redisPublisher) Close() error {
+	return p.client.Close()
+}
+
+const (
+	redisCommandGroup = "gowa"
+	redisReadBlock    = 5 * time.Second
+	redisReadCount    = 10
+)
+
+// redisConsumer reads commands with a consumer group. Commands that could not be handled stay pending
+// and are claimed again once they were idle for redeliveryDelay, also after a restart of the gateway.
+type redisConsumer struct {
+	client   *redis.Client
+	stream   string
+	consumer string
+}
+
+func newRedisConsumer(url, stream string) (*redisConsumer, error) {
+	options, err := redis.ParseURL(url)
+	if err != nil {
+		return nil, fmt.Errorf("invalid Redis URL: %w", err)
+	}
+
+	consumer, err := os.Hostname()
+	if err != nil || consumer == "" {
+		consumer = "gowa"
+	}
+	return &redisConsumer{client: redis.NewClient(options), stream: stream, consumer: consumer}, nil
+}
+
+func (c *redisConsumer) Consume(ctx context.Context, handle HandleFunc) error {
+	err := c.client.XGroupCreateMkStream(ctx, c.stream, redisCommandGroup, "0").Err()
+	if err != nil && !strings.HasPrefix(err.Error(), "BUSYGROUP") {
+		return fmt.Errorf("failed to create Redis consumer group on %s: %w", c.stream, err)
+	}
+
+	for ctx.Err() == nil {
+		claimed, _, err := c.client.XAutoClaim(ctx, &redis.XAutoClaimArgs{
+			Stream:   c.stream,
+			Group:    redisCommandGroup,
+			Consumer: c.consumer,
+			MinIdle:  redeliveryDelay,
+			Start:    "0-0",
+			Count:    redisReadCount,
+		}).Result()
+		if err == nil {
+			c.handleMessages(ctx, claimed, handle)
+		}
+
+		streams, err := c.client.XReadGroup(ctx, &redis.XReadGroupArgs{
+			Group:    redisCommandGroup,
+			Consumer: c.consumer,
+			Streams:  []string{c.stream, ">"},
+			Count:    redisReadCount,
+			Block:    redisReadBlock,
+		}).Result()
+		if errors.Is(err, redis.Nil) {
+			continue
+		}
+		if err != nil {
+			if ctx.Err() != nil {
+				break
+			}
+			logrus.Warnf("[BROKER] Failed to read commands from Redis stream %s: %v", c.stream, err)
+			sleep(ctx, redeliveryDelay)
+			continue
+		}
+		for _, stream := range streams {
+			c.handleMessages(ctx, stream.Messages, handle)
+		}
+	}
+	return nil
+}
+
+func (c *redisConsumer) handleMessages(ctx context.Context, messages []redis.XMessage, handle HandleFunc) {
+	for _, message := range messages {
+		data, _ := message.Values["command"].(string)
+		if err := handle(ctx, []byte(data)); err != nil {
+			continue
+		}
+		if err := c.client.XAck(ctx, c.stream, redisCommandGroup, message.ID).Err(); err != nil {
+			logrus.Warnf("[BROKER] Failed to acknowledge Redis command %s: %v", message.ID, err)
+		}
+	}
+}
+
+func (c *redisConsumer) Close() error {
+	return c.client.Close()
+}
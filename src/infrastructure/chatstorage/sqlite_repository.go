@@ -3,13 +3,16 @@ package chatstorage
 import (
 	"context"
 	"database/sql"
+	"encoding/json"
 	"fmt"
 	"strings"
 	"time"
 
 	domainChatStorage "github.com/aldinokemal/go-whatsapp-web-multidevice/domains/chatstorage"
+	"github.com/aldinokemal/go-whatsapp-web-multidevice/pkg/tracing"
 	"github.com/aldinokemal/go-whatsapp-web-multidevice/pkg/utils"
 	"github.com/sirupsen/logrus"
+	"go.mau.fi/whatsmeow/proto/waE2E"
 	"go.mau.fi/whatsmeow/types"
 	"go.mau.fi/whatsmeow/types/events"
 )
@@ -46,7 +49,8 @@ func (r *SQLiteRepository) StoreChat(chat *domainChatStorage.Chat) error {
 // GetChat retrieves a chat by JID
 func (r *SQLiteRepository) GetChat(jid string) (*domainChatStorage.Chat, error) {
 	query := `
-		SELECT jid, name, last_message_time, ephemeral_expiration, created_at, updated_at
+		SELECT jid, name, last_message_time, ephemeral_expiration, pinned, archived, muted_until, unread,
+			(SELECT GROUP_CONCAT(label_id) FROM chat_labels WHERE chat_jid = chats.jid), created_at, updated_at
 		FROM chats
 		WHERE jid = ?
 	`
@@ -65,7 +69,8 @@ func (r *SQLiteRepository) GetMessageByID(id string) (*domainChatStorage.Message
 	query := `
 		SELECT id, chat_jid, sender, content, timestamp, is_from_me,
 			media_type, filename, url, media_key, file_sha256,
-			file_enc_sha256, file_length, created_at, updated_at
+			file_enc_sha256, file_length, starred, edited_at, revoked, revoked_at,
+			quoted_message_id, mentions, created_at, updated_at
 		FROM messages
 		WHERE id = ?
 		LIMIT 1
@@ -85,7 +90,8 @@ func (r *SQLiteRepository) GetChats(filter *domainChatStorage.ChatFilter) ([]*do
 	var args []any
 
 	query := `
-		SELECT c.jid, c.name, c.last_message_time, c.ephemeral_expiration, c.created_at, c.updated_at
+		SELECT c.jid, c.name, c.last_message_time, c.ephemeral_expiration, c.pinned, c.archived, c.muted_until, c.unread,
+			(SELECT GROUP_CONCAT(label_id) FROM chat_labels WHERE chat_jid = c.jid), c.created_at, c.updated_at
 		FROM chats c
 	`
 
@@ -99,6 +105,35 @@ func (r *SQLiteRepository) GetChats(filter *domainChatStorage.ChatFilter) ([]*do
 		conditions = append(conditions, "m.media_type != ''")
 	}
 
+	if filter.Pinned != nil {
+		conditions = append(conditions, "c.pinned = ?")
+		args = append(args, *filter.Pinned)
+	}
+
+	if filter.Archived != nil {
+		conditions = append(conditions, "c.archived = ?")
+		args = append(args, *filter.Archived)
+	}
+
+	if filter.Muted != nil {
+		muted := "(c.muted_until = ? OR c.muted_until > ?)"
+		if !*filter.Muted {
+			muted = "NOT " + muted
+		}
+		conditions = append(conditions, muted)
+		args = append(args, domainChatStorage.MutedForever, time.Now().Unix())
+	}
+
+	if filter.Unread != nil {
+		conditions = append(conditions, "c.unread = ?")
+		args = append(args, *filter.Unread)
+	}
+
+	if filter.LabelID != "" {
+		conditions = append(conditions, "c.jid IN (SELECT chat_jid FROM chat_labels WHERE label_id = ?)")
+		args = append(args, filter.LabelID)
+	}
+
 	if len(conditions) > 0 {
 		query += " WHERE " + strings.Join(conditions, " AND ")
 	}
@@ -152,6 +187,12 @@ func (r *SQLiteRepository) DeleteChat(jid string) error {
 		return err
 	}
 
+	for _, table := range []string{"message_edits", "message_reactions"} {
+		if _, err = tx.Exec("DELETE FROM "+table+" WHERE chat_jid = ?", jid); err != nil {
+			return err
+		}
+	}
+
 	// Delete chat
 	_, err = tx.Exec("DELETE FROM chats WHERE jid = ?", jid)
 	if err != nil {
@@ -161,6 +202,66 @@ func (r *SQLiteRepository) DeleteChat(jid string) error {
 	return tx.Commit()
 }
 
+// UpdateChatState persists the app state of a chat. Chats that are not stored yet are created,
+// so the state is kept when their first message arrives later.
+func (r *SQLiteRepository) UpdateChatState(jid string, update *domainChatStorage.ChatStateUpdate) error {
+	var columns []string
+	var args []any
+
+	if update.Pinned != nil {
+		columns = append(columns, "pinned")
+		args = append(args, *update.Pinned)
+	}
+	if update.Archived != nil {
+		columns = append(columns, "archived")
+		args = append(args, *update.Archived)
+	}
+	if update.MutedUntil != nil {
+		columns = append(columns, "muted_until")
+		args = append(args, *update.MutedUntil)
+	}
+	if update.Unread != nil {
+		columns = append(columns, "unread")
+		args = append(args, *update.Unread)
+	}
+	if update.EphemeralExpiration != nil {
+		columns = append(columns, "ephemeral_expiration")
+		args = append(args, *update.EphemeralExpiration)
+	}
+	if len(columns) == 0 {
+		return nil
+	}
+
+	name := jid
+	if parsedJID, err := types.ParseJID(jid); err == nil {
+		name = r.GetChatNameWithPushName(parsedJID, jid, parsedJID.User, "")
+	}
+
+	sets := make([]string, 0, len(columns)+1)
+	for _, column := range columns {
+		sets = append(sets, column+" = excluded."+column)
+	}
+	sets = append(sets, "updated_at = excluded.updated_at")
+
+	now := time.Now()
+	query := "INSERT INTO chats (jid, name, last_message_time, created_at, updated_at, " + strings.Join(columns, ", ") + ")" +
+		" VALUES (?, ?, ?, ?, ?" + strings.Repeat(", ?", len(columns)) + ")" +
+		" ON CONFLICT(jid) DO UPDATE SET " + strings.Join(sets, ", ")
+	_, err := r.db.Exec(query, append([]any{jid, name, time.Time{}, now, now}, args...)...)
+	return err
+}
+
+// ClearChatMessages deletes all messages of a chat with their revisions and reactions and keeps the chat itself
+func (r *SQLiteRepository) ClearChatMessages(jid string) error {
+	for _, table := range []string{"message_edits", "message_reactions"} {
+		if _, err := r.db.Exec("DELETE FROM "+table+" WHERE chat_jid = ?", jid); err != nil {
+			return err
+		}
+	}
+	_, err := r.db.Exec("DELETE FROM messages WHERE chat_jid = ?", jid)
+	return err
+}
+
 // StoreMessage creates or updates a message
 func (r *SQLiteRepository) StoreMessage(message *domainChatStorage.Message) error {
 	now := time.Now()
@@ -177,11 +278,12 @@ func (r *SQLiteRepository) StoreMessage(message *domainChatStorage.Message) erro
 		INSERT INTO messages (
 			id, chat_jid, sender, content, timestamp, is_from_me, 
 			media_type, filename, url, media_key, file_sha256, 
-			file_enc_sha256, file_length, created_at, updated_at
-		) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+			file_enc_sha256, file_length, quoted_message_id, mentions, created_at, updated_at
+		) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
 		ON CONFLICT(id, chat_jid) DO UPDATE SET
 			sender = excluded.sender,
-			content = excluded.content,
+			-- Edited and revoked messages keep their current content when the original is stored again
+			content = CASE WHEN messages.edited_at IS NULL AND NOT messages.revoked THEN excluded.content ELSE messages.content END,
 			timestamp = excluded.timestamp,
 			is_from_me = excluded.is_from_me,
 			media_type = excluded.media_type,
@@ -191,6 +293,8 @@ func (r *SQLiteRepository) StoreMessage(message *domainChatStorage.Message) erro
 			file_sha256 = excluded.file_sha256,
 			file_enc_sha256 = excluded.file_enc_sha256,
 			file_length = excluded.file_length,
+			quoted_message_id = excluded.quoted_message_id,
+			mentions = excluded.mentions,
 			updated_at = excluded.updated_at
 	`
 
@@ -198,7 +302,8 @@ func (r *SQLiteRepository) StoreMessage(message *domainChatStorage.Message) erro
 		message.ID, message.ChatJID, message.Sender, message.Content,
 		message.Timestamp, message.IsFromMe, message.MediaType, message.Filename,
 		message.URL, message.MediaKey, message.FileSHA256, message.FileEncSHA256,
-		message.FileLength, message.CreatedAt, message.UpdatedAt,
+		message.FileLength, message.QuotedMessageID, strings.Join(message.Mentions, ","),
+		message.CreatedAt, message.UpdatedAt,
 	)
 
 	return err
@@ -221,11 +326,12 @@ func (r *SQLiteRepository) StoreMessagesBatch(messages []*domainChatStorage.Mess
 		INSERT INTO messages (
 			id, chat_jid, sender, content, timestamp, is_from_me, 
 			media_type, filename, url, media_key, file_sha256, 
-			file_enc_sha256, file_length, created_at, updated_at
-		) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+			file_enc_sha256, file_length, quoted_message_id, mentions, created_at, updated_at
+		) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
 		ON CONFLICT(id, chat_jid) DO UPDATE SET
 			sender = excluded.sender,
-			content = excluded.content,
+			-- Edited and revoked messages keep their current content when the original is stored again
+			content = CASE WHEN messages.edited_at IS NULL AND NOT messages.revoked THEN excluded.content ELSE messages.content END,
 			timestamp = excluded.timestamp,
 			is_from_me = excluded.is_from_me,
 			media_type = excluded.media_type,
@@ -235,6 +341,8 @@ func (r *SQLiteRepository) StoreMessagesBatch(messages []*domainChatStorage.Mess
 			file_sha256 = excluded.file_sha256,
 			file_enc_sha256 = excluded.file_enc_sha256,
 			file_length = excluded.file_length,
+			quoted_message_id = excluded.quoted_message_id,
+			mentions = excluded.mentions,
 			updated_at = excluded.updated_at
 	`)
 	if err != nil {
@@ -256,58 +364,1236 @@ func (r *SQLiteRepository) StoreMessagesBatch(messages []*domainChatStorage.Mess
 			message.ID, message.ChatJID, message.Sender, message.Content,
 			message.Timestamp, message.IsFromMe, message.MediaType, message.Filename,
 			message.URL, message.MediaKey, message.FileSHA256, message.FileEncSHA256,
-			message.FileLength, message.CreatedAt, message.UpdatedAt,
+			message.FileLength, message.QuotedMessageID, strings.Join(message.Mentions, ","),
+			message.CreatedAt, message.UpdatedAt,
 		)
 		if err != nil {
-			return fmt.Errorf("failed to store message %s: %w", message.ID, err)
+			return fmt.Errorf("failed to store message %s: %w", message.ID, err)
+		}
+	}
+
+	return tx.Commit()
+}
+
+// GetMessages retrieves messages with filtering
+func (r *SQLiteRepository) GetMessages(filter *domainChatStorage.MessageFilter) ([]*domainChatStorage.Message, error) {
+	var conditions []string
+	var args []any
+
+	conditions = append(conditions, "chat_jid = ?")
+	args = append(args, filter.ChatJID)
+
+	if filter.StartTime != nil {
+		conditions = append(conditions, "timestamp >= ?")
+		args = append(args, *filter.StartTime)
+	}
+
+	if filter.EndTime != nil {
+		conditions = append(conditions, "timestamp <= ?")
+		args = append(args, *filter.EndTime)
+	}
+
+	if filter.MediaOnly {
+		conditions = append(conditions, "media_type != ''")
+	}
+
+	if filter.IsFromMe != nil {
+		conditions = append(conditions, "is_from_me = ?")
+		args = append(args, *filter.IsFromMe)
+	}
+
+	query := `
+		SELECT id, chat_jid, sender, content, timestamp, is_from_me,
+			media_type, filename, url, media_key, file_sha256,
+			file_enc_sha256, file_length, starred, edited_at, revoked, revoked_at,
+			quoted_message_id, mentions, created_at, updated_at
+		FROM messages
+		WHERE ` + strings.Join(conditions, " AND ") + `
+		ORDER BY timestamp DESC
+	`
+
+	// Safely add LIMIT and OFFSET using parameterized values
+	if filter.Limit > 0 {
+		// Validate limit to prevent abuse
+		if filter.Limit > 1000 {
+			filter.Limit = 1000
+		}
+		query += " LIMIT ?"
+		args = append(args, filter.Limit)
+
+		if filter.Offset > 0 {
+			query += " OFFSET ?"
+			args = append(args, filter.Offset)
+		}
+	}
+
+	rows, err := r.db.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var messages []*domainChatStorage.Message
+	for rows.Next() {
+		message, err := r.scanMessage(rows)
+		if err != nil {
+			return nil, err
+		}
+		messages = append(messages, message)
+	}
+
+	return messages, rows.Err()
+}
+
+// SearchMessages performs database-level search for messages containing specific text
+func (r *SQLiteRepository) SearchMessages(chatJID, searchText string, limit int) ([]*domainChatStorage.Message, error) {
+	// Return empty results for empty search text
+	if strings.TrimSpace(searchText) == "" {
+		return []*domainChatStorage.Message{}, nil
+	}
+
+	var conditions []string
+	var args []any
+
+	// Always filter by chat JID
+	conditions = append(conditions, "chat_jid = ?")
+	args = append(args, chatJID)
+
+	// Add search condition using LIKE operator for case-insensitive search
+	conditions = append(conditions, "LOWER(content) LIKE ?")
+	args = append(args, "%"+strings.ToLower(searchText)+"%")
+
+	query := `
+		SELECT id, chat_jid, sender, content, timestamp, is_from_me,
+			media_type, filename, url, media_key, file_sha256,
+			file_enc_sha256, file_length, starred, edited_at, revoked, revoked_at,
+			quoted_message_id, mentions, created_at, updated_at
+		FROM messages
+		WHERE ` + strings.Join(conditions, " AND ") + `
+		ORDER BY timestamp DESC
+	`
+
+	// Add limit with validation
+	if limit > 0 {
+		// Validate limit to prevent abuse
+		if limit > 1000 {
+			limit = 1000
+		}
+		query += " LIMIT ?"
+		args = append(args, limit)
+	}
+
+	rows, err := r.db.Query(query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to search messages: %w", err)
+	}
+	defer rows.Close()
+
+	var messages []*domainChatStorage.Message
+	for rows.Next() {
+		message, err := r.scanMessage(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan message: %w", err)
+		}
+		messages = append(messages, message)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating messages: %w", err)
+	}
+
+	return messages, nil
+}
+
+// DeleteMessage deletes a specific message with its revisions and reactions
+func (r *SQLiteRepository) DeleteMessage(id, chatJID string) error {
+	for _, table := range []string{"message_edits", "message_reactions"} {
+		if _, err := r.db.Exec("DELETE FROM "+table+" WHERE chat_jid = ? AND message_id = ?", chatJID, id); err != nil {
+			return err
+		}
+	}
+	_, err := r.db.Exec("DELETE FROM messages WHERE id = ? AND chat_jid = ?", id, chatJID)
+	return err
+}
+
+// SetMessageStarred stars or unstars a stored message
+func (r *SQLiteRepository) SetMessageStarred(id, chatJID string, starred bool) error {
+	_, err := r.db.Exec("UPDATE messages SET starred = ?, updated_at = ? WHERE id = ? AND chat_jid = ?", starred, time.Now(), id, chatJID)
+	return err
+}
+
+// EditMessage replaces the content of a stored message and keeps the previous revisions. The original content is
+// recorded as the first revision on the first edit. Edits of messages that are not stored, edits delivered again and
+// edits older than the current content are only kept in the history, if at all.
+func (r *SQLiteRepository) EditMessage(chatJID, messageID, editID, content string, editedAt time.Time) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	var original string
+	var timestamp time.Time
+	var revoked bool
+	err = tx.QueryRow("SELECT content, timestamp, revoked FROM messages WHERE id = ? AND chat_jid = ?", messageID, chatJID).
+		Scan(&original, &timestamp, &revoked)
+	if err == sql.ErrNoRows || revoked {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(`
+		INSERT INTO message_edits (chat_jid, message_id, edit_id, content, edited_at)
+		SELECT ?, ?, ?, ?, ?
+		WHERE NOT EXISTS (SELECT 1 FROM message_edits WHERE chat_jid = ? AND message_id = ?)
+	`, chatJID, messageID, messageID, original, timestamp, chatJID, messageID)
+	if err != nil {
+		return fmt.Errorf("failed to store original revision: %w", err)
+	}
+
+	_, err = tx.Exec(`
+		INSERT INTO message_edits (chat_jid, message_id, edit_id, content, edited_at)
+		VALUES (?, ?, ?, ?, ?)
+		ON CONFLICT(chat_jid, message_id, edit_id) DO NOTHING
+	`, chatJID, messageID, editID, content, editedAt)
+	if err != nil {
+		return fmt.Errorf("failed to store revision: %w", err)
+	}
+
+	_, err = tx.Exec(`
+		UPDATE messages SET content = ?, edited_at = ?, updated_at = ?
+		WHERE id = ? AND chat_jid = ? AND (edited_at IS NULL OR edited_at <= ?)
+	`, content, editedAt, time.Now(), messageID, chatJID, editedAt)
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// RevokeMessage flags a stored message as deleted for everyone and clears its content, media and revisions
+func (r *SQLiteRepository) RevokeMessage(chatJID, messageID string, revokedAt time.Time) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	_, err = tx.Exec(`
+		UPDATE messages SET revoked = TRUE, revoked_at = ?, content = '', media_type = '', filename = '', url = '',
+			media_key = NULL, file_sha256 = NULL, file_enc_sha256 = NULL, file_length = 0, updated_at = ?
+		WHERE id = ? AND chat_jid = ? AND NOT revoked
+	`, revokedAt, time.Now(), messageID, chatJID)
+	if err != nil {
+		return err
+	}
+
+	if _, err = tx.Exec("DELETE FROM message_edits WHERE chat_jid = ? AND message_id = ?", chatJID, messageID); err != nil {
+		return fmt.Errorf("failed to delete revisions: %w", err)
+	}
+
+	return tx.Commit()
+}
+
+// GetMessageEdits lists the revisions of a message from the original to the latest
+func (r *SQLiteRepository) GetMessageEdits(chatJID, messageID string) ([]*domainChatStorage.MessageEdit, error) {
+	rows, err := r.db.Query(`
+		SELECT chat_jid, message_id, edit_id, content, edited_at
+		FROM message_edits
+		WHERE chat_jid = ? AND message_id = ?
+		ORDER BY edited_at ASC
+	`, chatJID, messageID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var edits []*domainChatStorage.MessageEdit
+	for rows.Next() {
+		edit := &domainChatStorage.MessageEdit{}
+		if err := rows.Scan(&edit.ChatJID, &edit.MessageID, &edit.EditID, &edit.Content, &edit.EditedAt); err != nil {
+			return nil, err
+		}
+		edits = append(edits, edit)
+	}
+
+	return edits, rows.Err()
+}
+
+// GetMessageReplies lists the stored messages replying to a message, oldest first
+func (r *SQLiteRepository) GetMessageReplies(chatJID, messageID string) ([]*domainChatStorage.Message, error) {
+	rows, err := r.db.Query(`
+		SELECT id, chat_jid, sender, content, timestamp, is_from_me,
+			media_type, filename, url, media_key, file_sha256,
+			file_enc_sha256, file_length, starred, edited_at, revoked, revoked_at,
+			quoted_message_id, mentions, created_at, updated_at
+		FROM messages
+		WHERE chat_jid = ? AND quoted_message_id = ?
+		ORDER BY timestamp ASC
+	`, chatJID, messageID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var messages []*domainChatStorage.Message
+	for rows.Next() {
+		message, err := r.scanMessage(rows)
+		if err != nil {
+			return nil, err
+		}
+		messages = append(messages, message)
+	}
+
+	return messages, rows.Err()
+}
+
+// GetOldestMessage retrieves the earliest stored message of a chat, or nil when the chat has no messages
+func (r *SQLiteRepository) GetOldestMessage(chatJID string) (*domainChatStorage.Message, error) {
+	query := `
+		SELECT id, chat_jid, sender, content, timestamp, is_from_me,
+			media_type, filename, url, media_key, file_sha256,
+			file_enc_sha256, file_length, starred, edited_at, revoked, revoked_at,
+			quoted_message_id, mentions, created_at, updated_at
+		FROM messages
+		WHERE chat_jid = ?
+		ORDER BY timestamp ASC
+		LIMIT 1
+	`
+
+	message, err := r.scanMessage(r.db.QueryRow(query, chatJID))
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+
+	return message, err
+}
+
+// StoreReaction stores the reaction of a user to a message, replacing their previous one. An empty emoji removes
+// the reaction. Reactions older than the stored one are ignored.
+func (r *SQLiteRepository) StoreReaction(reaction *domainChatStorage.MessageReaction) error {
+	if reaction.Emoji == "" {
+		_, err := r.db.Exec(
+			"DELETE FROM message_reactions WHERE chat_jid = ? AND message_id = ? AND sender_jid = ? AND timestamp <= ?",
+			reaction.ChatJID, reaction.MessageID, reaction.SenderJID, reaction.Timestamp,
+		)
+		return err
+	}
+
+	_, err := r.db.Exec(`
+		INSERT INTO message_reactions (chat_jid, message_id, sender_jid, emoji, timestamp)
+		VALUES (?, ?, ?, ?, ?)
+		ON CONFLICT(chat_jid, message_id, sender_jid) DO UPDATE SET
+			emoji = excluded.emoji,
+			timestamp = excluded.timestamp
+		WHERE excluded.timestamp >= message_reactions.timestamp
+	`, reaction.ChatJID, reaction.MessageID, reaction.SenderJID, reaction.Emoji, reaction.Timestamp)
+	return err
+}
+
+// GetMessageReactions returns the reactions to the given messages of a chat keyed by message ID, oldest first
+func (r *SQLiteRepository) GetMessageReactions(chatJID string, messageIDs []string) (map[string][]*domainChatStorage.MessageReaction, error) {
+	reactions := make(map[string][]*domainChatStorage.MessageReaction)
+	if len(messageIDs) == 0 {
+		return reactions, nil
+	}
+
+	args := []any{chatJID}
+	for _, id := range messageIDs {
+		args = append(args, id)
+	}
+
+	rows, err := r.db.Query(`
+		SELECT chat_jid, message_id, sender_jid, emoji, timestamp
+		FROM message_reactions
+		WHERE chat_jid = ? AND message_id IN (?`+strings.Repeat(", ?", len(messageIDs)-1)+`)
+		ORDER BY timestamp ASC
+	`, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		reaction := &domainChatStorage.MessageReaction{}
+		if err := rows.Scan(&reaction.ChatJID, &reaction.MessageID, &reaction.SenderJID, &reaction.Emoji, &reaction.Timestamp); err != nil {
+			return nil, err
+		}
+		reactions[reaction.MessageID] = append(reactions[reaction.MessageID], reaction)
+	}
+
+	return reactions, rows.Err()
+}
+
+// StoreLabel creates or updates a label, reviving it if it was deleted
+func (r *SQLiteRepository) StoreLabel(label *domainChatStorage.Label) error {
+	now := time.Now()
+	if label.CreatedAt.IsZero() {
+		label.CreatedAt = now
+	}
+	label.UpdatedAt = now
+
+	query := `
+		INSERT INTO labels (id, name, color, predefined_id, created_at, updated_at)
+		VALUES (?, ?, ?, ?, ?, ?)
+		ON CONFLICT(id) DO UPDATE SET
+			name = excluded.name,
+			color = excluded.color,
+			predefined_id = excluded.predefined_id,
+			deleted = FALSE,
+			updated_at = excluded.updated_at
+	`
+
+	_, err := r.db.Exec(query, label.ID, label.Name, label.Color, label.PredefinedID, label.CreatedAt, label.UpdatedAt)
+	return err
+}
+
+// GetLabel retrieves a label by ID
+func (r *SQLiteRepository) GetLabel(id string) (*domainChatStorage.Label, error) {
+	label := &domainChatStorage.Label{}
+	err := r.db.QueryRow("SELECT id, name, color, predefined_id, created_at, updated_at FROM labels WHERE id = ? AND NOT deleted", id).
+		Scan(&label.ID, &label.Name, &label.Color, &label.PredefinedID, &label.CreatedAt, &label.UpdatedAt)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	return label, err
+}
+
+// GetLabels returns all labels ordered by name
+func (r *SQLiteRepository) GetLabels() ([]*domainChatStorage.Label, error) {
+	rows, err := r.db.Query("SELECT id, name, color, predefined_id, created_at, updated_at FROM labels WHERE NOT deleted ORDER BY name")
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var labels []*domainChatStorage.Label
+	for rows.Next() {
+		label := &domainChatStorage.Label{}
+		if err := rows.Scan(&label.ID, &label.Name, &label.Color, &label.PredefinedID, &label.CreatedAt, &label.UpdatedAt); err != nil {
+			return nil, err
+		}
+		labels = append(labels, label)
+	}
+
+	return labels, rows.Err()
+}
+
+// DeleteLabel deletes a label and removes it from every chat and message. The label row is kept as a
+// tombstone so its ID is never given to a new label.
+func (r *SQLiteRepository) DeleteLabel(id string) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	for _, query := range []string{
+		"DELETE FROM chat_labels WHERE label_id = ?",
+		"DELETE FROM message_labels WHERE label_id = ?",
+	} {
+		if _, err := tx.Exec(query, id); err != nil {
+			return err
+		}
+	}
+
+	now := time.Now()
+	if _, err := tx.Exec(`
+		INSERT INTO labels (id, name, deleted, created_at, updated_at)
+		VALUES (?, '', TRUE, ?, ?)
+		ON CONFLICT(id) DO UPDATE SET deleted = TRUE, updated_at = excluded.updated_at
+	`, id, now, now); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// GetHighestLabelID returns the highest numeric label ID, including deleted labels, or 0 without labels
+func (r *SQLiteRepository) GetHighestLabelID() (int, error) {
+	var highest int
+	err := r.db.QueryRow("SELECT COALESCE(MAX(CAST(id AS INTEGER)), 0) FROM labels WHERE id != '' AND id NOT GLOB '*[^0-9]*'").Scan(&highest)
+	return highest, err
+}
+
+// SetChatLabel applies a label to a chat or removes it
+func (r *SQLiteRepository) SetChatLabel(chatJID, labelID string, labeled bool) error {
+	query := "DELETE FROM chat_labels WHERE chat_jid = ? AND label_id = ?"
+	if labeled {
+		query = "INSERT OR IGNORE INTO chat_labels (chat_jid, label_id) VALUES (?, ?)"
+	}
+	_, err := r.db.Exec(query, chatJID, labelID)
+	return err
+}
+
+// SetMessageLabel applies a label to a message or removes it
+func (r *SQLiteRepository) SetMessageLabel(chatJID, messageID, labelID string, labeled bool) error {
+	query := "DELETE FROM message_labels WHERE chat_jid = ? AND message_id = ? AND label_id = ?"
+	if labeled {
+		query = "INSERT OR IGNORE INTO message_labels (chat_jid, message_id, label_id) VALUES (?, ?, ?)"
+	}
+	_, err := r.db.Exec(query, chatJID, messageID, labelID)
+	return err
+}
+
+// StoreStatus creates or updates a status update
+func (r *SQLiteRepository) StoreStatus(status *domainChatStorage.Status) error {
+	query := `
+		INSERT INTO statuses (id, sender, pushname, content, media_type, media_path, mime_type, is_from_me, timestamp, expires_at, created_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+		ON CONFLICT(id, sender) DO UPDATE SET
+			pushname = excluded.pushname,
+			content = excluded.content,
+			media_type = excluded.media_type,
+			media_path = excluded.media_path,
+			mime_type = excluded.mime_type,
+			expires_at = excluded.expires_at
+	`
+
+	_, err := r.db.Exec(query, status.ID, status.Sender, status.PushName, status.Content, status.MediaType,
+		status.MediaPath, status.MimeType, status.IsFromMe, status.Timestamp, status.ExpiresAt, time.Now())
+	return err
+}
+
+// GetStatuses retrieves status updates with filtering, newest first
+func (r *SQLiteRepository) GetStatuses(filter *domainChatStorage.StatusFilter) ([]*domainChatStorage.Status, error) {
+	var conditions []string
+	var args []any
+
+	query := `
+		SELECT id, sender, pushname, content, media_type, media_path, mime_type, is_from_me, timestamp, expires_at, created_at
+		FROM statuses
+	`
+
+	if filter.Sender != "" {
+		conditions = append(conditions, "sender = ?")
+		args = append(args, filter.Sender)
+	}
+
+	if !filter.IncludeExpired {
+		conditions = append(conditions, "expires_at > ?")
+		args = append(args, time.Now())
+	}
+
+	if len(conditions) > 0 {
+		query += " WHERE " + strings.Join(conditions, " AND ")
+	}
+
+	query += " ORDER BY timestamp DESC"
+
+	if filter.Limit > 0 {
+		query += " LIMIT ?"
+		args = append(args, filter.Limit)
+
+		if filter.Offset > 0 {
+			query += " OFFSET ?"
+			args = append(args, filter.Offset)
+		}
+	}
+
+	rows, err := r.db.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var statuses []*domainChatStorage.Status
+	for rows.Next() {
+		status := &domainChatStorage.Status{}
+		err := rows.Scan(
+			&status.ID, &status.Sender, &status.PushName, &status.Content, &status.MediaType,
+			&status.MediaPath, &status.MimeType, &status.IsFromMe, &status.Timestamp,
+			&status.ExpiresAt, &status.CreatedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+		statuses = append(statuses, status)
+	}
+
+	return statuses, rows.Err()
+}
+
+// DeleteStatus deletes a status update, e.g. when the sender revokes it
+func (r *SQLiteRepository) DeleteStatus(id, sender string) error {
+	_, err := r.db.Exec("DELETE FROM statuses WHERE id = ? AND sender = ?", id, sender)
+	return err
+}
+
+// StoreCall creates a call log entry or updates the outcome of an existing one
+func (r *SQLiteRepository) StoreCall(call *domainChatStorage.Call) error {
+	call.UpdatedAt = time.Now()
+	if call.Timestamp.IsZero() {
+		call.Timestamp = call.UpdatedAt
+	}
+
+	query := `
+		INSERT INTO calls (id, caller_jid, group_jid, is_video, is_group, outcome, reason, timestamp, updated_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)
+		ON CONFLICT(id) DO UPDATE SET
+			outcome = excluded.outcome,
+			reason = excluded.reason,
+			updated_at = excluded.updated_at
+	`
+
+	_, err := r.db.Exec(query, call.ID, call.CallerJID, call.GroupJID, call.IsVideo, call.IsGroup,
+		call.Outcome, call.Reason, call.Timestamp, call.UpdatedAt)
+	return err
+}
+
+// GetCall retrieves a call log entry by call ID
+func (r *SQLiteRepository) GetCall(id string) (*domainChatStorage.Call, error) {
+	call := &domainChatStorage.Call{}
+	err := r.db.QueryRow(`
+		SELECT id, caller_jid, group_jid, is_video, is_group, outcome, reason, timestamp, updated_at
+		FROM calls WHERE id = ?
+	`, id).Scan(
+		&call.ID, &call.CallerJID, &call.GroupJID, &call.IsVideo, &call.IsGroup,
+		&call.Outcome, &call.Reason, &call.Timestamp, &call.UpdatedAt,
+	)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	return call, err
+}
+
+// GetCalls retrieves call log entries matching the filter, newest first
+func (r *SQLiteRepository) GetCalls(filter *domainChatStorage.CallFilter) ([]*domainChatStorage.Call, error) {
+	where, args := buildCallConditions(filter)
+	query := `
+		SELECT id, caller_jid, group_jid, is_video, is_group, outcome, reason, timestamp, updated_at
+		FROM calls` + where + `
+		ORDER BY timestamp DESC
+	`
+
+	if filter.Limit > 0 {
+		query += " LIMIT ?"
+		args = append(args, filter.Limit)
+
+		if filter.Offset > 0 {
+			query += " OFFSET ?"
+			args = append(args, filter.Offset)
+		}
+	}
+
+	rows, err := r.db.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var calls []*domainChatStorage.Call
+	for rows.Next() {
+		call := &domainChatStorage.Call{}
+		if err := rows.Scan(
+			&call.ID, &call.CallerJID, &call.GroupJID, &call.IsVideo, &call.IsGroup,
+			&call.Outcome, &call.Reason, &call.Timestamp, &call.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		calls = append(calls, call)
+	}
+
+	return calls, rows.Err()
+}
+
+// CountCalls counts the call log entries matching the filter, ignoring limit and offset
+func (r *SQLiteRepository) CountCalls(filter *domainChatStorage.CallFilter) (int64, error) {
+	where, args := buildCallConditions(filter)
+	return r.getCount("SELECT COUNT(*) FROM calls"+where, args...)
+}
+
+// buildCallConditions builds the WHERE clause shared by the call log queries
+func buildCallConditions(filter *domainChatStorage.CallFilter) (string, []any) {
+	var conditions []string
+	var args []any
+
+	if filter.CallerJID != "" {
+		conditions = append(conditions, "caller_jid = ?")
+		args = append(args, filter.CallerJID)
+	}
+	if filter.Outcome != "" {
+		conditions = append(conditions, "outcome = ?")
+		args = append(args, filter.Outcome)
+	}
+
+	if len(conditions) == 0 {
+		return "", args
+	}
+	return " WHERE " + strings.Join(conditions, " AND "), args
+}
+
+// ReplaceBlocklist replaces the stored blocklist with the given JIDs, keeping when already blocked contacts were blocked
+func (r *SQLiteRepository) ReplaceBlocklist(jids []string) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	deleteQuery := "DELETE FROM blocked_contacts"
+	args := make([]any, 0, len(jids))
+	if len(jids) > 0 {
+		deleteQuery += " WHERE jid NOT IN (?" + strings.Repeat(", ?", len(jids)-1) + ")"
+		for _, jid := range jids {
+			args = append(args, jid)
+		}
+	}
+	if _, err := tx.Exec(deleteQuery, args...); err != nil {
+		return err
+	}
+
+	now := time.Now()
+	for _, jid := range jids {
+		if _, err := tx.Exec("INSERT OR IGNORE INTO blocked_contacts (jid, blocked_at) VALUES (?, ?)", jid, now); err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+// SetContactBlocked adds a contact to the stored blocklist or removes it
+func (r *SQLiteRepository) SetContactBlocked(jid string, blocked bool) error {
+	var err error
+	if blocked {
+		_, err = r.db.Exec("INSERT OR IGNORE INTO blocked_contacts (jid, blocked_at) VALUES (?, ?)", jid, time.Now())
+	} else {
+		_, err = r.db.Exec("DELETE FROM blocked_contacts WHERE jid = ?", jid)
+	}
+	return err
+}
+
+// GetBlocklist returns the blocked contacts, most recently blocked first
+func (r *SQLiteRepository) GetBlocklist() ([]*domainChatStorage.BlockedContact, error) {
+	rows, err := r.db.Query("SELECT jid, blocked_at FROM blocked_contacts ORDER BY blocked_at DESC, jid")
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var contacts []*domainChatStorage.BlockedContact
+	for rows.Next() {
+		contact := &domainChatStorage.BlockedContact{}
+		if err := rows.Scan(&contact.JID, &contact.BlockedAt); err != nil {
+			return nil, err
+		}
+		contacts = append(contacts, contact)
+	}
+
+	return contacts, rows.Err()
+}
+
+// IsContactBlocked reports whether a contact is on the stored blocklist
+func (r *SQLiteRepository) IsContactBlocked(jid string) (bool, error) {
+	count, err := r.getCount("SELECT COUNT(*) FROM blocked_contacts WHERE jid = ?", jid)
+	return count > 0, err
+}
+
+// StorePushNames creates or updates the push names of contacts
+func (r *SQLiteRepository) StorePushNames(pushNames []*domainChatStorage.PushName) error {
+	if len(pushNames) == 0 {
+		return nil
+	}
+
+	tx, err := r.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	stmt, err := tx.Prepare(`
+		INSERT INTO push_names (jid, push_name, updated_at)
+		VALUES (?, ?, ?)
+		ON CONFLICT(jid) DO UPDATE SET
+			push_name = excluded.push_name,
+			updated_at = excluded.updated_at
+	`)
+	if err != nil {
+		return err
+	}
+	defer stmt.Close()
+
+	now := time.Now()
+	for _, pushName := range pushNames {
+		if _, err := stmt.Exec(pushName.JID, pushName.PushName, now); err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+// GetPushName retrieves the stored push name of a contact, or an empty string when it is unknown
+func (r *SQLiteRepository) GetPushName(jid string) (string, error) {
+	var pushName string
+	err := r.db.QueryRow("SELECT push_name FROM push_names WHERE jid = ?", jid).Scan(&pushName)
+	if err == sql.ErrNoRows {
+		return "", nil
+	}
+	return pushName, err
+}
+
+// StoreGroupMetadata creates or updates the metadata of a group. Empty fields keep their stored value,
+// and the participants are only replaced when the metadata carries any
+func (r *SQLiteRepository) StoreGroupMetadata(metadata *domainChatStorage.GroupMetadata) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	var createdAt any
+	if !metadata.CreatedAt.IsZero() {
+		createdAt = metadata.CreatedAt
+	}
+
+	_, err = tx.Exec(`
+		INSERT INTO group_metadata (jid, name, topic, creator_jid, group_created_at, updated_at)
+		VALUES (?, ?, ?, ?, ?, ?)
+		ON CONFLICT(jid) DO UPDATE SET
+			name = COALESCE(NULLIF(excluded.name, ''), name),
+			topic = COALESCE(NULLIF(excluded.topic, ''), topic),
+			creator_jid = COALESCE(NULLIF(excluded.creator_jid, ''), creator_jid),
+			group_created_at = COALESCE(excluded.group_created_at, group_created_at),
+			updated_at = excluded.updated_at
+	`, metadata.JID, metadata.Name, metadata.Topic, metadata.CreatorJID, createdAt, time.Now())
+	if err != nil {
+		return err
+	}
+
+	// History sync chunks without participants must not wipe the known ones
+	if len(metadata.Participants) == 0 {
+		return tx.Commit()
+	}
+
+	if _, err := tx.Exec("DELETE FROM group_participants WHERE group_jid = ?", metadata.JID); err != nil {
+		return err
+	}
+	for _, participant := range metadata.Participants {
+		if _, err := tx.Exec(
+			"INSERT OR REPLACE INTO group_participants (group_jid, jid, is_admin, is_super_admin) VALUES (?, ?, ?, ?)",
+			metadata.JID, participant.JID, participant.IsAdmin, participant.IsSuperAdmin,
+		); err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+// GetGroupMetadata retrieves the stored metadata of a group with its participants, or nil when it is unknown
+func (r *SQLiteRepository) GetGroupMetadata(jid string) (*domainChatStorage.GroupMetadata, error) {
+	metadata := &domainChatStorage.GroupMetadata{}
+	var createdAt sql.NullTime
+	err := r.db.QueryRow(
+		"SELECT jid, name, topic, creator_jid, group_created_at, updated_at FROM group_metadata WHERE jid = ?", jid,
+	).Scan(&metadata.JID, &metadata.Name, &metadata.Topic, &metadata.CreatorJID, &createdAt, &metadata.UpdatedAt)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if createdAt.Valid {
+		metadata.CreatedAt = createdAt.Time
+	}
+
+	rows, err := r.db.Query(
+		"SELECT jid, is_admin, is_super_admin FROM group_participants WHERE group_jid = ? ORDER BY jid", jid,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		participant := &domainChatStorage.GroupParticipant{}
+		if err := rows.Scan(&participant.JID, &participant.IsAdmin, &participant.IsSuperAdmin); err != nil {
+			return nil, err
+		}
+		metadata.Participants = append(metadata.Participants, participant)
+	}
+
+	return metadata, rows.Err()
+}
+
+// StoreGroupModeration creates or updates the moderation settings of a group
+func (r *SQLiteRepository) StoreGroupModeration(moderation *domainChatStorage.GroupModeration) error {
+	bannedWords, err := json.Marshal(moderation.BannedWords)
+	if err != nil {
+		return fmt.Errorf("failed to encode banned words: %w", err)
+	}
+
+	now := time.Now()
+	query := `
+		INSERT INTO group_moderation (group_jid, welcome_message, goodbye_message, anti_link, banned_words,
+			flood_limit, flood_window_seconds, max_warnings, created_at, updated_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+		ON CONFLICT(group_jid) DO UPDATE SET
+			welcome_message = excluded.welcome_message,
+			goodbye_message = excluded.goodbye_message,
+			anti_link = excluded.anti_link,
+			banned_words = excluded.banned_words,
+			flood_limit = excluded.flood_limit,
+			flood_window_seconds = excluded.flood_window_seconds,
+			max_warnings = excluded.max_warnings,
+			updated_at = excluded.updated_at
+	`
+
+	_, err = r.db.Exec(query, moderation.GroupJID, moderation.WelcomeMessage, moderation.GoodbyeMessage,
+		moderation.AntiLink, string(bannedWords), moderation.FloodLimit, moderation.FloodWindowSeconds,
+		moderation.MaxWarnings, now, now)
+	return err
+}
+
+// GetGroupModeration retrieves the moderation settings of a group, or nil when none are configured
+func (r *SQLiteRepository) GetGroupModeration(groupJID string) (*domainChatStorage.GroupModeration, error) {
+	query := `
+		SELECT group_jid, welcome_message, goodbye_message, anti_link, banned_words,
+			flood_limit, flood_window_seconds, max_warnings, created_at, updated_at
+		FROM group_moderation
+		WHERE group_jid = ?
+	`
+
+	moderation, err := r.scanGroupModeration(r.db.QueryRow(query, groupJID))
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	return moderation, err
+}
+
+// GetGroupModerations retrieves the moderation settings of all configured groups
+func (r *SQLiteRepository) GetGroupModerations() ([]*domainChatStorage.GroupModeration, error) {
+	query := `
+		SELECT group_jid, welcome_message, goodbye_message, anti_link, banned_words,
+			flood_limit, flood_window_seconds, max_warnings, created_at, updated_at
+		FROM group_moderation
+		ORDER BY updated_at DESC
+	`
+
+	rows, err := r.db.Query(query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var moderations []*domainChatStorage.GroupModeration
+	for rows.Next() {
+		moderation, err := r.scanGroupModeration(rows)
+		if err != nil {
+			return nil, err
+		}
+		moderations = append(moderations, moderation)
+	}
+
+	return moderations, rows.Err()
+}
+
+// DeleteGroupModeration removes the moderation settings of a group
+func (r *SQLiteRepository) DeleteGroupModeration(groupJID string) error {
+	_, err := r.db.Exec("DELETE FROM group_moderation WHERE group_jid = ?", groupJID)
+	return err
+}
+
+// scanGroupModeration is a private helper for scanning group moderation rows
+func (r *SQLiteRepository) scanGroupModeration(scanner interface{ Scan(...any) error }) (*domainChatStorage.GroupModeration, error) {
+	moderation := &domainChatStorage.GroupModeration{}
+	var bannedWords string
+	err := scanner.Scan(
+		&moderation.GroupJID, &moderation.WelcomeMessage, &moderation.GoodbyeMessage, &moderation.AntiLink,
+		&bannedWords, &moderation.FloodLimit, &moderation.FloodWindowSeconds, &moderation.MaxWarnings,
+		&moderation.CreatedAt, &moderation.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if bannedWords != "" {
+		if err := json.Unmarshal([]byte(bannedWords), &moderation.BannedWords); err != nil {
+			return nil, fmt.Errorf("failed to decode banned words: %w", err)
+		}
+	}
+
+	return moderation, nil
+}
+
+// CreateGroupBulkJob stores a new bulk group job together with its rows
+func (r *SQLiteRepository) CreateGroupBulkJob(job *domainChatStorage.GroupBulkJob, rows []*domainChatStorage.GroupBulkJobRow) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	now := time.Now()
+	job.CreatedAt = now
+	job.UpdatedAt = now
+	_, err = tx.Exec(`
+		INSERT INTO group_bulk_jobs (id, status, batch_size, delay_seconds, total, processed, succeeded, failed, invited, created_at, updated_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+	`, job.ID, job.Status, job.BatchSize, job.DelaySeconds, job.Total, job.Processed, job.Succeeded, job.Failed, job.Invited, now, now)
+	if err != nil {
+		return err
+	}
+
+	stmt, err := tx.Prepare(`
+		INSERT INTO group_bulk_job_rows (job_id, row_number, group_jid, phone, action, status, message)
+		VALUES (?, ?, ?, ?, ?, ?, ?)
+	`)
+	if err != nil {
+		return err
+	}
+	defer stmt.Close()
+
+	for _, row := range rows {
+		if _, err := stmt.Exec(job.ID, row.RowNumber, row.GroupJID, row.Phone, row.Action, row.Status, row.Message); err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+// UpdateGroupBulkJob updates the status and counters of a bulk group job
+func (r *SQLiteRepository) UpdateGroupBulkJob(job *domainChatStorage.GroupBulkJob) error {
+	job.UpdatedAt = time.Now()
+	_, err := r.db.Exec(`
+		UPDATE group_bulk_jobs
+		SET status = ?, processed = ?, succeeded = ?, failed = ?, invited = ?, updated_at = ?, finished_at = ?
+		WHERE id = ?
+	`, job.Status, job.Processed, job.Succeeded, job.Failed, job.Invited, job.UpdatedAt, job.FinishedAt, job.ID)
+	return err
+}
+
+// UpdateGroupBulkJobRow records the outcome of a single bulk group job row
+func (r *SQLiteRepository) UpdateGroupBulkJobRow(row *domainChatStorage.GroupBulkJobRow) error {
+	_, err := r.db.Exec(`
+		UPDATE group_bulk_job_rows
+		SET status = ?, message = ?, processed_at = ?
+		WHERE job_id = ? AND row_number = ?
+	`, row.Status, row.Message, row.ProcessedAt, row.JobID, row.RowNumber)
+	return err
+}
+
+// GetGroupBulkJob retrieves a bulk group job, or nil when it does not exist
+func (r *SQLiteRepository) GetGroupBulkJob(id string) (*domainChatStorage.GroupBulkJob, error) {
+	job, err := r.scanGroupBulkJob(r.db.QueryRow(`
+		SELECT id, status, batch_size, delay_seconds, total, processed, succeeded, failed, invited, created_at, updated_at, finished_at
+		FROM group_bulk_jobs
+		WHERE id = ?
+	`, id))
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return job, nil
+}
+
+// GetUnfinishedGroupBulkJobs retrieves the bulk group jobs that have not finished, oldest first
+func (r *SQLiteRepository) GetUnfinishedGroupBulkJobs() ([]*domainChatStorage.GroupBulkJob, error) {
+	rows, err := r.db.Query(`
+		SELECT id, status, batch_size, delay_seconds, total, processed, succeeded, failed, invited, created_at, updated_at, finished_at
+		FROM group_bulk_jobs
+		WHERE finished_at IS NULL
+		ORDER BY created_at ASC
+	`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var jobs []*domainChatStorage.GroupBulkJob
+	for rows.Next() {
+		job, err := r.scanGroupBulkJob(rows)
+		if err != nil {
+			return nil, err
+		}
+		jobs = append(jobs, job)
+	}
+
+	return jobs, rows.Err()
+}
+
+// scanGroupBulkJob is a private helper for scanning bulk group job rows
+func (r *SQLiteRepository) scanGroupBulkJob(scanner interface{ Scan(...any) error }) (*domainChatStorage.GroupBulkJob, error) {
+	job := &domainChatStorage.GroupBulkJob{}
+	err := scanner.Scan(
+		&job.ID, &job.Status, &job.BatchSize, &job.DelaySeconds, &job.Total, &job.Processed,
+		&job.Succeeded, &job.Failed, &job.Invited, &job.CreatedAt, &job.UpdatedAt, &job.FinishedAt,
+	)
+	return job, err
+}
+
+// GetGroupBulkJobRows retrieves the rows of a bulk group job in CSV order
+func (r *SQLiteRepository) GetGroupBulkJobRows(jobID string) ([]*domainChatStorage.GroupBulkJobRow, error) {
+	rows, err := r.db.Query(`
+		SELECT job_id, row_number, group_jid, phone, action, status, message, processed_at
+		FROM group_bulk_job_rows
+		WHERE job_id = ?
+		ORDER BY row_number ASC
+	`, jobID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var result []*domainChatStorage.GroupBulkJobRow
+	for rows.Next() {
+		row := &domainChatStorage.GroupBulkJobRow{}
+		if err := rows.Scan(
+			&row.JobID, &row.RowNumber, &row.GroupJID, &row.Phone, &row.Action,
+			&row.Status, &row.Message, &row.ProcessedAt,
+		); err != nil {
+			return nil, err
+		}
+		result = append(result, row)
+	}
+
+	return result, rows.Err()
+}
+
+// StoreAPIKey stores a new API key
+func (r *SQLiteRepository) StoreAPIKey(key *domainChatStorage.APIKey) error {
+	scopes, err := json.Marshal(key.Scopes)
+	if err != nil {
+		return fmt.Errorf("failed to encode scopes: %w", err)
+	}
+	allowedRecipients, err := json.Marshal(key.AllowedRecipients)
+	if err != nil {
+		return fmt.Errorf("failed to encode allowed recipients: %w", err)
+	}
+
+	if key.CreatedAt.IsZero() {
+		key.CreatedAt = time.Now()
+	}
+	_, err = r.db.Exec(`
+		INSERT INTO api_keys (id, name, key_hash, prefix, scopes, allowed_recipients, rate_limit, expires_at, created_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)
+	`, key.ID, key.Name, key.KeyHash, key.Prefix, string(scopes), string(allowedRecipients), key.RateLimit, key.ExpiresAt, key.CreatedAt)
+	return err
+}
+
+// GetAPIKeyByHash retrieves an API key by the hash of its secret, or nil when it does not exist
+func (r *SQLiteRepository) GetAPIKeyByHash(keyHash string) (*domainChatStorage.APIKey, error) {
+	query := `
+		SELECT id, name, key_hash, prefix, scopes, allowed_recipients, rate_limit, expires_at, last_used_at, revoked_at, created_at
+		FROM api_keys
+		WHERE key_hash = ?
+	`
+
+	key, err := r.scanAPIKey(r.db.QueryRow(query, keyHash))
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	return key, err
+}
+
+// GetAPIKeys retrieves all API keys, newest first
+func (r *SQLiteRepository) GetAPIKeys() ([]*domainChatStorage.APIKey, error) {
+	query := `
+		SELECT id, name, key_hash, prefix, scopes, allowed_recipients, rate_limit, expires_at, last_used_at, revoked_at, created_at
+		FROM api_keys
+		ORDER BY created_at DESC
+	`
+
+	rows, err := r.db.Query(query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var keys []*domainChatStorage.APIKey
+	for rows.Next() {
+		key, err := r.scanAPIKey(rows)
+		if err != nil {
+			return nil, err
 		}
+		keys = append(keys, key)
 	}
 
-	return tx.Commit()
+	return keys, rows.Err()
 }
 
-// GetMessages retrieves messages with filtering
-func (r *SQLiteRepository) GetMessages(filter *domainChatStorage.MessageFilter) ([]*domainChatStorage.Message, error) {
-	var conditions []string
-	var args []any
+// RevokeAPIKey marks an API key as revoked and reports whether an active key was found
+func (r *SQLiteRepository) RevokeAPIKey(id string, revokedAt time.Time) (bool, error) {
+	result, err := r.db.Exec("UPDATE api_keys SET revoked_at = ? WHERE id = ? AND revoked_at IS NULL", revokedAt, id)
+	if err != nil {
+		return false, err
+	}
+	affected, err := result.RowsAffected()
+	return affected > 0, err
+}
 
-	conditions = append(conditions, "chat_jid = ?")
-	args = append(args, filter.ChatJID)
+// UpdateAPIKeyLastUsed records when an API key was last used
+func (r *SQLiteRepository) UpdateAPIKeyLastUsed(id string, lastUsedAt time.Time) error {
+	_, err := r.db.Exec("UPDATE api_keys SET last_used_at = ? WHERE id = ?", lastUsedAt, id)
+	return err
+}
 
-	if filter.StartTime != nil {
-		conditions = append(conditions, "timestamp >= ?")
-		args = append(args, *filter.StartTime)
+// CountActiveAPIKeys counts the API keys that are neither revoked nor expired
+func (r *SQLiteRepository) CountActiveAPIKeys(now time.Time) (int64, error) {
+	return r.getCount("SELECT COUNT(*) FROM api_keys WHERE revoked_at IS NULL AND (expires_at IS NULL OR expires_at > ?)", now)
+}
+
+// scanAPIKey is a private helper for scanning API key rows
+func (r *SQLiteRepository) scanAPIKey(scanner interface{ Scan(...any) error }) (*domainChatStorage.APIKey, error) {
+	key := &domainChatStorage.APIKey{}
+	var scopes, allowedRecipients string
+	err := scanner.Scan(
+		&key.ID, &key.Name, &key.KeyHash, &key.Prefix, &scopes, &allowedRecipients,
+		&key.RateLimit, &key.ExpiresAt, &key.LastUsedAt, &key.RevokedAt, &key.CreatedAt,
+	)
+	if err != nil {
+		return nil, err
 	}
 
-	if filter.EndTime != nil {
-		conditions = append(conditions, "timestamp <= ?")
-		args = append(args, *filter.EndTime)
+	if scopes != "" {
+		if err := json.Unmarshal([]byte(scopes), &key.Scopes); err != nil {
+			return nil, fmt.Errorf("failed to decode scopes: %w", err)
+		}
+	}
+	if allowedRecipients != "" {
+		if err := json.Unmarshal([]byte(allowedRecipients), &key.AllowedRecipients); err != nil {
+			return nil, fmt.Errorf("failed to decode allowed recipients: %w", err)
+		}
 	}
 
-	if filter.MediaOnly {
-		conditions = append(conditions, "media_type != ''")
+	return key, nil
+}
+
+// StoreAuditLog appends an entry to the audit log
+func (r *SQLiteRepository) StoreAuditLog(entry *domainChatStorage.AuditLog) error {
+	if entry.CreatedAt.IsZero() {
+		entry.CreatedAt = time.Now()
 	}
 
-	if filter.IsFromMe != nil {
-		conditions = append(conditions, "is_from_me = ?")
-		args = append(args, *filter.IsFromMe)
+	result, err := r.db.Exec(`
+		INSERT INTO audit_logs (actor, endpoint, action, target_jid, message_id, details, result, error, created_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)
+	`, entry.Actor, entry.Endpoint, entry.Action, entry.TargetJID, entry.MessageID, entry.Details, entry.Result, entry.Error, entry.CreatedAt)
+	if err != nil {
+		return err
 	}
 
+	entry.ID, err = result.LastInsertId()
+	return err
+}
+
+// GetAuditLogs retrieves audit log entries matching the filter, newest first
+func (r *SQLiteRepository) GetAuditLogs(filter *domainChatStorage.AuditLogFilter) ([]*domainChatStorage.AuditLog, error) {
+	where, args := buildAuditLogConditions(filter)
 	query := `
-		SELECT id, chat_jid, sender, content, timestamp, is_from_me,
-			media_type, filename, url, media_key, file_sha256,
-			file_enc_sha256, file_length, created_at, updated_at
-		FROM messages
-		WHERE ` + strings.Join(conditions, " AND ") + `
-		ORDER BY timestamp DESC
+		SELECT id, actor, endpoint, action, target_jid, message_id, details, result, error, created_at
+		FROM audit_logs` + where + `
+		ORDER BY id DESC
 	`
 
-	// Safely add LIMIT and OFFSET using parameterized values
 	if filter.Limit > 0 {
-		// Validate limit to prevent abuse
-		if filter.Limit > 1000 {
-			filter.Limit = 1000
-		}
 		query += " LIMIT ?"
 		args = append(args, filter.Limit)
 
@@ -323,80 +1609,122 @@ func (r *SQLiteRepository) GetMessages(filter *domainChatStorage.MessageFilter)
 	}
 	defer rows.Close()
 
-	var messages []*domainChatStorage.Message
+	var entries []*domainChatStorage.AuditLog
 	for rows.Next() {
-		message, err := r.scanMessage(rows)
-		if err != nil {
+		entry := &domainChatStorage.AuditLog{}
+		if err := rows.Scan(
+			&entry.ID, &entry.Actor, &entry.Endpoint, &entry.Action, &entry.TargetJID,
+			&entry.MessageID, &entry.Details, &entry.Result, &entry.Error, &entry.CreatedAt,
+		); err != nil {
 			return nil, err
 		}
-		messages = append(messages, message)
+		entries = append(entries, entry)
 	}
 
-	return messages, rows.Err()
+	return entries, rows.Err()
 }
 
-// SearchMessages performs database-level search for messages containing specific text
-func (r *SQLiteRepository) SearchMessages(chatJID, searchText string, limit int) ([]*domainChatStorage.Message, error) {
-	// Return empty results for empty search text
-	if strings.TrimSpace(searchText) == "" {
-		return []*domainChatStorage.Message{}, nil
-	}
+// CountAuditLogs counts the audit log entries matching the filter, ignoring limit and offset
+func (r *SQLiteRepository) CountAuditLogs(filter *domainChatStorage.AuditLogFilter) (int64, error) {
+	where, args := buildAuditLogConditions(filter)
+	return r.getCount("SELECT COUNT(*) FROM audit_logs"+where, args...)
+}
 
+// buildAuditLogConditions builds the WHERE clause shared by the audit log queries
+func buildAuditLogConditions(filter *domainChatStorage.AuditLogFilter) (string, []any) {
 	var conditions []string
 	var args []any
 
-	// Always filter by chat JID
-	conditions = append(conditions, "chat_jid = ?")
-	args = append(args, chatJID)
-
-	// Add search condition using LIKE operator for case-insensitive search
-	conditions = append(conditions, "LOWER(content) LIKE ?")
-	args = append(args, "%"+strings.ToLower(searchText)+"%")
+	if filter.Actor != "" {
+		conditions = append(conditions, "actor = ?")
+		args = append(args, filter.Actor)
+	}
+	if filter.Action != "" {
+		conditions = append(conditions, "action = ?")
+		args = append(args, filter.Action)
+	}
+	if filter.TargetJID != "" {
+		conditions = append(conditions, "target_jid = ?")
+		args = append(args, filter.TargetJID)
+	}
+	if filter.Result != "" {
+		conditions = append(conditions, "result = ?")
+		args = append(args, filter.Result)
+	}
+	if filter.From != nil {
+		conditions = append(conditions, "created_at >= ?")
+		args = append(args, *filter.From)
+	}
+	if filter.To != nil {
+		conditions = append(conditions, "created_at <= ?")
+		args = append(args, *filter.To)
+	}
 
-	query := `
-		SELECT id, chat_jid, sender, content, timestamp, is_from_me,
-			media_type, filename, url, media_key, file_sha256,
-			file_enc_sha256, file_length, created_at, updated_at
-		FROM messages
-		WHERE ` + strings.Join(conditions, " AND ") + `
-		ORDER BY timestamp DESC
-	`
+	if len(conditions) == 0 {
+		return "", args
+	}
+	return " WHERE " + strings.Join(conditions, " AND "), args
+}
 
-	// Add limit with validation
-	if limit > 0 {
-		// Validate limit to prevent abuse
-		if limit > 1000 {
-			limit = 1000
-		}
-		query += " LIMIT ?"
-		args = append(args, limit)
+// ClaimIdempotencyKey stores key unless the same actor already used it after expiredBefore, in which case
+// the existing record is returned. Records older than expiredBefore are removed first.
+func (r *SQLiteRepository) ClaimIdempotencyKey(key *domainChatStorage.IdempotencyKey, expiredBefore time.Time) (*domainChatStorage.IdempotencyKey, error) {
+	if key.CreatedAt.IsZero() {
+		key.CreatedAt = time.Now()
 	}
 
-	rows, err := r.db.Query(query, args...)
+	tx, err := r.db.Begin()
 	if err != nil {
-		return nil, fmt.Errorf("failed to search messages: %w", err)
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
 	}
-	defer rows.Close()
+	defer tx.Rollback()
 
-	var messages []*domainChatStorage.Message
-	for rows.Next() {
-		message, err := r.scanMessage(rows)
-		if err != nil {
-			return nil, fmt.Errorf("failed to scan message: %w", err)
-		}
-		messages = append(messages, message)
+	if _, err := tx.Exec("DELETE FROM idempotency_keys WHERE created_at < ?", expiredBefore); err != nil {
+		return nil, err
 	}
 
-	if err := rows.Err(); err != nil {
-		return nil, fmt.Errorf("error iterating messages: %w", err)
+	result, err := tx.Exec(`
+		INSERT OR IGNORE INTO idempotency_keys (actor, idempotency_key, action, recipient, created_at)
+		VALUES (?, ?, ?, ?, ?)
+	`, key.Actor, key.Key, key.Action, key.Recipient, key.CreatedAt)
+	if err != nil {
+		return nil, err
+	}
+	inserted, err := result.RowsAffected()
+	if err != nil {
+		return nil, err
+	}
+	if inserted > 0 {
+		return nil, tx.Commit()
 	}
 
-	return messages, nil
+	existing := &domainChatStorage.IdempotencyKey{}
+	err = tx.QueryRow(`
+		SELECT actor, idempotency_key, action, recipient, message_id, status, created_at, completed_at
+		FROM idempotency_keys
+		WHERE actor = ? AND idempotency_key = ?
+	`, key.Actor, key.Key).Scan(
+		&existing.Actor, &existing.Key, &existing.Action, &existing.Recipient,
+		&existing.MessageID, &existing.Status, &existing.CreatedAt, &existing.CompletedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return existing, tx.Commit()
 }
 
-// DeleteMessage deletes a specific message
-func (r *SQLiteRepository) DeleteMessage(id, chatJID string) error {
-	_, err := r.db.Exec("DELETE FROM messages WHERE id = ? AND chat_jid = ?", id, chatJID)
+// CompleteIdempotencyKey records the response of the request that claimed an idempotency key
+func (r *SQLiteRepository) CompleteIdempotencyKey(actor, key, messageID, status string, completedAt time.Time) error {
+	_, err := r.db.Exec(`
+		UPDATE idempotency_keys SET message_id = ?, status = ?, completed_at = ?
+		WHERE actor = ? AND idempotency_key = ?
+	`, messageID, status, completedAt, actor, key)
+	return err
+}
+
+// DeleteIdempotencyKey frees an idempotency key, so a failed request can be retried with it
+func (r *SQLiteRepository) DeleteIdempotencyKey(actor, key string) error {
+	_, err := r.db.Exec("DELETE FROM idempotency_keys WHERE actor = ? AND idempotency_key = ?", actor, key)
 	return err
 }
 
@@ -410,22 +1738,39 @@ func (r *SQLiteRepository) getCount(query string, args ...any) (int64, error) {
 // scanMessage is a private helper for scanning message rows
 func (r *SQLiteRepository) scanMessage(scanner interface{ Scan(...any) error }) (*domainChatStorage.Message, error) {
 	message := &domainChatStorage.Message{}
+	var editedAt, revokedAt sql.NullTime
+	var mentions string
 	err := scanner.Scan(
 		&message.ID, &message.ChatJID, &message.Sender, &message.Content,
 		&message.Timestamp, &message.IsFromMe, &message.MediaType, &message.Filename,
 		&message.URL, &message.MediaKey, &message.FileSHA256, &message.FileEncSHA256,
-		&message.FileLength, &message.CreatedAt, &message.UpdatedAt,
+		&message.FileLength, &message.Starred, &editedAt, &message.Revoked, &revokedAt,
+		&message.QuotedMessageID, &mentions, &message.CreatedAt, &message.UpdatedAt,
 	)
+	if mentions != "" {
+		message.Mentions = strings.Split(mentions, ",")
+	}
+	if editedAt.Valid {
+		message.EditedAt = &editedAt.Time
+	}
+	if revokedAt.Valid {
+		message.RevokedAt = &revokedAt.Time
+	}
 	return message, err
 }
 
 // scanChat is a private helper for scanning chat rows
 func (r *SQLiteRepository) scanChat(scanner interface{ Scan(...any) error }) (*domainChatStorage.Chat, error) {
 	chat := &domainChatStorage.Chat{}
+	var labelIDs sql.NullString
 	err := scanner.Scan(
 		&chat.JID, &chat.Name, &chat.LastMessageTime, &chat.EphemeralExpiration,
-		&chat.CreatedAt, &chat.UpdatedAt,
+		&chat.Pinned, &chat.Archived, &chat.MutedUntil, &chat.Unread,
+		&labelIDs, &chat.CreatedAt, &chat.UpdatedAt,
 	)
+	if labelIDs.String != "" {
+		chat.LabelIDs = strings.Split(labelIDs.String, ",")
+	}
 	return chat, err
 }
 
@@ -465,6 +1810,20 @@ func (r *SQLiteRepository) TruncateAllChats() error {
 		return fmt.Errorf("failed to delete chats: %w", err)
 	}
 
+	// Delete status updates
+	_, err = tx.Exec("DELETE FROM statuses")
+	if err != nil {
+		return fmt.Errorf("failed to delete statuses: %w", err)
+	}
+
+	// Delete labels, the call log, the blocklist and synced contact details, they belong to the logged out account
+	for _, table := range []string{"message_edits", "message_reactions", "chat_labels", "message_labels", "labels", "calls", "blocked_contacts",
+		"push_names", "group_participants", "group_metadata"} {
+		if _, err = tx.Exec("DELETE FROM " + table); err != nil {
+			return fmt.Errorf("failed to delete %s: %w", table, err)
+		}
+	}
+
 	return tx.Commit()
 }
 
@@ -485,15 +1844,21 @@ func (r *SQLiteRepository) GetChatNameWithPushName(jid types.JID, chatJID string
 
 	switch jid.Server {
 	case "g.us":
-		// This is a group chat
-		// For now, use a generic name - this can be enhanced later with group info
-		name = fmt.Sprintf("Group %s", jid.User)
+		// This is a group chat, named after the metadata from history sync when known
+		if metadata, err := r.GetGroupMetadata(chatJID); err == nil && metadata != nil && metadata.Name != "" {
+			name = metadata.Name
+		} else {
+			name = fmt.Sprintf("Group %s", jid.User)
+		}
 	case "newsletter":
 		// This is a newsletter/channel
 		name = fmt.Sprintf("Newsletter %s", jid.User)
 	default:
 		// This is an individual contact
-		// Priority: pushName > senderUser > JID user
+		// Priority: pushName > stored push name > senderUser > JID user
+		if pushName == "" {
+			pushName, _ = r.GetPushName(chatJID)
+		}
 		if pushName != "" && pushName != senderUser && pushName != jid.User {
 			name = pushName
 		} else if senderUser != "" {
@@ -506,16 +1871,44 @@ func (r *SQLiteRepository) GetChatNameWithPushName(jid types.JID, chatJID string
 	return name
 }
 
-func (r *SQLiteRepository) CreateMessage(ctx context.Context, evt *events.Message) error {
+func (r *SQLiteRepository) CreateMessage(ctx context.Context, evt *events.Message) (err error) {
 	if evt == nil || evt.Message == nil {
 		return nil
 	}
 
+	_, span := tracing.Start(ctx, "chatstorage.create_message",
+		tracing.AttrMessageID.String(evt.Info.ID),
+		tracing.AttrChatJID.String(evt.Info.Chat.String()),
+	)
+	defer func() { tracing.End(span, err) }()
+
 	// Extract chat and sender information
 	chatJID := evt.Info.Chat.String()
 	// Store the full sender JID (user@server) to ensure consistency between received and sent messages
 	sender := evt.Info.Sender.String()
 
+	// Edits and revokes update the original message instead of being stored themselves
+	if protocolMessage := evt.Message.GetProtocolMessage(); protocolMessage != nil {
+		switch protocolMessage.GetType() {
+		case waE2E.ProtocolMessage_MESSAGE_EDIT:
+			content := utils.ExtractMessageTextFromProto(protocolMessage.GetEditedMessage())
+			return r.EditMessage(chatJID, protocolMessage.GetKey().GetID(), evt.Info.ID, content, evt.Info.Timestamp)
+		case waE2E.ProtocolMessage_REVOKE:
+			return r.RevokeMessage(chatJID, protocolMessage.GetKey().GetID(), evt.Info.Timestamp)
+		}
+	}
+
+	// Reactions are kept per user on the message they react to
+	if reaction := evt.Message.GetReactionMessage(); reaction != nil {
+		return r.StoreReaction(&domainChatStorage.MessageReaction{
+			ChatJID:   chatJID,
+			MessageID: reaction.GetKey().GetID(),
+			SenderJID: evt.Info.Sender.ToNonAD().String(),
+			Emoji:     reaction.GetText(),
+			Timestamp: evt.Info.Timestamp,
+		})
+	}
+
 	// Get appropriate chat name using pushname if available
 	chatName := r.GetChatNameWithPushName(evt.Info.Chat, chatJID, evt.Info.Sender.User, evt.Info.PushName)
 
@@ -574,6 +1967,10 @@ func (r *SQLiteRepository) CreateMessage(ctx context.Context, evt *events.Messag
 		FileEncSHA256: fileEncSHA256,
 		FileLength:    fileLength,
 	}
+	if contextInfo := utils.ExtractContextInfo(evt.Message); contextInfo != nil {
+		message.QuotedMessageID = contextInfo.GetStanzaID()
+		message.Mentions = contextInfo.GetMentionedJID()
+	}
 
 	// Store the message
 	return r.StoreMessage(message)
@@ -596,6 +1993,11 @@ func (r *SQLiteRepository) GetStorageStatistics() (chatCount int64, messageCount
 	return chatCount, messageCount, nil
 }
 
+// GetDatabaseSize returns the size of the database in bytes, excluding the write-ahead log
+func (r *SQLiteRepository) GetDatabaseSize() (int64, error) {
+	return r.getCount("SELECT page_count * page_size FROM pragma_page_count(), pragma_page_size()")
+}
+
 // TruncateAllDataWithLogging performs truncation with detailed logging
 func (r *SQLiteRepository) TruncateAllDataWithLogging(logPrefix string) error {
 	// Get statistics before truncation
@@ -627,8 +2029,15 @@ func (r *SQLiteRepository) TruncateAllDataWithLogging(logPrefix string) error {
 	return nil
 }
 
-// StoreSentMessageWithContext stores a message that was sent by the user with context cancellation support
-func (r *SQLiteRepository) StoreSentMessageWithContext(ctx context.Context, messageID string, senderJID string, recipientJID string, content string, timestamp time.Time) error {
+// StoreSentMessageWithContext stores a message that was sent by the user with context cancellation support.
+// quotedMessageID and mentions are empty when the message is no reply and mentions nobody.
+func (r *SQLiteRepository) StoreSentMessageWithContext(ctx context.Context, messageID string, senderJID string, recipientJID string, content string, timestamp time.Time, quotedMessageID string, mentions []string) (err error) {
+	_, span := tracing.Start(ctx, "chatstorage.store_sent_message",
+		tracing.AttrMessageID.String(messageID),
+		tracing.AttrChatJID.String(recipientJID),
+	)
+	defer func() { tracing.End(span, err) }()
+
 	// Check if context is already cancelled before starting
 	select {
 	case <-ctx.Done():
@@ -685,12 +2094,14 @@ func (r *SQLiteRepository) StoreSentMessageWithContext(ctx context.Context, mess
 
 	// Store the sent message
 	message := &domainChatStorage.Message{
-		ID:        messageID,
-		ChatJID:   chatJID,
-		Sender:    senderJID,
-		Content:   content,
-		Timestamp: timestamp,
-		IsFromMe:  true,
+		ID:              messageID,
+		ChatJID:         chatJID,
+		Sender:          senderJID,
+		Content:         content,
+		Timestamp:       timestamp,
+		IsFromMe:        true,
+		QuotedMessageID: quotedMessageID,
+		Mentions:        mentions,
 	}
 
 	return r.StoreMessage(message)
@@ -810,5 +2221,268 @@ func (r *SQLiteRepository) getMigrations() []string {
 		`
 		CREATE INDEX IF NOT EXISTS idx_messages_id ON messages(id);
 		`,
+
+		// Migration 3: Status (stories) updates
+		`
+		CREATE TABLE IF NOT EXISTS statuses (
+			id TEXT NOT NULL,
+			sender TEXT NOT NULL,
+			pushname TEXT DEFAULT '',
+			content TEXT DEFAULT '',
+			media_type TEXT DEFAULT '',
+			media_path TEXT DEFAULT '',
+			mime_type TEXT DEFAULT '',
+			is_from_me BOOLEAN DEFAULT FALSE,
+			timestamp TIMESTAMP NOT NULL,
+			expires_at TIMESTAMP NOT NULL,
+			created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+			PRIMARY KEY (id, sender)
+		);
+
+		CREATE INDEX IF NOT EXISTS idx_statuses_sender ON statuses(sender);
+		CREATE INDEX IF NOT EXISTS idx_statuses_expires_at ON statuses(expires_at);
+		`,
+
+		// Migration 4: Per-group moderation settings
+		`
+		CREATE TABLE IF NOT EXISTS group_moderation (
+			group_jid TEXT PRIMARY KEY,
+			welcome_message TEXT DEFAULT '',
+			goodbye_message TEXT DEFAULT '',
+			anti_link BOOLEAN DEFAULT FALSE,
+			banned_words TEXT DEFAULT '[]',
+			flood_limit INTEGER DEFAULT 0,
+			flood_window_seconds INTEGER DEFAULT 0,
+			max_warnings INTEGER DEFAULT 0,
+			created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+			updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+		);
+		`,
+
+		// Migration 5: Bulk group participant jobs imported from CSV
+		`
+		CREATE TABLE IF NOT EXISTS group_bulk_jobs (
+			id TEXT PRIMARY KEY,
+			status TEXT NOT NULL,
+			batch_size INTEGER DEFAULT 0,
+			delay_seconds INTEGER DEFAULT 0,
+			total INTEGER DEFAULT 0,
+			processed INTEGER DEFAULT 0,
+			succeeded INTEGER DEFAULT 0,
+			failed INTEGER DEFAULT 0,
+			invited INTEGER DEFAULT 0,
+			created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+			updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+			finished_at TIMESTAMP NULL
+		);
+
+		CREATE TABLE IF NOT EXISTS group_bulk_job_rows (
+			job_id TEXT NOT NULL,
+			row_number INTEGER NOT NULL,
+			group_jid TEXT NOT NULL,
+			phone TEXT NOT NULL,
+			action TEXT NOT NULL,
+			status TEXT NOT NULL,
+			message TEXT DEFAULT '',
+			processed_at TIMESTAMP NULL,
+			PRIMARY KEY (job_id, row_number),
+			FOREIGN KEY (job_id) REFERENCES group_bulk_jobs(id) ON DELETE CASCADE
+		);
+		`,
+
+		// Migration 6: Scoped REST API keys
+		`
+		CREATE TABLE IF NOT EXISTS api_keys (
+			id TEXT PRIMARY KEY,
+			name TEXT NOT NULL,
+			key_hash TEXT NOT NULL UNIQUE,
+			prefix TEXT NOT NULL,
+			scopes TEXT DEFAULT '[]',
+			allowed_recipients TEXT DEFAULT '[]',
+			rate_limit INTEGER DEFAULT 0,
+			expires_at TIMESTAMP NULL,
+			last_used_at TIMESTAMP NULL,
+			revoked_at TIMESTAMP NULL,
+			created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+		);
+		`,
+
+		// Migration 7: Append-only audit log of state-changing actions
+		`
+		CREATE TABLE IF NOT EXISTS audit_logs (
+			id INTEGER PRIMARY KEY AUTOINCREMENT,
+			actor TEXT NOT NULL,
+			endpoint TEXT DEFAULT '',
+			action TEXT NOT NULL,
+			target_jid TEXT DEFAULT '',
+			message_id TEXT DEFAULT '',
+			details TEXT DEFAULT '',
+			result TEXT NOT NULL,
+			error TEXT DEFAULT '',
+			created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+		);
+
+		CREATE INDEX IF NOT EXISTS idx_audit_logs_actor ON audit_logs(actor);
+		CREATE INDEX IF NOT EXISTS idx_audit_logs_action ON audit_logs(action);
+		CREATE INDEX IF NOT EXISTS idx_audit_logs_target_jid ON audit_logs(target_jid);
+		CREATE INDEX IF NOT EXISTS idx_audit_logs_created_at ON audit_logs(created_at);
+
+		CREATE TRIGGER IF NOT EXISTS audit_logs_no_update BEFORE UPDATE ON audit_logs
+		BEGIN
+			SELECT RAISE(ABORT, 'audit log is append-only');
+		END;
+
+		CREATE TRIGGER IF NOT EXISTS audit_logs_no_delete BEFORE DELETE ON audit_logs
+		BEGIN
+			SELECT RAISE(ABORT, 'audit log is append-only');
+		END;
+		`,
+
+		// Migration 8: Idempotency keys of send requests
+		`
+		CREATE TABLE IF NOT EXISTS idempotency_keys (
+			actor TEXT NOT NULL,
+			idempotency_key TEXT NOT NULL,
+			action TEXT NOT NULL,
+			recipient TEXT DEFAULT '',
+			message_id TEXT DEFAULT '',
+			status TEXT DEFAULT '',
+			created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+			completed_at TIMESTAMP NULL,
+			PRIMARY KEY (actor, idempotency_key)
+		);
+
+		CREATE INDEX IF NOT EXISTS idx_idempotency_keys_created_at ON idempotency_keys(created_at);
+		`,
+
+		// Migration 9: App state of chats (pin, archive, mute and unread)
+		`
+		ALTER TABLE chats ADD COLUMN pinned BOOLEAN DEFAULT FALSE;
+		ALTER TABLE chats ADD COLUMN archived BOOLEAN DEFAULT FALSE;
+		ALTER TABLE chats ADD COLUMN muted_until INTEGER DEFAULT 0;
+		ALTER TABLE chats ADD COLUMN unread BOOLEAN DEFAULT FALSE;
+		`,
+
+		// Migration 10: Starred messages and labels synced from app state
+		`
+		ALTER TABLE messages ADD COLUMN starred BOOLEAN DEFAULT FALSE;
+
+		CREATE TABLE IF NOT EXISTS labels (
+			id TEXT PRIMARY KEY,
+			name TEXT NOT NULL,
+			color INTEGER DEFAULT 0,
+			predefined_id INTEGER DEFAULT 0,
+			created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+			updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+		);
+
+		CREATE TABLE IF NOT EXISTS chat_labels (
+			chat_jid TEXT NOT NULL,
+			label_id TEXT NOT NULL,
+			created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+			PRIMARY KEY (chat_jid, label_id)
+		);
+
+		CREATE TABLE IF NOT EXISTS message_labels (
+			chat_jid TEXT NOT NULL,
+			message_id TEXT NOT NULL,
+			label_id TEXT NOT NULL,
+			created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+			PRIMARY KEY (chat_jid, message_id, label_id)
+		);
+
+		CREATE INDEX IF NOT EXISTS idx_chat_labels_label_id ON chat_labels(label_id);
+		CREATE INDEX IF NOT EXISTS idx_message_labels_label_id ON message_labels(label_id);
+		`,
+
+		// Migration 11: Log of incoming calls
+		`
+		CREATE TABLE IF NOT EXISTS calls (
+			id TEXT PRIMARY KEY,
+			caller_jid TEXT NOT NULL,
+			group_jid TEXT DEFAULT '',
+			is_video BOOLEAN DEFAULT FALSE,
+			is_group BOOLEAN DEFAULT FALSE,
+			outcome TEXT NOT NULL,
+			reason TEXT DEFAULT '',
+			timestamp TIMESTAMP NOT NULL,
+			updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+		);
+
+		CREATE INDEX IF NOT EXISTS idx_calls_caller_jid ON calls(caller_jid);
+		CREATE INDEX IF NOT EXISTS idx_calls_timestamp ON calls(timestamp);
+		`,
+
+		// Migration 12: Blocklist of the account
+		`
+		CREATE TABLE IF NOT EXISTS blocked_contacts (
+			jid TEXT PRIMARY KEY,
+			blocked_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+		);
+		`,
+
+		// Migration 13: Edit history and revoked messages
+		`
+		ALTER TABLE messages ADD COLUMN edited_at TIMESTAMP NULL;
+		ALTER TABLE messages ADD COLUMN revoked BOOLEAN DEFAULT FALSE;
+		ALTER TABLE messages ADD COLUMN revoked_at TIMESTAMP NULL;
+
+		CREATE TABLE IF NOT EXISTS message_edits (
+			chat_jid TEXT NOT NULL,
+			message_id TEXT NOT NULL,
+			edit_id TEXT NOT NULL,
+			content TEXT DEFAULT '',
+			edited_at TIMESTAMP NOT NULL,
+			PRIMARY KEY (chat_jid, message_id, edit_id)
+		);
+		`,
+
+		// Migration 14: Replies, mentions and reactions
+		`
+		ALTER TABLE messages ADD COLUMN quoted_message_id TEXT DEFAULT '';
+		ALTER TABLE messages ADD COLUMN mentions TEXT DEFAULT '';
+
+		CREATE INDEX IF NOT EXISTS idx_messages_quoted_message_id ON messages(chat_jid, quoted_message_id);
+
+		CREATE TABLE IF NOT EXISTS message_reactions (
+			chat_jid TEXT NOT NULL,
+			message_id TEXT NOT NULL,
+			sender_jid TEXT NOT NULL,
+			emoji TEXT NOT NULL,
+			timestamp TIMESTAMP NOT NULL,
+			PRIMARY KEY (chat_jid, message_id, sender_jid)
+		);
+		`,
+
+		// Migration 15: Push names and group metadata from history sync
+		`
+		CREATE TABLE IF NOT EXISTS push_names (
+			jid TEXT PRIMARY KEY,
+			push_name TEXT NOT NULL,
+			updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+		);
+
+		CREATE TABLE IF NOT EXISTS group_metadata (
+			jid TEXT PRIMARY KEY,
+			name TEXT DEFAULT '',
+			topic TEXT DEFAULT '',
+			creator_jid TEXT DEFAULT '',
+			group_created_at TIMESTAMP NULL,
+			updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+		);
+
+		CREATE TABLE IF NOT EXISTS group_participants (
+			group_jid TEXT NOT NULL,
+			jid TEXT NOT NULL,
+			is_admin BOOLEAN DEFAULT FALSE,
+			is_super_admin BOOLEAN DEFAULT FALSE,
+			PRIMARY KEY (group_jid, jid)
+		);
+		`,
+
+		// Migration 16: Keep deleted labels as tombstones so their IDs are not reused
+		`
+		ALTER TABLE labels ADD COLUMN deleted BOOLEAN DEFAULT FALSE;
+		`,
 	}
 }
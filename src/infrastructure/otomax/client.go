@@ -10,12 +10,16 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"strconv"
 	"time"
 
 	"github.com/aldinokemal/go-whatsapp-web-multidevice/config"
 	domainOtomax "github.com/aldinokemal/go-whatsapp-web-multidevice/domains/otomax"
 	pkgError "github.com/aldinokemal/go-whatsapp-web-multidevice/pkg/error"
+	"github.com/aldinokemal/go-whatsapp-web-multidevice/pkg/metrics"
+	"github.com/aldinokemal/go-whatsapp-web-multidevice/pkg/tracing"
 	"github.com/sirupsen/logrus"
+	"go.opentelemetry.io/otel/attribute"
 )
 
 type Client struct {
@@ -84,7 +88,15 @@ func cleanBase64String(s string) string {
 }
 
 // makeRequest makes HTTP request to OtomaX API with authentication
-func (c *Client) makeRequest(ctx context.Context, endpoint string, requestBody interface{}) (*http.Response, error) {
+func (c *Client) makeRequest(ctx context.Context, endpoint string, requestBody interface{}) (resp *http.Response, err error) {
+	ctx, span := tracing.Start(ctx, "otomax."+endpoint, attribute.String("http.request.method", http.MethodPost))
+	defer func() {
+		if resp != nil {
+			span.SetAttributes(attribute.Int("http.response.status_code", resp.StatusCode))
+		}
+		tracing.End(span, err)
+	}()
+
 	// Marshal request body
 	bodyBytes, err := json.Marshal(requestBody)
 	if err != nil {
@@ -107,6 +119,7 @@ func (c *Client) makeRequest(ctx context.Context, endpoint string, requestBody i
 	// Set headers
 	req.Header.Set("Content-Type", "application/json")
 	req.Header.Set("User-Agent", "WhatsApp-Center/1.0")
+	tracing.InjectHTTP(ctx, req.Header)
 	
 	// Debug: Log request details (simplified)
 	logrus.Debugf("OtomaX API Request - URL: %s", url)
@@ -114,7 +127,7 @@ func (c *Client) makeRequest(ctx context.Context, endpoint string, requestBody i
 	
 	// Make request
 	logrus.Debugf("Making OtomaX API request to: %s", endpoint)
-	resp, err := c.httpClient.Do(req)
+	resp, err = c.httpClient.Do(req)
 	if err != nil {
 		return nil, fmt.Errorf("failed to make HTTP request: %w", err)
 	}
@@ -160,12 +173,15 @@ func (c *Client) InsertInbox(ctx context.Context, request domainOtomax.InsertInb
 	
 	resp, err := c.makeRequest(ctx, "InsertInbox", request)
 	if err != nil {
+		metrics.OtomaxInsertInbox.WithLabelValues("error").Inc()
 		return nil, err
 	}
 	
 	if err := c.parseResponse(resp, &response); err != nil {
+		metrics.OtomaxInsertInbox.WithLabelValues("error").Inc()
 		return nil, err
 	}
+	metrics.OtomaxInsertInbox.WithLabelValues(strconv.Itoa(response.Result.Status)).Inc()
 	
 	// Log parsed response details
 	logrus.Infof("OtomaX InsertInbox Success - Ok: %t, KodeInbox: %d, Status: %d, StatusDesc: %s", 
@@ -0,0 +1,170 @@
+package whatsapp
+
+import (
+	"context"
+	"time"
+
+	domainChatStorage "github.com/aldinokemal/go-whatsapp-web-multidevice/domains/chatstorage"
+	"github.com/sirupsen/logrus"
+	"go.mau.fi/whatsmeow/proto/waSyncAction"
+	"go.mau.fi/whatsmeow/types/events"
+)
+
+// handleAppStateEvent stores chat, message and label changes made on another device, such as a chat archived
+// on the phone, and forwards them to webhooks. Changes replayed by a full app state sync are stored only,
+// so a new login does not flood the webhooks with the whole history of the account.
+func handleAppStateEvent(ctx context.Context, rawEvt any, chatStorageRepo domainChatStorage.IChatStorageRepository) {
+	if chatStorageRepo != nil {
+		if err := storeAppStateEvent(chatStorageRepo, rawEvt); err != nil {
+			logrus.Errorf("Failed to store app state event %T: %v", rawEvt, err)
+		}
+	}
+
+	if !eventForwardingEnabled() {
+		return
+	}
+
+	payload, fromFullSync := createAppStatePayload(rawEvt)
+	if payload == nil || fromFullSync {
+		return
+	}
+
+	go func() {
+		if err := forwardPayloadToConfiguredWebhooks(ctx, payload, "app state event"); err != nil {
+			logrus.Errorf("Failed to forward app state event to webhook: %v", err)
+		}
+	}()
+}
+
+// storeAppStateEvent applies an app state event to chat storage
+func storeAppStateEvent(repo domainChatStorage.IChatStorageRepository, rawEvt any) error {
+	switch evt := rawEvt.(type) {
+	case *events.Pin:
+		pinned := evt.Action.GetPinned()
+		return repo.UpdateChatState(evt.JID.String(), &domainChatStorage.ChatStateUpdate{Pinned: &pinned})
+	case *events.Archive:
+		archived := evt.Action.GetArchived()
+		return repo.UpdateChatState(evt.JID.String(), &domainChatStorage.ChatStateUpdate{Archived: &archived})
+	case *events.Mute:
+		mutedUntil := muteEnd(evt.Action)
+		return repo.UpdateChatState(evt.JID.String(), &domainChatStorage.ChatStateUpdate{MutedUntil: &mutedUntil})
+	case *events.MarkChatAsRead:
+		unread := !evt.Action.GetRead()
+		return repo.UpdateChatState(evt.JID.String(), &domainChatStorage.ChatStateUpdate{Unread: &unread})
+	case *events.ClearChat:
+		return repo.ClearChatMessages(evt.JID.String())
+	case *events.DeleteChat:
+		return repo.DeleteChat(evt.JID.String())
+	case *events.Star:
+		return repo.SetMessageStarred(evt.MessageID, evt.ChatJID.String(), evt.Action.GetStarred())
+	case *events.LabelEdit:
+		if evt.Action.GetDeleted() {
+			return repo.DeleteLabel(evt.LabelID)
+		}
+		return repo.StoreLabel(&domainChatStorage.Label{
+			ID:           evt.LabelID,
+			Name:         evt.Action.GetName(),
+			Color:        evt.Action.GetColor(),
+			PredefinedID: evt.Action.GetPredefinedID(),
+		})
+	case *events.LabelAssociationChat:
+		return repo.SetChatLabel(evt.JID.String(), evt.LabelID, evt.Action.GetLabeled())
+	case *events.LabelAssociationMessage:
+		return repo.SetMessageLabel(evt.JID.String(), evt.MessageID, evt.LabelID, evt.Action.GetLabeled())
+	}
+	return nil
+}
+
+// muteEnd converts a mute action to the MutedUntil value stored for the chat
+func muteEnd(action *waSyncAction.MuteAction) int64 {
+	if !action.GetMuted() {
+		return 0
+	}
+	if end := action.GetMuteEndTimestamp(); end > 0 {
+		return time.UnixMilli(end).Unix()
+	}
+	return domainChatStorage.MutedForever
+}
+
+// createAppStatePayload creates a webhook payload for app state events and reports whether the event
+// comes from a full sync
+func createAppStatePayload(rawEvt any) (map[string]any, bool) {
+	body := make(map[string]any)
+	payload := make(map[string]any)
+	var timestamp time.Time
+	var fromFullSync bool
+
+	switch evt := rawEvt.(type) {
+	case *events.Pin:
+		body["event"] = "chat.pin"
+		payload["chat_id"] = evt.JID.String()
+		payload["pinned"] = evt.Action.GetPinned()
+		timestamp, fromFullSync = evt.Timestamp, evt.FromFullSync
+	case *events.Archive:
+		body["event"] = "chat.archive"
+		payload["chat_id"] = evt.JID.String()
+		payload["archived"] = evt.Action.GetArchived()
+		timestamp, fromFullSync = evt.Timestamp, evt.FromFullSync
+	case *events.Mute:
+		body["event"] = "chat.mute"
+		payload["chat_id"] = evt.JID.String()
+		payload["muted"] = evt.Action.GetMuted()
+		if end := muteEnd(evt.Action); end > 0 {
+			payload["muted_until"] = time.Unix(end, 0).Format(time.RFC3339)
+		}
+		timestamp, fromFullSync = evt.Timestamp, evt.FromFullSync
+	case *events.MarkChatAsRead:
+		body["event"] = "chat.read"
+		payload["chat_id"] = evt.JID.String()
+		payload["read"] = evt.Action.GetRead()
+		timestamp, fromFullSync = evt.Timestamp, evt.FromFullSync
+	case *events.ClearChat:
+		body["event"] = "chat.clear"
+		payload["chat_id"] = evt.JID.String()
+		timestamp, fromFullSync = evt.Timestamp, evt.FromFullSync
+	case *events.DeleteChat:
+		body["event"] = "chat.delete"
+		payload["chat_id"] = evt.JID.String()
+		timestamp, fromFullSync = evt.Timestamp, evt.FromFullSync
+	case *events.Star:
+		body["event"] = "message.star"
+		payload["chat_id"] = evt.ChatJID.String()
+		payload["message_id"] = evt.MessageID
+		payload["is_from_me"] = evt.IsFromMe
+		if !evt.SenderJID.IsEmpty() {
+			payload["sender_id"] = evt.SenderJID.String()
+		}
+		payload["starred"] = evt.Action.GetStarred()
+		timestamp, fromFullSync = evt.Timestamp, evt.FromFullSync
+	case *events.LabelEdit:
+		body["event"] = "label.edit"
+		payload["label_id"] = evt.LabelID
+		payload["name"] = evt.Action.GetName()
+		payload["color"] = evt.Action.GetColor()
+		payload["deleted"] = evt.Action.GetDeleted()
+		timestamp, fromFullSync = evt.Timestamp, evt.FromFullSync
+	case *events.LabelAssociationChat:
+		body["event"] = "label.chat"
+		payload["label_id"] = evt.LabelID
+		payload["chat_id"] = evt.JID.String()
+		payload["labeled"] = evt.Action.GetLabeled()
+		timestamp, fromFullSync = evt.Timestamp, evt.FromFullSync
+	case *events.LabelAssociationMessage:
+		body["event"] = "label.message"
+		payload["label_id"] = evt.LabelID
+		payload["chat_id"] = evt.JID.String()
+		payload["message_id"] = evt.MessageID
+		payload["labeled"] = evt.Action.GetLabeled()
+		timestamp, fromFullSync = evt.Timestamp, evt.FromFullSync
+	default:
+		return nil, false
+	}
+
+	if timestamp.IsZero() {
+		timestamp = time.Now()
+	}
+	body["payload"] = payload
+	body["timestamp"] = timestamp.Format(time.RFC3339)
+
+	return body, fromFullSync
+}
@@ -0,0 +1,134 @@
+package whatsapp
+
+import (
+	"testing"
+	"time"
+
+	domainChatStorage "github.com/aldinokemal/go-whatsapp-web-multidevice/domains/chatstorage"
+	"go.mau.fi/whatsmeow/proto/waSyncAction"
+	"go.mau.fi/whatsmeow/types"
+	"go.mau.fi/whatsmeow/types/events"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestStoreAppStateEvent(t *testing.T) {
+	repo := newSQLiteTestRepo(t)
+
+	chatJID := types.NewJID("628123456789", types.DefaultUserServer)
+	now := time.Now()
+	if err := repo.StoreChat(&domainChatStorage.Chat{JID: chatJID.String(), Name: "Alice", LastMessageTime: now}); err != nil {
+		t.Fatal(err)
+	}
+	if err := repo.StoreMessage(&domainChatStorage.Message{ID: "MSG1", ChatJID: chatJID.String(), Sender: chatJID.String(), Content: "hi", Timestamp: now}); err != nil {
+		t.Fatal(err)
+	}
+
+	muteEndMS := now.Add(time.Hour).UnixMilli()
+	for _, evt := range []any{
+		&events.Pin{JID: chatJID, Action: &waSyncAction.PinAction{Pinned: proto.Bool(true)}},
+		&events.Archive{JID: chatJID, Action: &waSyncAction.ArchiveChatAction{Archived: proto.Bool(true)}},
+		&events.Mute{JID: chatJID, Action: &waSyncAction.MuteAction{Muted: proto.Bool(true), MuteEndTimestamp: proto.Int64(muteEndMS)}},
+		&events.MarkChatAsRead{JID: chatJID, Action: &waSyncAction.MarkChatAsReadAction{Read: proto.Bool(false)}},
+		&events.Star{ChatJID: chatJID, MessageID: "MSG1", Action: &waSyncAction.StarAction{Starred: proto.Bool(true)}},
+		&events.LabelEdit{LabelID: "5", Action: &waSyncAction.LabelEditAction{Name: proto.String("Pending payment"), Color: proto.Int32(3)}},
+		&events.LabelAssociationChat{JID: chatJID, LabelID: "5", Action: &waSyncAction.LabelAssociationAction{Labeled: proto.Bool(true)}},
+	} {
+		if err := storeAppStateEvent(repo, evt); err != nil {
+			t.Fatalf("store %T: %v", evt, err)
+		}
+	}
+
+	chat, err := repo.GetChat(chatJID.String())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !chat.Pinned || !chat.Archived || !chat.Unread || chat.MutedUntil != time.UnixMilli(muteEndMS).Unix() {
+		t.Fatalf("unexpected chat state %+v", chat)
+	}
+
+	// App state of chats without stored messages is kept for when they arrive
+	otherJID := types.NewJID("628999999999", types.DefaultUserServer)
+	if err := storeAppStateEvent(repo, &events.Archive{JID: otherJID, Action: &waSyncAction.ArchiveChatAction{Archived: proto.Bool(true)}}); err != nil {
+		t.Fatal(err)
+	}
+	if err := repo.StoreChat(&domainChatStorage.Chat{JID: otherJID.String(), Name: "Bob", LastMessageTime: now}); err != nil {
+		t.Fatal(err)
+	}
+	if other, err := repo.GetChat(otherJID.String()); err != nil || other == nil || !other.Archived || other.Name != "Bob" {
+		t.Fatalf("expected the archived state of a new chat to be kept, got %+v (%v)", other, err)
+	}
+
+	message, err := repo.GetMessageByID("MSG1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !message.Starred {
+		t.Fatal("expected message to be starred")
+	}
+
+	labels, err := repo.GetLabels()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(labels) != 1 || labels[0].Name != "Pending payment" || labels[0].Color != 3 {
+		t.Fatalf("unexpected labels %+v", labels)
+	}
+
+	deleted := &events.LabelEdit{LabelID: "5", Action: &waSyncAction.LabelEditAction{Deleted: proto.Bool(true)}}
+	if err := storeAppStateEvent(repo, deleted); err != nil {
+		t.Fatal(err)
+	}
+	if labels, _ := repo.GetLabels(); len(labels) != 0 {
+		t.Fatalf("expected label to be deleted, got %+v", labels)
+	}
+}
+
+func TestMuteEnd(t *testing.T) {
+	end := time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
+	tests := []struct {
+		name   string
+		action *waSyncAction.MuteAction
+		want   int64
+	}{
+		{name: "unmuted", action: &waSyncAction.MuteAction{Muted: proto.Bool(false)}, want: 0},
+		{name: "muted until", action: &waSyncAction.MuteAction{Muted: proto.Bool(true), MuteEndTimestamp: proto.Int64(end.UnixMilli())}, want: end.Unix()},
+		{name: "muted forever", action: &waSyncAction.MuteAction{Muted: proto.Bool(true), MuteEndTimestamp: proto.Int64(-1)}, want: domainChatStorage.MutedForever},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := muteEnd(tt.action); got != tt.want {
+				t.Fatalf("got %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCreateAppStatePayload(t *testing.T) {
+	chatJID := types.NewJID("628123456789", types.DefaultUserServer)
+	timestamp := time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	payload, fromFullSync := createAppStatePayload(&events.Archive{
+		JID:       chatJID,
+		Timestamp: timestamp,
+		Action:    &waSyncAction.ArchiveChatAction{Archived: proto.Bool(true)},
+	})
+	if fromFullSync {
+		t.Fatal("expected a live event")
+	}
+	if payload["event"] != "chat.archive" || payload["timestamp"] != "2025-01-02T03:04:05Z" {
+		t.Fatalf("unexpected payload %+v", payload)
+	}
+	data := payload["payload"].(map[string]any)
+	if data["chat_id"] != chatJID.String() || data["archived"] != true {
+		t.Fatalf("unexpected payload data %+v", data)
+	}
+
+	_, fromFullSync = createAppStatePayload(&events.Star{ChatJID: chatJID, MessageID: "MSG1", FromFullSync: true, Action: &waSyncAction.StarAction{}})
+	if !fromFullSync {
+		t.Fatal("expected the full sync flag to be reported")
+	}
+
+	if payload, _ := createAppStatePayload(&events.AppState{}); payload != nil {
+		t.Fatalf("expected no payload for raw app state, got %+v", payload)
+	}
+}
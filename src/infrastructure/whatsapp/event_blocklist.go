@@ -0,0 +1,144 @@
+package whatsapp
+
+import (
+	"context"
+	"time"
+
+	domainChatStorage "github.com/aldinokemal/go-whatsapp-web-multidevice/domains/chatstorage"
+	"github.com/sirupsen/logrus"
+	"go.mau.fi/whatsmeow/types"
+	"go.mau.fi/whatsmeow/types/events"
+)
+
+// handleBlocklist stores contacts blocked or unblocked on another device and forwards the changes to webhooks.
+// When WhatsApp only reports that the blocklist was modified, the whole list is fetched again.
+func handleBlocklist(ctx context.Context, evt *events.Blocklist, chatStorageRepo domainChatStorage.IChatStorageRepository) {
+	if chatStorageRepo == nil {
+		return
+	}
+
+	changes := evt.Changes
+	if evt.Action == events.BlocklistActionModify || len(changes) == 0 {
+		changes = syncBlocklist(ctx, chatStorageRepo)
+	} else {
+		for _, change := range changes {
+			blocked := change.Action == events.BlocklistChangeActionBlock
+			if err := chatStorageRepo.SetContactBlocked(change.JID.ToNonAD().String(), blocked); err != nil {
+				logrus.Errorf("Failed to store blocklist change of %s: %v", change.JID.String(), err)
+			}
+		}
+	}
+
+	if len(changes) == 0 || !eventForwardingEnabled() {
+		return
+	}
+
+	payload := createBlocklistPayload(changes)
+	go func() {
+		if err := forwardPayloadToConfiguredWebhooks(ctx, payload, "blocklist event"); err != nil {
+			logrus.Errorf("Failed to forward blocklist event to webhook: %v", err)
+		}
+	}()
+}
+
+// syncBlocklist fetches the blocklist from WhatsApp, stores it and returns how it differs from the stored one
+func syncBlocklist(ctx context.Context, chatStorageRepo domainChatStorage.IChatStorageRepository) []events.BlocklistChange {
+	if cli == nil || chatStorageRepo == nil {
+		return nil
+	}
+
+	blocklist, err := cli.GetBlocklist(ctx)
+	if err != nil {
+		logrus.Errorf("Failed to get blocklist: %v", err)
+		return nil
+	}
+
+	return StoreBlocklist(chatStorageRepo, blocklist.JIDs)
+}
+
+// StoreBlocklist replaces the stored blocklist and returns how the given one differs from it
+func StoreBlocklist(chatStorageRepo domainChatStorage.IChatStorageRepository, jids []types.JID) []events.BlocklistChange {
+	stored, err := chatStorageRepo.GetBlocklist()
+	if err != nil {
+		logrus.Errorf("Failed to get stored blocklist: %v", err)
+		return nil
+	}
+
+	current := make([]string, 0, len(jids))
+	for _, jid := range jids {
+		current = append(current, jid.ToNonAD().String())
+	}
+	if err := chatStorageRepo.ReplaceBlocklist(current); err != nil {
+		logrus.Errorf("Failed to store blocklist: %v", err)
+		return nil
+	}
+
+	return blocklistChanges(stored, current)
+}
+
+// blocklistChanges lists the contacts blocked and unblocked between two versions of the blocklist
+func blocklistChanges(stored []*domainChatStorage.BlockedContact, current []string) []events.BlocklistChange {
+	previous := make(map[string]bool, len(stored))
+	for _, contact := range stored {
+		previous[contact.JID] = true
+	}
+
+	var changes []events.BlocklistChange
+	for _, jid := range current {
+		if previous[jid] {
+			delete(previous, jid)
+			continue
+		}
+		if parsed, err := types.ParseJID(jid); err == nil {
+			changes = append(changes, events.BlocklistChange{JID: parsed, Action: events.BlocklistChangeActionBlock})
+		}
+	}
+	for _, contact := range stored {
+		if !previous[contact.JID] {
+			continue
+		}
+		if parsed, err := types.ParseJID(contact.JID); err == nil {
+			changes = append(changes, events.BlocklistChange{JID: parsed, Action: events.BlocklistChangeActionUnblock})
+		}
+	}
+	return changes
+}
+
+// isBlockedSender reports whether the sender of a message is on the stored blocklist, by either of its addresses
+func isBlockedSender(info types.MessageInfo, chatStorageRepo domainChatStorage.IChatStorageRepository) bool {
+	if chatStorageRepo == nil || info.IsFromMe {
+		return false
+	}
+
+	for _, jid := range []types.JID{info.Sender, info.SenderAlt} {
+		if jid.IsEmpty() {
+			continue
+		}
+		blocked, err := chatStorageRepo.IsContactBlocked(jid.ToNonAD().String())
+		if err != nil {
+			logrus.Errorf("Failed to check whether %s is blocked: %v", jid.String(), err)
+			continue
+		}
+		if blocked {
+			return true
+		}
+	}
+	return false
+}
+
+// createBlocklistPayload creates a webhook payload for blocklist changes
+func createBlocklistPayload(changes []events.BlocklistChange) map[string]any {
+	items := make([]map[string]any, 0, len(changes))
+	for _, change := range changes {
+		items = append(items, map[string]any{
+			"jid":    change.JID.ToNonAD().String(),
+			"action": string(change.Action),
+		})
+	}
+
+	return map[string]any{
+		"event":     "blocklist",
+		"payload":   map[string]any{"changes": items},
+		"timestamp": time.Now().Format(time.RFC3339),
+	}
+}
@@ -0,0 +1,63 @@
+package whatsapp
+
+import (
+	"context"
+	"testing"
+
+	"go.mau.fi/whatsmeow/types"
+	"go.mau.fi/whatsmeow/types/events"
+)
+
+func TestBlocklist(t *testing.T) {
+	repo := newSQLiteTestRepo(t)
+
+	alice := types.NewJID("628111", types.DefaultUserServer)
+	bob := types.NewJID("628222", types.DefaultUserServer)
+	carol := types.NewJID("123456789", types.HiddenUserServer)
+
+	if changes := StoreBlocklist(repo, []types.JID{alice, bob}); len(changes) != 2 {
+		t.Fatalf("expected 2 changes, got %+v", changes)
+	}
+
+	changes := StoreBlocklist(repo, []types.JID{bob, carol})
+	if len(changes) != 2 ||
+		changes[0].JID != carol || changes[0].Action != events.BlocklistChangeActionBlock ||
+		changes[1].JID != alice || changes[1].Action != events.BlocklistChangeActionUnblock {
+		t.Fatalf("unexpected changes %+v", changes)
+	}
+
+	handleBlocklist(context.Background(), &events.Blocklist{Changes: []events.BlocklistChange{
+		{JID: bob, Action: events.BlocklistChangeActionUnblock},
+		{JID: alice, Action: events.BlocklistChangeActionBlock},
+	}}, repo)
+
+	stored, err := repo.GetBlocklist()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(stored) != 2 {
+		t.Fatalf("expected 2 blocked contacts, got %+v", stored)
+	}
+
+	// Senders are matched by either their LID or phone number
+	if !isBlockedSender(types.MessageInfo{MessageSource: types.MessageSource{Sender: carol}}, repo) {
+		t.Fatal("expected LID sender to be blocked")
+	}
+	lid := types.NewJID("987654321", types.HiddenUserServer)
+	if !isBlockedSender(types.MessageInfo{MessageSource: types.MessageSource{Sender: lid, SenderAlt: alice}}, repo) {
+		t.Fatal("expected sender to be blocked by phone number")
+	}
+	if isBlockedSender(types.MessageInfo{MessageSource: types.MessageSource{Sender: bob}}, repo) {
+		t.Fatal("expected unblocked sender")
+	}
+}
+
+func TestCreateBlocklistPayload(t *testing.T) {
+	jid := types.NewJID("628111", types.DefaultUserServer)
+	payload := createBlocklistPayload([]events.BlocklistChange{{JID: jid, Action: events.BlocklistChangeActionBlock}})
+
+	changes := payload["payload"].(map[string]any)["changes"].([]map[string]any)
+	if payload["event"] != "blocklist" || len(changes) != 1 || changes[0]["jid"] != jid.String() || changes[0]["action"] != "block" {
+		t.Fatalf("unexpected payload %+v", payload)
+	}
+}
@@ -0,0 +1,226 @@
+package whatsapp
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/aldinokemal/go-whatsapp-web-multidevice/config"
+	domainChatStorage "github.com/aldinokemal/go-whatsapp-web-multidevice/domains/chatstorage"
+	"github.com/sirupsen/logrus"
+	waBinary "go.mau.fi/whatsmeow/binary"
+	"go.mau.fi/whatsmeow/proto/waE2E"
+	"go.mau.fi/whatsmeow/types"
+	"go.mau.fi/whatsmeow/types/events"
+	"google.golang.org/protobuf/proto"
+)
+
+// handleCallEvent records incoming calls in the call log, rejects them when call rejection is enabled and
+// forwards every change of their outcome to webhooks as "call" events.
+func handleCallEvent(ctx context.Context, rawEvt any, chatStorageRepo domainChatStorage.IChatStorageRepository) {
+	var call *domainChatStorage.Call
+	switch evt := rawEvt.(type) {
+	case *events.CallOffer:
+		call = newCall(evt.BasicCallMeta, isVideoOffer(evt.Data), false)
+		handleCallOffer(ctx, call, evt.From, chatStorageRepo)
+	case *events.CallOfferNotice:
+		call = newCall(evt.BasicCallMeta, evt.Media == "video", evt.Type == "group")
+		handleCallOffer(ctx, call, evt.From, chatStorageRepo)
+	case *events.CallAccept, *events.CallReject, *events.CallTerminate:
+		call = updateCallOutcome(rawEvt, chatStorageRepo)
+	}
+
+	if call == nil || !eventForwardingEnabled() {
+		return
+	}
+
+	payload := createCallPayload(call)
+	go func() {
+		if err := forwardPayloadToConfiguredWebhooks(ctx, payload, "call event"); err != nil {
+			logrus.Errorf("Failed to forward call event to webhook: %v", err)
+		}
+	}()
+}
+
+// handleCallOffer rejects a new call when configured, sends the reject message and stores the call
+func handleCallOffer(ctx context.Context, call *domainChatStorage.Call, from types.JID, chatStorageRepo domainChatStorage.IChatStorageRepository) {
+	logrus.Infof("Incoming %s call %s from %s", callType(call.IsVideo), call.ID, call.CallerJID)
+
+	if config.WhatsappCallReject && cli != nil {
+		if err := cli.RejectCall(ctx, from, call.ID); err != nil {
+			logrus.Errorf("Failed to reject call %s from %s: %v", call.ID, call.CallerJID, err)
+		} else {
+			call.Outcome = domainChatStorage.CallOutcomeRejected
+		}
+	}
+
+	if chatStorageRepo != nil {
+		if err := chatStorageRepo.StoreCall(call); err != nil {
+			logrus.Errorf("Failed to store call %s: %v", call.ID, err)
+		}
+	}
+
+	if call.Outcome == domainChatStorage.CallOutcomeRejected && config.WhatsappCallRejectMessage != "" {
+		sendCallRejectMessage(ctx, call, chatStorageRepo)
+	}
+}
+
+// sendCallRejectMessage sends the configured reject message to the caller and stores it in chat storage
+func sendCallRejectMessage(ctx context.Context, call *domainChatStorage.Call, chatStorageRepo domainChatStorage.IChatStorageRepository) {
+	recipientJID, err := types.ParseJID(call.CallerJID)
+	if err != nil {
+		logrus.Errorf("Failed to parse caller %s of call %s: %v", call.CallerJID, call.ID, err)
+		return
+	}
+
+	text := callRejectMessage(call.IsVideo)
+	response, err := SendMessage(ctx, recipientJID, &waE2E.Message{Conversation: proto.String(text)})
+	if err != nil {
+		logrus.Errorf("Failed to send reject message for call %s: %v", call.ID, err)
+		return
+	}
+
+	if chatStorageRepo != nil && cli.Store.ID != nil {
+		if err := chatStorageRepo.StoreSentMessageWithContext(ctx, response.ID, cli.Store.ID.String(), recipientJID.String(), text, response.Timestamp, "", nil); err != nil {
+			logrus.Errorf("Failed to store reject message for call %s in chat storage: %v", call.ID, err)
+		}
+	}
+}
+
+// updateCallOutcome applies an accept, reject or terminate event to a stored call. It returns nil for calls
+// that are not in the call log, such as calls made from the phone, or when the outcome does not change.
+func updateCallOutcome(rawEvt any, chatStorageRepo domainChatStorage.IChatStorageRepository) *domainChatStorage.Call {
+	if chatStorageRepo == nil {
+		return nil
+	}
+
+	var callID, reason string
+	switch evt := rawEvt.(type) {
+	case *events.CallAccept:
+		callID = evt.CallID
+	case *events.CallReject:
+		callID = evt.CallID
+	case *events.CallTerminate:
+		callID, reason = evt.CallID, evt.Reason
+	}
+
+	call, err := chatStorageRepo.GetCall(callID)
+	if err != nil {
+		logrus.Errorf("Failed to get call %s: %v", callID, err)
+		return nil
+	}
+	if call == nil {
+		return nil
+	}
+
+	outcome := nextCallOutcome(call.Outcome, rawEvt)
+	if outcome == call.Outcome {
+		return nil
+	}
+	call.Outcome = outcome
+	if reason != "" {
+		call.Reason = reason
+	}
+
+	if err := chatStorageRepo.StoreCall(call); err != nil {
+		logrus.Errorf("Failed to store call %s: %v", call.ID, err)
+	}
+	return call
+}
+
+// nextCallOutcome returns the outcome of a call after an accept, reject or terminate event. A call that is
+// terminated while still ringing was missed; an accepted call that is terminated has ended.
+func nextCallOutcome(current string, rawEvt any) string {
+	switch rawEvt.(type) {
+	case *events.CallAccept:
+		if current == domainChatStorage.CallOutcomeRinging {
+			return domainChatStorage.CallOutcomeAccepted
+		}
+	case *events.CallReject:
+		if current == domainChatStorage.CallOutcomeRinging {
+			return domainChatStorage.CallOutcomeRejected
+		}
+	case *events.CallTerminate:
+		switch current {
+		case domainChatStorage.CallOutcomeRinging:
+			return domainChatStorage.CallOutcomeMissed
+		case domainChatStorage.CallOutcomeAccepted:
+			return domainChatStorage.CallOutcomeEnded
+		}
+	}
+	return current
+}
+
+// newCall creates the call log entry of a ringing call. Callers addressed by LID are logged with their
+// phone number when WhatsApp sends it along.
+func newCall(meta types.BasicCallMeta, isVideo, isGroup bool) *domainChatStorage.Call {
+	caller := meta.CallCreator
+	if caller.IsEmpty() {
+		caller = meta.From
+	}
+	if caller.Server == types.HiddenUserServer && meta.CallCreatorAlt.Server == types.DefaultUserServer {
+		caller = meta.CallCreatorAlt
+	}
+
+	call := &domainChatStorage.Call{
+		ID:        meta.CallID,
+		CallerJID: caller.ToNonAD().String(),
+		IsVideo:   isVideo,
+		IsGroup:   isGroup || !meta.GroupJID.IsEmpty(),
+		Outcome:   domainChatStorage.CallOutcomeRinging,
+		Timestamp: meta.Timestamp,
+	}
+	if !meta.GroupJID.IsEmpty() {
+		call.GroupJID = meta.GroupJID.String()
+	}
+	return call
+}
+
+// isVideoOffer reports whether a call offer includes video
+func isVideoOffer(data *waBinary.Node) bool {
+	if data == nil {
+		return false
+	}
+	_, ok := data.GetOptionalChildByTag("video")
+	return ok
+}
+
+func callType(isVideo bool) string {
+	if isVideo {
+		return "video"
+	}
+	return "voice"
+}
+
+// callRejectMessage fills the {type} placeholder of the configured reject message
+func callRejectMessage(isVideo bool) string {
+	return strings.ReplaceAll(config.WhatsappCallRejectMessage, "{type}", callType(isVideo))
+}
+
+// createCallPayload creates a webhook payload for call events
+func createCallPayload(call *domainChatStorage.Call) map[string]any {
+	payload := map[string]any{
+		"call_id":  call.ID,
+		"from":     call.CallerJID,
+		"is_video": call.IsVideo,
+		"is_group": call.IsGroup,
+		"outcome":  call.Outcome,
+	}
+	if call.GroupJID != "" {
+		payload["group_id"] = call.GroupJID
+	}
+	if call.Reason != "" {
+		payload["reason"] = call.Reason
+	}
+
+	timestamp := call.UpdatedAt
+	if timestamp.IsZero() {
+		timestamp = time.Now()
+	}
+
+	return map[string]any{
+		"event":     "call",
+		"payload":   payload,
+		"timestamp": timestamp.Format(time.RFC3339),
+	}
+}
@@ -0,0 +1,84 @@
+package whatsapp
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	domainChatStorage "github.com/aldinokemal/go-whatsapp-web-multidevice/domains/chatstorage"
+	waBinary "go.mau.fi/whatsmeow/binary"
+	"go.mau.fi/whatsmeow/types"
+	"go.mau.fi/whatsmeow/types/events"
+)
+
+func TestCallLog(t *testing.T) {
+	repo := newSQLiteTestRepo(t)
+
+	caller := types.NewJID("628123456789", types.DefaultUserServer)
+	meta := types.BasicCallMeta{From: caller, CallCreator: caller, CallID: "CALL1", Timestamp: time.Now()}
+	offer := &waBinary.Node{Tag: "offer", Content: []waBinary.Node{{Tag: "audio"}, {Tag: "video"}}}
+
+	handleCallEvent(context.Background(), &events.CallOffer{BasicCallMeta: meta, Data: offer}, repo)
+	handleCallEvent(context.Background(), &events.CallTerminate{BasicCallMeta: meta, Reason: "timeout"}, repo)
+
+	calls, err := repo.GetCalls(&domainChatStorage.CallFilter{CallerJID: caller.String()})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(calls) != 1 {
+		t.Fatalf("expected 1 call, got %d", len(calls))
+	}
+	call := calls[0]
+	if !call.IsVideo || call.IsGroup || call.Outcome != domainChatStorage.CallOutcomeMissed || call.Reason != "timeout" {
+		t.Fatalf("unexpected call %+v", call)
+	}
+
+	// Calls that were not offered to this device, e.g. made from the phone, are not logged
+	other := types.BasicCallMeta{From: caller, CallID: "CALL2"}
+	handleCallEvent(context.Background(), &events.CallTerminate{BasicCallMeta: other}, repo)
+	if count, _ := repo.CountCalls(&domainChatStorage.CallFilter{}); count != 1 {
+		t.Fatalf("expected 1 logged call, got %d", count)
+	}
+}
+
+func TestNextCallOutcome(t *testing.T) {
+	tests := []struct {
+		name    string
+		current string
+		event   any
+		want    string
+	}{
+		{name: "accepted", current: domainChatStorage.CallOutcomeRinging, event: &events.CallAccept{}, want: domainChatStorage.CallOutcomeAccepted},
+		{name: "rejected on another device", current: domainChatStorage.CallOutcomeRinging, event: &events.CallReject{}, want: domainChatStorage.CallOutcomeRejected},
+		{name: "missed", current: domainChatStorage.CallOutcomeRinging, event: &events.CallTerminate{}, want: domainChatStorage.CallOutcomeMissed},
+		{name: "ended", current: domainChatStorage.CallOutcomeAccepted, event: &events.CallTerminate{}, want: domainChatStorage.CallOutcomeEnded},
+		{name: "rejected stays rejected", current: domainChatStorage.CallOutcomeRejected, event: &events.CallTerminate{}, want: domainChatStorage.CallOutcomeRejected},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := nextCallOutcome(tt.current, tt.event); got != tt.want {
+				t.Fatalf("got %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNewCall(t *testing.T) {
+	lid := types.NewJID("123456789", types.HiddenUserServer)
+	pn := types.NewJID("628123456789", types.DefaultUserServer)
+	group := types.NewJID("120363025246125486", types.GroupServer)
+
+	call := newCall(types.BasicCallMeta{From: lid, CallCreator: lid, CallCreatorAlt: pn, CallID: "CALL1", GroupJID: group}, false, false)
+	if call.CallerJID != pn.String() {
+		t.Fatalf("expected caller %s, got %s", pn, call.CallerJID)
+	}
+	if !call.IsGroup || call.GroupJID != group.String() || call.Outcome != domainChatStorage.CallOutcomeRinging {
+		t.Fatalf("unexpected call %+v", call)
+	}
+
+	payload := createCallPayload(call)
+	data := payload["payload"].(map[string]any)
+	if payload["event"] != "call" || data["from"] != pn.String() || data["group_id"] != group.String() || data["outcome"] != "ringing" {
+		t.Fatalf("unexpected payload %+v", payload)
+	}
+}
@@ -0,0 +1,111 @@
+package whatsapp
+
+import (
+	"context"
+	"time"
+
+	domainChatStorage "github.com/aldinokemal/go-whatsapp-web-multidevice/domains/chatstorage"
+	"github.com/sirupsen/logrus"
+	"go.mau.fi/whatsmeow/proto/waE2E"
+	"go.mau.fi/whatsmeow/types"
+	"go.mau.fi/whatsmeow/types/events"
+)
+
+// handleDisappearingSetting stores the disappearing messages timer of a private chat when it is changed by the
+// other party or from another device, and forwards the change to webhooks. Group changes arrive as group info.
+func handleDisappearingSetting(ctx context.Context, evt *events.Message, chatStorageRepo domainChatStorage.IChatStorageRepository) {
+	protocolMessage := evt.Message.GetProtocolMessage()
+	if protocolMessage.GetType() != waE2E.ProtocolMessage_EPHEMERAL_SETTING || evt.Info.IsGroup {
+		return
+	}
+
+	storeDisappearingTimer(evt.Info.Chat, protocolMessage.GetEphemeralExpiration(), chatStorageRepo)
+	forwardDisappearingChange(ctx, createDisappearingPayload(evt.Info.Chat, protocolMessage.GetEphemeralExpiration(), evt.Info.Sender, evt.Info.IsFromMe, evt.Info.Timestamp))
+}
+
+// handleGroupDisappearing stores the disappearing messages timer of a group and forwards the change to webhooks
+func handleGroupDisappearing(ctx context.Context, evt *events.GroupInfo, chatStorageRepo domainChatStorage.IChatStorageRepository) {
+	if evt.Ephemeral == nil {
+		return
+	}
+
+	var expiration uint32
+	if evt.Ephemeral.IsEphemeral {
+		expiration = evt.Ephemeral.DisappearingTimer
+	}
+
+	var sender types.JID
+	if evt.Sender != nil {
+		sender = *evt.Sender
+	}
+	if evt.SenderPN != nil {
+		sender = *evt.SenderPN
+	}
+	isFromMe := cli != nil && cli.Store.ID != nil && sender.User == cli.Store.ID.User
+
+	storeDisappearingTimer(evt.JID, expiration, chatStorageRepo)
+	forwardDisappearingChange(ctx, createDisappearingPayload(evt.JID, expiration, sender, isFromMe, evt.Timestamp))
+}
+
+func storeDisappearingTimer(chatJID types.JID, expiration uint32, chatStorageRepo domainChatStorage.IChatStorageRepository) {
+	if chatStorageRepo == nil {
+		return
+	}
+
+	logrus.Infof("Disappearing messages timer of %s changed to %ds", chatJID.String(), expiration)
+	update := &domainChatStorage.ChatStateUpdate{EphemeralExpiration: &expiration}
+	if err := chatStorageRepo.UpdateChatState(chatJID.String(), update); err != nil {
+		logrus.Errorf("Failed to store disappearing timer of %s: %v", chatJID.String(), err)
+	}
+}
+
+func forwardDisappearingChange(ctx context.Context, payload map[string]any) {
+	if !eventForwardingEnabled() {
+		return
+	}
+
+	go func() {
+		if err := forwardPayloadToConfiguredWebhooks(ctx, payload, "disappearing messages event"); err != nil {
+			logrus.Errorf("Failed to forward disappearing messages event to webhook: %v", err)
+		}
+	}()
+}
+
+// disappearingTimerName returns the name of a timer as accepted by the API, or its duration for other timers
+func disappearingTimerName(expiration uint32) string {
+	switch time.Duration(expiration) * time.Second {
+	case 0:
+		return "off"
+	case 24 * time.Hour:
+		return "24h"
+	case 7 * 24 * time.Hour:
+		return "7d"
+	case 90 * 24 * time.Hour:
+		return "90d"
+	default:
+		return (time.Duration(expiration) * time.Second).String()
+	}
+}
+
+// createDisappearingPayload creates a webhook payload for a change of the disappearing messages timer
+func createDisappearingPayload(chatJID types.JID, expiration uint32, sender types.JID, isFromMe bool, timestamp time.Time) map[string]any {
+	payload := map[string]any{
+		"chat_id":              chatJID.String(),
+		"timer":                disappearingTimerName(expiration),
+		"ephemeral_expiration": expiration,
+		"is_from_me":           isFromMe,
+	}
+	if !sender.IsEmpty() {
+		payload["changed_by"] = sender.ToNonAD().String()
+	}
+
+	if timestamp.IsZero() {
+		timestamp = time.Now()
+	}
+
+	return map[string]any{
+		"event":     "chat.disappearing",
+		"payload":   payload,
+		"timestamp": timestamp.Format(time.RFC3339),
+	}
+}
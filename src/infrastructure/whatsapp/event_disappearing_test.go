@@ -0,0 +1,62 @@
+package whatsapp
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	domainChatStorage "github.com/aldinokemal/go-whatsapp-web-multidevice/domains/chatstorage"
+	"go.mau.fi/whatsmeow/proto/waE2E"
+	"go.mau.fi/whatsmeow/types"
+	"go.mau.fi/whatsmeow/types/events"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestDisappearingTimerChanges(t *testing.T) {
+	repo := newSQLiteTestRepo(t)
+
+	alice := types.NewJID("628111", types.DefaultUserServer)
+	group := types.NewJID("120363024512399999", types.GroupServer)
+	for _, jid := range []types.JID{alice, group} {
+		if err := repo.StoreChat(&domainChatStorage.Chat{JID: jid.String(), Name: jid.User, LastMessageTime: time.Now(), EphemeralExpiration: 86400}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	expiration := func(jid types.JID) uint32 {
+		chat, err := repo.GetChat(jid.String())
+		if err != nil || chat == nil {
+			t.Fatalf("failed to get chat %s: %v", jid, err)
+		}
+		return chat.EphemeralExpiration
+	}
+
+	handleDisappearingSetting(context.Background(), &events.Message{
+		Info: types.MessageInfo{MessageSource: types.MessageSource{Chat: alice, Sender: alice}},
+		Message: &waE2E.Message{ProtocolMessage: &waE2E.ProtocolMessage{
+			Type:                waE2E.ProtocolMessage_EPHEMERAL_SETTING.Enum(),
+			EphemeralExpiration: proto.Uint32(0),
+		}},
+	}, repo)
+	if got := expiration(alice); got != 0 {
+		t.Fatalf("expected disappearing messages of private chat to be off, got %d", got)
+	}
+
+	handleGroupDisappearing(context.Background(), &events.GroupInfo{
+		JID:       group,
+		Sender:    &alice,
+		Ephemeral: &types.GroupEphemeral{IsEphemeral: true, DisappearingTimer: 7776000},
+	}, repo)
+	if got := expiration(group); got != 7776000 {
+		t.Fatalf("expected group timer of 90 days, got %d", got)
+	}
+
+	payload := createDisappearingPayload(group, 604800, alice, false, time.Now())
+	if payload["event"] != "chat.disappearing" {
+		t.Fatalf("unexpected event %v", payload["event"])
+	}
+	body := payload["payload"].(map[string]any)
+	if body["timer"] != "7d" || body["changed_by"] != alice.String() || body["chat_id"] != group.String() {
+		t.Fatalf("unexpected payload %+v", body)
+	}
+}
@@ -2,11 +2,8 @@ package whatsapp
 
 import (
 	"context"
-	"fmt"
-	"strings"
 	"time"
 
-	"github.com/aldinokemal/go-whatsapp-web-multidevice/config"
 	"github.com/sirupsen/logrus"
 	"go.mau.fi/whatsmeow/types"
 	"go.mau.fi/whatsmeow/types/events"
@@ -49,10 +46,8 @@ func jidsToStrings(jids []types.JID) []string {
 	return result
 }
 
-// forwardGroupInfoToWebhook forwards group information events to the configured webhook URLs
+// forwardGroupInfoToWebhook forwards group information events to the configured webhook URLs and event broker
 func forwardGroupInfoToWebhook(ctx context.Context, evt *events.GroupInfo) error {
-	logrus.Infof("Forwarding group info event to %d configured webhook(s)", len(config.WhatsappWebhook))
-
 	// Send separate webhook events for each action type
 	actions := []struct {
 		actionType string
@@ -68,26 +63,8 @@ func forwardGroupInfoToWebhook(ctx context.Context, evt *events.GroupInfo) error
 		if len(action.jids) > 0 {
 			payload := createGroupInfoPayload(evt, action.actionType, action.jids)
 
-			// Collect errors from all webhook URLs instead of failing fast
-			var errors []error
-			for _, url := range config.WhatsappWebhook {
-				if err := submitWebhook(ctx, payload, url); err != nil {
-					errors = append(errors, fmt.Errorf("webhook %s failed: %w", url, err))
-				}
-			}
-
-			// If all webhooks failed, return combined error
-			if len(errors) == len(config.WhatsappWebhook) && len(errors) > 0 {
-				var errMessages []string
-				for _, err := range errors {
-					errMessages = append(errMessages, err.Error())
-				}
-				return fmt.Errorf("all webhook URLs failed: %s", strings.Join(errMessages, "; "))
-			}
-
-			// Log partial failures
-			if len(errors) > 0 {
-				logrus.Warnf("Some webhook URLs failed for group %s event: %v", action.actionType, errors)
+			if err := forwardPayloadToConfiguredWebhooks(ctx, payload, "group "+action.actionType+" event"); err != nil {
+				return err
 			}
 
 			logrus.Infof("Group %s event forwarded to webhook: %d users %s", action.actionType, len(action.jids), action.actionType)
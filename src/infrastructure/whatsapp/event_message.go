@@ -3,7 +3,6 @@ package whatsapp
 import (
 	"context"
 	"fmt"
-	"regexp"
 	"strings"
 	"time"
 
@@ -11,8 +10,9 @@ import (
 
 	"github.com/aldinokemal/go-whatsapp-web-multidevice/config"
 	domainOtomax "github.com/aldinokemal/go-whatsapp-web-multidevice/domains/otomax"
+	domainWebhook "github.com/aldinokemal/go-whatsapp-web-multidevice/domains/webhook"
 	"github.com/aldinokemal/go-whatsapp-web-multidevice/infrastructure/otomax"
-	pkgError "github.com/aldinokemal/go-whatsapp-web-multidevice/pkg/error"
+	"github.com/aldinokemal/go-whatsapp-web-multidevice/pkg/tracing"
 	"github.com/aldinokemal/go-whatsapp-web-multidevice/pkg/utils"
 	"github.com/sirupsen/logrus"
 	"go.mau.fi/whatsmeow/proto/waE2E"
@@ -20,14 +20,16 @@ import (
 	"google.golang.org/protobuf/proto"
 )
 
-// forwardMessageToWebhook is a helper function to forward message event to webhook url
+// forwardMessageToWebhook is a helper function to forward message event to webhook url.
+// The media is downloaded once and shared by the payloads of every schema.
 func forwardMessageToWebhook(ctx context.Context, evt *events.Message) error {
-	payload, err := createMessagePayload(ctx, evt)
-	if err != nil {
-		return err
-	}
+	media := downloadMessageMedia(ctx, evt)
+	payload := createMessagePayload(ctx, evt, media)
 
-	return forwardPayloadToConfiguredWebhooks(ctx, payload, "message event")
+	buildV2 := func() *domainWebhook.Event {
+		return createMessageEventV2(ctx, evt, media)
+	}
+	return forwardEventToConfiguredWebhooks(ctx, payload, buildV2, "message event")
 }
 
 // createOtomaxInsertInboxRequest creates request for OtomaX InsertInbox API
@@ -67,7 +69,13 @@ func createOtomaxInsertInboxRequest(ctx context.Context, evt *events.Message) (d
 }
 
 // forwardMessageToOtomax forwards WhatsApp message to OtomaX via InsertInbox
-func forwardMessageToOtomax(ctx context.Context, evt *events.Message) error {
+func forwardMessageToOtomax(ctx context.Context, evt *events.Message) (err error) {
+	ctx, span := tracing.Start(ctx, "otomax.forward_message",
+		tracing.AttrMessageID.String(evt.Info.ID),
+		tracing.AttrSenderJID.String(evt.Info.Sender.String()),
+	)
+	defer func() { tracing.End(span, err) }()
+
 	// Check if OtomaX integration is enabled
 	if !config.OtomaxEnabled {
 		logrus.Debugf("OtomaX integration is disabled, skipping message forwarding")
@@ -175,7 +183,7 @@ func sendAutoReplyToWhatsAppWithJID(ctx context.Context, senderJID, statusDesc s
 	}
 	
 	// Send the auto-reply message using direct WhatsApp client
-	response, err := cli.SendMessage(
+	response, err := SendMessage(
 		ctx,
 		recipientJID,
 		&waE2E.Message{Conversation: proto.String(statusDesc)},
@@ -196,7 +204,7 @@ func sendAutoReplyToWhatsApp(ctx context.Context, phoneNumber, statusDesc string
 	recipientJID := utils.FormatJID(phoneNumber + "@s.whatsapp.net")
 	
 	// Send the auto-reply message using direct WhatsApp client
-	response, err := cli.SendMessage(
+	response, err := SendMessage(
 		ctx,
 		recipientJID,
 		&waE2E.Message{Conversation: proto.String(statusDesc)},
@@ -215,7 +223,9 @@ func sendAutoReplyToWhatsApp(ctx context.Context, phoneNumber, statusDesc string
 	return nil
 }
 
-func createMessagePayload(ctx context.Context, evt *events.Message) (map[string]any, error) {
+// createMessagePayload creates the v1 webhook payload of a message. A failed media download is reported
+// in "media_error" instead of dropping the message.
+func createMessagePayload(ctx context.Context, evt *events.Message, media *downloadedMedia) map[string]any {
 	message := utils.BuildEventMessage(evt)
 	waReaction := utils.BuildEventReaction(evt)
 	forwarded := utils.BuildForwarded(evt)
@@ -255,25 +265,7 @@ func createMessagePayload(ctx context.Context, evt *events.Message) (map[string]
 		}
 	}
 	if message.ID != "" {
-		tags := regexp.MustCompile(`\B@\w+`).FindAllString(message.Text, -1)
-		tagsMap := make(map[string]bool)
-		for _, tag := range tags {
-			tagsMap[tag] = true
-		}
-		for tag := range tagsMap {
-			lid, err := types.ParseJID(tag[1:] + "@lid")
-			if err != nil {
-				logrus.Errorf("Error when parse jid: %v", err)
-			} else {
-				pn, err := cli.Store.LIDs.GetPNForLID(ctx, lid)
-				if err != nil {
-					logrus.Errorf("Error when get pn for lid %s: %v", lid.String(), err)
-				}
-				if !pn.IsEmpty() {
-					message.Text = strings.Replace(message.Text, tag, fmt.Sprintf("@%s", pn.User), -1)
-				}
-			}
-		}
+		message.Text = replaceLIDMentions(ctx, message.Text)
 		body["message"] = message
 	}
 	if pushname := evt.Info.PushName; pushname != "" {
@@ -292,6 +284,15 @@ func createMessagePayload(ctx context.Context, evt *events.Message) (map[string]
 		body["timestamp"] = timestamp
 	}
 
+	// Channel posts carry the newsletter JID and server ID; edits replace the content in place
+	if evt.Info.Chat.Server == types.NewsletterServer {
+		body["newsletter_id"] = evt.Info.Chat.String()
+		body["server_id"] = int(evt.Info.ServerID)
+		if evt.NewsletterMeta != nil && !evt.NewsletterMeta.EditTS.IsZero() {
+			body["edited_at"] = evt.NewsletterMeta.EditTS.Format(time.RFC3339)
+		}
+	}
+
 	// Handle protocol messages (revoke, etc.)
 	if protocolMessage := evt.Message.GetProtocolMessage(); protocolMessage != nil {
 		protocolType := protocolMessage.GetType().String()
@@ -318,37 +319,18 @@ func createMessagePayload(ctx context.Context, evt *events.Message) (map[string]
 		}
 	}
 
-	if audioMedia := evt.Message.GetAudioMessage(); audioMedia != nil {
-		path, err := utils.ExtractMedia(ctx, cli, config.PathMedia, audioMedia)
-		if err != nil {
-			logrus.Errorf("Failed to download audio from %s: %v", evt.Info.SourceString(), err)
-			return nil, pkgError.WebhookError(fmt.Sprintf("Failed to download audio: %v", err))
+	if media != nil {
+		if media.err != nil {
+			body["media_error"] = media.err.Error()
+		} else {
+			body[media.kind] = media.extracted
 		}
-		body["audio"] = path
 	}
 
 	if contactMessage := evt.Message.GetContactMessage(); contactMessage != nil {
 		body["contact"] = contactMessage
 	}
 
-	if documentMedia := evt.Message.GetDocumentMessage(); documentMedia != nil {
-		path, err := utils.ExtractMedia(ctx, cli, config.PathMedia, documentMedia)
-		if err != nil {
-			logrus.Errorf("Failed to download document from %s: %v", evt.Info.SourceString(), err)
-			return nil, pkgError.WebhookError(fmt.Sprintf("Failed to download document: %v", err))
-		}
-		body["document"] = path
-	}
-
-	if imageMedia := evt.Message.GetImageMessage(); imageMedia != nil {
-		path, err := utils.ExtractMedia(ctx, cli, config.PathMedia, imageMedia)
-		if err != nil {
-			logrus.Errorf("Failed to download image from %s: %v", evt.Info.SourceString(), err)
-			return nil, pkgError.WebhookError(fmt.Sprintf("Failed to download image: %v", err))
-		}
-		body["image"] = path
-	}
-
 	if listMessage := evt.Message.GetListMessage(); listMessage != nil {
 		body["list"] = listMessage
 	}
@@ -365,23 +347,5 @@ func createMessagePayload(ctx context.Context, evt *events.Message) (map[string]
 		body["order"] = orderMessage
 	}
 
-	if stickerMedia := evt.Message.GetStickerMessage(); stickerMedia != nil {
-		path, err := utils.ExtractMedia(ctx, cli, config.PathMedia, stickerMedia)
-		if err != nil {
-			logrus.Errorf("Failed to download sticker from %s: %v", evt.Info.SourceString(), err)
-			return nil, pkgError.WebhookError(fmt.Sprintf("Failed to download sticker: %v", err))
-		}
-		body["sticker"] = path
-	}
-
-	if videoMedia := evt.Message.GetVideoMessage(); videoMedia != nil {
-		path, err := utils.ExtractMedia(ctx, cli, config.PathMedia, videoMedia)
-		if err != nil {
-			logrus.Errorf("Failed to download video from %s: %v", evt.Info.SourceString(), err)
-			return nil, pkgError.WebhookError(fmt.Sprintf("Failed to download video: %v", err))
-		}
-		body["video"] = path
-	}
-
-	return body, nil
+	return body
 }
@@ -0,0 +1,74 @@
+package whatsapp
+
+import (
+	"context"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"go.mau.fi/whatsmeow/types/events"
+)
+
+// handleNewsletterEvent forwards channel (newsletter) membership, mute and live update events to webhooks.
+// Regular channel posts arrive as message events and are forwarded by handleMessage.
+func handleNewsletterEvent(ctx context.Context, rawEvt any) {
+	if !eventForwardingEnabled() {
+		return
+	}
+
+	payload := createNewsletterPayload(rawEvt)
+	if payload == nil {
+		return
+	}
+
+	go func() {
+		if err := forwardPayloadToConfiguredWebhooks(ctx, payload, "newsletter event"); err != nil {
+			logrus.Errorf("Failed to forward newsletter event to webhook: %v", err)
+		}
+	}()
+}
+
+// createNewsletterPayload creates a webhook payload for newsletter events
+func createNewsletterPayload(rawEvt any) map[string]any {
+	body := make(map[string]any)
+	payload := make(map[string]any)
+	timestamp := time.Now()
+
+	switch evt := rawEvt.(type) {
+	case *events.NewsletterJoin:
+		body["event"] = "newsletter.join"
+		payload["newsletter_id"] = evt.ID.String()
+		payload["name"] = evt.ThreadMeta.Name.Text
+		if evt.ViewerMeta != nil {
+			payload["role"] = string(evt.ViewerMeta.Role)
+		}
+	case *events.NewsletterLeave:
+		body["event"] = "newsletter.leave"
+		payload["newsletter_id"] = evt.ID.String()
+		payload["role"] = string(evt.Role)
+	case *events.NewsletterMuteChange:
+		body["event"] = "newsletter.mute"
+		payload["newsletter_id"] = evt.ID.String()
+		payload["mute"] = string(evt.Mute)
+	case *events.NewsletterLiveUpdate:
+		body["event"] = "newsletter.update"
+		payload["newsletter_id"] = evt.JID.String()
+		timestamp = evt.Time
+
+		messages := make([]map[string]any, 0, len(evt.Messages))
+		for _, message := range evt.Messages {
+			messages = append(messages, map[string]any{
+				"server_id": int(message.MessageServerID),
+				"views":     message.ViewsCount,
+				"reactions": message.ReactionCounts,
+			})
+		}
+		payload["messages"] = messages
+	default:
+		return nil
+	}
+
+	body["payload"] = payload
+	body["timestamp"] = timestamp.Format(time.RFC3339)
+
+	return body
+}
@@ -0,0 +1,123 @@
+package whatsapp
+
+import (
+	"context"
+	"time"
+
+	"github.com/aldinokemal/go-whatsapp-web-multidevice/config"
+	domainChatStorage "github.com/aldinokemal/go-whatsapp-web-multidevice/domains/chatstorage"
+	domainStatus "github.com/aldinokemal/go-whatsapp-web-multidevice/domains/status"
+	"github.com/aldinokemal/go-whatsapp-web-multidevice/pkg/mediastorage"
+	"github.com/aldinokemal/go-whatsapp-web-multidevice/pkg/utils"
+	"github.com/sirupsen/logrus"
+	"go.mau.fi/whatsmeow"
+	"go.mau.fi/whatsmeow/proto/waE2E"
+	"go.mau.fi/whatsmeow/types/events"
+)
+
+// handleStatusMessage stores a status update posted by a contact and optionally forwards it to webhooks
+func handleStatusMessage(ctx context.Context, evt *events.Message, chatStorageRepo domainChatStorage.IChatStorageRepository) {
+	sender := evt.Info.Sender.ToNonAD().String()
+
+	// A revoked status is removed from storage
+	if protocolMessage := evt.Message.GetProtocolMessage(); protocolMessage != nil {
+		if protocolMessage.GetType() == waE2E.ProtocolMessage_REVOKE {
+			if err := chatStorageRepo.DeleteStatus(protocolMessage.GetKey().GetID(), sender); err != nil {
+				log.Errorf("Failed to delete revoked status %s: %v", protocolMessage.GetKey().GetID(), err)
+			}
+		}
+		return
+	}
+
+	status := &domainChatStorage.Status{
+		ID:        evt.Info.ID,
+		Sender:    sender,
+		PushName:  evt.Info.PushName,
+		Content:   utils.ExtractMessageTextFromProto(evt.Message),
+		IsFromMe:  evt.Info.IsFromMe,
+		Timestamp: evt.Info.Timestamp,
+		ExpiresAt: evt.Info.Timestamp.Add(domainStatus.Lifetime),
+	}
+
+	var media whatsmeow.DownloadableMessage
+	switch {
+	case evt.Message.GetImageMessage() != nil:
+		status.MediaType = "image"
+		media = evt.Message.GetImageMessage()
+	case evt.Message.GetVideoMessage() != nil:
+		status.MediaType = "video"
+		media = evt.Message.GetVideoMessage()
+	case evt.Message.GetAudioMessage() != nil:
+		status.MediaType = "audio"
+		media = evt.Message.GetAudioMessage()
+	}
+
+	if status.Content == "" && media == nil {
+		log.Debugf("Skipping status %s from %s - no content or media", evt.Info.ID, sender)
+		return
+	}
+
+	if media != nil {
+		extracted, err := utils.ExtractMedia(ctx, cli, config.PathStatuses, media)
+		if err != nil {
+			// Keep the status without its media rather than dropping it
+			log.Errorf("Failed to download status media %s: %v", evt.Info.ID, err)
+		} else {
+			status.MediaPath = extracted.MediaPath
+			status.MimeType = extracted.MimeType
+		}
+	}
+
+	if err := chatStorageRepo.StoreStatus(status); err != nil {
+		log.Errorf("Failed to store status %s from %s: %v", evt.Info.ID, sender, err)
+	}
+
+	if eventForwardingEnabled() && config.WhatsappWebhookStatus && !evt.Info.IsFromMe {
+		go func(status *domainChatStorage.Status) {
+			if err := forwardStatusToWebhook(ctx, status); err != nil {
+				logrus.Errorf("Failed to forward status event to webhook: %v", err)
+			}
+		}(status)
+	}
+}
+
+// createStatusPayload creates a webhook payload for status updates
+func createStatusPayload(ctx context.Context, status *domainChatStorage.Status) map[string]any {
+	body := make(map[string]any)
+	payload := make(map[string]any)
+
+	payload["id"] = status.ID
+	payload["from"] = status.Sender
+	payload["pushname"] = status.PushName
+	payload["expires_at"] = status.ExpiresAt.Format(time.RFC3339)
+
+	if status.Content != "" {
+		payload["content"] = status.Content
+	}
+	if status.MediaType != "" {
+		payload["media_type"] = status.MediaType
+	}
+	if status.MediaPath != "" {
+		payload["media_path"] = status.MediaPath
+		payload["mime_type"] = status.MimeType
+		if !mediastorage.IsLocal() {
+			if url, err := mediastorage.URL(ctx, status.MediaPath); err != nil {
+				logrus.Warnf("Failed to sign URL for status media %s: %v", status.ID, err)
+			} else {
+				payload["media_url"] = url
+			}
+		}
+	}
+
+	body["payload"] = payload
+	body["event"] = "status"
+	body["timestamp"] = status.Timestamp.Format(time.RFC3339)
+
+	return body
+}
+
+// forwardStatusToWebhook forwards status updates to the configured webhook URLs
+func forwardStatusToWebhook(ctx context.Context, status *domainChatStorage.Status) error {
+	payload := createStatusPayload(ctx, status)
+	return forwardPayloadToConfiguredWebhooks(ctx, payload, "status event")
+}
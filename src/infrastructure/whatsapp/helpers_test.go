@@ -0,0 +1,27 @@
+package whatsapp
+
+import (
+	"database/sql"
+	"testing"
+
+	domainChatStorage "github.com/aldinokemal/go-whatsapp-web-multidevice/domains/chatstorage"
+	"github.com/aldinokemal/go-whatsapp-web-multidevice/infrastructure/chatstorage"
+	_ "github.com/mattn/go-sqlite3"
+)
+
+// newSQLiteTestRepo returns a chat storage repository on a fresh in-memory database
+func newSQLiteTestRepo(t *testing.T) domainChatStorage.IChatStorageRepository {
+	t.Helper()
+	db, err := sql.Open("sqlite3", ":memory:")
+	if err != nil {
+		t.Fatal(err)
+	}
+	db.SetMaxOpenConns(1)
+	t.Cleanup(func() { db.Close() })
+
+	repo := chatstorage.NewStorageRepository(db)
+	if err := repo.InitializeSchema(); err != nil {
+		t.Fatal(err)
+	}
+	return repo
+}
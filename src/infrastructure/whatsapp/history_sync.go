@@ -0,0 +1,133 @@
+package whatsapp
+
+import (
+	"compress/gzip"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	domainChatStorage "github.com/aldinokemal/go-whatsapp-web-multidevice/domains/chatstorage"
+	"github.com/sirupsen/logrus"
+	"go.mau.fi/whatsmeow/proto/waHistorySync"
+	"go.mau.fi/whatsmeow/proto/waWeb"
+	"go.mau.fi/whatsmeow/types"
+)
+
+// historySyncDumpPattern matches the history sync dumps written to the storages directory
+const historySyncDumpPattern = "history-*.json.gz"
+
+// dumpHistorySync writes a history sync blob as gzipped JSON into dir and removes the oldest dumps beyond maxFiles
+func dumpHistorySync(dir, name string, data *waHistorySync.HistorySync, maxFiles int) (string, error) {
+	fileName := filepath.Join(dir, name+".json.gz")
+
+	file, err := os.OpenFile(fileName, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0600)
+	if err != nil {
+		return "", fmt.Errorf("failed to open file: %w", err)
+	}
+	defer file.Close()
+
+	gz := gzip.NewWriter(file)
+	if err := json.NewEncoder(gz).Encode(data); err != nil {
+		return "", fmt.Errorf("failed to encode history sync: %w", err)
+	}
+	if err := gz.Close(); err != nil {
+		return "", fmt.Errorf("failed to compress history sync: %w", err)
+	}
+
+	rotateHistorySyncDumps(dir, maxFiles)
+	return fileName, nil
+}
+
+// rotateHistorySyncDumps removes the oldest history sync dumps in dir until at most keep are left
+func rotateHistorySyncDumps(dir string, keep int) {
+	if keep < 1 {
+		keep = 1
+	}
+
+	paths, err := filepath.Glob(filepath.Join(dir, historySyncDumpPattern))
+	if err != nil || len(paths) <= keep {
+		return
+	}
+
+	modTimes := make(map[string]time.Time, len(paths))
+	for _, path := range paths {
+		if info, err := os.Stat(path); err == nil {
+			modTimes[path] = info.ModTime()
+		}
+	}
+	sort.Slice(paths, func(i, j int) bool {
+		if !modTimes[paths[i]].Equal(modTimes[paths[j]]) {
+			return modTimes[paths[i]].After(modTimes[paths[j]])
+		}
+		return paths[i] > paths[j]
+	})
+
+	for _, path := range paths[keep:] {
+		if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+			logrus.Warnf("Failed to remove old history sync dump %s: %v", path, err)
+		}
+	}
+}
+
+// groupMetadataFromConversation extracts the metadata of a group conversation from history sync
+func groupMetadataFromConversation(jid string, conv *waHistorySync.Conversation) *domainChatStorage.GroupMetadata {
+	metadata := &domainChatStorage.GroupMetadata{
+		JID:        jid,
+		Name:       conv.GetName(),
+		Topic:      conv.GetDescription(),
+		CreatorJID: conv.GetCreatedBy(),
+	}
+	if createdAt := conv.GetCreatedAt(); createdAt > 0 {
+		metadata.CreatedAt = time.Unix(int64(createdAt), 0)
+	}
+
+	for _, participant := range conv.GetParticipant() {
+		if participant.GetUserJID() == "" {
+			continue
+		}
+		rank := participant.GetRank()
+		metadata.Participants = append(metadata.Participants, &domainChatStorage.GroupParticipant{
+			JID:          participant.GetUserJID(),
+			IsAdmin:      rank == waHistorySync.GroupParticipant_ADMIN || rank == waHistorySync.GroupParticipant_SUPERADMIN,
+			IsSuperAdmin: rank == waHistorySync.GroupParticipant_SUPERADMIN,
+		})
+	}
+
+	return metadata
+}
+
+// reactionsFromHistoryMessage extracts the reactions history sync attaches to a message of chatJID.
+// Reactions of our own account are kept under ownJID and skipped when it is unknown.
+func reactionsFromHistoryMessage(chatJID, messageID string, reactions []*waWeb.Reaction, ownJID string) []*domainChatStorage.MessageReaction {
+	var stored []*domainChatStorage.MessageReaction
+	for _, reaction := range reactions {
+		if reaction.GetText() == "" {
+			continue
+		}
+
+		key := reaction.GetKey()
+		sender := key.GetParticipant()
+		switch {
+		case key.GetFromMe():
+			sender = ownJID
+		case sender == "":
+			sender = key.GetRemoteJID()
+		}
+		senderJID, err := types.ParseJID(sender)
+		if sender == "" || err != nil {
+			continue
+		}
+
+		stored = append(stored, &domainChatStorage.MessageReaction{
+			ChatJID:   chatJID,
+			MessageID: messageID,
+			SenderJID: senderJID.ToNonAD().String(),
+			Emoji:     reaction.GetText(),
+			Timestamp: time.UnixMilli(reaction.GetSenderTimestampMS()),
+		})
+	}
+	return stored
+}
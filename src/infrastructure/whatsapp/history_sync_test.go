@@ -0,0 +1,171 @@
+package whatsapp
+
+import (
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	domainChatStorage "github.com/aldinokemal/go-whatsapp-web-multidevice/domains/chatstorage"
+	"go.mau.fi/whatsmeow/proto/waCommon"
+	"go.mau.fi/whatsmeow/proto/waE2E"
+	"go.mau.fi/whatsmeow/proto/waHistorySync"
+	"go.mau.fi/whatsmeow/proto/waWeb"
+	waLog "go.mau.fi/whatsmeow/util/log"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestDumpHistorySyncCompressesAndRotates(t *testing.T) {
+	dir := t.TempDir()
+	data := &waHistorySync.HistorySync{SyncType: waHistorySync.HistorySync_RECENT.Enum(), ChunkOrder: proto.Uint32(1)}
+
+	// Dumps of other runs and unrelated files are left alone
+	stale := filepath.Join(dir, "history-1-old.json")
+	if err := os.WriteFile(stale, []byte("{}"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	var written []string
+	for _, name := range []string{"history-1-a", "history-1-b", "history-1-c"} {
+		fileName, err := dumpHistorySync(dir, name, data, 2)
+		if err != nil {
+			t.Fatalf("failed to dump history sync: %v", err)
+		}
+		written = append(written, fileName)
+		// Spread the modification times in the past, the next dump is always the newest
+		modTime := time.Now().Add(-time.Duration(10-len(written)) * time.Minute)
+		if err := os.Chtimes(fileName, modTime, modTime); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if _, err := os.Stat(written[0]); !os.IsNotExist(err) {
+		t.Fatalf("expected the oldest dump to be removed, got %v", err)
+	}
+	if _, err := os.Stat(stale); err != nil {
+		t.Fatalf("expected uncompressed dumps to be kept, got %v", err)
+	}
+
+	file, err := os.Open(written[2])
+	if err != nil {
+		t.Fatalf("expected the newest dump to be kept, got %v", err)
+	}
+	defer file.Close()
+	gz, err := gzip.NewReader(file)
+	if err != nil {
+		t.Fatalf("expected a gzipped dump: %v", err)
+	}
+	var decoded waHistorySync.HistorySync
+	if err := json.NewDecoder(gz).Decode(&decoded); err != nil {
+		t.Fatalf("failed to decode dump: %v", err)
+	}
+	if decoded.GetSyncType() != waHistorySync.HistorySync_RECENT || decoded.GetChunkOrder() != 1 {
+		t.Fatalf("unexpected dump content %+v", &decoded)
+	}
+}
+
+func TestProcessHistorySyncStoresGroupMetadataAndPushNames(t *testing.T) {
+	previousLog := log
+	log = waLog.Noop
+	defer func() { log = previousLog }()
+
+	repo := newSQLiteTestRepo(t)
+
+	groupJID := "120363024512399999@g.us"
+	message := func(id, participant, text string, timestamp int64) *waHistorySync.HistorySyncMsg {
+		return &waHistorySync.HistorySyncMsg{Message: &waWeb.WebMessageInfo{
+			Key:              &waCommon.MessageKey{RemoteJID: proto.String(groupJID), ID: proto.String(id), Participant: proto.String(participant)},
+			Message:          &waE2E.Message{Conversation: proto.String(text)},
+			MessageTimestamp: proto.Uint64(uint64(timestamp)),
+		}}
+	}
+
+	// The chat already has newer messages and a disappearing timer
+	latest := time.Unix(1700000500, 0)
+	if err := repo.StoreChat(&domainChatStorage.Chat{JID: groupJID, Name: "Team", LastMessageTime: latest, EphemeralExpiration: 86400}); err != nil {
+		t.Fatal(err)
+	}
+
+	withReaction := func(msg *waHistorySync.HistorySyncMsg, sender, emoji string) *waHistorySync.HistorySyncMsg {
+		msg.Message.Reactions = append(msg.Message.Reactions, &waWeb.Reaction{
+			Key:               &waCommon.MessageKey{RemoteJID: proto.String(groupJID), Participant: proto.String(sender)},
+			Text:              proto.String(emoji),
+			SenderTimestampMS: proto.Int64(1700000200000),
+		})
+		return msg
+	}
+
+	err := processHistorySync(context.Background(), &waHistorySync.HistorySync{
+		SyncType: waHistorySync.HistorySync_ON_DEMAND.Enum(),
+		Conversations: []*waHistorySync.Conversation{{
+			ID:          proto.String(groupJID),
+			Name:        proto.String("Team"),
+			Description: proto.String("Weekly sync"),
+			CreatedBy:   proto.String("628111@s.whatsapp.net"),
+			CreatedAt:   proto.Uint64(1700000000),
+			Participant: []*waHistorySync.GroupParticipant{
+				{UserJID: proto.String("628111@s.whatsapp.net"), Rank: waHistorySync.GroupParticipant_SUPERADMIN.Enum()},
+				{UserJID: proto.String("628222@s.whatsapp.net")},
+			},
+			Messages: []*waHistorySync.HistorySyncMsg{
+				withReaction(message("OLD1", "628222@s.whatsapp.net", "older message", 1700000100), "628111@s.whatsapp.net", "👍"),
+			},
+		}},
+		Pushnames: []*waHistorySync.Pushname{
+			{ID: proto.String("628222@s.whatsapp.net"), Pushname: proto.String("Bob")},
+		},
+	}, repo)
+	if err != nil {
+		t.Fatalf("failed to process history sync: %v", err)
+	}
+
+	metadata, err := repo.GetGroupMetadata(groupJID)
+	if err != nil || metadata == nil {
+		t.Fatalf("expected group metadata to be stored, got %v", err)
+	}
+	if metadata.Name != "Team" || metadata.Topic != "Weekly sync" || metadata.CreatorJID != "628111@s.whatsapp.net" ||
+		!metadata.CreatedAt.Equal(time.Unix(1700000000, 0)) {
+		t.Fatalf("unexpected group metadata %+v", metadata)
+	}
+	if len(metadata.Participants) != 2 || !metadata.Participants[0].IsSuperAdmin || metadata.Participants[1].IsAdmin {
+		t.Fatalf("unexpected group participants %+v", metadata.Participants)
+	}
+
+	chat, err := repo.GetChat(groupJID)
+	if err != nil || chat == nil || chat.Name != "Team" {
+		t.Fatalf("expected the group chat to be named after the group, got %+v (%v)", chat, err)
+	}
+	if !chat.LastMessageTime.Equal(latest) || chat.EphemeralExpiration != 86400 {
+		t.Fatalf("expected older history to keep the last message time and disappearing timer, got %+v", chat)
+	}
+	if stored, err := repo.GetMessageByID("OLD1"); err != nil || stored == nil {
+		t.Fatalf("expected on-demand history to be stored, got %v", err)
+	}
+	reactions, err := repo.GetMessageReactions(groupJID, []string{"OLD1"})
+	if err != nil || len(reactions["OLD1"]) != 1 || reactions["OLD1"][0].SenderJID != "628111@s.whatsapp.net" || reactions["OLD1"][0].Emoji != "👍" {
+		t.Fatalf("expected the history reaction to be stored, got %+v (%v)", reactions["OLD1"], err)
+	}
+
+	if pushName, err := repo.GetPushName("628222@s.whatsapp.net"); err != nil || pushName != "Bob" {
+		t.Fatalf("expected push name Bob, got %q (%v)", pushName, err)
+	}
+
+	// A later chunk without metadata and participants keeps what is known of the group
+	err = processHistorySync(context.Background(), &waHistorySync.HistorySync{
+		SyncType: waHistorySync.HistorySync_ON_DEMAND.Enum(),
+		Conversations: []*waHistorySync.Conversation{{
+			ID:       proto.String(groupJID),
+			Messages: []*waHistorySync.HistorySyncMsg{message("OLD2", "628111@s.whatsapp.net", "oldest message", 1700000050)},
+		}},
+	}, repo)
+	if err != nil {
+		t.Fatalf("failed to process history sync: %v", err)
+	}
+	metadata, err = repo.GetGroupMetadata(groupJID)
+	if err != nil || metadata == nil || metadata.Name != "Team" || metadata.Topic != "Weekly sync" || len(metadata.Participants) != 2 {
+		t.Fatalf("expected the group metadata to be kept, got %+v (%v)", metadata, err)
+	}
+}
@@ -2,7 +2,6 @@ package whatsapp
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
 	"os"
 	"path/filepath"
@@ -14,8 +13,11 @@ import (
 
 	"github.com/aldinokemal/go-whatsapp-web-multidevice/config"
 	domainChatStorage "github.com/aldinokemal/go-whatsapp-web-multidevice/domains/chatstorage"
+	domainModeration "github.com/aldinokemal/go-whatsapp-web-multidevice/domains/moderation"
 	domainOtomax "github.com/aldinokemal/go-whatsapp-web-multidevice/domains/otomax"
 	pkgError "github.com/aldinokemal/go-whatsapp-web-multidevice/pkg/error"
+	"github.com/aldinokemal/go-whatsapp-web-multidevice/pkg/metrics"
+	"github.com/aldinokemal/go-whatsapp-web-multidevice/pkg/tracing"
 	"github.com/aldinokemal/go-whatsapp-web-multidevice/pkg/utils"
 	"github.com/aldinokemal/go-whatsapp-web-multidevice/ui/websocket"
 	"github.com/sirupsen/logrus"
@@ -46,6 +48,8 @@ var (
 	historySyncID int32
 	startupTime   = time.Now().Unix()
 	otomaxService domainOtomax.IOtomaxUsecase
+
+	moderationService domainModeration.IModerationEnforcer
 )
 
 // InitWaDB initializes the WhatsApp database connection
@@ -141,6 +145,8 @@ func InitWaCLI(ctx context.Context, storeContainer, keysStoreContainer *sqlstore
 	cli = whatsmeow.NewClient(device, waLog.Stdout("Client", config.WhatsappLogLevel, true))
 	cli.EnableAutoReconnect = true
 	cli.AutoTrustIdentity = true
+	// Store the chat states and labels of full app state syncs too; webhooks still skip those events
+	cli.EmitAppStateEventsOnFullSync = true
 
 	cli.AddEventHandler(func(rawEvt interface{}) {
 		handler(ctx, rawEvt, chatStorageRepo)
@@ -173,6 +179,11 @@ func GetOtomaxService() domainOtomax.IOtomaxUsecase {
 	return otomaxService
 }
 
+// SetModerationService sets the service that enforces group moderation rules
+func SetModerationService(service domainModeration.IModerationEnforcer) {
+	moderationService = service
+}
+
 // Get DB instance
 func GetDB() *sqlstore.Container {
 	return db
@@ -411,6 +422,14 @@ func handleRemoteLogout(ctx context.Context, chatStorageRepo domainChatStorage.I
 
 // handler is the main event handler for WhatsApp events
 func handler(ctx context.Context, rawEvt any, chatStorageRepo domainChatStorage.IChatStorageRepository) {
+	observeConnectionEvent(rawEvt)
+	supervisor.handleEvent(rawEvt)
+
+	ctx, span := tracing.Start(ctx, "whatsapp.handle_event",
+		tracing.AttrEventType.String(strings.TrimPrefix(fmt.Sprintf("%T", rawEvt), "*events.")),
+	)
+	defer span.End()
+
 	switch evt := rawEvt.(type) {
 	case *events.DeleteForMe:
 		handleDeleteForMe(ctx, evt, chatStorageRepo)
@@ -420,10 +439,13 @@ func handler(ctx context.Context, rawEvt any, chatStorageRepo domainChatStorage.
 		handlePairSuccess(ctx, evt)
 	case *events.LoggedOut:
 		handleLoggedOut(ctx, chatStorageRepo)
-	case *events.Connected, *events.PushNameSetting:
+	case *events.Connected:
+		handleConnectionEvents(ctx)
+		go syncBlocklist(ctx, chatStorageRepo)
+	case *events.PushNameSetting:
 		handleConnectionEvents(ctx)
-	case *events.StreamReplaced:
-		handleStreamReplaced(ctx)
+	case *events.Blocklist:
+		handleBlocklist(ctx, evt, chatStorageRepo)
 	case *events.Message:
 		handleMessage(ctx, evt, chatStorageRepo)
 	case *events.Receipt:
@@ -434,8 +456,16 @@ func handler(ctx context.Context, rawEvt any, chatStorageRepo domainChatStorage.
 		handleHistorySync(ctx, evt, chatStorageRepo)
 	case *events.AppState:
 		handleAppState(ctx, evt)
+	case *events.Pin, *events.Archive, *events.Mute, *events.MarkChatAsRead, *events.ClearChat, *events.DeleteChat,
+		*events.Star, *events.LabelEdit, *events.LabelAssociationChat, *events.LabelAssociationMessage:
+		handleAppStateEvent(ctx, evt, chatStorageRepo)
+	case *events.CallOffer, *events.CallOfferNotice, *events.CallAccept, *events.CallReject, *events.CallTerminate:
+		handleCallEvent(ctx, evt, chatStorageRepo)
 	case *events.GroupInfo:
 		handleGroupInfo(ctx, evt)
+		handleGroupDisappearing(ctx, evt, chatStorageRepo)
+	case *events.NewsletterJoin, *events.NewsletterLeave, *events.NewsletterMuteChange, *events.NewsletterLiveUpdate:
+		handleNewsletterEvent(ctx, evt)
 	}
 }
 
@@ -464,7 +494,7 @@ func handleDeleteForMe(ctx context.Context, evt *events.DeleteForMe, chatStorage
 	}
 
 	// Send webhook notification for delete event
-	if len(config.WhatsappWebhook) > 0 {
+	if eventForwardingEnabled() {
 		go func() {
 			if err := forwardDeleteToWebhook(ctx, evt, message); err != nil {
 				log.Errorf("Failed to forward delete event to webhook: %v", err)
@@ -519,11 +549,16 @@ func handleConnectionEvents(_ context.Context) {
 	}
 }
 
-func handleStreamReplaced(_ context.Context) {
-	os.Exit(0)
-}
-
 func handleMessage(ctx context.Context, evt *events.Message, chatStorageRepo domainChatStorage.IChatStorageRepository) {
+	messageType := metrics.MessageType(evt.Message)
+	metrics.MessagesReceived.WithLabelValues(messageType).Inc()
+	tracing.SetAttributes(ctx,
+		tracing.AttrMessageID.String(evt.Info.ID),
+		tracing.AttrChatJID.String(evt.Info.Chat.String()),
+		tracing.AttrSenderJID.String(evt.Info.Sender.String()),
+		tracing.AttrMessageType.String(messageType),
+	)
+
 	// Log message metadata
 	metaParts := buildMessageMetaParts(evt)
 	log.Infof("Received message %s from %s (%s): %+v",
@@ -533,25 +568,44 @@ func handleMessage(ctx context.Context, evt *events.Message, chatStorageRepo dom
 		evt.Message,
 	)
 
+	// Status updates are kept apart from regular chats and never reach auto-reply or OtomaX
+	if evt.Info.Chat == types.StatusBroadcastJID {
+		handleStatusMessage(ctx, evt, chatStorageRepo)
+		return
+	}
+
 	if err := chatStorageRepo.CreateMessage(ctx, evt); err != nil {
 		// Log storage errors to avoid silent failures that could lead to data loss
 		log.Errorf("Failed to store incoming message %s: %v", evt.Info.ID, err)
 	}
 
+	// Track disappearing messages timers changed by the other party or from another device
+	handleDisappearingSetting(ctx, evt, chatStorageRepo)
+
 	// Handle image message if present
 	handleImageMessage(ctx, evt)
 
 	// Auto-mark message as read if configured
 	handleAutoMarkRead(ctx, evt)
 
+	// Blocked contacts can still reach us through groups, keep them away from auto-reply and OtomaX
+	blocked := config.WhatsappIgnoreBlocked && isBlockedSender(evt.Info, chatStorageRepo)
+
 	// Handle auto-reply if configured
-	handleAutoReply(ctx, evt, chatStorageRepo)
+	if !blocked {
+		handleAutoReply(ctx, evt, chatStorageRepo)
+	}
+
+	// Enforce group moderation rules if configured for this group
+	if moderationService != nil && evt.Info.IsGroup {
+		go moderationService.HandleGroupMessage(ctx, evt)
+	}
 
 	// Forward to webhook if configured
 	handleWebhookForward(ctx, evt)
 
 	// Handle OtomaX integration if configured (using webhook approach)
-	if config.OtomaxEnabled {
+	if config.OtomaxEnabled && !blocked {
 		go func() {
 			if err := forwardMessageToOtomax(ctx, evt); err != nil {
 				logrus.Errorf("Failed to forward message to OtomaX: %v", err)
@@ -676,7 +730,7 @@ func handleAutoReply(ctx context.Context, evt *events.Message, chatStorageRepo d
 	recipientJID := utils.FormatJID(evt.Info.Sender.String())
 
 	// Send the auto-reply message
-	response, err := cli.SendMessage(
+	response, err := SendMessage(
 		ctx,
 		recipientJID,
 		&waE2E.Message{Conversation: proto.String(config.WhatsappAutoReplyMessage)},
@@ -703,6 +757,8 @@ func handleAutoReply(ctx context.Context, evt *events.Message, chatStorageRepo d
 			recipientJID.String(),           // Recipient JID
 			config.WhatsappAutoReplyMessage, // Auto-reply content
 			response.Timestamp,              // Timestamp from response
+			"",                              // Auto-replies quote nothing
+			nil,                             // and mention nobody
 		); err != nil {
 			// Log storage error but don't fail the auto-reply
 			log.Errorf("Failed to store auto-reply message in chat storage: %v", err)
@@ -723,7 +779,7 @@ func handleWebhookForward(ctx context.Context, evt *events.Message) {
 		}
 	}
 
-	if len(config.WhatsappWebhook) > 0 &&
+	if eventForwardingEnabled() &&
 		!strings.Contains(evt.Info.SourceString(), "broadcast") {
 		go func(evt *events.Message) {
 			if err := forwardMessageToWebhook(ctx, evt); err != nil {
@@ -746,7 +802,7 @@ func handleReceipt(ctx context.Context, evt *events.Receipt) {
 
 	// Forward receipt (ack) event to webhook if configured
 	// Note: Receipt events are not rate limited as they are critical for message delivery status
-	if len(config.WhatsappWebhook) > 0 && sendReceipt {
+	if eventForwardingEnabled() && sendReceipt {
 		go func(e *events.Receipt) {
 			if err := forwardReceiptToWebhook(ctx, e); err != nil {
 				logrus.Errorf("Failed to forward ack event to webhook: %v", err)
@@ -769,30 +825,22 @@ func handlePresence(_ context.Context, evt *events.Presence) {
 
 func handleHistorySync(ctx context.Context, evt *events.HistorySync, chatStorageRepo domainChatStorage.IChatStorageRepository) {
 	id := atomic.AddInt32(&historySyncID, 1)
-	fileName := fmt.Sprintf("%s/history-%d-%s-%d-%s.json",
-		config.PathStorages,
-		startupTime,
-		cli.Store.ID.String(),
-		id,
-		evt.Data.SyncType.String(),
-	)
 
-	file, err := os.OpenFile(fileName, os.O_WRONLY|os.O_CREATE, 0600)
-	if err != nil {
-		log.Errorf("Failed to open file to write history sync: %v", err)
-		return
-	}
-	defer file.Close()
-
-	enc := json.NewEncoder(file)
-	enc.SetIndent("", "  ")
-	if err = enc.Encode(evt.Data); err != nil {
-		log.Errorf("Failed to write history sync: %v", err)
-		return
+	// Raw dumps are only kept for debugging, they hold the full message history
+	if config.WhatsappHistorySyncDump {
+		name := fmt.Sprintf("history-%d-%s-%d-%s",
+			startupTime,
+			cli.Store.ID.String(),
+			id,
+			evt.Data.SyncType.String(),
+		)
+		if fileName, err := dumpHistorySync(config.PathStorages, name, evt.Data, config.WhatsappHistorySyncDumpMaxFiles); err != nil {
+			log.Errorf("Failed to write history sync: %v", err)
+		} else {
+			log.Infof("Wrote history sync to %s", fileName)
+		}
 	}
 
-	log.Infof("Wrote history sync to %s", fileName)
-
 	// Process history sync data to database
 	if chatStorageRepo != nil {
 		if err := processHistorySync(ctx, evt.Data, chatStorageRepo); err != nil {
@@ -815,9 +863,14 @@ func processHistorySync(ctx context.Context, data *waHistorySync.HistorySync, ch
 	log.Infof("Processing history sync type: %s", syncType.String())
 
 	switch syncType {
-	case waHistorySync.HistorySync_INITIAL_BOOTSTRAP, waHistorySync.HistorySync_RECENT:
-		// Process conversation messages
-		return processConversationMessages(ctx, data, chatStorageRepo)
+	case waHistorySync.HistorySync_INITIAL_BOOTSTRAP, waHistorySync.HistorySync_RECENT,
+		waHistorySync.HistorySync_FULL, waHistorySync.HistorySync_ON_DEMAND:
+		// Process conversation messages, ON_DEMAND answers a backfill request for older messages of a chat
+		if err := processConversationMessages(ctx, data, chatStorageRepo); err != nil {
+			return err
+		}
+		// Push names may be sent along with the conversations
+		return processPushNames(ctx, data, chatStorageRepo)
 	case waHistorySync.HistorySync_PUSH_NAME:
 		// Process push names to update chat names
 		return processPushNames(ctx, data, chatStorageRepo)
@@ -833,6 +886,11 @@ func processConversationMessages(_ context.Context, data *waHistorySync.HistoryS
 	conversations := data.GetConversations()
 	log.Infof("Processing %d conversations from history sync", len(conversations))
 
+	ownJID := ""
+	if cli != nil && cli.Store.ID != nil {
+		ownJID = cli.Store.ID.ToNonAD().String()
+	}
+
 	for _, conv := range conversations {
 		chatJID := conv.GetID()
 		if chatJID == "" {
@@ -848,8 +906,18 @@ func processConversationMessages(_ context.Context, data *waHistorySync.HistoryS
 
 		displayName := conv.GetDisplayName()
 
+		// Keep the name, topic and participants of groups
+		if jid.Server == types.GroupServer {
+			if err := chatStorageRepo.StoreGroupMetadata(groupMetadataFromConversation(chatJID, conv)); err != nil {
+				log.Warnf("Failed to store group metadata for %s: %v", chatJID, err)
+			}
+		}
+
 		// Get or create chat
 		chatName := chatStorageRepo.GetChatNameWithPushName(jid, chatJID, "", displayName)
+		if jid.Server == types.GroupServer && conv.GetName() != "" {
+			chatName = conv.GetName()
+		}
 
 		// Extract ephemeral expiration from conversation
 		ephemeralExpiration := conv.GetEphemeralExpiration()
@@ -860,6 +928,7 @@ func processConversationMessages(_ context.Context, data *waHistorySync.HistoryS
 
 		// Collect messages for batch processing
 		var messageBatch []*domainChatStorage.Message
+		var reactions []*domainChatStorage.MessageReaction
 		var latestTimestamp time.Time
 
 		for _, histMsg := range messages {
@@ -946,8 +1015,13 @@ func processConversationMessages(_ context.Context, data *waHistorySync.HistoryS
 				FileEncSHA256: fileEncSHA256,
 				FileLength:    fileLength,
 			}
+			if contextInfo := utils.ExtractContextInfo(msg.GetMessage()); contextInfo != nil {
+				message.QuotedMessageID = contextInfo.GetStanzaID()
+				message.Mentions = contextInfo.GetMentionedJID()
+			}
 
 			messageBatch = append(messageBatch, message)
+			reactions = append(reactions, reactionsFromHistoryMessage(chatJID, messageID, msg.GetReactions(), ownJID)...)
 		}
 
 		// Store or update the chat with latest message time
@@ -959,6 +1033,17 @@ func processConversationMessages(_ context.Context, data *waHistorySync.HistoryS
 				EphemeralExpiration: ephemeralExpiration,
 			}
 
+			// Older history (on-demand and full syncs) must not move the chat back in time
+			// or reset a disappearing timer the conversation does not carry
+			if existingChat, err := chatStorageRepo.GetChat(chatJID); err == nil && existingChat != nil {
+				if existingChat.LastMessageTime.After(chat.LastMessageTime) {
+					chat.LastMessageTime = existingChat.LastMessageTime
+				}
+				if conv.EphemeralExpiration == nil {
+					chat.EphemeralExpiration = existingChat.EphemeralExpiration
+				}
+			}
+
 			// Store or update the chat
 			if err := chatStorageRepo.StoreChat(chat); err != nil {
 				log.Warnf("Failed to store chat %s: %v", chatJID, err)
@@ -971,6 +1056,12 @@ func processConversationMessages(_ context.Context, data *waHistorySync.HistoryS
 			} else {
 				log.Debugf("Stored %d messages for chat %s", len(messageBatch), chatJID)
 			}
+
+			for _, reaction := range reactions {
+				if err := chatStorageRepo.StoreReaction(reaction); err != nil {
+					log.Warnf("Failed to store reaction to message %s: %v", reaction.MessageID, err)
+				}
+			}
 		}
 	}
 
@@ -980,8 +1071,22 @@ func processConversationMessages(_ context.Context, data *waHistorySync.HistoryS
 // processPushNames processes push names from history sync to update chat names
 func processPushNames(_ context.Context, data *waHistorySync.HistorySync, chatStorageRepo domainChatStorage.IChatStorageRepository) error {
 	pushnames := data.GetPushnames()
+	if len(pushnames) == 0 {
+		return nil
+	}
 	log.Infof("Processing %d push names from history sync", len(pushnames))
 
+	// Keep every push name, also of contacts without a chat yet, so their chats get a name later
+	stored := make([]*domainChatStorage.PushName, 0, len(pushnames))
+	for _, pushname := range pushnames {
+		if pushname.GetID() != "" && pushname.GetPushname() != "" {
+			stored = append(stored, &domainChatStorage.PushName{JID: pushname.GetID(), PushName: pushname.GetPushname()})
+		}
+	}
+	if err := chatStorageRepo.StorePushNames(stored); err != nil {
+		log.Warnf("Failed to store push names: %v", err)
+	}
+
 	for _, pushname := range pushnames {
 		jidStr := pushname.GetID()
 		name := pushname.GetPushname()
@@ -1034,8 +1139,13 @@ func handleGroupInfo(ctx context.Context, evt *events.GroupInfo) {
 		log.Infof("Group %s: %d users demoted at %s", evt.JID, len(evt.Demote), evt.Timestamp)
 	}
 
+	// Send welcome and goodbye messages if configured for this group
+	if moderationService != nil {
+		go moderationService.HandleParticipantsChange(ctx, evt)
+	}
+
 	// Forward group info event to webhook if configured
-	if len(config.WhatsappWebhook) > 0 {
+	if eventForwardingEnabled() {
 		go func(e *events.GroupInfo) {
 			if err := forwardGroupInfoToWebhook(ctx, e); err != nil {
 				logrus.Errorf("Failed to forward group info event to webhook: %v", err)
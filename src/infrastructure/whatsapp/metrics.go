@@ -0,0 +1,81 @@
+package whatsapp
+
+import (
+	"sync/atomic"
+
+	domainChatStorage "github.com/aldinokemal/go-whatsapp-web-multidevice/domains/chatstorage"
+	"github.com/aldinokemal/go-whatsapp-web-multidevice/pkg/metrics"
+	"github.com/sirupsen/logrus"
+	"go.mau.fi/whatsmeow/types/events"
+)
+
+// everConnected tells a reconnect apart from the first connection of the process
+var everConnected atomic.Bool
+
+// observeConnectionEvent counts connection related events for the metrics endpoint
+func observeConnectionEvent(rawEvt any) {
+	var event string
+	switch rawEvt.(type) {
+	case *events.Connected:
+		event = "connected"
+		if everConnected.Swap(true) {
+			metrics.Reconnects.Inc()
+		}
+	case *events.Disconnected:
+		event = "disconnected"
+	case *events.LoggedOut:
+		event = "logged_out"
+	case *events.StreamReplaced:
+		event = "stream_replaced"
+	case *events.KeepAliveTimeout:
+		event = "keepalive_timeout"
+	case *events.ConnectFailure:
+		event = "connect_failure"
+	case *events.TemporaryBan:
+		event = "temporary_ban"
+	default:
+		return
+	}
+	metrics.ConnectionEvents.WithLabelValues(event).Inc()
+}
+
+// RegisterMetrics exposes the connection state and chat storage size as gauges read on every scrape
+func RegisterMetrics(chatStorageRepo domainChatStorage.IChatStorageRepository) {
+	metrics.RegisterGaugeFunc("whatsapp_connected", "1 when the WhatsApp websocket is connected, 0 otherwise.", func() float64 {
+		client := GetClient()
+		return boolToFloat(client != nil && client.IsConnected())
+	})
+	metrics.RegisterGaugeFunc("whatsapp_logged_in", "1 when a WhatsApp account is logged in, 0 otherwise.", func() float64 {
+		client := GetClient()
+		return boolToFloat(client != nil && client.IsLoggedIn())
+	})
+
+	metrics.RegisterGaugeFunc("chatstorage_size_bytes", "Size of the chat storage database in bytes.", func() float64 {
+		size, err := chatStorageRepo.GetDatabaseSize()
+		if err != nil {
+			logrus.Warnf("Failed to read chat storage size for metrics: %v", err)
+		}
+		return float64(size)
+	})
+	metrics.RegisterGaugeFunc("chatstorage_chats", "Chats stored in the chat storage.", func() float64 {
+		count, err := chatStorageRepo.GetTotalChatCount()
+		if err != nil {
+			logrus.Warnf("Failed to count chats for metrics: %v", err)
+		}
+		return float64(count)
+	})
+	metrics.RegisterGaugeFunc("chatstorage_messages", "Messages stored in the chat storage.", func() float64 {
+		count, err := chatStorageRepo.GetTotalMessageCount()
+		if err != nil {
+			logrus.Warnf("Failed to count messages for metrics: %v", err)
+		}
+		return float64(count)
+	})
+}
+
+func boolToFloat(value bool) float64 {
+	if value {
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,36 @@
+package whatsapp
+
+import (
+	"context"
+	"time"
+
+	"github.com/aldinokemal/go-whatsapp-web-multidevice/pkg/metrics"
+	"github.com/aldinokemal/go-whatsapp-web-multidevice/pkg/tracing"
+	"go.mau.fi/whatsmeow"
+	"go.mau.fi/whatsmeow/proto/waE2E"
+	"go.mau.fi/whatsmeow/types"
+)
+
+// SendMessage sends a message with the global client inside a span carrying the recipient and the resulting message ID.
+// Every send is counted in the send metrics, also the replies and alerts the app sends by itself.
+func SendMessage(ctx context.Context, to types.JID, msg *waE2E.Message, extra ...whatsmeow.SendRequestExtra) (response whatsmeow.SendResponse, err error) {
+	messageType := metrics.MessageType(msg)
+	if to == types.StatusBroadcastJID {
+		messageType = "status"
+	}
+
+	started := time.Now()
+	ctx, span := tracing.Start(ctx, "whatsapp.send_message",
+		tracing.AttrChatJID.String(to.String()),
+		tracing.AttrMessageType.String(messageType),
+	)
+	defer func() {
+		if err == nil {
+			span.SetAttributes(tracing.AttrMessageID.String(response.ID))
+		}
+		tracing.End(span, err)
+		metrics.ObserveSend(messageType, started, err)
+	}()
+
+	return cli.SendMessage(ctx, to, msg, extra...)
+}
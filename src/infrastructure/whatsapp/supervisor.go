@@ -0,0 +1,303 @@
+package whatsapp
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/aldinokemal/go-whatsapp-web-multidevice/config"
+	"github.com/aldinokemal/go-whatsapp-web-multidevice/pkg/utils"
+	"github.com/sirupsen/logrus"
+	"go.mau.fi/whatsmeow"
+	"go.mau.fi/whatsmeow/proto/waE2E"
+	"go.mau.fi/whatsmeow/types/events"
+	"google.golang.org/protobuf/proto"
+)
+
+// ConnectionState is the state of the WhatsApp session as seen by the connection supervisor
+type ConnectionState string
+
+const (
+	StateConnecting     ConnectionState = "connecting"
+	StateConnected      ConnectionState = "connected"
+	StateDisconnected   ConnectionState = "disconnected"
+	StateNotPaired      ConnectionState = "not_paired"
+	StateLoggedOut      ConnectionState = "logged_out"
+	StateStreamReplaced ConnectionState = "stream_replaced"
+	StateTemporaryBan   ConnectionState = "temporary_ban"
+)
+
+const (
+	supervisorCheckInterval = 5 * time.Second
+	reconnectBaseDelay      = 2 * time.Second
+	reconnectMaxDelay       = 5 * time.Minute
+	// reconnectAlertAttempts is the number of failed reconnects after which the admin is alerted
+	reconnectAlertAttempts = 5
+	maxPendingAlerts       = 20
+)
+
+// SupervisorStatus is a snapshot of the connection supervisor
+type SupervisorStatus struct {
+	State             ConnectionState `json:"state"`
+	IsConnected       bool            `json:"is_connected"`
+	IsLoggedIn        bool            `json:"is_logged_in"`
+	Since             time.Time       `json:"since"`
+	ReconnectAttempts int             `json:"reconnect_attempts"`
+	NextReconnectAt   *time.Time      `json:"next_reconnect_at,omitempty"`
+	LastError         string          `json:"last_error,omitempty"`
+	LastCheckAt       time.Time       `json:"last_check_at"`
+}
+
+// connectionSupervisor follows the current global client, reconnects it with exponential backoff
+// and alerts the admin when the session is lost or needs a new scan
+type connectionSupervisor struct {
+	mu            sync.Mutex
+	state         ConnectionState
+	since         time.Time
+	attempts      int
+	nextAttempt   time.Time
+	lastError     string
+	lastCheck     time.Time
+	pendingAlerts []string
+}
+
+var supervisor = &connectionSupervisor{state: StateConnecting, since: time.Now()}
+
+// RunConnectionSupervisor checks the connection until ctx is done
+func RunConnectionSupervisor(ctx context.Context) {
+	ticker := time.NewTicker(supervisorCheckInterval)
+	defer ticker.Stop()
+
+	for {
+		supervisor.check(time.Now())
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// GetSupervisorStatus returns the connection state tracked by the supervisor
+func GetSupervisorStatus() SupervisorStatus {
+	isConnected, isLoggedIn, _ := GetConnectionStatus()
+
+	supervisor.mu.Lock()
+	defer supervisor.mu.Unlock()
+
+	status := SupervisorStatus{
+		State:             supervisor.state,
+		IsConnected:       isConnected,
+		IsLoggedIn:        isLoggedIn,
+		Since:             supervisor.since,
+		ReconnectAttempts: supervisor.attempts,
+		LastError:         supervisor.lastError,
+		LastCheckAt:       supervisor.lastCheck,
+	}
+	if supervisor.state == StateDisconnected && !supervisor.nextAttempt.IsZero() {
+		next := supervisor.nextAttempt
+		status.NextReconnectAt = &next
+	}
+	return status
+}
+
+// IsSupervisorAlive reports whether the supervisor loop ran recently
+func IsSupervisorAlive() bool {
+	supervisor.mu.Lock()
+	defer supervisor.mu.Unlock()
+	return time.Since(supervisor.lastCheck) < 3*supervisorCheckInterval
+}
+
+func (s *connectionSupervisor) check(now time.Time) {
+	client := GetClient()
+
+	s.mu.Lock()
+	s.lastCheck = now
+	s.mu.Unlock()
+
+	if client == nil {
+		return
+	}
+
+	switch {
+	case client.Store.ID == nil:
+		s.transition(StateNotPaired, "")
+	case client.IsConnected() && client.IsLoggedIn():
+		s.transition(StateConnected, "")
+	case client.IsConnected():
+		// Connected and authenticating, the Connected event follows
+	default:
+		s.reconnect(client, now)
+	}
+}
+
+// reconnect connects the client again once the backoff delay has passed. Sessions that were
+// logged out, replaced or banned are left alone, they need a human to log in again.
+func (s *connectionSupervisor) reconnect(client *whatsmeow.Client, now time.Time) {
+	s.mu.Lock()
+	switch s.state {
+	case StateLoggedOut, StateStreamReplaced, StateTemporaryBan:
+		s.mu.Unlock()
+		return
+	}
+	if now.Before(s.nextAttempt) {
+		s.mu.Unlock()
+		return
+	}
+	s.attempts++
+	attempts := s.attempts
+	s.mu.Unlock()
+
+	s.transition(StateDisconnected, "")
+
+	logrus.Infof("[SUPERVISOR] Reconnecting to WhatsApp (attempt %d)", attempts)
+	err := client.Connect()
+	if errors.Is(err, whatsmeow.ErrAlreadyConnected) {
+		err = nil
+	}
+
+	s.mu.Lock()
+	s.nextAttempt = now.Add(reconnectDelay(attempts))
+	if err != nil {
+		s.lastError = err.Error()
+	}
+	s.mu.Unlock()
+
+	if err != nil {
+		logrus.Warnf("[SUPERVISOR] Reconnect attempt %d failed, next attempt in %s: %v", attempts, reconnectDelay(attempts), err)
+		if attempts == reconnectAlertAttempts {
+			s.alert(StateDisconnected, fmt.Sprintf("WhatsApp connection lost, %d reconnect attempts failed: %v", attempts, err))
+		}
+	}
+}
+
+// handleEvent follows the connection events of the current client
+func (s *connectionSupervisor) handleEvent(rawEvt any) {
+	switch evt := rawEvt.(type) {
+	case *events.Connected:
+		s.transition(StateConnected, "")
+	case *events.Disconnected:
+		s.transition(StateDisconnected, "")
+	case *events.LoggedOut:
+		s.transition(StateLoggedOut, fmt.Sprintf("WhatsApp session was logged out (%s), scan the QR code again to restore it", evt.Reason.String()))
+	case *events.StreamReplaced:
+		s.transition(StateStreamReplaced, "WhatsApp session was replaced by another client using the same credentials, reconnect manually once it is stopped")
+	case *events.TemporaryBan:
+		s.transition(StateTemporaryBan, fmt.Sprintf("WhatsApp account is temporarily banned: %s", evt.String()))
+	case *events.ConnectFailure:
+		if evt.Reason.IsLoggedOut() {
+			s.transition(StateLoggedOut, fmt.Sprintf("WhatsApp rejected the session (%s), scan the QR code again to restore it", evt.Reason.String()))
+			return
+		}
+		s.mu.Lock()
+		s.lastError = fmt.Sprintf("connect failure: %s %s", evt.Reason.String(), evt.Message)
+		s.mu.Unlock()
+	}
+}
+
+// transition moves to state and alerts with message when the state changed and message is set.
+// A new, unpaired client only alerts when it did not follow a logout that was already alerted.
+func (s *connectionSupervisor) transition(state ConnectionState, message string) {
+	s.mu.Lock()
+	previous := s.state
+	if previous == state {
+		s.mu.Unlock()
+		return
+	}
+	s.state = state
+	s.since = time.Now()
+	if state == StateConnected || state == StateNotPaired {
+		s.attempts = 0
+		s.nextAttempt = time.Time{}
+		s.lastError = ""
+	}
+	s.mu.Unlock()
+
+	logrus.Infof("[SUPERVISOR] Connection state changed from %s to %s", previous, state)
+
+	if state == StateNotPaired && previous != StateLoggedOut {
+		message = "WhatsApp has no active session, log in with the QR code or a pair code"
+	}
+	if message != "" {
+		s.alert(state, message)
+	}
+	if state == StateConnected {
+		go s.flushPendingAlerts()
+	}
+}
+
+// alert sends message to the alert webhooks and to the admin phone. WhatsApp alerts that cannot
+// be sent because the session is down are kept and sent once the connection is back.
+func (s *connectionSupervisor) alert(state ConnectionState, message string) {
+	logrus.Warnf("[SUPERVISOR] %s", message)
+
+	if len(config.WhatsappAlertWebhook) > 0 {
+		payload := map[string]any{
+			"event":     "connection.alert",
+			"state":     string(state),
+			"message":   message,
+			"timestamp": time.Now().Format(time.RFC3339),
+		}
+		go func() {
+			for _, url := range config.WhatsappAlertWebhook {
+				if err := submitWebhookFn(context.Background(), payload, url); err != nil {
+					logrus.Errorf("[SUPERVISOR] Failed to send alert webhook to %s: %v", url, err)
+				}
+			}
+		}()
+	}
+
+	if config.WhatsappAlertPhone == "" {
+		return
+	}
+	text := fmt.Sprintf("[%s] %s", time.Now().Format(time.RFC3339), message)
+	if isConnected, isLoggedIn, _ := GetConnectionStatus(); isConnected && isLoggedIn {
+		go sendAlertMessage(text)
+		return
+	}
+
+	s.mu.Lock()
+	if len(s.pendingAlerts) >= maxPendingAlerts {
+		s.pendingAlerts = s.pendingAlerts[1:]
+	}
+	s.pendingAlerts = append(s.pendingAlerts, text)
+	s.mu.Unlock()
+}
+
+func (s *connectionSupervisor) flushPendingAlerts() {
+	if config.WhatsappAlertPhone == "" {
+		return
+	}
+
+	s.mu.Lock()
+	pending := s.pendingAlerts
+	s.pendingAlerts = nil
+	s.mu.Unlock()
+
+	for _, text := range pending {
+		sendAlertMessage(text)
+	}
+}
+
+func sendAlertMessage(text string) {
+	recipient, err := utils.ParseJID(config.WhatsappAlertPhone)
+	if err != nil {
+		logrus.Errorf("[SUPERVISOR] Invalid alert phone %s: %v", config.WhatsappAlertPhone, err)
+		return
+	}
+	if _, err := SendMessage(context.Background(), recipient, &waE2E.Message{Conversation: proto.String(text)}); err != nil {
+		logrus.Errorf("[SUPERVISOR] Failed to send alert to %s: %v", config.WhatsappAlertPhone, err)
+	}
+}
+
+// reconnectDelay doubles the delay after every failed attempt, up to reconnectMaxDelay
+func reconnectDelay(attempts int) time.Duration {
+	delay := reconnectBaseDelay
+	for i := 1; i < attempts && delay < reconnectMaxDelay; i++ {
+		delay *= 2
+	}
+	return min(delay, reconnectMaxDelay)
+}
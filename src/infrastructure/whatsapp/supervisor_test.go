@@ -0,0 +1,87 @@
+package whatsapp
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/aldinokemal/go-whatsapp-web-multidevice/config"
+	"go.mau.fi/whatsmeow/types/events"
+)
+
+func TestReconnectDelay(t *testing.T) {
+	tests := []struct {
+		attempts int
+		want     time.Duration
+	}{
+		{attempts: 1, want: 2 * time.Second},
+		{attempts: 2, want: 4 * time.Second},
+		{attempts: 5, want: 32 * time.Second},
+		{attempts: 8, want: 256 * time.Second},
+		{attempts: 9, want: reconnectMaxDelay},
+		{attempts: 50, want: reconnectMaxDelay},
+	}
+
+	for _, tt := range tests {
+		if got := reconnectDelay(tt.attempts); got != tt.want {
+			t.Fatalf("reconnectDelay(%d) = %s, want %s", tt.attempts, got, tt.want)
+		}
+	}
+}
+
+func TestSupervisorAlertsOnceForLostSession(t *testing.T) {
+	originalWebhooks, originalPhone := config.WhatsappAlertWebhook, config.WhatsappAlertPhone
+	config.WhatsappAlertWebhook = []string{"https://alert"}
+	config.WhatsappAlertPhone = "6281234567890"
+	defer func() {
+		config.WhatsappAlertWebhook, config.WhatsappAlertPhone = originalWebhooks, originalPhone
+	}()
+
+	alerts := make(chan string, 10)
+	originalSubmit := submitWebhookFn
+	submitWebhookFn = func(_ context.Context, payload map[string]any, _ string) error {
+		alerts <- payload["state"].(string)
+		return nil
+	}
+	defer func() { submitWebhookFn = originalSubmit }()
+
+	s := &connectionSupervisor{state: StateConnected}
+	s.handleEvent(&events.Disconnected{})
+	s.handleEvent(&events.LoggedOut{})
+	// The cleanup after a logout installs a new client without a session
+	s.transition(StateNotPaired, "")
+
+	select {
+	case state := <-alerts:
+		if state != string(StateLoggedOut) {
+			t.Fatalf("expected logged_out alert, got %s", state)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("expected an alert webhook")
+	}
+	select {
+	case state := <-alerts:
+		t.Fatalf("expected a single alert, got another for %s", state)
+	case <-time.After(100 * time.Millisecond):
+	}
+
+	if s.state != StateNotPaired {
+		t.Fatalf("expected not_paired state, got %s", s.state)
+	}
+	// The client is not connected, so the WhatsApp alert waits for the next session
+	if len(s.pendingAlerts) != 1 {
+		t.Fatalf("expected 1 pending WhatsApp alert, got %d", len(s.pendingAlerts))
+	}
+}
+
+func TestSupervisorDoesNotReconnectReplacedSession(t *testing.T) {
+	s := &connectionSupervisor{state: StateConnected}
+	s.handleEvent(&events.StreamReplaced{})
+
+	// reconnect must return before touching the client
+	s.reconnect(nil, time.Now())
+
+	if s.state != StateStreamReplaced || s.attempts != 0 {
+		t.Fatalf("expected replaced session to be left alone, got state %s after %d attempts", s.state, s.attempts)
+	}
+}
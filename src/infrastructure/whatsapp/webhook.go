@@ -11,11 +11,31 @@ import (
 
 	"github.com/aldinokemal/go-whatsapp-web-multidevice/config"
 	pkgError "github.com/aldinokemal/go-whatsapp-web-multidevice/pkg/error"
+	"github.com/aldinokemal/go-whatsapp-web-multidevice/pkg/metrics"
+	"github.com/aldinokemal/go-whatsapp-web-multidevice/pkg/tracing"
 	"github.com/aldinokemal/go-whatsapp-web-multidevice/pkg/utils"
 	"github.com/sirupsen/logrus"
 )
 
-func submitWebhook(ctx context.Context, payload map[string]any, url string) error {
+func submitWebhook(ctx context.Context, payload map[string]any, url string) (err error) {
+	ctx, span := tracing.Start(ctx, "webhook.submit", tracing.AttrWebhookHost.String(metrics.WebhookHost(url)))
+	if message, ok := payload["message"].(utils.EvtMessage); ok && message.ID != "" {
+		span.SetAttributes(tracing.AttrMessageID.String(message.ID))
+	} else if message, ok := payload["message"].(map[string]any); ok {
+		// v2 payloads
+		if id, _ := message["id"].(string); id != "" {
+			span.SetAttributes(tracing.AttrMessageID.String(id))
+		}
+	}
+
+	started := time.Now()
+	metrics.WebhookInFlight.Inc()
+	defer func() {
+		metrics.WebhookInFlight.Dec()
+		metrics.ObserveWebhook(url, started, err)
+		tracing.End(span, err)
+	}()
+
 	client := &http.Client{Timeout: 10 * time.Second}
 
 	postBody, err := json.Marshal(payload)
@@ -36,6 +56,7 @@ func submitWebhook(ctx context.Context, payload map[string]any, url string) erro
 
 	req.Header.Set("Content-Type", "application/json")
 	req.Header.Set("X-Hub-Signature-256", fmt.Sprintf("sha256=%s", signature))
+	tracing.InjectHTTP(ctx, req.Header)
 
 	var attempt int
 	var maxAttempts = 5
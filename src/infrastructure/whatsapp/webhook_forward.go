@@ -4,19 +4,57 @@ import (
 	"context"
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/aldinokemal/go-whatsapp-web-multidevice/config"
+	domainWebhook "github.com/aldinokemal/go-whatsapp-web-multidevice/domains/webhook"
+	"github.com/aldinokemal/go-whatsapp-web-multidevice/infrastructure/broker"
 	pkgError "github.com/aldinokemal/go-whatsapp-web-multidevice/pkg/error"
+	"github.com/aldinokemal/go-whatsapp-web-multidevice/pkg/metrics"
+	"github.com/aldinokemal/go-whatsapp-web-multidevice/pkg/tracing"
 	"github.com/sirupsen/logrus"
 )
 
-var submitWebhookFn = submitWebhook
+const brokerPublishTimeout = 10 * time.Second
+
+var (
+	submitWebhookFn = submitWebhook
+	// eventPublisher receives every event forwarded to webhooks, nil when no broker is configured
+	eventPublisher broker.Publisher
+)
+
+// SetEventPublisher sets the message broker that receives the webhook events, nil disables publishing
+func SetEventPublisher(publisher broker.Publisher) {
+	eventPublisher = publisher
+}
+
+// eventForwardingEnabled reports whether events are sent anywhere, to webhooks or to a broker
+func eventForwardingEnabled() bool {
+	return len(config.WhatsappWebhook) > 0 || eventPublisher != nil
+}
 
 // forwardPayloadToConfiguredWebhooks attempts to deliver the provided payload to every configured webhook URL.
 // It only returns an error when all webhook deliveries fail. Partial failures are logged and suppressed so
 // successful targets still receive the event.
 func forwardPayloadToConfiguredWebhooks(ctx context.Context, payload map[string]any, eventName string) error {
+	return forwardEventToConfiguredWebhooks(ctx, payload, nil, eventName)
+}
+
+// forwardEventToConfiguredWebhooks is forwardPayloadToConfiguredWebhooks for events with a typed v2 payload.
+// Webhooks configured for v2 receive the event built by buildV2, or payload wrapped in Data when it is nil.
+// The v2 payload is built once, on the first webhook that needs it.
+func forwardEventToConfiguredWebhooks(ctx context.Context, payload map[string]any, buildV2 func() *domainWebhook.Event, eventName string) error {
 	total := len(config.WhatsappWebhook)
+
+	if eventPublisher != nil {
+		if err := PublishEvent(ctx, payload); err != nil {
+			logrus.Errorf("Failed publishing %s to %s broker: %v", eventName, config.EventBroker, err)
+			if total == 0 {
+				return err
+			}
+		}
+	}
+
 	logrus.Infof("Forwarding %s to %d configured webhook(s)", eventName, total)
 
 	if total == 0 {
@@ -28,8 +66,26 @@ func forwardPayloadToConfiguredWebhooks(ctx context.Context, payload map[string]
 		failed    []string
 		successes int
 	)
+	var payloadV2 map[string]any
 	for _, url := range config.WhatsappWebhook {
-		if err := submitWebhookFn(ctx, payload, url); err != nil {
+		body := payload
+		if webhookSchema(url) == domainWebhook.SchemaV2 {
+			if payloadV2 == nil {
+				event := createGenericEventV2(payload)
+				if buildV2 != nil {
+					event = buildV2()
+				}
+				var err error
+				if payloadV2, err = eventV2Payload(event); err != nil {
+					failed = append(failed, fmt.Sprintf("%s: %v", url, err))
+					logrus.Errorf("Failed building v2 payload of %s: %v", eventName, err)
+					continue
+				}
+			}
+			body = payloadV2
+		}
+
+		if err := submitWebhookFn(ctx, body, url); err != nil {
 			failed = append(failed, fmt.Sprintf("%s: %v", url, err))
 			logrus.Warnf("Failed forwarding %s to %s: %v", eventName, url, err)
 			continue
@@ -49,3 +105,52 @@ func forwardPayloadToConfiguredWebhooks(ctx context.Context, payload map[string]
 
 	return nil
 }
+
+// PublishEvent wraps payload in the broker envelope and publishes it to the configured broker, if any
+func PublishEvent(ctx context.Context, payload map[string]any) (err error) {
+	if eventPublisher == nil {
+		return nil
+	}
+
+	event := broker.NewEnvelope(eventType(payload), deviceJID(), payload)
+
+	ctx, span := tracing.Start(ctx, "broker.publish", tracing.AttrEventType.String(event.Type))
+	defer func() {
+		tracing.End(span, err)
+		result := metrics.ResultSuccess
+		if err != nil {
+			result = metrics.ResultFailed
+		}
+		metrics.EventsPublished.WithLabelValues(config.EventBroker, result).Inc()
+	}()
+
+	ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), brokerPublishTimeout)
+	defer cancel()
+	return eventPublisher.Publish(ctx, event)
+}
+
+// eventType names the event of a webhook payload. Most payloads carry it in "event", message
+// payloads only carry an "action" for protocol messages.
+func eventType(payload map[string]any) string {
+	if event, ok := payload["event"].(string); ok && event != "" {
+		return event
+	}
+	switch payload["action"] {
+	case "message_revoked":
+		return "message.revoked"
+	case "message_edited":
+		return "message.edited"
+	case "event.delete_for_me":
+		return "message.deleted_for_me"
+	}
+	return "message"
+}
+
+// deviceJID returns the JID of the logged in device, or an empty string before pairing
+func deviceJID() string {
+	client := GetClient()
+	if client == nil || client.Store == nil || client.Store.ID == nil {
+		return ""
+	}
+	return client.Store.ID.String()
+}
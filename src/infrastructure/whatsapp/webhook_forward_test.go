@@ -7,6 +7,7 @@ import (
 	"testing"
 
 	"github.com/aldinokemal/go-whatsapp-web-multidevice/config"
+	"github.com/aldinokemal/go-whatsapp-web-multidevice/infrastructure/broker"
 )
 
 func TestForwardPayloadToConfiguredWebhooks_NoWebhooksConfigured(t *testing.T) {
@@ -75,3 +76,94 @@ func TestForwardPayloadToConfiguredWebhooks_AllFail(t *testing.T) {
 		t.Fatalf("expected error when all webhooks fail")
 	}
 }
+
+type recordingPublisher struct {
+	events []broker.Envelope
+	err    error
+}
+
+func (p *recordingPublisher) Publish(_ context.Context, event broker.Envelope) error {
+	p.events = append(p.events, event)
+	return p.err
+}
+
+func (p *recordingPublisher) Close() error { return nil }
+
+func TestForwardPayloadToConfiguredWebhooks_PublishesToBroker(t *testing.T) {
+	ctx := context.Background()
+	payload := map[string]any{"event": "message.ack", "payload": map[string]any{"ids": []string{"ABC"}}}
+
+	originalWebhooks := config.WhatsappWebhook
+	config.WhatsappWebhook = nil
+	defer func() { config.WhatsappWebhook = originalWebhooks }()
+
+	publisher := &recordingPublisher{}
+	SetEventPublisher(publisher)
+	defer SetEventPublisher(nil)
+
+	if !eventForwardingEnabled() {
+		t.Fatal("expected event forwarding to be enabled with only a broker configured")
+	}
+	if err := forwardPayloadToConfiguredWebhooks(ctx, payload, "test"); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	if len(publisher.events) != 1 {
+		t.Fatalf("expected 1 published event, got %d", len(publisher.events))
+	}
+	event := publisher.events[0]
+	if event.ID == "" || event.Timestamp.IsZero() {
+		t.Fatalf("expected envelope ID and timestamp to be set, got %+v", event)
+	}
+	if event.Type != "message.ack" {
+		t.Fatalf("expected type message.ack, got %s", event.Type)
+	}
+	if event.Payload["event"] != "message.ack" {
+		t.Fatalf("expected the webhook payload to be published unchanged, got %v", event.Payload)
+	}
+}
+
+func TestForwardPayloadToConfiguredWebhooks_BrokerFailure(t *testing.T) {
+	ctx := context.Background()
+	payload := map[string]any{"foo": "bar"}
+
+	publisher := &recordingPublisher{err: errors.New("broker down")}
+	SetEventPublisher(publisher)
+	defer SetEventPublisher(nil)
+
+	originalSubmit := submitWebhookFn
+	submitWebhookFn = func(context.Context, map[string]any, string) error { return nil }
+	defer func() { submitWebhookFn = originalSubmit }()
+
+	originalWebhooks := config.WhatsappWebhook
+	defer func() { config.WhatsappWebhook = originalWebhooks }()
+
+	config.WhatsappWebhook = nil
+	if err := forwardPayloadToConfiguredWebhooks(ctx, payload, "test"); err == nil {
+		t.Fatal("expected error when the broker is the only target and fails")
+	}
+
+	config.WhatsappWebhook = []string{"https://success"}
+	if err := forwardPayloadToConfiguredWebhooks(ctx, payload, "test"); err != nil {
+		t.Fatalf("expected webhook delivery to succeed despite the broker failure, got %v", err)
+	}
+}
+
+func TestEventType(t *testing.T) {
+	tests := []struct {
+		payload  map[string]any
+		expected string
+	}{
+		{map[string]any{"event": "group.participants"}, "group.participants"},
+		{map[string]any{"action": "message_revoked"}, "message.revoked"},
+		{map[string]any{"action": "message_edited"}, "message.edited"},
+		{map[string]any{"action": "event.delete_for_me"}, "message.deleted_for_me"},
+		{map[string]any{"message": map[string]any{"text": "hi"}}, "message"},
+	}
+
+	for _, tt := range tests {
+		if got := eventType(tt.payload); got != tt.expected {
+			t.Errorf("eventType(%v) = %s, want %s", tt.payload, got, tt.expected)
+		}
+	}
+}
@@ -0,0 +1,330 @@
+package whatsapp
+
+import (
+	"context"
+	"crypto/hmac"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"path"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/aldinokemal/go-whatsapp-web-multidevice/config"
+	domainWebhook "github.com/aldinokemal/go-whatsapp-web-multidevice/domains/webhook"
+	"github.com/aldinokemal/go-whatsapp-web-multidevice/pkg/metrics"
+	"github.com/aldinokemal/go-whatsapp-web-multidevice/pkg/utils"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	"go.mau.fi/whatsmeow"
+	"go.mau.fi/whatsmeow/proto/waE2E"
+	"go.mau.fi/whatsmeow/types"
+	"go.mau.fi/whatsmeow/types/events"
+)
+
+var mentionPattern = regexp.MustCompile(`\B@\w+`)
+
+// downloadedMedia is the media of a message, downloaded once for the payloads of every schema
+type downloadedMedia struct {
+	kind      string // audio, document, image, sticker or video, the v1 field name
+	mimeType  string
+	fileName  string
+	extracted utils.ExtractedMedia
+	data      []byte
+	err       error
+}
+
+// downloadMessageMedia downloads the media of evt into the media storage, nil when it has none
+func downloadMessageMedia(ctx context.Context, evt *events.Message) *downloadedMedia {
+	media := &downloadedMedia{}
+	var file whatsmeow.DownloadableMessage
+
+	switch {
+	case evt.Message.GetAudioMessage() != nil:
+		file, media.kind, media.mimeType = evt.Message.GetAudioMessage(), "audio", evt.Message.GetAudioMessage().GetMimetype()
+	case evt.Message.GetDocumentMessage() != nil:
+		document := evt.Message.GetDocumentMessage()
+		file, media.kind, media.mimeType, media.fileName = document, "document", document.GetMimetype(), document.GetFileName()
+	case evt.Message.GetImageMessage() != nil:
+		file, media.kind, media.mimeType = evt.Message.GetImageMessage(), "image", evt.Message.GetImageMessage().GetMimetype()
+	case evt.Message.GetStickerMessage() != nil:
+		file, media.kind, media.mimeType = evt.Message.GetStickerMessage(), "sticker", evt.Message.GetStickerMessage().GetMimetype()
+	case evt.Message.GetVideoMessage() != nil:
+		file, media.kind, media.mimeType = evt.Message.GetVideoMessage(), "video", evt.Message.GetVideoMessage().GetMimetype()
+	default:
+		return nil
+	}
+
+	media.extracted, media.data, media.err = utils.DownloadMedia(ctx, cli, config.PathMedia, file)
+	if media.err != nil {
+		logrus.Errorf("Failed to download %s from %s, forwarding the message without it: %v", media.kind, evt.Info.SourceString(), media.err)
+	}
+	return media
+}
+
+// createMessageEventV2 creates the v2 webhook payload of a message
+func createMessageEventV2(ctx context.Context, evt *events.Message, media *downloadedMedia) *domainWebhook.Event {
+	msg := evt.Message
+	message := &domainWebhook.Message{
+		ID:        evt.Info.ID,
+		ChatJID:   evt.Info.Chat.ToNonAD().String(),
+		SenderJID: evt.Info.Sender.ToNonAD().String(),
+		PushName:  evt.Info.PushName,
+		IsFromMe:  evt.Info.IsFromMe,
+		IsGroup:   evt.Info.IsGroup,
+		Type:      metrics.MessageType(msg),
+		Text:      replaceLIDMentions(ctx, utils.ExtractMessageTextFromProto(msg)),
+		Timestamp: evt.Info.Timestamp.UTC(),
+		Forwarded: utils.BuildForwarded(evt),
+		ViewOnce:  evt.IsViewOnce,
+		ServerID:  int(evt.Info.ServerID),
+	}
+	if evt.Info.Sender.Server == types.HiddenUserServer {
+		message.SenderLID = message.SenderJID
+		if pn := senderPN(ctx, evt.Info); !pn.IsEmpty() {
+			message.SenderJID = pn.ToNonAD().String()
+		}
+	}
+
+	event := domainWebhook.EventMessage
+	if protocolMessage := msg.GetProtocolMessage(); protocolMessage != nil {
+		switch protocolMessage.GetType() {
+		case waE2E.ProtocolMessage_REVOKE:
+			event = domainWebhook.EventMessageRevoked
+			message.TargetMessageID = protocolMessage.GetKey().GetID()
+		case waE2E.ProtocolMessage_MESSAGE_EDIT:
+			event = domainWebhook.EventMessageEdited
+			message.TargetMessageID = protocolMessage.GetKey().GetID()
+			message.Text = replaceLIDMentions(ctx, utils.ExtractMessageTextFromProto(protocolMessage.GetEditedMessage()))
+		}
+	}
+
+	if contextInfo := utils.ExtractContextInfo(msg); contextInfo.GetStanzaID() != "" {
+		message.Quoted = &domainWebhook.Quoted{
+			MessageID: contextInfo.GetStanzaID(),
+			SenderJID: contextInfo.GetParticipant(),
+			Text:      utils.ExtractMessageTextFromProto(contextInfo.GetQuotedMessage()),
+		}
+	}
+	if reaction := msg.GetReactionMessage(); reaction != nil {
+		message.Reaction = &domainWebhook.Reaction{MessageID: reaction.GetKey().GetID(), Emoji: reaction.GetText()}
+	}
+	if location := msg.GetLocationMessage(); location != nil {
+		message.Location = &domainWebhook.Location{
+			Latitude:  location.GetDegreesLatitude(),
+			Longitude: location.GetDegreesLongitude(),
+			Name:      location.GetName(),
+			Address:   location.GetAddress(),
+		}
+	} else if location := msg.GetLiveLocationMessage(); location != nil {
+		message.Location = &domainWebhook.Location{
+			Latitude:  location.GetDegreesLatitude(),
+			Longitude: location.GetDegreesLongitude(),
+			Live:      true,
+		}
+	}
+	if contact := msg.GetContactMessage(); contact != nil {
+		message.Contacts = append(message.Contacts, domainWebhook.Contact{DisplayName: contact.GetDisplayName(), VCard: contact.GetVcard()})
+	}
+	for _, contact := range msg.GetContactsArrayMessage().GetContacts() {
+		message.Contacts = append(message.Contacts, domainWebhook.Contact{DisplayName: contact.GetDisplayName(), VCard: contact.GetVcard()})
+	}
+	if media != nil {
+		message.Media = webhookMedia(media)
+	}
+
+	return &domainWebhook.Event{
+		SchemaVersion: domainWebhook.SchemaVersion,
+		ID:            uuid.NewString(),
+		Event:         event,
+		Device:        deviceJID(),
+		Timestamp:     time.Now().UTC(),
+		Message:       message,
+	}
+}
+
+// createGenericEventV2 wraps the v1 payload of an event without a typed v2 body in Data
+func createGenericEventV2(payload map[string]any) *domainWebhook.Event {
+	timestamp := time.Now().UTC()
+	if value, ok := payload["timestamp"].(string); ok {
+		if parsed, err := time.Parse(time.RFC3339, value); err == nil {
+			timestamp = parsed.UTC()
+		}
+	}
+
+	return &domainWebhook.Event{
+		SchemaVersion: domainWebhook.SchemaVersion,
+		ID:            uuid.NewString(),
+		Event:         eventType(payload),
+		Device:        deviceJID(),
+		Timestamp:     timestamp,
+		Data:          payload,
+	}
+}
+
+// eventV2Payload converts event to the map submitted to webhooks, so v1 and v2 payloads share the delivery path
+func eventV2Payload(event *domainWebhook.Event) (map[string]any, error) {
+	body, err := json.Marshal(event)
+	if err != nil {
+		return nil, err
+	}
+	var payload map[string]any
+	err = json.Unmarshal(body, &payload)
+	return payload, err
+}
+
+// webhookSchema returns the payload schema of the webhook at webhookURL. A "url=v2" entry applies to
+// that URL only, a bare "v2" entry to every other URL. Webhooks receive v1 payloads by default.
+func webhookSchema(webhookURL string) string {
+	schema := domainWebhook.SchemaV1
+	for _, entry := range config.WhatsappWebhookSchema {
+		entry = strings.TrimSpace(entry)
+		index := strings.LastIndex(entry, "=")
+		if index < 0 {
+			schema = strings.ToLower(entry)
+			continue
+		}
+		if entry[:index] == webhookURL {
+			return strings.ToLower(entry[index+1:])
+		}
+	}
+	return schema
+}
+
+// webhookMedia describes downloaded media in a v2 payload
+func webhookMedia(media *downloadedMedia) *domainWebhook.Media {
+	result := &domainWebhook.Media{MimeType: media.mimeType, FileName: media.fileName}
+	if media.err != nil {
+		result.Error = media.err.Error()
+		return result
+	}
+
+	if result.FileName == "" {
+		result.FileName = path.Base(media.extracted.MediaPath)
+	}
+	result.FileSize = media.extracted.FileSize
+	result.URL = media.extracted.MediaURL
+	if result.URL == "" {
+		mediaURL, err := signedMediaURL(media.extracted.MediaPath, time.Now().Add(config.MediaURLExpiry))
+		if err != nil {
+			logrus.Errorf("Failed to sign media URL of %s: %v", media.extracted.MediaPath, err)
+		}
+		result.URL = mediaURL
+	}
+	if limit := config.WhatsappWebhookMediaInlineMaxSize; limit > 0 && int64(len(media.data)) <= limit {
+		result.Base64 = base64.StdEncoding.EncodeToString(media.data)
+	}
+	return result
+}
+
+// signedMediaURL returns the URL of the /webhook/media endpoint serving key until expires, or an empty
+// string when no public base URL is configured
+func signedMediaURL(key string, expires time.Time) (string, error) {
+	if config.WhatsappWebhookMediaBaseURL == "" {
+		return "", nil
+	}
+	if !mediaSigningEnabled() {
+		return "", fmt.Errorf("set a webhook secret other than the default to sign media URLs")
+	}
+	key, err := CleanMediaKey(key)
+	if err != nil {
+		return "", err
+	}
+	expiresAt := strconv.FormatInt(expires.Unix(), 10)
+	signature, err := mediaSignature(key, expiresAt)
+	if err != nil {
+		return "", err
+	}
+	query := url.Values{"key": {key}, "expires": {expiresAt}, "signature": {signature}}
+	return fmt.Sprintf("%s/webhook/media?%s", strings.TrimSuffix(config.WhatsappWebhookMediaBaseURL, "/"), query.Encode()), nil
+}
+
+// VerifyMediaSignature reports whether a /webhook/media request was signed by this app and has not expired
+func VerifyMediaSignature(key, expires, signature string) bool {
+	if !mediaSigningEnabled() {
+		return false
+	}
+	if _, err := CleanMediaKey(key); err != nil {
+		return false
+	}
+	expiresAt, err := strconv.ParseInt(expires, 10, 64)
+	if err != nil || time.Now().Unix() > expiresAt {
+		return false
+	}
+	expected, err := mediaSignature(key, expires)
+	if err != nil {
+		return false
+	}
+	return hmac.Equal([]byte(expected), []byte(signature))
+}
+
+// CleanMediaKey returns the cleaned form of a media key, rejecting keys outside the media and status directories.
+// Keys are file paths for local storage, so anything else could expose the session database.
+func CleanMediaKey(key string) (string, error) {
+	if key == "" || path.IsAbs(key) || filepath.IsAbs(key) || strings.Contains(key, "..") {
+		return "", fmt.Errorf("invalid media key %q", key)
+	}
+	cleaned := path.Clean(filepath.ToSlash(key))
+	for _, dir := range []string{config.PathMedia, config.PathStatuses} {
+		if strings.HasPrefix(cleaned, path.Clean(filepath.ToSlash(dir))+"/") {
+			return cleaned, nil
+		}
+	}
+	return "", fmt.Errorf("media key %q is outside the media directories", key)
+}
+
+// mediaSigningEnabled reports whether the webhook secret can sign media URLs. The default secret is public,
+// anyone could forge signatures with it.
+func mediaSigningEnabled() bool {
+	return config.WhatsappWebhookSecret != "" && config.WhatsappWebhookSecret != config.DefaultWebhookSecret
+}
+
+func mediaSignature(key, expires string) (string, error) {
+	return utils.GetMessageDigestOrSignature([]byte(key+"\n"+expires), []byte(config.WhatsappWebhookSecret))
+}
+
+// senderPN returns the phone number JID of a sender addressed by LID, or an empty JID when it is unknown
+func senderPN(ctx context.Context, info types.MessageInfo) types.JID {
+	if info.SenderAlt.Server == types.DefaultUserServer {
+		return info.SenderAlt
+	}
+	if cli == nil {
+		return types.EmptyJID
+	}
+	pn, err := cli.Store.LIDs.GetPNForLID(ctx, info.Sender.ToNonAD())
+	if err != nil {
+		logrus.Errorf("Error when get pn for lid %s: %v", info.Sender.String(), err)
+	}
+	return pn
+}
+
+// replaceLIDMentions replaces the "@<lid>" mentions in text with the phone number of the mentioned user, when known
+func replaceLIDMentions(ctx context.Context, text string) string {
+	tags := make(map[string]bool)
+	for _, tag := range mentionPattern.FindAllString(text, -1) {
+		tags[tag] = true
+	}
+	if len(tags) == 0 || cli == nil {
+		return text
+	}
+
+	for tag := range tags {
+		lid, err := types.ParseJID(tag[1:] + "@lid")
+		if err != nil {
+			logrus.Errorf("Error when parse jid: %v", err)
+			continue
+		}
+		pn, err := cli.Store.LIDs.GetPNForLID(ctx, lid)
+		if err != nil {
+			logrus.Errorf("Error when get pn for lid %s: %v", lid.String(), err)
+		}
+		if !pn.IsEmpty() {
+			text = strings.ReplaceAll(text, tag, fmt.Sprintf("@%s", pn.User))
+		}
+	}
+	return text
+}
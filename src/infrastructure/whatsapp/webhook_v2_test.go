@@ -0,0 +1,290 @@
+package whatsapp
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/aldinokemal/go-whatsapp-web-multidevice/config"
+	domainWebhook "github.com/aldinokemal/go-whatsapp-web-multidevice/domains/webhook"
+	"github.com/aldinokemal/go-whatsapp-web-multidevice/pkg/utils"
+	"github.com/invopop/jsonschema"
+	"go.mau.fi/whatsmeow/proto/waE2E"
+	"go.mau.fi/whatsmeow/types"
+	"go.mau.fi/whatsmeow/types/events"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestWebhookSchema(t *testing.T) {
+	original := config.WhatsappWebhookSchema
+	defer func() { config.WhatsappWebhookSchema = original }()
+
+	config.WhatsappWebhookSchema = nil
+	if schema := webhookSchema("https://a"); schema != domainWebhook.SchemaV1 {
+		t.Fatalf("expected v1 by default, got %s", schema)
+	}
+
+	config.WhatsappWebhookSchema = []string{"https://a?token=x=v2", "V2", "https://b=v1"}
+	cases := map[string]string{
+		"https://a?token=x": domainWebhook.SchemaV2,
+		"https://b":         domainWebhook.SchemaV1,
+		"https://c":         domainWebhook.SchemaV2,
+	}
+	for webhookURL, expected := range cases {
+		if schema := webhookSchema(webhookURL); schema != expected {
+			t.Fatalf("expected %s for %s, got %s", expected, webhookURL, schema)
+		}
+	}
+}
+
+func TestForwardEventToConfiguredWebhooks_MixedSchemas(t *testing.T) {
+	originalWebhooks, originalSchema := config.WhatsappWebhook, config.WhatsappWebhookSchema
+	config.WhatsappWebhook = []string{"https://v1", "https://v2a", "https://v2b"}
+	config.WhatsappWebhookSchema = []string{"v2", "https://v1=v1"}
+	defer func() { config.WhatsappWebhook, config.WhatsappWebhookSchema = originalWebhooks, originalSchema }()
+
+	originalSubmit := submitWebhookFn
+	received := make(map[string]map[string]any)
+	submitWebhookFn = func(_ context.Context, payload map[string]any, url string) error {
+		received[url] = payload
+		return nil
+	}
+	defer func() { submitWebhookFn = originalSubmit }()
+
+	builds := 0
+	buildV2 := func() *domainWebhook.Event {
+		builds++
+		return &domainWebhook.Event{
+			SchemaVersion: domainWebhook.SchemaVersion,
+			Event:         domainWebhook.EventMessage,
+			Message:       &domainWebhook.Message{ID: "ABC", Type: "text", Text: "hello"},
+		}
+	}
+
+	payload := map[string]any{"message": utils.EvtMessage{ID: "ABC", Text: "hello"}}
+	if err := forwardEventToConfiguredWebhooks(context.Background(), payload, buildV2, "message event"); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	if builds != 1 {
+		t.Fatalf("expected the v2 payload to be built once, got %d", builds)
+	}
+	if _, ok := received["https://v1"]["message"].(utils.EvtMessage); !ok {
+		t.Fatalf("expected the v1 webhook to receive the v1 payload, got %#v", received["https://v1"])
+	}
+	for _, webhookURL := range []string{"https://v2a", "https://v2b"} {
+		body := received[webhookURL]
+		message, _ := body["message"].(map[string]any)
+		if body["schema_version"] != domainWebhook.SchemaVersion || message["id"] != "ABC" || message["text"] != "hello" {
+			t.Fatalf("expected %s to receive the v2 payload, got %#v", webhookURL, body)
+		}
+	}
+}
+
+func TestForwardPayloadToConfiguredWebhooks_GenericV2(t *testing.T) {
+	originalWebhooks, originalSchema := config.WhatsappWebhook, config.WhatsappWebhookSchema
+	config.WhatsappWebhook = []string{"https://v2"}
+	config.WhatsappWebhookSchema = []string{"v2"}
+	defer func() { config.WhatsappWebhook, config.WhatsappWebhookSchema = originalWebhooks, originalSchema }()
+
+	originalSubmit := submitWebhookFn
+	var received map[string]any
+	submitWebhookFn = func(_ context.Context, payload map[string]any, _ string) error {
+		received = payload
+		return nil
+	}
+	defer func() { submitWebhookFn = originalSubmit }()
+
+	payload := map[string]any{"event": "message.ack", "timestamp": "2025-01-02T03:04:05Z", "payload": map[string]any{"ids": []string{"ABC"}}}
+	if err := forwardPayloadToConfiguredWebhooks(context.Background(), payload, "message ack event"); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	data, _ := received["data"].(map[string]any)
+	if received["event"] != "message.ack" || received["timestamp"] != "2025-01-02T03:04:05Z" || data["event"] != "message.ack" {
+		t.Fatalf("expected the v1 body wrapped in data, got %#v", received)
+	}
+}
+
+func TestCreateMessagePayloads_MediaFailureKeepsMessage(t *testing.T) {
+	evt := &events.Message{
+		Info: types.MessageInfo{
+			MessageSource: types.MessageSource{
+				Chat:   types.NewJID("6281234567890", types.DefaultUserServer),
+				Sender: types.NewJID("6281234567890", types.DefaultUserServer),
+			},
+			ID:        "ABC",
+			Timestamp: time.Unix(1700000000, 0),
+		},
+		Message: &waE2E.Message{ImageMessage: &waE2E.ImageMessage{
+			Caption:  proto.String("look"),
+			Mimetype: proto.String("image/jpeg"),
+		}},
+	}
+	media := &downloadedMedia{kind: "image", mimeType: "image/jpeg", err: errors.New("media expired")}
+
+	payload := createMessagePayload(context.Background(), evt, media)
+	if payload["media_error"] != "media expired" {
+		t.Fatalf("expected media_error in the v1 payload, got %#v", payload)
+	}
+	if _, ok := payload["image"]; ok {
+		t.Fatalf("expected no image in the v1 payload, got %#v", payload["image"])
+	}
+	if message, ok := payload["message"].(utils.EvtMessage); !ok || message.ID != "ABC" {
+		t.Fatalf("expected the message in the v1 payload, got %#v", payload["message"])
+	}
+
+	event := createMessageEventV2(context.Background(), evt, media)
+	if event.Message.Type != "image" || event.Message.Text != "look" {
+		t.Fatalf("expected the image caption in the v2 payload, got %#v", event.Message)
+	}
+	if event.Message.Media == nil || event.Message.Media.Error != "media expired" || event.Message.Media.MimeType != "image/jpeg" {
+		t.Fatalf("expected the media error in the v2 payload, got %#v", event.Message.Media)
+	}
+}
+
+// withWebhookSecret sets a non-default webhook secret for the duration of a test
+func withWebhookSecret(t *testing.T) {
+	t.Helper()
+	original := config.WhatsappWebhookSecret
+	config.WhatsappWebhookSecret = "test-secret"
+	t.Cleanup(func() { config.WhatsappWebhookSecret = original })
+}
+
+func TestWebhookMedia_URLAndInline(t *testing.T) {
+	withWebhookSecret(t)
+	originalBaseURL, originalInline := config.WhatsappWebhookMediaBaseURL, config.WhatsappWebhookMediaInlineMaxSize
+	config.WhatsappWebhookMediaBaseURL = "https://gowa.example.com/"
+	config.WhatsappWebhookMediaInlineMaxSize = 4
+	defer func() {
+		config.WhatsappWebhookMediaBaseURL, config.WhatsappWebhookMediaInlineMaxSize = originalBaseURL, originalInline
+	}()
+
+	media := &downloadedMedia{
+		kind:      "image",
+		mimeType:  "image/jpeg",
+		extracted: utils.ExtractedMedia{MediaPath: "statics/media/1700000000-photo.jpg", FileSize: 4},
+		data:      []byte("jpeg"),
+	}
+	result := webhookMedia(media)
+	if result.Base64 != "anBlZw==" || result.FileName != "1700000000-photo.jpg" {
+		t.Fatalf("expected inline media, got %#v", result)
+	}
+
+	parsed, err := url.Parse(result.URL)
+	if err != nil || parsed.Host != "gowa.example.com" || parsed.Path != "/webhook/media" {
+		t.Fatalf("expected a /webhook/media URL, got %s", result.URL)
+	}
+	query := parsed.Query()
+	if !VerifyMediaSignature(query.Get("key"), query.Get("expires"), query.Get("signature")) {
+		t.Fatal("expected the signed URL to verify")
+	}
+	if VerifyMediaSignature("statics/media/other.jpg", query.Get("expires"), query.Get("signature")) {
+		t.Fatal("expected a signature for another key to fail")
+	}
+
+	config.WhatsappWebhookMediaInlineMaxSize = 3
+	if result := webhookMedia(media); result.Base64 != "" {
+		t.Fatalf("expected media above the limit not to be inlined, got %#v", result)
+	}
+}
+
+func TestVerifyMediaSignature_Expired(t *testing.T) {
+	withWebhookSecret(t)
+	expires := strconv.FormatInt(time.Now().Add(-time.Minute).Unix(), 10)
+	signature, err := mediaSignature("statics/media/photo.jpg", expires)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if VerifyMediaSignature("statics/media/photo.jpg", expires, signature) {
+		t.Fatal("expected an expired signature to fail")
+	}
+}
+
+func TestVerifyMediaSignature_RejectsKeysOutsideMedia(t *testing.T) {
+	withWebhookSecret(t)
+	expires := strconv.FormatInt(time.Now().Add(time.Hour).Unix(), 10)
+
+	for _, key := range []string{
+		"storages/whatsapp.db",
+		"statics/media/../../storages/whatsapp.db",
+		"/etc/passwd",
+		"statics/media",
+	} {
+		if _, err := CleanMediaKey(key); err == nil {
+			t.Errorf("expected key %q to be rejected", key)
+		}
+		// Even a correctly signed traversal key is refused
+		signature, err := mediaSignature(key, expires)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if VerifyMediaSignature(key, expires, signature) {
+			t.Errorf("expected the signature of %q to fail", key)
+		}
+	}
+
+	if key, err := CleanMediaKey("statics/statuses/./photo.jpg"); err != nil || key != "statics/statuses/photo.jpg" {
+		t.Fatalf("expected a status key to be cleaned, got %q (%v)", key, err)
+	}
+}
+
+func TestVerifyMediaSignature_DefaultSecret(t *testing.T) {
+	originalSecret, originalBaseURL := config.WhatsappWebhookSecret, config.WhatsappWebhookMediaBaseURL
+	config.WhatsappWebhookSecret = config.DefaultWebhookSecret
+	config.WhatsappWebhookMediaBaseURL = "https://gowa.example.com"
+	defer func() {
+		config.WhatsappWebhookSecret, config.WhatsappWebhookMediaBaseURL = originalSecret, originalBaseURL
+	}()
+
+	expires := strconv.FormatInt(time.Now().Add(time.Hour).Unix(), 10)
+	signature, err := mediaSignature("statics/media/photo.jpg", expires)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if VerifyMediaSignature("statics/media/photo.jpg", expires, signature) {
+		t.Fatal("expected signatures made with the default secret to fail")
+	}
+	if _, err := signedMediaURL("statics/media/photo.jpg", time.Now().Add(time.Hour)); err == nil {
+		t.Fatal("expected signing with the default secret to fail")
+	}
+}
+
+var updateSchema = flag.Bool("update", false, "regenerate docs/webhook-payload-v2.schema.json")
+
+// TestWebhookPayloadV2Schema keeps the published JSON Schema in sync with domainWebhook.Event,
+// run with -update after changing the payload types
+func TestWebhookPayloadV2Schema(t *testing.T) {
+	// New fields can be added to v2 without breaking receivers that validate the payloads
+	reflector := jsonschema.Reflector{AllowAdditionalProperties: true}
+	schema := reflector.Reflect(&domainWebhook.Event{})
+	schema.ID = "https://github.com/aldinokemal/go-whatsapp-web-multidevice/docs/webhook-payload-v2.schema.json"
+	schema.Title = "WhatsApp webhook payload v2"
+	generated, err := json.MarshalIndent(schema, "", "  ")
+	if err != nil {
+		t.Fatal(err)
+	}
+	generated = append(generated, '\n')
+
+	path := filepath.Join("..", "..", "..", "docs", "webhook-payload-v2.schema.json")
+	if *updateSchema {
+		if err := os.WriteFile(path, generated, 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	published, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(published, generated) {
+		t.Fatalf("%s is outdated, run go test ./infrastructure/whatsapp -run TestWebhookPayloadV2Schema -update", path)
+	}
+}
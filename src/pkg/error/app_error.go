@@ -107,4 +107,55 @@ var (
 	ErrReconnect       = throwReconnectError("reconnect error")
 	ErrQrChannel       = throwQrChannelError("QR channel error")
 	ErrSessionSaved    = throwSessionSavedError("your session have been saved, please wait to connect 2 second and refresh again")
+	ErrAuthRequired    = throwAuthError("authentication required, use an API key or basic auth")
+	ErrInvalidAPIKey   = throwAuthError("API key is invalid, revoked or expired")
+	ErrRateLimited     = RateLimitError("rate limit exceeded for this API key")
 )
+
+type ForbiddenError string
+
+func (err ForbiddenError) Error() string {
+	return string(err)
+}
+
+// ErrCode will return the error code based on the error data type
+func (err ForbiddenError) ErrCode() string {
+	return "FORBIDDEN"
+}
+
+// StatusCode will return the HTTP status code based on the error data type
+func (err ForbiddenError) StatusCode() int {
+	return http.StatusForbidden
+}
+
+type RateLimitError string
+
+func (err RateLimitError) Error() string {
+	return string(err)
+}
+
+// ErrCode will return the error code based on the error data type
+func (err RateLimitError) ErrCode() string {
+	return "RATE_LIMITED"
+}
+
+// StatusCode will return the HTTP status code based on the error data type
+func (err RateLimitError) StatusCode() int {
+	return http.StatusTooManyRequests
+}
+
+type ConflictError string
+
+func (err ConflictError) Error() string {
+	return string(err)
+}
+
+// ErrCode will return the error code based on the error data type
+func (err ConflictError) ErrCode() string {
+	return "CONFLICT"
+}
+
+// StatusCode will return the HTTP status code based on the error data type
+func (err ConflictError) StatusCode() int {
+	return http.StatusConflict
+}
@@ -0,0 +1,161 @@
+package mediastorage
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/aldinokemal/go-whatsapp-web-multidevice/config"
+	"github.com/minio/minio-go/v7"
+	"github.com/minio/minio-go/v7/pkg/credentials"
+)
+
+const (
+	BackendLocal = "local"
+	BackendS3    = "s3"
+)
+
+// Backend stores downloaded media. Keys are slash separated paths such as statics/media/1700000000-uuid.jpg,
+// which the local backend uses as file paths and the S3 backend as object keys.
+type Backend interface {
+	Save(ctx context.Context, key string, data []byte, contentType string) error
+	// URL returns where the media can be fetched: the file path, or a pre-signed URL for S3
+	URL(ctx context.Context, key string) (string, error)
+	Open(ctx context.Context, key string) (io.ReadCloser, error)
+}
+
+var backend Backend = localBackend{}
+
+// Init selects the backend configured with --media-storage
+func Init(ctx context.Context) error {
+	switch config.MediaStorage {
+	case "", BackendLocal:
+		backend = localBackend{}
+		return nil
+	case BackendS3:
+		s3, err := newS3Backend(ctx)
+		if err != nil {
+			return err
+		}
+		backend = s3
+		return nil
+	default:
+		return fmt.Errorf("unknown media storage %q, use local or s3", config.MediaStorage)
+	}
+}
+
+// IsLocal reports whether media is written to the local disk
+func IsLocal() bool {
+	_, ok := backend.(localBackend)
+	return ok
+}
+
+// Save stores data under key with the selected backend
+func Save(ctx context.Context, key string, data []byte, contentType string) error {
+	return backend.Save(ctx, key, data, contentType)
+}
+
+// URL returns where the media stored under key can be fetched
+func URL(ctx context.Context, key string) (string, error) {
+	return backend.URL(ctx, key)
+}
+
+// Open reads the media stored under key
+func Open(ctx context.Context, key string) (io.ReadCloser, error) {
+	return backend.Open(ctx, key)
+}
+
+type localBackend struct{}
+
+func (localBackend) Save(_ context.Context, key string, data []byte, _ string) error {
+	if err := os.MkdirAll(filepath.Dir(key), 0755); err != nil {
+		return fmt.Errorf("failed to create directory: %w", err)
+	}
+	return os.WriteFile(key, data, 0600)
+}
+
+func (localBackend) URL(_ context.Context, key string) (string, error) {
+	return key, nil
+}
+
+func (localBackend) Open(_ context.Context, key string) (io.ReadCloser, error) {
+	return os.Open(key)
+}
+
+// s3Backend writes to any S3-compatible object storage, such as AWS S3 or MinIO
+type s3Backend struct {
+	client *minio.Client
+	bucket string
+	expiry time.Duration
+}
+
+func newS3Backend(ctx context.Context) (*s3Backend, error) {
+	if config.MediaS3Endpoint == "" || config.MediaS3Bucket == "" {
+		return nil, fmt.Errorf("s3 media storage needs an endpoint and a bucket")
+	}
+
+	// Without explicit keys, fall back to the AWS and MinIO environment variables and instance roles
+	creds := credentials.NewChainCredentials([]credentials.Provider{
+		&credentials.EnvAWS{},
+		&credentials.EnvMinio{},
+		&credentials.IAM{},
+	})
+	if config.MediaS3AccessKey != "" {
+		creds = credentials.NewStaticV4(config.MediaS3AccessKey, config.MediaS3SecretKey, "")
+	}
+
+	client, err := minio.New(config.MediaS3Endpoint, &minio.Options{
+		Creds:  creds,
+		Secure: config.MediaS3UseSSL,
+		Region: config.MediaS3Region,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create S3 client: %w", err)
+	}
+
+	exists, err := client.BucketExists(ctx, config.MediaS3Bucket)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check S3 bucket %s: %w", config.MediaS3Bucket, err)
+	}
+	if !exists {
+		return nil, fmt.Errorf("S3 bucket %s does not exist", config.MediaS3Bucket)
+	}
+
+	return &s3Backend{client: client, bucket: config.MediaS3Bucket, expiry: config.MediaURLExpiry}, nil
+}
+
+func (b *s3Backend) Save(ctx context.Context, key string, data []byte, contentType string) error {
+	_, err := b.client.PutObject(ctx, b.bucket, objectKey(key), bytes.NewReader(data), int64(len(data)), minio.PutObjectOptions{
+		ContentType: contentType,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to upload %s to S3: %w", key, err)
+	}
+	return nil
+}
+
+func (b *s3Backend) URL(ctx context.Context, key string) (string, error) {
+	url, err := b.client.PresignedGetObject(ctx, b.bucket, objectKey(key), b.expiry, nil)
+	if err != nil {
+		return "", fmt.Errorf("failed to sign URL for %s: %w", key, err)
+	}
+	return url.String(), nil
+}
+
+func (b *s3Backend) Open(ctx context.Context, key string) (io.ReadCloser, error) {
+	object, err := b.client.GetObject(ctx, b.bucket, objectKey(key), minio.GetObjectOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s from S3: %w", key, err)
+	}
+	return object, nil
+}
+
+// objectKey turns a local style path into an S3 object key
+func objectKey(key string) string {
+	return strings.TrimPrefix(filepath.ToSlash(filepath.Clean(key)), "/")
+}
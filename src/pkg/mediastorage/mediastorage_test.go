@@ -0,0 +1,63 @@
+package mediastorage_test
+
+import (
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/aldinokemal/go-whatsapp-web-multidevice/config"
+	"github.com/aldinokemal/go-whatsapp-web-multidevice/pkg/mediastorage"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLocalBackend(t *testing.T) {
+	ctx := context.Background()
+	original := config.MediaStorage
+	t.Cleanup(func() {
+		config.MediaStorage = original
+		_ = mediastorage.Init(ctx)
+	})
+
+	config.MediaStorage = mediastorage.BackendLocal
+	require.NoError(t, mediastorage.Init(ctx))
+	assert.True(t, mediastorage.IsLocal())
+
+	key := filepath.Join(t.TempDir(), "media", "6281234567890", "1700000000-photo.jpg")
+	require.NoError(t, mediastorage.Save(ctx, key, []byte("jpeg"), "image/jpeg"))
+
+	data, err := os.ReadFile(key)
+	require.NoError(t, err)
+	assert.Equal(t, "jpeg", string(data))
+
+	url, err := mediastorage.URL(ctx, key)
+	require.NoError(t, err)
+	assert.Equal(t, key, url)
+
+	file, err := mediastorage.Open(ctx, key)
+	require.NoError(t, err)
+	defer file.Close()
+	data, err = io.ReadAll(file)
+	require.NoError(t, err)
+	assert.Equal(t, "jpeg", string(data))
+}
+
+func TestInitErrors(t *testing.T) {
+	ctx := context.Background()
+	originalStorage, originalEndpoint, originalBucket := config.MediaStorage, config.MediaS3Endpoint, config.MediaS3Bucket
+	t.Cleanup(func() {
+		config.MediaStorage, config.MediaS3Endpoint, config.MediaS3Bucket = originalStorage, originalEndpoint, originalBucket
+		_ = mediastorage.Init(ctx)
+	})
+
+	config.MediaStorage = "ftp"
+	assert.ErrorContains(t, mediastorage.Init(ctx), "unknown media storage")
+
+	config.MediaStorage = mediastorage.BackendS3
+	config.MediaS3Endpoint = "minio:9000"
+	config.MediaS3Bucket = ""
+	assert.ErrorContains(t, mediastorage.Init(ctx), "needs an endpoint and a bucket")
+	assert.True(t, mediastorage.IsLocal(), "a failed init keeps the previous backend")
+}
@@ -0,0 +1,176 @@
+package metrics
+
+import (
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"go.mau.fi/whatsmeow/proto/waE2E"
+)
+
+const namespace = "gowa"
+
+const (
+	ResultSuccess = "success"
+	ResultFailed  = "failed"
+)
+
+var (
+	MessagesSent = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "messages_sent_total",
+		Help:      "Messages sent to WhatsApp by message type and result.",
+	}, []string{"type", "result"})
+
+	MessagesReceived = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "messages_received_total",
+		Help:      "Messages received from WhatsApp by message type.",
+	}, []string{"type"})
+
+	SendDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "message_send_duration_seconds",
+		Help:      "Time taken by WhatsApp to accept a sent message.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"type"})
+
+	WebhookDeliveries = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "webhook_deliveries_total",
+		Help:      "Webhook deliveries by host and result, after retries.",
+	}, []string{"host", "result"})
+
+	WebhookDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "webhook_delivery_duration_seconds",
+		Help:      "Time taken to deliver a webhook, including retries.",
+		Buckets:   []float64{0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10, 20, 40},
+	}, []string{"host"})
+
+	WebhookInFlight = promauto.NewGauge(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "webhook_deliveries_in_flight",
+		Help:      "Webhook deliveries currently being sent or retried.",
+	})
+
+	EventsPublished = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "events_published_total",
+		Help:      "Events published to the message broker by broker and result.",
+	}, []string{"broker", "result"})
+
+	OtomaxInsertInbox = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "otomax_insert_inbox_total",
+		Help:      "OtomaX InsertInbox calls by response status code, or \"error\" when the call failed.",
+	}, []string{"status"})
+
+	ConnectionEvents = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "whatsapp_connection_events_total",
+		Help:      "WhatsApp connection events such as connected, disconnected and logged_out.",
+	}, []string{"event"})
+
+	Reconnects = promauto.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "whatsapp_reconnects_total",
+		Help:      "Reconnections to WhatsApp after the first successful connection.",
+	})
+
+	BulkParticipantRowsPending = promauto.NewGauge(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "bulk_participant_rows_pending",
+		Help:      "Rows of bulk participant import jobs waiting to be processed.",
+	})
+)
+
+// Handler serves every registered metric, including the Go runtime and process collectors
+func Handler() http.Handler {
+	return promhttp.Handler()
+}
+
+// RegisterGaugeFunc registers a gauge whose value is read from fn on every scrape
+func RegisterGaugeFunc(name, help string, fn func() float64) {
+	prometheus.MustRegister(prometheus.NewGaugeFunc(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      name,
+		Help:      help,
+	}, fn))
+}
+
+// ObserveSend records the result and latency of a message sent to WhatsApp
+func ObserveSend(messageType string, started time.Time, err error) {
+	result := ResultSuccess
+	if err != nil {
+		result = ResultFailed
+	} else {
+		SendDuration.WithLabelValues(messageType).Observe(time.Since(started).Seconds())
+	}
+	MessagesSent.WithLabelValues(messageType, result).Inc()
+}
+
+// ObserveWebhook records the result and latency of a webhook delivery, labelled by the host of webhookURL
+func ObserveWebhook(webhookURL string, started time.Time, err error) {
+	result := ResultSuccess
+	if err != nil {
+		result = ResultFailed
+	}
+	host := WebhookHost(webhookURL)
+	WebhookDeliveries.WithLabelValues(host, result).Inc()
+	WebhookDuration.WithLabelValues(host).Observe(time.Since(started).Seconds())
+}
+
+// WebhookHost returns the host and port of a webhook URL. Credentials, path and query often carry
+// tokens and are left out, so the value is safe to expose in metrics and traces.
+func WebhookHost(webhookURL string) string {
+	parsed, err := url.Parse(webhookURL)
+	if err != nil || parsed.Host == "" {
+		return "invalid"
+	}
+	return parsed.Host
+}
+
+// MessageType returns a short label for the content of a message, e.g. text, image or poll
+func MessageType(msg *waE2E.Message) string {
+	switch {
+	case msg == nil:
+		return "unknown"
+	case msg.GetConversation() != "" || msg.GetExtendedTextMessage() != nil:
+		return "text"
+	case msg.GetImageMessage() != nil:
+		return "image"
+	case msg.GetVideoMessage() != nil:
+		return "video"
+	case msg.GetAudioMessage() != nil:
+		return "audio"
+	case msg.GetDocumentMessage() != nil:
+		return "document"
+	case msg.GetStickerMessage() != nil:
+		return "sticker"
+	case msg.GetContactMessage() != nil, msg.GetContactsArrayMessage() != nil:
+		return "contact"
+	case msg.GetLocationMessage() != nil, msg.GetLiveLocationMessage() != nil:
+		return "location"
+	case msg.GetPollCreationMessage() != nil, msg.GetPollCreationMessageV3() != nil, msg.GetPollUpdateMessage() != nil:
+		return "poll"
+	case msg.GetReactionMessage() != nil:
+		return "reaction"
+	case msg.GetEditedMessage() != nil:
+		return "edit"
+	case msg.GetProtocolMessage() != nil:
+		if msg.GetProtocolMessage().GetType() == waE2E.ProtocolMessage_REVOKE {
+			return "revoke"
+		}
+		return "protocol"
+	case msg.GetEphemeralMessage() != nil:
+		return MessageType(msg.GetEphemeralMessage().GetMessage())
+	case msg.GetViewOnceMessage() != nil:
+		return MessageType(msg.GetViewOnceMessage().GetMessage())
+	default:
+		return "other"
+	}
+}
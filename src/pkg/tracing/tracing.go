@@ -0,0 +1,116 @@
+package tracing
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"os"
+
+	"github.com/aldinokemal/go-whatsapp-web-multidevice/config"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const (
+	ExporterNone   = "none"
+	ExporterOTLP   = "otlp"
+	ExporterStdout = "stdout"
+
+	instrumentationName = "github.com/aldinokemal/go-whatsapp-web-multidevice"
+)
+
+// Attribute keys shared by the spans of the message pipeline
+const (
+	AttrMessageID   = attribute.Key("whatsapp.message.id")
+	AttrChatJID     = attribute.Key("whatsapp.chat.jid")
+	AttrSenderJID   = attribute.Key("whatsapp.sender.jid")
+	AttrEventType   = attribute.Key("whatsapp.event.type")
+	AttrMessageType = attribute.Key("whatsapp.message.type")
+	AttrWebhookHost = attribute.Key("webhook.host")
+)
+
+// ShutdownFunc flushes the spans that are not exported yet and stops the tracer provider
+type ShutdownFunc func(ctx context.Context) error
+
+// Init installs the global tracer provider for the configured exporter. With the none exporter
+// the default no-op provider stays in place, so spans cost next to nothing.
+// The returned shutdown func must be called before the process exits.
+func Init(ctx context.Context) (ShutdownFunc, error) {
+	var exporter sdktrace.SpanExporter
+	var err error
+
+	noop := func(context.Context) error { return nil }
+	switch config.OtelExporter {
+	case "", ExporterNone:
+		return noop, nil
+	case ExporterOTLP:
+		var options []otlptracehttp.Option
+		if config.OtelEndpoint != "" {
+			options = append(options, otlptracehttp.WithEndpointURL(config.OtelEndpoint))
+		}
+		exporter, err = otlptracehttp.New(ctx, options...)
+	case ExporterStdout:
+		exporter, err = stdouttrace.New(stdouttrace.WithWriter(os.Stdout), stdouttrace.WithPrettyPrint())
+	default:
+		return noop, fmt.Errorf("unknown OpenTelemetry exporter %q, use none, otlp or stdout", config.OtelExporter)
+	}
+	if err != nil {
+		return noop, fmt.Errorf("failed to create %s exporter: %w", config.OtelExporter, err)
+	}
+
+	res, err := resource.New(ctx,
+		resource.WithFromEnv(),
+		resource.WithTelemetrySDK(),
+		resource.WithAttributes(
+			attribute.String("service.name", config.OtelServiceName),
+			attribute.String("service.version", config.AppVersion),
+		),
+	)
+	if err != nil {
+		return noop, fmt.Errorf("failed to create resource: %w", err)
+	}
+
+	options := []sdktrace.TracerProviderOption{sdktrace.WithResource(res)}
+	if config.OtelExporter == ExporterStdout {
+		// Print spans as soon as they end when tracing locally
+		options = append(options, sdktrace.WithSyncer(exporter))
+	} else {
+		options = append(options, sdktrace.WithBatcher(exporter))
+	}
+
+	provider := sdktrace.NewTracerProvider(options...)
+	otel.SetTracerProvider(provider)
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{}))
+	return provider.Shutdown, nil
+}
+
+// Start starts a span as a child of the span in ctx, if any
+func Start(ctx context.Context, name string, attrs ...attribute.KeyValue) (context.Context, trace.Span) {
+	return otel.Tracer(instrumentationName).Start(ctx, name, trace.WithAttributes(attrs...))
+}
+
+// SetAttributes adds attributes to the span in ctx, if any
+func SetAttributes(ctx context.Context, attrs ...attribute.KeyValue) {
+	trace.SpanFromContext(ctx).SetAttributes(attrs...)
+}
+
+// End records err on the span, when set, and ends it
+func End(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
+
+// InjectHTTP adds the trace context of ctx to outgoing HTTP headers so receivers can continue the trace
+func InjectHTTP(ctx context.Context, header http.Header) {
+	otel.GetTextMapPropagator().Inject(ctx, propagation.HeaderCarrier(header))
+}
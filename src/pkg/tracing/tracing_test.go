@@ -0,0 +1,53 @@
+package tracing_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/aldinokemal/go-whatsapp-web-multidevice/config"
+	"github.com/aldinokemal/go-whatsapp-web-multidevice/pkg/tracing"
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func TestInitExporter(t *testing.T) {
+	original := config.OtelExporter
+	defer func() { config.OtelExporter = original }()
+
+	config.OtelExporter = tracing.ExporterNone
+	shutdown, err := tracing.Init(context.Background())
+	assert.NoError(t, err)
+	assert.NoError(t, shutdown(context.Background()))
+
+	config.OtelExporter = "zipkin"
+	_, err = tracing.Init(context.Background())
+	assert.Error(t, err)
+}
+
+func TestSpans(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	original := otel.GetTracerProvider()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+	defer otel.SetTracerProvider(original)
+
+	ctx, parent := tracing.Start(context.Background(), "whatsapp.handle_event")
+	tracing.SetAttributes(ctx, tracing.AttrMessageID.String("3EB0ABC"))
+
+	_, child := tracing.Start(ctx, "otomax.InsertInbox")
+	tracing.End(child, errors.New("connection refused"))
+	tracing.End(parent, nil)
+
+	spans := recorder.Ended()
+	if assert.Len(t, spans, 2) {
+		assert.Equal(t, "otomax.InsertInbox", spans[0].Name())
+		assert.Equal(t, codes.Error, spans[0].Status().Code)
+		assert.Equal(t, parent.SpanContext().TraceID(), spans[0].SpanContext().TraceID())
+
+		assert.Equal(t, codes.Unset, spans[1].Status().Code)
+		assert.Contains(t, spans[1].Attributes(), tracing.AttrMessageID.String("3EB0ABC"))
+	}
+}
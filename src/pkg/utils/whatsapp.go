@@ -7,7 +7,6 @@ import (
 	"encoding/hex"
 	"fmt"
 	"mime"
-	"os"
 	"path/filepath"
 	"regexp"
 	"strings"
@@ -21,6 +20,7 @@ import (
 
 	"github.com/aldinokemal/go-whatsapp-web-multidevice/config"
 	pkgError "github.com/aldinokemal/go-whatsapp-web-multidevice/pkg/error"
+	"github.com/aldinokemal/go-whatsapp-web-multidevice/pkg/mediastorage"
 	"go.mau.fi/whatsmeow"
 )
 
@@ -307,6 +307,29 @@ func ExtractMediaInfo(msg *waE2E.Message) (mediaType string, filename string, ur
 	return "", "", "", nil, nil, nil, 0
 }
 
+// ExtractContextInfo returns the context info of the content of msg, which holds the quoted message and mentions
+func ExtractContextInfo(msg *waE2E.Message) *waE2E.ContextInfo {
+	switch {
+	case msg.GetExtendedTextMessage() != nil:
+		return msg.GetExtendedTextMessage().GetContextInfo()
+	case msg.GetImageMessage() != nil:
+		return msg.GetImageMessage().GetContextInfo()
+	case msg.GetVideoMessage() != nil:
+		return msg.GetVideoMessage().GetContextInfo()
+	case msg.GetAudioMessage() != nil:
+		return msg.GetAudioMessage().GetContextInfo()
+	case msg.GetDocumentMessage() != nil:
+		return msg.GetDocumentMessage().GetContextInfo()
+	case msg.GetStickerMessage() != nil:
+		return msg.GetStickerMessage().GetContextInfo()
+	case msg.GetContactMessage() != nil:
+		return msg.GetContactMessage().GetContextInfo()
+	case msg.GetLocationMessage() != nil:
+		return msg.GetLocationMessage().GetContextInfo()
+	}
+	return nil
+}
+
 // ExtractEphemeralExpiration extracts ephemeral expiration from a WhatsApp message
 func ExtractEphemeralExpiration(msg *waE2E.Message) uint32 {
 	logrus.Debug("ExtractEphemeralExpiration: Starting extraction process")
@@ -535,26 +558,34 @@ func FormatJID(jid string) types.JID {
 // ExtractedMedia represents extracted media information
 type ExtractedMedia struct {
 	MediaPath string `json:"media_path"`
+	MediaURL  string `json:"media_url,omitempty"` // Pre-signed URL when media is stored in S3
 	MimeType  string `json:"mime_type"`
 	Caption   string `json:"caption"`
+	FileSize  int64  `json:"file_size,omitempty"`
 }
 
-// ExtractMedia is a helper function to extract media from whatsapp
+// ExtractMedia is a helper function to extract media from whatsapp into the configured media storage
 func ExtractMedia(ctx context.Context, client *whatsmeow.Client, storageLocation string, mediaFile whatsmeow.DownloadableMessage) (extractedMedia ExtractedMedia, err error) {
+	extractedMedia, _, err = DownloadMedia(ctx, client, storageLocation, mediaFile)
+	return extractedMedia, err
+}
+
+// DownloadMedia stores media like ExtractMedia and also returns its content
+func DownloadMedia(ctx context.Context, client *whatsmeow.Client, storageLocation string, mediaFile whatsmeow.DownloadableMessage) (extractedMedia ExtractedMedia, data []byte, err error) {
 	if mediaFile == nil {
 		logrus.Info("Skip download because data is nil")
-		return extractedMedia, nil
+		return extractedMedia, nil, nil
 	}
 
-	data, err := client.Download(ctx, mediaFile)
+	data, err = client.Download(ctx, mediaFile)
 	if err != nil {
-		return extractedMedia, err
+		return extractedMedia, nil, err
 	}
 
 	// Validate file size before writing to disk
 	maxFileSize := config.WhatsappSettingMaxDownloadSize
 	if int64(len(data)) > maxFileSize {
-		return extractedMedia, fmt.Errorf("file size exceeds the maximum limit of %d bytes", maxFileSize)
+		return extractedMedia, nil, fmt.Errorf("file size exceeds the maximum limit of %d bytes", maxFileSize)
 	}
 
 	var originalFilename string
@@ -579,11 +610,18 @@ func ExtractMedia(ctx context.Context, client *whatsmeow.Client, storageLocation
 	extension := determineMediaExtension(originalFilename, extractedMedia.MimeType)
 
 	extractedMedia.MediaPath = fmt.Sprintf("%s/%d-%s%s", storageLocation, time.Now().Unix(), uuid.NewString(), extension)
-	err = os.WriteFile(extractedMedia.MediaPath, data, 0600)
+	extractedMedia.FileSize = int64(len(data))
+	err = mediastorage.Save(ctx, extractedMedia.MediaPath, data, extractedMedia.MimeType)
 	if err != nil {
-		return extractedMedia, err
+		return extractedMedia, nil, err
+	}
+	if !mediastorage.IsLocal() {
+		extractedMedia.MediaURL, err = mediastorage.URL(ctx, extractedMedia.MediaPath)
+		if err != nil {
+			return extractedMedia, nil, err
+		}
 	}
-	return extractedMedia, nil
+	return extractedMedia, data, nil
 }
 
 // SanitizePhone sanitizes phone number by adding appropriate WhatsApp suffix
@@ -0,0 +1,233 @@
+package command
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	domainAudit "github.com/aldinokemal/go-whatsapp-web-multidevice/domains/audit"
+	domainMessage "github.com/aldinokemal/go-whatsapp-web-multidevice/domains/message"
+	domainSend "github.com/aldinokemal/go-whatsapp-web-multidevice/domains/send"
+	"github.com/aldinokemal/go-whatsapp-web-multidevice/infrastructure/broker"
+	"github.com/aldinokemal/go-whatsapp-web-multidevice/infrastructure/whatsapp"
+	"github.com/aldinokemal/go-whatsapp-web-multidevice/pkg/utils"
+	"github.com/sirupsen/logrus"
+)
+
+// Command types accepted from the command topic
+const (
+	TypeSendMessage   = "send.message"
+	TypeSendImage     = "send.image"
+	TypeSendVideo     = "send.video"
+	TypeSendAudio     = "send.audio"
+	TypeSendContact   = "send.contact"
+	TypeSendLink      = "send.link"
+	TypeSendLocation  = "send.location"
+	TypeSendPoll      = "send.poll"
+	TypeMessageReact  = "message.react"
+	TypeMessageRevoke = "message.revoke"
+)
+
+const (
+	// EventResult is the event type of the result published for every command
+	EventResult = "command.result"
+
+	StatusSuccess = "success"
+	StatusFailed  = "failed"
+)
+
+var errNotConnected = errors.New("whatsapp is not connected")
+
+var (
+	connectionStatus = whatsapp.GetConnectionStatus
+	publishEvent     = whatsapp.PublishEvent
+)
+
+// Command is a message published to the command topic. Payload holds the same fields as the REST request
+// of the command type; ID is returned as correlation_id in the result event.
+type Command struct {
+	ID      string          `json:"id"`
+	Type    string          `json:"type"`
+	Payload json.RawMessage `json:"payload"`
+}
+
+type Handler struct {
+	sendService    domainSend.ISendUsecase
+	messageService domainMessage.IMessageUsecase
+}
+
+func InitCommandHandler(sendService domainSend.ISendUsecase, messageService domainMessage.IMessageUsecase) *Handler {
+	return &Handler{
+		sendService:    sendService,
+		messageService: messageService,
+	}
+}
+
+// Consume runs the commands read by consumer until ctx is done
+func Consume(ctx context.Context, consumer broker.Consumer, handler *Handler) {
+	logrus.Info("[BROKER] Consuming commands")
+	if err := consumer.Consume(ctx, handler.Handle); err != nil {
+		logrus.Errorf("[BROKER] Command consumer stopped: %v", err)
+	}
+}
+
+// Handle runs one command and publishes its result. It only returns an error, so the broker delivers the
+// command again later, while WhatsApp is not connected. Invalid and failed commands are acknowledged
+// with a failed result, retrying them would not help.
+func (h *Handler) Handle(ctx context.Context, data []byte) error {
+	var command Command
+	if err := json.Unmarshal(data, &command); err != nil {
+		h.publishResult(ctx, command, "", fmt.Errorf("invalid command: %w", err))
+		return nil
+	}
+
+	if isConnected, isLoggedIn, _ := connectionStatus(); !isConnected || !isLoggedIn {
+		logrus.Debugf("[BROKER] Deferring command %s (%s) until WhatsApp is connected", command.ID, command.Type)
+		return errNotConnected
+	}
+
+	ctx = domainAudit.ContextWithOrigin(ctx, domainAudit.Origin{
+		Actor:    "broker",
+		Endpoint: "command " + command.Type,
+	})
+	messageID, err := h.dispatch(ctx, command)
+	h.publishResult(ctx, command, messageID, err)
+	return nil
+}
+
+func (h *Handler) dispatch(ctx context.Context, command Command) (messageID string, err error) {
+	defer func() {
+		if recovered := recover(); recovered != nil {
+			err = fmt.Errorf("%v", recovered)
+		}
+	}()
+
+	switch command.Type {
+	case TypeSendMessage:
+		var request domainSend.MessageRequest
+		if err := decode(command, &request, &request.Phone); err != nil {
+			return "", err
+		}
+		defaultIdempotencyKey(command, &request.BaseRequest)
+		res, err := h.sendService.SendText(ctx, request)
+		return res.MessageID, err
+	case TypeSendImage:
+		var request domainSend.ImageRequest
+		if err := decode(command, &request, &request.Phone); err != nil {
+			return "", err
+		}
+		defaultIdempotencyKey(command, &request.BaseRequest)
+		res, err := h.sendService.SendImage(ctx, request)
+		return res.MessageID, err
+	case TypeSendVideo:
+		var request domainSend.VideoRequest
+		if err := decode(command, &request, &request.Phone); err != nil {
+			return "", err
+		}
+		defaultIdempotencyKey(command, &request.BaseRequest)
+		res, err := h.sendService.SendVideo(ctx, request)
+		return res.MessageID, err
+	case TypeSendAudio:
+		var request domainSend.AudioRequest
+		if err := decode(command, &request, &request.Phone); err != nil {
+			return "", err
+		}
+		defaultIdempotencyKey(command, &request.BaseRequest)
+		res, err := h.sendService.SendAudio(ctx, request)
+		return res.MessageID, err
+	case TypeSendContact:
+		var request domainSend.ContactRequest
+		if err := decode(command, &request, &request.Phone); err != nil {
+			return "", err
+		}
+		defaultIdempotencyKey(command, &request.BaseRequest)
+		res, err := h.sendService.SendContact(ctx, request)
+		return res.MessageID, err
+	case TypeSendLink:
+		var request domainSend.LinkRequest
+		if err := decode(command, &request, &request.Phone); err != nil {
+			return "", err
+		}
+		defaultIdempotencyKey(command, &request.BaseRequest)
+		res, err := h.sendService.SendLink(ctx, request)
+		return res.MessageID, err
+	case TypeSendLocation:
+		var request domainSend.LocationRequest
+		if err := decode(command, &request, &request.Phone); err != nil {
+			return "", err
+		}
+		defaultIdempotencyKey(command, &request.BaseRequest)
+		res, err := h.sendService.SendLocation(ctx, request)
+		return res.MessageID, err
+	case TypeSendPoll:
+		var request domainSend.PollRequest
+		if err := decode(command, &request, &request.Phone); err != nil {
+			return "", err
+		}
+		defaultIdempotencyKey(command, &request.BaseRequest)
+		res, err := h.sendService.SendPoll(ctx, request)
+		return res.MessageID, err
+	case TypeMessageReact:
+		var request domainMessage.ReactionRequest
+		if err := decode(command, &request, &request.Phone); err != nil {
+			return "", err
+		}
+		res, err := h.messageService.ReactMessage(ctx, request)
+		return res.MessageID, err
+	case TypeMessageRevoke:
+		var request domainMessage.RevokeRequest
+		if err := decode(command, &request, &request.Phone); err != nil {
+			return "", err
+		}
+		res, err := h.messageService.RevokeMessage(ctx, request)
+		return res.MessageID, err
+	default:
+		return "", fmt.Errorf("unknown command type %q", command.Type)
+	}
+}
+
+// decode reads the command payload into request and sanitizes its phone, like the REST handlers do
+func decode(command Command, request any, phone *string) error {
+	if len(command.Payload) == 0 {
+		return fmt.Errorf("command %s has no payload", command.Type)
+	}
+	if err := json.Unmarshal(command.Payload, request); err != nil {
+		return fmt.Errorf("invalid %s payload: %w", command.Type, err)
+	}
+	utils.SanitizePhone(phone)
+	return nil
+}
+
+// defaultIdempotencyKey uses the command ID as idempotency key, so a command delivered twice is only sent once
+func defaultIdempotencyKey(command Command, request *domainSend.BaseRequest) {
+	if request.IdempotencyKey == "" {
+		request.IdempotencyKey = command.ID
+	}
+}
+
+func (h *Handler) publishResult(ctx context.Context, command Command, messageID string, err error) {
+	result := map[string]any{
+		"correlation_id": command.ID,
+		"command":        command.Type,
+		"status":         StatusSuccess,
+	}
+	if messageID != "" {
+		result["message_id"] = messageID
+	}
+	if err != nil {
+		result["status"] = StatusFailed
+		result["error"] = err.Error()
+		logrus.Warnf("[BROKER] Command %s (%s) failed: %v", command.ID, command.Type, err)
+	}
+
+	payload := map[string]any{
+		"event":     EventResult,
+		"payload":   result,
+		"timestamp": time.Now().Format(time.RFC3339),
+	}
+	if err := publishEvent(ctx, payload); err != nil {
+		logrus.Errorf("[BROKER] Failed to publish the result of command %s: %v", command.ID, err)
+	}
+}
@@ -0,0 +1,121 @@
+package command
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	domainMessage "github.com/aldinokemal/go-whatsapp-web-multidevice/domains/message"
+	domainSend "github.com/aldinokemal/go-whatsapp-web-multidevice/domains/send"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeSendService struct {
+	domainSend.ISendUsecase
+	requests []domainSend.MessageRequest
+	err      error
+}
+
+func (s *fakeSendService) SendText(_ context.Context, request domainSend.MessageRequest) (domainSend.GenericResponse, error) {
+	s.requests = append(s.requests, request)
+	if s.err != nil {
+		return domainSend.GenericResponse{}, s.err
+	}
+	return domainSend.GenericResponse{MessageID: "3EB0MSG"}, nil
+}
+
+type fakeMessageService struct {
+	domainMessage.IMessageUsecase
+	reactions []domainMessage.ReactionRequest
+}
+
+func (s *fakeMessageService) ReactMessage(_ context.Context, request domainMessage.ReactionRequest) (domainMessage.GenericResponse, error) {
+	s.reactions = append(s.reactions, request)
+	return domainMessage.GenericResponse{MessageID: "3EB0REACT"}, nil
+}
+
+func setup(t *testing.T, connected bool) *[]map[string]any {
+	t.Helper()
+
+	originalStatus, originalPublish := connectionStatus, publishEvent
+	t.Cleanup(func() { connectionStatus, publishEvent = originalStatus, originalPublish })
+
+	connectionStatus = func() (bool, bool, string) { return connected, connected, "" }
+	var published []map[string]any
+	publishEvent = func(_ context.Context, payload map[string]any) error {
+		published = append(published, payload["payload"].(map[string]any))
+		return nil
+	}
+	return &published
+}
+
+func TestHandle_SendsAndPublishesResult(t *testing.T) {
+	published := setup(t, true)
+	sendService := &fakeSendService{}
+	handler := InitCommandHandler(sendService, &fakeMessageService{})
+
+	err := handler.Handle(context.Background(), []byte(`{"id":"order-42","type":"send.message","payload":{"phone":"6281234567890","message":"hello"}}`))
+	require.NoError(t, err)
+
+	require.Len(t, sendService.requests, 1)
+	assert.Equal(t, "hello", sendService.requests[0].Message)
+	assert.Equal(t, "6281234567890@s.whatsapp.net", sendService.requests[0].Phone)
+	assert.Equal(t, "order-42", sendService.requests[0].IdempotencyKey, "the command ID is the default idempotency key")
+
+	require.Len(t, *published, 1)
+	assert.Equal(t, "order-42", (*published)[0]["correlation_id"])
+	assert.Equal(t, StatusSuccess, (*published)[0]["status"])
+	assert.Equal(t, "3EB0MSG", (*published)[0]["message_id"])
+}
+
+func TestHandle_DispatchesReactions(t *testing.T) {
+	published := setup(t, true)
+	messageService := &fakeMessageService{}
+	handler := InitCommandHandler(&fakeSendService{}, messageService)
+
+	err := handler.Handle(context.Background(), []byte(`{"id":"r1","type":"message.react","payload":{"phone":"6281234567890","message_id":"3EB0ABC","emoji":"👍"}}`))
+	require.NoError(t, err)
+
+	require.Len(t, messageService.reactions, 1)
+	assert.Equal(t, "3EB0ABC", messageService.reactions[0].MessageID)
+	assert.Equal(t, "3EB0REACT", (*published)[0]["message_id"])
+}
+
+func TestHandle_RetriesWhileDisconnected(t *testing.T) {
+	published := setup(t, false)
+	sendService := &fakeSendService{}
+	handler := InitCommandHandler(sendService, &fakeMessageService{})
+
+	err := handler.Handle(context.Background(), []byte(`{"id":"order-42","type":"send.message","payload":{"phone":"6281234567890","message":"hello"}}`))
+	assert.ErrorIs(t, err, errNotConnected)
+	assert.Empty(t, sendService.requests)
+	assert.Empty(t, *published)
+}
+
+func TestHandle_AcknowledgesFailedCommands(t *testing.T) {
+	tests := []struct {
+		name      string
+		data      string
+		sendErr   error
+		wantError string
+	}{
+		{name: "invalid json", data: `{"id":`, wantError: "invalid command"},
+		{name: "unknown type", data: `{"id":"c1","type":"send.fax","payload":{}}`, wantError: "unknown command type"},
+		{name: "missing payload", data: `{"id":"c1","type":"send.message"}`, wantError: "has no payload"},
+		{name: "send failure", data: `{"id":"c1","type":"send.message","payload":{"phone":"6281234567890","message":"hi"}}`, sendErr: errors.New("phone is not on whatsapp"), wantError: "phone is not on whatsapp"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			published := setup(t, true)
+			handler := InitCommandHandler(&fakeSendService{err: tt.sendErr}, &fakeMessageService{})
+
+			require.NoError(t, handler.Handle(context.Background(), []byte(tt.data)))
+
+			require.Len(t, *published, 1)
+			assert.Equal(t, StatusFailed, (*published)[0]["status"])
+			assert.Contains(t, (*published)[0]["error"], tt.wantError)
+		})
+	}
+}
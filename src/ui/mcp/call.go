@@ -0,0 +1,62 @@
+package mcp
+
+import (
+	"context"
+	"fmt"
+
+	domainCall "github.com/aldinokemal/go-whatsapp-web-multidevice/domains/call"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+type CallHandler struct {
+	callService domainCall.ICallUsecase
+}
+
+func InitMcpCall(callService domainCall.ICallUsecase) *CallHandler {
+	return &CallHandler{callService: callService}
+}
+
+func (h *CallHandler) AddCallTools(mcpServer *server.MCPServer) {
+	mcpServer.AddTool(h.toolListCalls(), h.handleListCalls)
+}
+
+func (h *CallHandler) toolListCalls() mcp.Tool {
+	return mcp.NewTool(
+		"whatsapp_list_calls",
+		mcp.WithDescription("Retrieve the log of incoming voice and video calls, newest first."),
+		mcp.WithTitleAnnotation("List Calls"),
+		mcp.WithReadOnlyHintAnnotation(true),
+		mcp.WithDestructiveHintAnnotation(false),
+		mcp.WithIdempotentHintAnnotation(true),
+		mcp.WithString("phone",
+			mcp.Description("Return only calls from this phone number or JID."),
+		),
+		mcp.WithString("outcome",
+			mcp.Description("Return only calls with this outcome: ringing, accepted, rejected, missed or ended."),
+		),
+		mcp.WithNumber("limit",
+			mcp.Description("Maximum number of calls to return (default 50, max 100)."),
+			mcp.DefaultNumber(50),
+		),
+		mcp.WithNumber("offset",
+			mcp.Description("Number of calls to skip from the start (default 0)."),
+			mcp.DefaultNumber(0),
+		),
+	)
+}
+
+func (h *CallHandler) handleListCalls(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	resp, err := h.callService.ListCalls(ctx, domainCall.ListCallsRequest{
+		Phone:   request.GetString("phone", ""),
+		Outcome: request.GetString("outcome", ""),
+		Limit:   request.GetInt("limit", 50),
+		Offset:  request.GetInt("offset", 0),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	fallback := fmt.Sprintf("Retrieved %d of %d calls", len(resp.Data), resp.Pagination.Total)
+	return mcp.NewToolResultStructured(resp, fallback), nil
+}
@@ -0,0 +1,335 @@
+package mcp
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	domainChat "github.com/aldinokemal/go-whatsapp-web-multidevice/domains/chat"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+type ChatHandler struct {
+	chatService domainChat.IChatUsecase
+}
+
+func InitMcpChat(chatService domainChat.IChatUsecase) *ChatHandler {
+	return &ChatHandler{chatService: chatService}
+}
+
+func (h *ChatHandler) AddChatTools(mcpServer *server.MCPServer) {
+	mcpServer.AddTool(h.toolPinChat(), h.handlePinChat)
+	mcpServer.AddTool(h.toolArchiveChat(), h.handleArchiveChat)
+	mcpServer.AddTool(h.toolMuteChat(), h.handleMuteChat)
+	mcpServer.AddTool(h.toolMarkChatRead(), h.handleMarkChatRead)
+	mcpServer.AddTool(h.toolSetDisappearingTimer(), h.handleSetDisappearingTimer)
+	mcpServer.AddTool(h.toolRequestHistory(), h.handleRequestHistory)
+	mcpServer.AddTool(h.toolClearChat(), h.handleClearChat)
+	mcpServer.AddTool(h.toolDeleteChat(), h.handleDeleteChat)
+}
+
+func chatJIDArgument() mcp.ToolOption {
+	return mcp.WithString("chat_jid",
+		mcp.Description("The chat JID (e.g., 628123456789@s.whatsapp.net or group@g.us)."),
+		mcp.Required(),
+	)
+}
+
+func (h *ChatHandler) toolPinChat() mcp.Tool {
+	return mcp.NewTool(
+		"whatsapp_chat_pin",
+		mcp.WithDescription("Pin or unpin a chat at the top of the chat list."),
+		mcp.WithTitleAnnotation("Pin Chat"),
+		mcp.WithReadOnlyHintAnnotation(false),
+		mcp.WithDestructiveHintAnnotation(false),
+		mcp.WithIdempotentHintAnnotation(true),
+		chatJIDArgument(),
+		mcp.WithBoolean("pinned",
+			mcp.Description("Set to true to pin the chat, false to unpin it."),
+			mcp.Required(),
+		),
+	)
+}
+
+func (h *ChatHandler) handlePinChat(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	chatJID, err := request.RequireString("chat_jid")
+	if err != nil {
+		return nil, err
+	}
+
+	pinned, err := requireBool(request, "pinned")
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := h.chatService.PinChat(ctx, domainChat.PinChatRequest{ChatJID: strings.TrimSpace(chatJID), Pinned: pinned})
+	if err != nil {
+		return nil, err
+	}
+
+	return mcp.NewToolResultStructured(resp, resp.Message), nil
+}
+
+func (h *ChatHandler) toolArchiveChat() mcp.Tool {
+	return mcp.NewTool(
+		"whatsapp_chat_archive",
+		mcp.WithDescription("Archive or unarchive a chat. Archiving also unpins it."),
+		mcp.WithTitleAnnotation("Archive Chat"),
+		mcp.WithReadOnlyHintAnnotation(false),
+		mcp.WithDestructiveHintAnnotation(false),
+		mcp.WithIdempotentHintAnnotation(true),
+		chatJIDArgument(),
+		mcp.WithBoolean("archived",
+			mcp.Description("Set to true to archive the chat, false to unarchive it."),
+			mcp.Required(),
+		),
+	)
+}
+
+func (h *ChatHandler) handleArchiveChat(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	chatJID, err := request.RequireString("chat_jid")
+	if err != nil {
+		return nil, err
+	}
+
+	archived, err := requireBool(request, "archived")
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := h.chatService.ArchiveChat(ctx, domainChat.ArchiveChatRequest{ChatJID: strings.TrimSpace(chatJID), Archived: archived})
+	if err != nil {
+		return nil, err
+	}
+
+	return mcp.NewToolResultStructured(resp, resp.Message), nil
+}
+
+func (h *ChatHandler) toolMuteChat() mcp.Tool {
+	return mcp.NewTool(
+		"whatsapp_chat_mute",
+		mcp.WithDescription("Mute a chat for a duration or until it is unmuted, or unmute it."),
+		mcp.WithTitleAnnotation("Mute Chat"),
+		mcp.WithReadOnlyHintAnnotation(false),
+		mcp.WithDestructiveHintAnnotation(false),
+		mcp.WithIdempotentHintAnnotation(true),
+		chatJIDArgument(),
+		mcp.WithBoolean("muted",
+			mcp.Description("Set to true to mute the chat, false to unmute it."),
+			mcp.Required(),
+		),
+		mcp.WithNumber("duration",
+			mcp.Description("Mute duration in seconds, e.g. 28800 for 8 hours. 0 mutes until unmuted (default 0)."),
+			mcp.DefaultNumber(0),
+		),
+	)
+}
+
+func (h *ChatHandler) handleMuteChat(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	chatJID, err := request.RequireString("chat_jid")
+	if err != nil {
+		return nil, err
+	}
+
+	muted, err := requireBool(request, "muted")
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := h.chatService.MuteChat(ctx, domainChat.MuteChatRequest{
+		ChatJID:  strings.TrimSpace(chatJID),
+		Muted:    muted,
+		Duration: int64(request.GetInt("duration", 0)),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return mcp.NewToolResultStructured(resp, resp.Message), nil
+}
+
+func (h *ChatHandler) toolSetDisappearingTimer() mcp.Tool {
+	return mcp.NewTool(
+		"whatsapp_chat_disappearing",
+		mcp.WithDescription("Turn disappearing messages on or off in a private chat or group."),
+		mcp.WithTitleAnnotation("Set Disappearing Messages"),
+		mcp.WithReadOnlyHintAnnotation(false),
+		mcp.WithDestructiveHintAnnotation(false),
+		mcp.WithIdempotentHintAnnotation(true),
+		chatJIDArgument(),
+		mcp.WithString("timer",
+			mcp.Description("How long messages are kept: off, 24h, 7d or 90d."),
+			mcp.Enum("off", "24h", "7d", "90d"),
+			mcp.Required(),
+		),
+	)
+}
+
+func (h *ChatHandler) handleSetDisappearingTimer(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	chatJID, err := request.RequireString("chat_jid")
+	if err != nil {
+		return nil, err
+	}
+
+	timer, err := request.RequireString("timer")
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := h.chatService.SetDisappearingTimer(ctx, domainChat.SetDisappearingTimerRequest{
+		ChatJID: strings.TrimSpace(chatJID),
+		Timer:   strings.TrimSpace(timer),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return mcp.NewToolResultStructured(resp, resp.Message), nil
+}
+
+func (h *ChatHandler) toolRequestHistory() mcp.Tool {
+	return mcp.NewTool(
+		"whatsapp_chat_request_history",
+		mcp.WithDescription("Ask the phone for messages older than the oldest stored one of a chat. They arrive in the background and can then be read with whatsapp_get_chat_messages."),
+		mcp.WithTitleAnnotation("Request Older Chat History"),
+		mcp.WithReadOnlyHintAnnotation(false),
+		mcp.WithDestructiveHintAnnotation(false),
+		mcp.WithIdempotentHintAnnotation(false),
+		chatJIDArgument(),
+		mcp.WithNumber("count",
+			mcp.Description("How many older messages to request, up to 100."),
+			mcp.DefaultNumber(domainChat.DefaultHistoryRequestCount),
+		),
+	)
+}
+
+func (h *ChatHandler) handleRequestHistory(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	chatJID, err := request.RequireString("chat_jid")
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := h.chatService.RequestHistory(ctx, domainChat.RequestHistoryRequest{
+		ChatJID: strings.TrimSpace(chatJID),
+		Count:   request.GetInt("count", domainChat.DefaultHistoryRequestCount),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return mcp.NewToolResultStructured(resp, resp.Message), nil
+}
+
+func (h *ChatHandler) toolMarkChatRead() mcp.Tool {
+	return mcp.NewTool(
+		"whatsapp_chat_mark_read",
+		mcp.WithDescription("Mark a whole chat as read or unread."),
+		mcp.WithTitleAnnotation("Mark Chat Read"),
+		mcp.WithReadOnlyHintAnnotation(false),
+		mcp.WithDestructiveHintAnnotation(false),
+		mcp.WithIdempotentHintAnnotation(true),
+		chatJIDArgument(),
+		mcp.WithBoolean("read",
+			mcp.Description("Set to true to mark the chat as read, false to mark it as unread."),
+			mcp.Required(),
+		),
+	)
+}
+
+func (h *ChatHandler) handleMarkChatRead(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	chatJID, err := request.RequireString("chat_jid")
+	if err != nil {
+		return nil, err
+	}
+
+	read, err := requireBool(request, "read")
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := h.chatService.MarkChatRead(ctx, domainChat.MarkChatReadRequest{ChatJID: strings.TrimSpace(chatJID), Read: read})
+	if err != nil {
+		return nil, err
+	}
+
+	return mcp.NewToolResultStructured(resp, resp.Message), nil
+}
+
+func (h *ChatHandler) toolClearChat() mcp.Tool {
+	return mcp.NewTool(
+		"whatsapp_chat_clear",
+		mcp.WithDescription("Delete all messages of a chat on every linked device and keep the chat itself."),
+		mcp.WithTitleAnnotation("Clear Chat"),
+		mcp.WithReadOnlyHintAnnotation(false),
+		mcp.WithDestructiveHintAnnotation(true),
+		mcp.WithIdempotentHintAnnotation(true),
+		chatJIDArgument(),
+		mcp.WithBoolean("delete_starred",
+			mcp.Description("If true, starred messages are deleted too (default false)."),
+			mcp.DefaultBool(false),
+		),
+	)
+}
+
+func (h *ChatHandler) handleClearChat(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	chatJID, err := request.RequireString("chat_jid")
+	if err != nil {
+		return nil, err
+	}
+
+	deleteStarred, err := optionalBool(request.GetArguments(), "delete_starred")
+	if err != nil {
+		return nil, err
+	}
+
+	req := domainChat.ClearChatRequest{ChatJID: strings.TrimSpace(chatJID)}
+	if deleteStarred != nil {
+		req.DeleteStarred = *deleteStarred
+	}
+
+	resp, err := h.chatService.ClearChat(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	return mcp.NewToolResultStructured(resp, resp.Message), nil
+}
+
+func (h *ChatHandler) toolDeleteChat() mcp.Tool {
+	return mcp.NewTool(
+		"whatsapp_chat_delete",
+		mcp.WithDescription("Delete a chat and its messages on every linked device."),
+		mcp.WithTitleAnnotation("Delete Chat"),
+		mcp.WithReadOnlyHintAnnotation(false),
+		mcp.WithDestructiveHintAnnotation(true),
+		mcp.WithIdempotentHintAnnotation(true),
+		chatJIDArgument(),
+	)
+}
+
+func (h *ChatHandler) handleDeleteChat(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	chatJID, err := request.RequireString("chat_jid")
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := h.chatService.DeleteChat(ctx, domainChat.DeleteChatRequest{ChatJID: strings.TrimSpace(chatJID)})
+	if err != nil {
+		return nil, err
+	}
+
+	return mcp.NewToolResultStructured(resp, resp.Message), nil
+}
+
+// requireBool reads a required boolean argument, accepting the loose encodings handled by toBool
+func requireBool(request mcp.CallToolRequest, key string) (bool, error) {
+	value, err := optionalBool(request.GetArguments(), key)
+	if err != nil {
+		return false, err
+	}
+	if value == nil {
+		return false, fmt.Errorf("%s flag is required", key)
+	}
+	return *value, nil
+}
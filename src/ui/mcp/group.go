@@ -34,6 +34,11 @@ func (h *GroupHandler) AddGroupTools(mcpServer *server.MCPServer) {
 	mcpServer.AddTool(h.toolSetGroupAnnounce(), h.handleSetGroupAnnounce)
 	mcpServer.AddTool(h.toolListGroupJoinRequests(), h.handleListGroupJoinRequests)
 	mcpServer.AddTool(h.toolManageGroupJoinRequests(), h.handleManageGroupJoinRequests)
+	mcpServer.AddTool(h.toolCreateCommunity(), h.handleCreateCommunity)
+	mcpServer.AddTool(h.toolLinkCommunityGroup(), h.handleLinkCommunityGroup)
+	mcpServer.AddTool(h.toolUnlinkCommunityGroup(), h.handleUnlinkCommunityGroup)
+	mcpServer.AddTool(h.toolListCommunityGroups(), h.handleListCommunityGroups)
+	mcpServer.AddTool(h.toolCommunityAnnounce(), h.handleCommunityAnnounce)
 }
 
 func (h *GroupHandler) toolCreateGroup() mcp.Tool {
@@ -711,3 +716,193 @@ func toStringSlice(raw any) ([]string, error) {
 		return nil, fmt.Errorf("participants must be an array of strings")
 	}
 }
+
+func (h *GroupHandler) toolCreateCommunity() mcp.Tool {
+	return mcp.NewTool(
+		"whatsapp_community_create",
+		mcp.WithDescription("Create a new WhatsApp Community. The announcement group is created automatically."),
+		mcp.WithTitleAnnotation("Create Community"),
+		mcp.WithReadOnlyHintAnnotation(false),
+		mcp.WithDestructiveHintAnnotation(false),
+		mcp.WithIdempotentHintAnnotation(false),
+		mcp.WithString("name",
+			mcp.Description("Community name."),
+			mcp.Required(),
+		),
+		mcp.WithString("description",
+			mcp.Description("Community description."),
+		),
+	)
+}
+
+func (h *GroupHandler) handleCreateCommunity(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	name, err := request.RequireString("name")
+	if err != nil {
+		return nil, err
+	}
+
+	communityID, err := h.groupService.CreateCommunity(ctx, domainGroup.CreateCommunityRequest{
+		Name:        strings.TrimSpace(name),
+		Description: request.GetString("description", ""),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	result := map[string]string{"community_id": communityID}
+	return mcp.NewToolResultStructured(result, fmt.Sprintf("Created community %s", communityID)), nil
+}
+
+func communityGroupOptions() []mcp.ToolOption {
+	return []mcp.ToolOption{
+		mcp.WithString("community_id",
+			mcp.Description("Community JID or numeric ID."),
+			mcp.Required(),
+		),
+		mcp.WithString("group_id",
+			mcp.Description("Sub-group JID or numeric ID."),
+			mcp.Required(),
+		),
+	}
+}
+
+func (h *GroupHandler) toolLinkCommunityGroup() mcp.Tool {
+	options := []mcp.ToolOption{
+		mcp.WithDescription("Link an existing group to a community as a sub-group."),
+		mcp.WithTitleAnnotation("Link Community Group"),
+		mcp.WithReadOnlyHintAnnotation(false),
+		mcp.WithDestructiveHintAnnotation(false),
+		mcp.WithIdempotentHintAnnotation(true),
+	}
+	return mcp.NewTool("whatsapp_community_link_group", append(options, communityGroupOptions()...)...)
+}
+
+func (h *GroupHandler) handleLinkCommunityGroup(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	communityID, groupID, err := communityGroupArgs(request)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := h.groupService.LinkGroup(ctx, domainGroup.LinkGroupRequest{CommunityID: communityID, GroupID: groupID}); err != nil {
+		return nil, err
+	}
+
+	return mcp.NewToolResultText(fmt.Sprintf("Linked group %s to community %s", groupID, communityID)), nil
+}
+
+func (h *GroupHandler) toolUnlinkCommunityGroup() mcp.Tool {
+	options := []mcp.ToolOption{
+		mcp.WithDescription("Unlink a sub-group from a community. The group itself is kept."),
+		mcp.WithTitleAnnotation("Unlink Community Group"),
+		mcp.WithReadOnlyHintAnnotation(false),
+		mcp.WithDestructiveHintAnnotation(true),
+		mcp.WithIdempotentHintAnnotation(true),
+	}
+	return mcp.NewTool("whatsapp_community_unlink_group", append(options, communityGroupOptions()...)...)
+}
+
+func (h *GroupHandler) handleUnlinkCommunityGroup(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	communityID, groupID, err := communityGroupArgs(request)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := h.groupService.UnlinkGroup(ctx, domainGroup.UnlinkGroupRequest{CommunityID: communityID, GroupID: groupID}); err != nil {
+		return nil, err
+	}
+
+	return mcp.NewToolResultText(fmt.Sprintf("Unlinked group %s from community %s", groupID, communityID)), nil
+}
+
+func communityGroupArgs(request mcp.CallToolRequest) (communityID string, groupID string, err error) {
+	communityID, err = request.RequireString("community_id")
+	if err != nil {
+		return "", "", err
+	}
+	groupID, err = request.RequireString("group_id")
+	if err != nil {
+		return "", "", err
+	}
+
+	communityID = strings.TrimSpace(communityID)
+	groupID = strings.TrimSpace(groupID)
+	utils.SanitizePhone(&communityID)
+	utils.SanitizePhone(&groupID)
+	return communityID, groupID, nil
+}
+
+func (h *GroupHandler) toolListCommunityGroups() mcp.Tool {
+	return mcp.NewTool(
+		"whatsapp_community_groups",
+		mcp.WithDescription("List the groups linked to a community, including its announcement group."),
+		mcp.WithTitleAnnotation("List Community Groups"),
+		mcp.WithReadOnlyHintAnnotation(true),
+		mcp.WithDestructiveHintAnnotation(false),
+		mcp.WithIdempotentHintAnnotation(true),
+		mcp.WithString("community_id",
+			mcp.Description("Community JID or numeric ID."),
+			mcp.Required(),
+		),
+	)
+}
+
+func (h *GroupHandler) handleListCommunityGroups(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	communityID, err := request.RequireString("community_id")
+	if err != nil {
+		return nil, err
+	}
+
+	trimmed := strings.TrimSpace(communityID)
+	utils.SanitizePhone(&trimmed)
+
+	resp, err := h.groupService.GetLinkedGroups(ctx, domainGroup.GetLinkedGroupsRequest{CommunityID: trimmed})
+	if err != nil {
+		return nil, err
+	}
+
+	fallback := fmt.Sprintf("Community %s has %d linked groups", trimmed, len(resp.Groups))
+	return mcp.NewToolResultStructured(resp, fallback), nil
+}
+
+func (h *GroupHandler) toolCommunityAnnounce() mcp.Tool {
+	return mcp.NewTool(
+		"whatsapp_community_announce",
+		mcp.WithDescription("Send a text message to a community's announcement group."),
+		mcp.WithTitleAnnotation("Send Community Announcement"),
+		mcp.WithReadOnlyHintAnnotation(false),
+		mcp.WithDestructiveHintAnnotation(false),
+		mcp.WithIdempotentHintAnnotation(false),
+		mcp.WithString("community_id",
+			mcp.Description("Community JID or numeric ID."),
+			mcp.Required(),
+		),
+		mcp.WithString("message",
+			mcp.Description("Announcement text."),
+			mcp.Required(),
+		),
+	)
+}
+
+func (h *GroupHandler) handleCommunityAnnounce(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	communityID, err := request.RequireString("community_id")
+	if err != nil {
+		return nil, err
+	}
+	message, err := request.RequireString("message")
+	if err != nil {
+		return nil, err
+	}
+
+	trimmed := strings.TrimSpace(communityID)
+	utils.SanitizePhone(&trimmed)
+
+	resp, err := h.groupService.SendCommunityAnnouncement(ctx, domainGroup.CommunityAnnouncementRequest{
+		CommunityID: trimmed,
+		Message:     message,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return mcp.NewToolResultStructured(resp, resp.Status), nil
+}
@@ -0,0 +1,276 @@
+package mcp
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	domainLabel "github.com/aldinokemal/go-whatsapp-web-multidevice/domains/label"
+	"github.com/aldinokemal/go-whatsapp-web-multidevice/pkg/utils"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+type LabelHandler struct {
+	labelService domainLabel.ILabelUsecase
+}
+
+func InitMcpLabel(labelService domainLabel.ILabelUsecase) *LabelHandler {
+	return &LabelHandler{labelService: labelService}
+}
+
+func (h *LabelHandler) AddLabelTools(mcpServer *server.MCPServer) {
+	mcpServer.AddTool(h.toolListLabels(), h.handleListLabels)
+	mcpServer.AddTool(h.toolCreateLabel(), h.handleCreateLabel)
+	mcpServer.AddTool(h.toolUpdateLabel(), h.handleUpdateLabel)
+	mcpServer.AddTool(h.toolDeleteLabel(), h.handleDeleteLabel)
+	mcpServer.AddTool(h.toolLabelChat(), h.handleLabelChat)
+	mcpServer.AddTool(h.toolLabelMessage(), h.handleLabelMessage)
+}
+
+func (h *LabelHandler) toolListLabels() mcp.Tool {
+	return mcp.NewTool(
+		"whatsapp_list_labels",
+		mcp.WithDescription("List the WhatsApp Business labels of the account."),
+		mcp.WithTitleAnnotation("List Labels"),
+		mcp.WithReadOnlyHintAnnotation(true),
+		mcp.WithDestructiveHintAnnotation(false),
+		mcp.WithIdempotentHintAnnotation(true),
+	)
+}
+
+func (h *LabelHandler) handleListLabels(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	resp, err := h.labelService.ListLabels(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	fallback := fmt.Sprintf("Found %d labels", len(resp.Data))
+	return mcp.NewToolResultStructured(resp, fallback), nil
+}
+
+func (h *LabelHandler) toolCreateLabel() mcp.Tool {
+	return mcp.NewTool(
+		"whatsapp_label_create",
+		mcp.WithDescription("Create a WhatsApp Business label."),
+		mcp.WithTitleAnnotation("Create Label"),
+		mcp.WithReadOnlyHintAnnotation(false),
+		mcp.WithDestructiveHintAnnotation(false),
+		mcp.WithIdempotentHintAnnotation(false),
+		mcp.WithString("name",
+			mcp.Description("Label name, e.g. Pending payment."),
+			mcp.Required(),
+		),
+		mcp.WithNumber("color",
+			mcp.Description("Index of the label color in the WhatsApp palette, from 0 to 19 (default 0)."),
+			mcp.DefaultNumber(0),
+		),
+	)
+}
+
+func (h *LabelHandler) handleCreateLabel(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	name, err := request.RequireString("name")
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := h.labelService.CreateLabel(ctx, domainLabel.CreateLabelRequest{
+		Name:  strings.TrimSpace(name),
+		Color: int32(request.GetInt("color", 0)),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	fallback := fmt.Sprintf("Created label %s (%s)", resp.Name, resp.ID)
+	return mcp.NewToolResultStructured(resp, fallback), nil
+}
+
+func (h *LabelHandler) toolUpdateLabel() mcp.Tool {
+	return mcp.NewTool(
+		"whatsapp_label_update",
+		mcp.WithDescription("Rename or recolor a WhatsApp Business label."),
+		mcp.WithTitleAnnotation("Update Label"),
+		mcp.WithReadOnlyHintAnnotation(false),
+		mcp.WithDestructiveHintAnnotation(false),
+		mcp.WithIdempotentHintAnnotation(true),
+		mcp.WithString("label_id",
+			mcp.Description("Label ID, see whatsapp_list_labels."),
+			mcp.Required(),
+		),
+		mcp.WithString("name",
+			mcp.Description("New label name."),
+			mcp.Required(),
+		),
+		mcp.WithNumber("color",
+			mcp.Description("Index of the label color in the WhatsApp palette, from 0 to 19 (default 0)."),
+			mcp.DefaultNumber(0),
+		),
+	)
+}
+
+func (h *LabelHandler) handleUpdateLabel(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	labelID, err := request.RequireString("label_id")
+	if err != nil {
+		return nil, err
+	}
+
+	name, err := request.RequireString("name")
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := h.labelService.UpdateLabel(ctx, domainLabel.UpdateLabelRequest{
+		LabelID: strings.TrimSpace(labelID),
+		Name:    strings.TrimSpace(name),
+		Color:   int32(request.GetInt("color", 0)),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	fallback := fmt.Sprintf("Updated label %s (%s)", resp.Name, resp.ID)
+	return mcp.NewToolResultStructured(resp, fallback), nil
+}
+
+func (h *LabelHandler) toolDeleteLabel() mcp.Tool {
+	return mcp.NewTool(
+		"whatsapp_label_delete",
+		mcp.WithDescription("Delete a WhatsApp Business label and remove it from every chat and message."),
+		mcp.WithTitleAnnotation("Delete Label"),
+		mcp.WithReadOnlyHintAnnotation(false),
+		mcp.WithDestructiveHintAnnotation(true),
+		mcp.WithIdempotentHintAnnotation(true),
+		mcp.WithString("label_id",
+			mcp.Description("Label ID, see whatsapp_list_labels."),
+			mcp.Required(),
+		),
+	)
+}
+
+func (h *LabelHandler) handleDeleteLabel(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	labelID, err := request.RequireString("label_id")
+	if err != nil {
+		return nil, err
+	}
+
+	if err := h.labelService.DeleteLabel(ctx, domainLabel.DeleteLabelRequest{LabelID: strings.TrimSpace(labelID)}); err != nil {
+		return nil, err
+	}
+
+	return mcp.NewToolResultText(fmt.Sprintf("Deleted label %s", labelID)), nil
+}
+
+func (h *LabelHandler) toolLabelChat() mcp.Tool {
+	return mcp.NewTool(
+		"whatsapp_label_chat",
+		mcp.WithDescription("Apply a WhatsApp Business label to a chat or remove it."),
+		mcp.WithTitleAnnotation("Label Chat"),
+		mcp.WithReadOnlyHintAnnotation(false),
+		mcp.WithDestructiveHintAnnotation(false),
+		mcp.WithIdempotentHintAnnotation(true),
+		chatJIDArgument(),
+		mcp.WithString("label_id",
+			mcp.Description("Label ID, see whatsapp_list_labels."),
+			mcp.Required(),
+		),
+		mcp.WithBoolean("labeled",
+			mcp.Description("Set to true to apply the label, false to remove it."),
+			mcp.Required(),
+		),
+	)
+}
+
+func (h *LabelHandler) handleLabelChat(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	chatJID, err := request.RequireString("chat_jid")
+	if err != nil {
+		return nil, err
+	}
+
+	labelID, err := request.RequireString("label_id")
+	if err != nil {
+		return nil, err
+	}
+
+	labeled, err := requireBool(request, "labeled")
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := h.labelService.LabelChat(ctx, domainLabel.LabelChatRequest{
+		ChatJID: strings.TrimSpace(chatJID),
+		LabelID: strings.TrimSpace(labelID),
+		Labeled: labeled,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return mcp.NewToolResultStructured(resp, resp.Message), nil
+}
+
+func (h *LabelHandler) toolLabelMessage() mcp.Tool {
+	return mcp.NewTool(
+		"whatsapp_label_message",
+		mcp.WithDescription("Apply a WhatsApp Business label to a message or remove it."),
+		mcp.WithTitleAnnotation("Label Message"),
+		mcp.WithReadOnlyHintAnnotation(false),
+		mcp.WithDestructiveHintAnnotation(false),
+		mcp.WithIdempotentHintAnnotation(true),
+		mcp.WithString("message_id",
+			mcp.Description("The WhatsApp message ID."),
+			mcp.Required(),
+		),
+		mcp.WithString("phone",
+			mcp.Description("The phone number or JID of the chat containing the message."),
+			mcp.Required(),
+		),
+		mcp.WithString("label_id",
+			mcp.Description("Label ID, see whatsapp_list_labels."),
+			mcp.Required(),
+		),
+		mcp.WithBoolean("labeled",
+			mcp.Description("Set to true to apply the label, false to remove it."),
+			mcp.Required(),
+		),
+	)
+}
+
+func (h *LabelHandler) handleLabelMessage(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	messageID, err := request.RequireString("message_id")
+	if err != nil {
+		return nil, err
+	}
+
+	phone, err := request.RequireString("phone")
+	if err != nil {
+		return nil, err
+	}
+	utils.SanitizePhone(&phone)
+
+	labelID, err := request.RequireString("label_id")
+	if err != nil {
+		return nil, err
+	}
+
+	labeled, err := requireBool(request, "labeled")
+	if err != nil {
+		return nil, err
+	}
+
+	err = h.labelService.LabelMessage(ctx, domainLabel.LabelMessageRequest{
+		MessageID: strings.TrimSpace(messageID),
+		Phone:     phone,
+		LabelID:   strings.TrimSpace(labelID),
+		Labeled:   labeled,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	state := "removed from"
+	if labeled {
+		state = "applied to"
+	}
+	return mcp.NewToolResultText(fmt.Sprintf("Label %s %s message %s", labelID, state, messageID)), nil
+}
@@ -0,0 +1,367 @@
+package mcp
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	domainNewsletter "github.com/aldinokemal/go-whatsapp-web-multidevice/domains/newsletter"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+type NewsletterHandler struct {
+	newsletterService domainNewsletter.INewsletterUsecase
+}
+
+func InitMcpNewsletter(newsletterService domainNewsletter.INewsletterUsecase) *NewsletterHandler {
+	return &NewsletterHandler{newsletterService: newsletterService}
+}
+
+func (h *NewsletterHandler) AddNewsletterTools(mcpServer *server.MCPServer) {
+	mcpServer.AddTool(h.toolCreateNewsletter(), h.handleCreateNewsletter)
+	mcpServer.AddTool(h.toolFollowNewsletter(), h.handleFollowNewsletter)
+	mcpServer.AddTool(h.toolUnfollowNewsletter(), h.handleUnfollowNewsletter)
+	mcpServer.AddTool(h.toolGetNewsletterInfo(), h.handleGetNewsletterInfo)
+	mcpServer.AddTool(h.toolUpdateNewsletter(), h.handleUpdateNewsletter)
+	mcpServer.AddTool(h.toolMuteNewsletter(), h.handleMuteNewsletter)
+	mcpServer.AddTool(h.toolPostNewsletterText(), h.handlePostNewsletterText)
+	mcpServer.AddTool(h.toolPostNewsletterImage(), h.handlePostNewsletterImage)
+	mcpServer.AddTool(h.toolGetNewsletterMessages(), h.handleGetNewsletterMessages)
+}
+
+func newsletterIDOption() mcp.ToolOption {
+	return mcp.WithString("newsletter_id",
+		mcp.Description("Newsletter JID (e.g. 120363025246125486@newsletter) or its numeric part."),
+		mcp.Required(),
+	)
+}
+
+func (h *NewsletterHandler) toolCreateNewsletter() mcp.Tool {
+	return mcp.NewTool(
+		"whatsapp_newsletter_create",
+		mcp.WithDescription("Create a new WhatsApp channel (newsletter) owned by this account."),
+		mcp.WithTitleAnnotation("Create Newsletter"),
+		mcp.WithReadOnlyHintAnnotation(false),
+		mcp.WithDestructiveHintAnnotation(false),
+		mcp.WithIdempotentHintAnnotation(false),
+		mcp.WithString("name",
+			mcp.Description("Channel name."),
+			mcp.Required(),
+		),
+		mcp.WithString("description",
+			mcp.Description("Channel description."),
+		),
+	)
+}
+
+func (h *NewsletterHandler) handleCreateNewsletter(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	name, err := request.RequireString("name")
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := h.newsletterService.Create(ctx, domainNewsletter.CreateNewsletterRequest{
+		Name:        strings.TrimSpace(name),
+		Description: request.GetString("description", ""),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return mcp.NewToolResultStructured(resp, fmt.Sprintf("Newsletter %s created with id %s", resp.Name, resp.ID)), nil
+}
+
+func (h *NewsletterHandler) toolFollowNewsletter() mcp.Tool {
+	return mcp.NewTool(
+		"whatsapp_newsletter_follow",
+		mcp.WithDescription("Follow a WhatsApp channel (newsletter)."),
+		mcp.WithTitleAnnotation("Follow Newsletter"),
+		mcp.WithReadOnlyHintAnnotation(false),
+		mcp.WithDestructiveHintAnnotation(false),
+		mcp.WithIdempotentHintAnnotation(true),
+		newsletterIDOption(),
+	)
+}
+
+func (h *NewsletterHandler) handleFollowNewsletter(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	newsletterID, err := request.RequireString("newsletter_id")
+	if err != nil {
+		return nil, err
+	}
+
+	if err = h.newsletterService.Follow(ctx, domainNewsletter.FollowRequest{NewsletterID: newsletterID}); err != nil {
+		return nil, err
+	}
+
+	return mcp.NewToolResultText(fmt.Sprintf("Followed newsletter %s", newsletterID)), nil
+}
+
+func (h *NewsletterHandler) toolUnfollowNewsletter() mcp.Tool {
+	return mcp.NewTool(
+		"whatsapp_newsletter_unfollow",
+		mcp.WithDescription("Unfollow a WhatsApp channel (newsletter)."),
+		mcp.WithTitleAnnotation("Unfollow Newsletter"),
+		mcp.WithReadOnlyHintAnnotation(false),
+		mcp.WithDestructiveHintAnnotation(false),
+		mcp.WithIdempotentHintAnnotation(true),
+		newsletterIDOption(),
+	)
+}
+
+func (h *NewsletterHandler) handleUnfollowNewsletter(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	newsletterID, err := request.RequireString("newsletter_id")
+	if err != nil {
+		return nil, err
+	}
+
+	if err = h.newsletterService.Unfollow(ctx, domainNewsletter.UnfollowRequest{NewsletterID: newsletterID}); err != nil {
+		return nil, err
+	}
+
+	return mcp.NewToolResultText(fmt.Sprintf("Unfollowed newsletter %s", newsletterID)), nil
+}
+
+func (h *NewsletterHandler) toolGetNewsletterInfo() mcp.Tool {
+	return mcp.NewTool(
+		"whatsapp_newsletter_info",
+		mcp.WithDescription("Get channel (newsletter) details by JID or by invite link."),
+		mcp.WithTitleAnnotation("Get Newsletter Info"),
+		mcp.WithReadOnlyHintAnnotation(true),
+		mcp.WithDestructiveHintAnnotation(false),
+		mcp.WithIdempotentHintAnnotation(true),
+		mcp.WithString("newsletter_id",
+			mcp.Description("Newsletter JID or its numeric part. Either this or link is required."),
+		),
+		mcp.WithString("link",
+			mcp.Description("Channel invite link (https://whatsapp.com/channel/...) or invite code."),
+		),
+	)
+}
+
+func (h *NewsletterHandler) handleGetNewsletterInfo(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	newsletterID := strings.TrimSpace(request.GetString("newsletter_id", ""))
+	link := strings.TrimSpace(request.GetString("link", ""))
+
+	var (
+		resp domainNewsletter.NewsletterInfo
+		err  error
+	)
+	switch {
+	case newsletterID != "":
+		resp, err = h.newsletterService.GetInfo(ctx, domainNewsletter.GetInfoRequest{NewsletterID: newsletterID})
+	case link != "":
+		resp, err = h.newsletterService.GetInfoFromLink(ctx, domainNewsletter.GetInfoFromLinkRequest{Link: link})
+	default:
+		return nil, fmt.Errorf("either newsletter_id or link is required")
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	fallback := fmt.Sprintf("Newsletter %s (%s) has %d subscribers", resp.Name, resp.ID, resp.SubscriberCount)
+	return mcp.NewToolResultStructured(resp, fallback), nil
+}
+
+func (h *NewsletterHandler) toolUpdateNewsletter() mcp.Tool {
+	return mcp.NewTool(
+		"whatsapp_newsletter_update",
+		mcp.WithDescription("Update the name and/or description of a channel (newsletter) you own."),
+		mcp.WithTitleAnnotation("Update Newsletter"),
+		mcp.WithReadOnlyHintAnnotation(false),
+		mcp.WithDestructiveHintAnnotation(false),
+		mcp.WithIdempotentHintAnnotation(true),
+		newsletterIDOption(),
+		mcp.WithString("name",
+			mcp.Description("New channel name."),
+		),
+		mcp.WithString("description",
+			mcp.Description("New channel description."),
+		),
+	)
+}
+
+func (h *NewsletterHandler) handleUpdateNewsletter(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	newsletterID, err := request.RequireString("newsletter_id")
+	if err != nil {
+		return nil, err
+	}
+
+	req := domainNewsletter.UpdateNewsletterRequest{NewsletterID: newsletterID}
+	if args := request.GetArguments(); args != nil {
+		if _, ok := args["name"]; ok {
+			name := request.GetString("name", "")
+			req.Name = &name
+		}
+		if _, ok := args["description"]; ok {
+			description := request.GetString("description", "")
+			req.Description = &description
+		}
+	}
+
+	resp, err := h.newsletterService.Update(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	return mcp.NewToolResultStructured(resp, fmt.Sprintf("Newsletter %s updated", resp.ID)), nil
+}
+
+func (h *NewsletterHandler) toolMuteNewsletter() mcp.Tool {
+	return mcp.NewTool(
+		"whatsapp_newsletter_mute",
+		mcp.WithDescription("Mute or unmute notifications from a channel (newsletter)."),
+		mcp.WithTitleAnnotation("Mute Newsletter"),
+		mcp.WithReadOnlyHintAnnotation(false),
+		mcp.WithDestructiveHintAnnotation(false),
+		mcp.WithIdempotentHintAnnotation(true),
+		newsletterIDOption(),
+		mcp.WithBoolean("mute",
+			mcp.Description("True to mute, false to unmute."),
+			mcp.DefaultBool(true),
+		),
+	)
+}
+
+func (h *NewsletterHandler) handleMuteNewsletter(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	newsletterID, err := request.RequireString("newsletter_id")
+	if err != nil {
+		return nil, err
+	}
+
+	mute := true
+	if args := request.GetArguments(); args != nil {
+		if value, ok := args["mute"]; ok {
+			parsed, err := toBool(value)
+			if err != nil {
+				return nil, err
+			}
+			mute = parsed
+		}
+	}
+
+	if err = h.newsletterService.Mute(ctx, domainNewsletter.MuteRequest{NewsletterID: newsletterID, Mute: mute}); err != nil {
+		return nil, err
+	}
+
+	action := "Unmuted"
+	if mute {
+		action = "Muted"
+	}
+	return mcp.NewToolResultText(fmt.Sprintf("%s newsletter %s", action, newsletterID)), nil
+}
+
+func (h *NewsletterHandler) toolPostNewsletterText() mcp.Tool {
+	return mcp.NewTool(
+		"whatsapp_newsletter_post_text",
+		mcp.WithDescription("Post a text update to a channel (newsletter) you administer."),
+		mcp.WithTitleAnnotation("Post Newsletter Text"),
+		mcp.WithReadOnlyHintAnnotation(false),
+		mcp.WithDestructiveHintAnnotation(false),
+		mcp.WithIdempotentHintAnnotation(false),
+		newsletterIDOption(),
+		mcp.WithString("message",
+			mcp.Description("Text to post."),
+			mcp.Required(),
+		),
+	)
+}
+
+func (h *NewsletterHandler) handlePostNewsletterText(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	newsletterID, err := request.RequireString("newsletter_id")
+	if err != nil {
+		return nil, err
+	}
+	message, err := request.RequireString("message")
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := h.newsletterService.PostText(ctx, domainNewsletter.PostTextRequest{
+		NewsletterID: newsletterID,
+		Message:      message,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return mcp.NewToolResultStructured(resp, resp.Status), nil
+}
+
+func (h *NewsletterHandler) toolPostNewsletterImage() mcp.Tool {
+	return mcp.NewTool(
+		"whatsapp_newsletter_post_image",
+		mcp.WithDescription("Post an image update from a URL to a channel (newsletter) you administer."),
+		mcp.WithTitleAnnotation("Post Newsletter Image"),
+		mcp.WithReadOnlyHintAnnotation(false),
+		mcp.WithDestructiveHintAnnotation(false),
+		mcp.WithIdempotentHintAnnotation(false),
+		newsletterIDOption(),
+		mcp.WithString("image_url",
+			mcp.Description("URL of the image to post."),
+			mcp.Required(),
+		),
+		mcp.WithString("caption",
+			mcp.Description("Caption for the image."),
+		),
+	)
+}
+
+func (h *NewsletterHandler) handlePostNewsletterImage(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	newsletterID, err := request.RequireString("newsletter_id")
+	if err != nil {
+		return nil, err
+	}
+	imageURL, err := request.RequireString("image_url")
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := h.newsletterService.PostImage(ctx, domainNewsletter.PostImageRequest{
+		NewsletterID: newsletterID,
+		Caption:      request.GetString("caption", ""),
+		ImageURL:     &imageURL,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return mcp.NewToolResultStructured(resp, resp.Status), nil
+}
+
+func (h *NewsletterHandler) toolGetNewsletterMessages() mcp.Tool {
+	return mcp.NewTool(
+		"whatsapp_newsletter_messages",
+		mcp.WithDescription("Fetch recent posts of a channel (newsletter) with view and reaction counts."),
+		mcp.WithTitleAnnotation("Get Newsletter Messages"),
+		mcp.WithReadOnlyHintAnnotation(true),
+		mcp.WithDestructiveHintAnnotation(false),
+		mcp.WithIdempotentHintAnnotation(true),
+		newsletterIDOption(),
+		mcp.WithNumber("count",
+			mcp.Description("Number of posts to fetch (default 20, max 100)."),
+			mcp.DefaultNumber(20),
+		),
+		mcp.WithNumber("before",
+			mcp.Description("Only fetch posts older than this server ID, for pagination."),
+		),
+	)
+}
+
+func (h *NewsletterHandler) handleGetNewsletterMessages(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	newsletterID, err := request.RequireString("newsletter_id")
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := h.newsletterService.GetMessages(ctx, domainNewsletter.GetMessagesRequest{
+		NewsletterID: newsletterID,
+		Count:        request.GetInt("count", 20),
+		Before:       request.GetInt("before", 0),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return mcp.NewToolResultStructured(resp, fmt.Sprintf("Retrieved %d newsletter posts", len(resp.Data))), nil
+}
@@ -32,6 +32,7 @@ func (h *QueryHandler) AddQueryTools(mcpServer *server.MCPServer) {
 	mcpServer.AddTool(h.toolListContacts(), h.handleListContacts)
 	mcpServer.AddTool(h.toolListChats(), h.handleListChats)
 	mcpServer.AddTool(h.toolGetChatMessages(), h.handleGetChatMessages)
+	mcpServer.AddTool(h.toolGetMessageThread(), h.handleGetMessageThread)
 	mcpServer.AddTool(h.toolDownloadMedia(), h.handleDownloadMedia)
 }
 
@@ -79,6 +80,21 @@ func (h *QueryHandler) toolListChats() mcp.Tool {
 			mcp.Description("If true, return only chats that contain media messages."),
 			mcp.DefaultBool(false),
 		),
+		mcp.WithBoolean("pinned",
+			mcp.Description("If provided, return only pinned (true) or unpinned (false) chats."),
+		),
+		mcp.WithBoolean("archived",
+			mcp.Description("If provided, return only archived (true) or unarchived (false) chats."),
+		),
+		mcp.WithBoolean("muted",
+			mcp.Description("If provided, return only muted (true) or unmuted (false) chats."),
+		),
+		mcp.WithBoolean("unread",
+			mcp.Description("If provided, return only chats marked unread (true) or read (false)."),
+		),
+		mcp.WithString("label_id",
+			mcp.Description("Return only chats with this WhatsApp Business label, see whatsapp_list_labels."),
+		),
 	)
 }
 
@@ -100,6 +116,21 @@ func (h *QueryHandler) handleListChats(ctx context.Context, request mcp.CallTool
 		Offset:   request.GetInt("offset", 0),
 		Search:   request.GetString("search", ""),
 		HasMedia: hasMedia,
+		LabelID:  request.GetString("label_id", ""),
+	}
+
+	var err error
+	if req.Pinned, err = optionalBool(args, "pinned"); err != nil {
+		return nil, err
+	}
+	if req.Archived, err = optionalBool(args, "archived"); err != nil {
+		return nil, err
+	}
+	if req.Muted, err = optionalBool(args, "muted"); err != nil {
+		return nil, err
+	}
+	if req.Unread, err = optionalBool(args, "unread"); err != nil {
+		return nil, err
 	}
 
 	resp, err := h.chatService.ListChats(ctx, req)
@@ -152,6 +183,10 @@ func (h *QueryHandler) toolGetChatMessages() mcp.Tool {
 		mcp.WithString("search",
 			mcp.Description("Full-text search within the chat history (case-insensitive)."),
 		),
+		mcp.WithBoolean("include_edits",
+			mcp.Description("If true, include every revision of edited messages."),
+			mcp.DefaultBool(false),
+		),
 	)
 }
 
@@ -197,15 +232,27 @@ func (h *QueryHandler) handleGetChatMessages(ctx context.Context, request mcp.Ca
 		}
 	}
 
+	includeEdits := false
+	if args != nil {
+		if value, ok := args["include_edits"]; ok {
+			parsed, err := toBool(value)
+			if err != nil {
+				return nil, err
+			}
+			includeEdits = parsed
+		}
+	}
+
 	req := domainChat.GetChatMessagesRequest{
-		ChatJID:   chatJID,
-		Limit:     request.GetInt("limit", 50),
-		Offset:    request.GetInt("offset", 0),
-		StartTime: startTimePtr,
-		EndTime:   endTimePtr,
-		MediaOnly: mediaOnly,
-		IsFromMe:  isFromMePtr,
-		Search:    request.GetString("search", ""),
+		ChatJID:      chatJID,
+		Limit:        request.GetInt("limit", 50),
+		Offset:       request.GetInt("offset", 0),
+		StartTime:    startTimePtr,
+		EndTime:      endTimePtr,
+		MediaOnly:    mediaOnly,
+		IsFromMe:     isFromMePtr,
+		Search:       request.GetString("search", ""),
+		IncludeEdits: includeEdits,
 	}
 
 	resp, err := h.chatService.GetChatMessages(ctx, req)
@@ -221,6 +268,42 @@ func (h *QueryHandler) handleGetChatMessages(ctx context.Context, request mcp.Ca
 	return mcp.NewToolResultStructured(resp, fallback), nil
 }
 
+func (h *QueryHandler) toolGetMessageThread() mcp.Tool {
+	return mcp.NewTool(
+		"whatsapp_get_message_thread",
+		mcp.WithDescription("Fetch the reply chain of a message from its root, followed by every reply to it."),
+		mcp.WithTitleAnnotation("Get Message Thread"),
+		mcp.WithReadOnlyHintAnnotation(true),
+		mcp.WithDestructiveHintAnnotation(false),
+		mcp.WithIdempotentHintAnnotation(true),
+		mcp.WithString("message_id",
+			mcp.Description("The WhatsApp message ID to get the thread of."),
+			mcp.Required(),
+		),
+		mcp.WithString("chat_jid",
+			mcp.Description("Optional chat JID the message belongs to (e.g., 628123456789@s.whatsapp.net or group@g.us)."),
+		),
+	)
+}
+
+func (h *QueryHandler) handleGetMessageThread(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	messageID, err := request.RequireString("message_id")
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := h.chatService.GetMessageThread(ctx, domainChat.GetMessageThreadRequest{
+		MessageID: strings.TrimSpace(messageID),
+		ChatJID:   strings.TrimSpace(request.GetString("chat_jid", "")),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	fallback := fmt.Sprintf("Retrieved %d messages of the thread of %s", len(resp.Data), messageID)
+	return mcp.NewToolResultStructured(resp, fallback), nil
+}
+
 func (h *QueryHandler) toolDownloadMedia() mcp.Tool {
 	return mcp.NewTool(
 		"whatsapp_download_message_media",
@@ -285,3 +368,16 @@ func toBool(value any) (bool, error) {
 		return false, fmt.Errorf("unsupported boolean value type %T", value)
 	}
 }
+
+// optionalBool reads a boolean argument, returning nil when it is not set
+func optionalBool(args map[string]any, key string) (*bool, error) {
+	value, ok := args[key]
+	if !ok {
+		return nil, nil
+	}
+	parsed, err := toBool(value)
+	if err != nil {
+		return nil, err
+	}
+	return &parsed, nil
+}
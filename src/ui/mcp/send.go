@@ -43,6 +43,9 @@ func (s *SendHandler) toolSendText() mcp.Tool {
 		mcp.WithBoolean("is_forwarded",
 			mcp.Description("Whether this message is being forwarded (default: false)"),
 		),
+		mcp.WithString("idempotency_key",
+			mcp.Description("Unique key of this send, retries with the same key return the first result instead of sending again (optional)"),
+		),
 		mcp.WithString("reply_message_id",
 			mcp.Description("Message ID to reply to (optional)"),
 		),
@@ -67,6 +70,8 @@ func (s *SendHandler) handleSendText(ctx context.Context, request mcp.CallToolRe
 		isForwarded = false
 	}
 
+	idempotencyKey, _ := request.GetArguments()["idempotency_key"].(string)
+
 	replyMessageId, ok := request.GetArguments()["reply_message_id"].(string)
 	if !ok {
 		replyMessageId = ""
@@ -74,8 +79,9 @@ func (s *SendHandler) handleSendText(ctx context.Context, request mcp.CallToolRe
 
 	res, err := s.sendService.SendText(ctx, domainSend.MessageRequest{
 		BaseRequest: domainSend.BaseRequest{
-			Phone:       phone,
-			IsForwarded: isForwarded,
+			Phone:          phone,
+			IsForwarded:    isForwarded,
+			IdempotencyKey: idempotencyKey,
 		},
 		Message:        message,
 		ReplyMessageID: &replyMessageId,
@@ -106,6 +112,9 @@ func (s *SendHandler) toolSendContact() mcp.Tool {
 		mcp.WithBoolean("is_forwarded",
 			mcp.Description("Whether this message is being forwarded (default: false)"),
 		),
+		mcp.WithString("idempotency_key",
+			mcp.Description("Unique key of this send, retries with the same key return the first result instead of sending again (optional)"),
+		),
 	)
 
 	return sendContactTool
@@ -132,10 +141,13 @@ func (s *SendHandler) handleSendContact(ctx context.Context, request mcp.CallToo
 		isForwarded = false
 	}
 
+	idempotencyKey, _ := request.GetArguments()["idempotency_key"].(string)
+
 	res, err := s.sendService.SendContact(ctx, domainSend.ContactRequest{
 		BaseRequest: domainSend.BaseRequest{
-			Phone:       phone,
-			IsForwarded: isForwarded,
+			Phone:          phone,
+			IsForwarded:    isForwarded,
+			IdempotencyKey: idempotencyKey,
 		},
 		ContactName:  contactName,
 		ContactPhone: contactPhone,
@@ -166,6 +178,9 @@ func (s *SendHandler) toolSendLink() mcp.Tool {
 		mcp.WithBoolean("is_forwarded",
 			mcp.Description("Whether this message is being forwarded (default: false)"),
 		),
+		mcp.WithString("idempotency_key",
+			mcp.Description("Unique key of this send, retries with the same key return the first result instead of sending again (optional)"),
+		),
 	)
 
 	return sendLinkTool
@@ -192,10 +207,13 @@ func (s *SendHandler) handleSendLink(ctx context.Context, request mcp.CallToolRe
 		isForwarded = false
 	}
 
+	idempotencyKey, _ := request.GetArguments()["idempotency_key"].(string)
+
 	res, err := s.sendService.SendLink(ctx, domainSend.LinkRequest{
 		BaseRequest: domainSend.BaseRequest{
-			Phone:       phone,
-			IsForwarded: isForwarded,
+			Phone:          phone,
+			IsForwarded:    isForwarded,
+			IdempotencyKey: idempotencyKey,
 		},
 		Link:    link,
 		Caption: caption,
@@ -226,6 +244,9 @@ func (s *SendHandler) toolSendLocation() mcp.Tool {
 		mcp.WithBoolean("is_forwarded",
 			mcp.Description("Whether this message is being forwarded (default: false)"),
 		),
+		mcp.WithString("idempotency_key",
+			mcp.Description("Unique key of this send, retries with the same key return the first result instead of sending again (optional)"),
+		),
 	)
 
 	return sendLocationTool
@@ -252,10 +273,13 @@ func (s *SendHandler) handleSendLocation(ctx context.Context, request mcp.CallTo
 		isForwarded = false
 	}
 
+	idempotencyKey, _ := request.GetArguments()["idempotency_key"].(string)
+
 	res, err := s.sendService.SendLocation(ctx, domainSend.LocationRequest{
 		BaseRequest: domainSend.BaseRequest{
-			Phone:       phone,
-			IsForwarded: isForwarded,
+			Phone:          phone,
+			IsForwarded:    isForwarded,
+			IdempotencyKey: idempotencyKey,
 		},
 		Latitude:  latitude,
 		Longitude: longitude,
@@ -290,6 +314,9 @@ func (s *SendHandler) toolSendImage() mcp.Tool {
 		mcp.WithBoolean("is_forwarded",
 			mcp.Description("Whether this message is being forwarded (default: false)"),
 		),
+		mcp.WithString("idempotency_key",
+			mcp.Description("Unique key of this send, retries with the same key return the first result instead of sending again (optional)"),
+		),
 	)
 
 	return sendImageTool
@@ -326,11 +353,14 @@ func (s *SendHandler) handleSendImage(ctx context.Context, request mcp.CallToolR
 		isForwarded = false
 	}
 
+	idempotencyKey, _ := request.GetArguments()["idempotency_key"].(string)
+
 	// Create image request
 	imageRequest := domainSend.ImageRequest{
 		BaseRequest: domainSend.BaseRequest{
-			Phone:       phone,
-			IsForwarded: isForwarded,
+			Phone:          phone,
+			IsForwarded:    isForwarded,
+			IdempotencyKey: idempotencyKey,
 		},
 		Caption:  caption,
 		ViewOnce: viewOnce,
@@ -361,6 +391,9 @@ func (s *SendHandler) toolSendSticker() mcp.Tool {
 		mcp.WithBoolean("is_forwarded",
 			mcp.Description("Whether this is a forwarded sticker"),
 		),
+		mcp.WithString("idempotency_key",
+			mcp.Description("Unique key of this send, retries with the same key return the first result instead of sending again (optional)"),
+		),
 	)
 
 	return sendStickerTool
@@ -382,10 +415,13 @@ func (s *SendHandler) handleSendSticker(ctx context.Context, request mcp.CallToo
 		isForwarded = val
 	}
 
+	idempotencyKey, _ := request.GetArguments()["idempotency_key"].(string)
+
 	stickerRequest := domainSend.StickerRequest{
 		BaseRequest: domainSend.BaseRequest{
-			Phone:       phone,
-			IsForwarded: isForwarded,
+			Phone:          phone,
+			IsForwarded:    isForwarded,
+			IdempotencyKey: idempotencyKey,
 		},
 		StickerURL: &stickerURL,
 	}
@@ -0,0 +1,198 @@
+package mcp
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	domainStatus "github.com/aldinokemal/go-whatsapp-web-multidevice/domains/status"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+type StatusHandler struct {
+	statusService domainStatus.IStatusUsecase
+}
+
+func InitMcpStatus(statusService domainStatus.IStatusUsecase) *StatusHandler {
+	return &StatusHandler{statusService: statusService}
+}
+
+func (h *StatusHandler) AddStatusTools(mcpServer *server.MCPServer) {
+	mcpServer.AddTool(h.toolPostTextStatus(), h.handlePostTextStatus)
+	mcpServer.AddTool(h.toolPostImageStatus(), h.handlePostImageStatus)
+	mcpServer.AddTool(h.toolPostVideoStatus(), h.handlePostVideoStatus)
+	mcpServer.AddTool(h.toolListStatuses(), h.handleListStatuses)
+}
+
+func statusAudienceOption() mcp.ToolOption {
+	return mcp.WithString("audience",
+		mcp.Description("Audience: contacts, contacts-except or only-share-with. Must match the account's default status audience; omit to use it as-is."),
+		mcp.Enum(domainStatus.AudienceContacts, domainStatus.AudienceContactsExcept, domainStatus.AudienceOnlyShareWith),
+	)
+}
+
+func (h *StatusHandler) toolPostTextStatus() mcp.Tool {
+	return mcp.NewTool(
+		"whatsapp_status_post_text",
+		mcp.WithDescription("Post a text status (story) update."),
+		mcp.WithTitleAnnotation("Post Text Status"),
+		mcp.WithReadOnlyHintAnnotation(false),
+		mcp.WithDestructiveHintAnnotation(false),
+		mcp.WithIdempotentHintAnnotation(false),
+		mcp.WithString("message",
+			mcp.Description("Status text."),
+			mcp.Required(),
+		),
+		mcp.WithString("background_color",
+			mcp.Description("Background color as #RRGGBB."),
+		),
+		statusAudienceOption(),
+	)
+}
+
+func (h *StatusHandler) handlePostTextStatus(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	message, err := request.RequireString("message")
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := h.statusService.PostText(ctx, domainStatus.TextStatusRequest{
+		BaseStatusRequest: domainStatus.BaseStatusRequest{Audience: strings.TrimSpace(request.GetString("audience", ""))},
+		Message:           message,
+		BackgroundColor:   strings.TrimSpace(request.GetString("background_color", "")),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return mcp.NewToolResultStructured(resp, resp.Status), nil
+}
+
+func (h *StatusHandler) toolPostImageStatus() mcp.Tool {
+	return mcp.NewTool(
+		"whatsapp_status_post_image",
+		mcp.WithDescription("Post an image status (story) update from a URL."),
+		mcp.WithTitleAnnotation("Post Image Status"),
+		mcp.WithReadOnlyHintAnnotation(false),
+		mcp.WithDestructiveHintAnnotation(false),
+		mcp.WithIdempotentHintAnnotation(false),
+		mcp.WithString("image_url",
+			mcp.Description("URL of the image to post."),
+			mcp.Required(),
+		),
+		mcp.WithString("caption",
+			mcp.Description("Caption for the image."),
+		),
+		statusAudienceOption(),
+	)
+}
+
+func (h *StatusHandler) handlePostImageStatus(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	imageURL, err := request.RequireString("image_url")
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := h.statusService.PostImage(ctx, domainStatus.ImageStatusRequest{
+		BaseStatusRequest: domainStatus.BaseStatusRequest{Audience: strings.TrimSpace(request.GetString("audience", ""))},
+		Caption:           request.GetString("caption", ""),
+		ImageURL:          &imageURL,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return mcp.NewToolResultStructured(resp, resp.Status), nil
+}
+
+func (h *StatusHandler) toolPostVideoStatus() mcp.Tool {
+	return mcp.NewTool(
+		"whatsapp_status_post_video",
+		mcp.WithDescription("Post a video status (story) update from a URL."),
+		mcp.WithTitleAnnotation("Post Video Status"),
+		mcp.WithReadOnlyHintAnnotation(false),
+		mcp.WithDestructiveHintAnnotation(false),
+		mcp.WithIdempotentHintAnnotation(false),
+		mcp.WithString("video_url",
+			mcp.Description("URL of the video to post."),
+			mcp.Required(),
+		),
+		mcp.WithString("caption",
+			mcp.Description("Caption for the video."),
+		),
+		statusAudienceOption(),
+	)
+}
+
+func (h *StatusHandler) handlePostVideoStatus(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	videoURL, err := request.RequireString("video_url")
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := h.statusService.PostVideo(ctx, domainStatus.VideoStatusRequest{
+		BaseStatusRequest: domainStatus.BaseStatusRequest{Audience: strings.TrimSpace(request.GetString("audience", ""))},
+		Caption:           request.GetString("caption", ""),
+		VideoURL:          &videoURL,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return mcp.NewToolResultStructured(resp, resp.Status), nil
+}
+
+func (h *StatusHandler) toolListStatuses() mcp.Tool {
+	return mcp.NewTool(
+		"whatsapp_status_list",
+		mcp.WithDescription("List stored status (story) updates from contacts, newest first."),
+		mcp.WithTitleAnnotation("List Statuses"),
+		mcp.WithReadOnlyHintAnnotation(true),
+		mcp.WithDestructiveHintAnnotation(false),
+		mcp.WithIdempotentHintAnnotation(true),
+		mcp.WithString("sender",
+			mcp.Description("Only return statuses from this phone number or JID."),
+		),
+		mcp.WithBoolean("include_expired",
+			mcp.Description("If true, include statuses older than 24 hours."),
+			mcp.DefaultBool(false),
+		),
+		mcp.WithNumber("limit",
+			mcp.Description("Maximum number of statuses to return (default 50, max 100)."),
+			mcp.DefaultNumber(50),
+		),
+		mcp.WithNumber("offset",
+			mcp.Description("Number of statuses to skip from the start (default 0)."),
+			mcp.DefaultNumber(0),
+		),
+	)
+}
+
+func (h *StatusHandler) handleListStatuses(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	var includeExpired bool
+	if args := request.GetArguments(); args != nil {
+		if value, ok := args["include_expired"]; ok {
+			parsed, err := toBool(value)
+			if err != nil {
+				return nil, err
+			}
+			includeExpired = parsed
+		}
+	}
+
+	req := domainStatus.ListStatusesRequest{
+		Sender:         strings.TrimSpace(request.GetString("sender", "")),
+		IncludeExpired: includeExpired,
+		Limit:          request.GetInt("limit", 50),
+		Offset:         request.GetInt("offset", 0),
+	}
+
+	resp, err := h.statusService.ListStatuses(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	fallback := fmt.Sprintf("Retrieved %d statuses (offset %d, limit %d)", len(resp.Data), req.Offset, req.Limit)
+	return mcp.NewToolResultStructured(resp, fallback), nil
+}
@@ -0,0 +1,105 @@
+package mcp
+
+import (
+	"context"
+	"fmt"
+
+	domainUser "github.com/aldinokemal/go-whatsapp-web-multidevice/domains/user"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+type UserHandler struct {
+	userService domainUser.IUserUsecase
+}
+
+func InitMcpUser(userService domainUser.IUserUsecase) *UserHandler {
+	return &UserHandler{userService: userService}
+}
+
+func (h *UserHandler) AddUserTools(mcpServer *server.MCPServer) {
+	mcpServer.AddTool(h.toolBlockContact(), h.handleBlockContact)
+	mcpServer.AddTool(h.toolUnblockContact(), h.handleUnblockContact)
+	mcpServer.AddTool(h.toolListBlocked(), h.handleListBlocked)
+}
+
+func (h *UserHandler) toolBlockContact() mcp.Tool {
+	return mcp.NewTool(
+		"whatsapp_block_contact",
+		mcp.WithDescription("Block a contact so they can no longer call or message this account."),
+		mcp.WithTitleAnnotation("Block Contact"),
+		mcp.WithReadOnlyHintAnnotation(false),
+		mcp.WithDestructiveHintAnnotation(false),
+		mcp.WithIdempotentHintAnnotation(true),
+		mcp.WithString("phone",
+			mcp.Description("Phone number or JID of the contact, e.g. 6289685028129."),
+			mcp.Required(),
+		),
+	)
+}
+
+func (h *UserHandler) handleBlockContact(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	phone, err := request.RequireString("phone")
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := h.userService.Block(ctx, domainUser.BlockRequest{Phone: phone})
+	if err != nil {
+		return nil, err
+	}
+
+	fallback := fmt.Sprintf("Blocked %s, %d contacts are blocked", phone, len(resp.Data))
+	return mcp.NewToolResultStructured(resp, fallback), nil
+}
+
+func (h *UserHandler) toolUnblockContact() mcp.Tool {
+	return mcp.NewTool(
+		"whatsapp_unblock_contact",
+		mcp.WithDescription("Unblock a previously blocked contact."),
+		mcp.WithTitleAnnotation("Unblock Contact"),
+		mcp.WithReadOnlyHintAnnotation(false),
+		mcp.WithDestructiveHintAnnotation(false),
+		mcp.WithIdempotentHintAnnotation(true),
+		mcp.WithString("phone",
+			mcp.Description("Phone number or JID of the contact, e.g. 6289685028129."),
+			mcp.Required(),
+		),
+	)
+}
+
+func (h *UserHandler) handleUnblockContact(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	phone, err := request.RequireString("phone")
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := h.userService.Unblock(ctx, domainUser.BlockRequest{Phone: phone})
+	if err != nil {
+		return nil, err
+	}
+
+	fallback := fmt.Sprintf("Unblocked %s, %d contacts are blocked", phone, len(resp.Data))
+	return mcp.NewToolResultStructured(resp, fallback), nil
+}
+
+func (h *UserHandler) toolListBlocked() mcp.Tool {
+	return mcp.NewTool(
+		"whatsapp_list_blocked",
+		mcp.WithDescription("List the contacts blocked by this account."),
+		mcp.WithTitleAnnotation("List Blocked Contacts"),
+		mcp.WithReadOnlyHintAnnotation(true),
+		mcp.WithDestructiveHintAnnotation(false),
+		mcp.WithIdempotentHintAnnotation(true),
+	)
+}
+
+func (h *UserHandler) handleListBlocked(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	resp, err := h.userService.Blocklist(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	fallback := fmt.Sprintf("Found %d blocked contacts", len(resp.Data))
+	return mcp.NewToolResultStructured(resp, fallback), nil
+}
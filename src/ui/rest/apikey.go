@@ -0,0 +1,63 @@
+package rest
+
+import (
+	"fmt"
+
+	domainAPIKey "github.com/aldinokemal/go-whatsapp-web-multidevice/domains/apikey"
+	"github.com/aldinokemal/go-whatsapp-web-multidevice/pkg/utils"
+	"github.com/gofiber/fiber/v2"
+)
+
+type APIKey struct {
+	Service domainAPIKey.IAPIKeyUsecase
+}
+
+func InitRestAPIKey(app fiber.Router, service domainAPIKey.IAPIKeyUsecase) APIKey {
+	rest := APIKey{Service: service}
+	app.Get("/api-keys", rest.ListKeys)
+	app.Post("/api-keys", rest.CreateKey)
+	app.Post("/api-keys/:id/revoke", rest.RevokeKey)
+	return rest
+}
+
+func (controller *APIKey) ListKeys(c *fiber.Ctx) error {
+	response, err := controller.Service.ListKeys(c.UserContext())
+	utils.PanicIfNeeded(err)
+
+	return c.JSON(utils.ResponseData{
+		Status:  200,
+		Code:    "SUCCESS",
+		Message: "Success get API keys",
+		Results: response,
+	})
+}
+
+func (controller *APIKey) CreateKey(c *fiber.Ctx) error {
+	var request domainAPIKey.CreateKeyRequest
+	err := c.BodyParser(&request)
+	utils.PanicIfNeeded(err)
+
+	response, err := controller.Service.CreateKey(c.UserContext(), request)
+	utils.PanicIfNeeded(err)
+
+	return c.JSON(utils.ResponseData{
+		Status:  200,
+		Code:    "SUCCESS",
+		Message: "API key created, store the secret now as it will not be shown again",
+		Results: response,
+	})
+}
+
+func (controller *APIKey) RevokeKey(c *fiber.Ctx) error {
+	var request domainAPIKey.RevokeKeyRequest
+	request.ID = c.Params("id")
+
+	err := controller.Service.RevokeKey(c.UserContext(), request)
+	utils.PanicIfNeeded(err)
+
+	return c.JSON(utils.ResponseData{
+		Status:  200,
+		Code:    "SUCCESS",
+		Message: fmt.Sprintf("API key %s revoked", request.ID),
+	})
+}
@@ -0,0 +1,83 @@
+package rest
+
+import (
+	"bytes"
+	"encoding/csv"
+	"fmt"
+	"strconv"
+	"time"
+
+	domainAudit "github.com/aldinokemal/go-whatsapp-web-multidevice/domains/audit"
+	"github.com/aldinokemal/go-whatsapp-web-multidevice/pkg/utils"
+	"github.com/gofiber/fiber/v2"
+)
+
+type Audit struct {
+	Service domainAudit.IAuditUsecase
+}
+
+func InitRestAudit(app fiber.Router, service domainAudit.IAuditUsecase) Audit {
+	rest := Audit{Service: service}
+	app.Get("/audit", rest.List)
+	app.Get("/audit/export", rest.Export)
+	return rest
+}
+
+func (controller *Audit) List(c *fiber.Ctx) error {
+	var request domainAudit.ListRequest
+	err := c.QueryParser(&request)
+	utils.PanicIfNeeded(err)
+
+	response, err := controller.Service.List(c.UserContext(), request)
+	utils.PanicIfNeeded(err)
+
+	return c.JSON(utils.ResponseData{
+		Status:  200,
+		Code:    "SUCCESS",
+		Message: "Success get audit log",
+		Results: response,
+	})
+}
+
+func (controller *Audit) Export(c *fiber.Ctx) error {
+	var request domainAudit.ListRequest
+	err := c.QueryParser(&request)
+	utils.PanicIfNeeded(err)
+
+	if request.Limit == 0 {
+		request.Limit = 1000
+	}
+
+	response, err := controller.Service.List(c.UserContext(), request)
+	utils.PanicIfNeeded(err)
+
+	var buffer bytes.Buffer
+	writer := csv.NewWriter(&buffer)
+
+	utils.PanicIfNeeded(writer.Write([]string{"id", "created_at", "actor", "endpoint", "action", "target_jid", "message_id", "details", "result", "error"}))
+
+	for _, entry := range response.Data {
+		record := []string{
+			strconv.FormatInt(entry.ID, 10),
+			entry.CreatedAt.Format(time.RFC3339),
+			entry.Actor,
+			entry.Endpoint,
+			entry.Action,
+			entry.TargetJID,
+			entry.MessageID,
+			entry.Details,
+			entry.Result,
+			entry.Error,
+		}
+
+		utils.PanicIfNeeded(writer.Write(record))
+	}
+
+	writer.Flush()
+	utils.PanicIfNeeded(writer.Error())
+
+	c.Type("text/csv; charset=utf-8")
+	c.Attachment(fmt.Sprintf("audit-%s.csv", time.Now().Format("20060102-150405")))
+
+	return c.Send(buffer.Bytes())
+}
@@ -17,6 +17,16 @@ func InitRestChat(app fiber.Router, service domainChat.IChatUsecase) Chat {
 	app.Get("/chats", rest.ListChats)
 	app.Get("/chat/:chat_jid/messages", rest.GetChatMessages)
 	app.Post("/chat/:chat_jid/pin", rest.PinChat)
+	app.Post("/chat/:chat_jid/archive", rest.ArchiveChat)
+	app.Post("/chat/:chat_jid/mute", rest.MuteChat)
+	app.Post("/chat/:chat_jid/read", rest.MarkChatRead)
+	app.Post("/chat/:chat_jid/disappearing", rest.SetDisappearingTimer)
+	app.Post("/chat/:chat_jid/history", rest.RequestHistory)
+	app.Post("/chat/:chat_jid/clear", rest.ClearChat)
+	app.Post("/chat/:chat_jid/delete", rest.DeleteChat)
+
+	// Reply chains are read from chat storage like the chat messages
+	app.Get("/message/:message_id/thread", rest.GetMessageThread)
 
 	return rest
 }
@@ -29,6 +39,11 @@ func (controller *Chat) ListChats(c *fiber.Ctx) error {
 	request.Offset = c.QueryInt("offset", 0)
 	request.Search = c.Query("search", "")
 	request.HasMedia = c.QueryBool("has_media", false)
+	request.Pinned = queryBoolPtr(c, "pinned")
+	request.Archived = queryBoolPtr(c, "archived")
+	request.Muted = queryBoolPtr(c, "muted")
+	request.Unread = queryBoolPtr(c, "unread")
+	request.LabelID = c.Query("label_id")
 
 	response, err := controller.Service.ListChats(c.UserContext(), request)
 	utils.PanicIfNeeded(err)
@@ -52,6 +67,7 @@ func (controller *Chat) GetChatMessages(c *fiber.Ctx) error {
 	request.Offset = c.QueryInt("offset", 0)
 	request.MediaOnly = c.QueryBool("media_only", false)
 	request.Search = c.Query("search", "")
+	request.IncludeEdits = c.QueryBool("include_edits", false)
 
 	// Parse time filters
 	if startTime := c.Query("start_time"); startTime != "" {
@@ -78,6 +94,23 @@ func (controller *Chat) GetChatMessages(c *fiber.Ctx) error {
 	})
 }
 
+func (controller *Chat) GetMessageThread(c *fiber.Ctx) error {
+	var request domainChat.GetMessageThreadRequest
+
+	request.MessageID = c.Params("message_id")
+	request.ChatJID = c.Query("chat_jid")
+
+	response, err := controller.Service.GetMessageThread(c.UserContext(), request)
+	utils.PanicIfNeeded(err)
+
+	return c.JSON(utils.ResponseData{
+		Status:  200,
+		Code:    "SUCCESS",
+		Message: "Success get message thread",
+		Results: response,
+	})
+}
+
 func (controller *Chat) PinChat(c *fiber.Ctx) error {
 	var request domainChat.PinChatRequest
 
@@ -104,3 +137,195 @@ func (controller *Chat) PinChat(c *fiber.Ctx) error {
 		Results: response,
 	})
 }
+
+func (controller *Chat) ArchiveChat(c *fiber.Ctx) error {
+	var request domainChat.ArchiveChatRequest
+
+	// Parse path parameter
+	request.ChatJID = c.Params("chat_jid")
+
+	// Parse JSON body
+	if err := c.BodyParser(&request); err != nil {
+		return c.Status(400).JSON(utils.ResponseData{
+			Status:  400,
+			Code:    "BAD_REQUEST",
+			Message: "Invalid request body",
+			Results: nil,
+		})
+	}
+
+	response, err := controller.Service.ArchiveChat(c.UserContext(), request)
+	utils.PanicIfNeeded(err)
+
+	return c.JSON(utils.ResponseData{
+		Status:  200,
+		Code:    "SUCCESS",
+		Message: response.Message,
+		Results: response,
+	})
+}
+
+func (controller *Chat) MuteChat(c *fiber.Ctx) error {
+	var request domainChat.MuteChatRequest
+
+	// Parse path parameter
+	request.ChatJID = c.Params("chat_jid")
+
+	// Parse JSON body
+	if err := c.BodyParser(&request); err != nil {
+		return c.Status(400).JSON(utils.ResponseData{
+			Status:  400,
+			Code:    "BAD_REQUEST",
+			Message: "Invalid request body",
+			Results: nil,
+		})
+	}
+
+	response, err := controller.Service.MuteChat(c.UserContext(), request)
+	utils.PanicIfNeeded(err)
+
+	return c.JSON(utils.ResponseData{
+		Status:  200,
+		Code:    "SUCCESS",
+		Message: response.Message,
+		Results: response,
+	})
+}
+
+func (controller *Chat) SetDisappearingTimer(c *fiber.Ctx) error {
+	var request domainChat.SetDisappearingTimerRequest
+
+	// Parse path parameter
+	request.ChatJID = c.Params("chat_jid")
+
+	// Parse JSON body
+	if err := c.BodyParser(&request); err != nil {
+		return c.Status(400).JSON(utils.ResponseData{
+			Status:  400,
+			Code:    "BAD_REQUEST",
+			Message: "Invalid request body",
+			Results: nil,
+		})
+	}
+
+	response, err := controller.Service.SetDisappearingTimer(c.UserContext(), request)
+	utils.PanicIfNeeded(err)
+
+	return c.JSON(utils.ResponseData{
+		Status:  200,
+		Code:    "SUCCESS",
+		Message: response.Message,
+		Results: response,
+	})
+}
+
+func (controller *Chat) RequestHistory(c *fiber.Ctx) error {
+	var request domainChat.RequestHistoryRequest
+
+	// Parse path parameter
+	request.ChatJID = c.Params("chat_jid")
+
+	// The body is optional, the count defaults to 50
+	if len(c.Body()) > 0 {
+		if err := c.BodyParser(&request); err != nil {
+			return c.Status(400).JSON(utils.ResponseData{
+				Status:  400,
+				Code:    "BAD_REQUEST",
+				Message: "Invalid request body",
+				Results: nil,
+			})
+		}
+	}
+
+	response, err := controller.Service.RequestHistory(c.UserContext(), request)
+	utils.PanicIfNeeded(err)
+
+	return c.JSON(utils.ResponseData{
+		Status:  200,
+		Code:    "SUCCESS",
+		Message: response.Message,
+		Results: response,
+	})
+}
+
+func (controller *Chat) MarkChatRead(c *fiber.Ctx) error {
+	var request domainChat.MarkChatReadRequest
+
+	// Parse path parameter
+	request.ChatJID = c.Params("chat_jid")
+
+	// Parse JSON body
+	if err := c.BodyParser(&request); err != nil {
+		return c.Status(400).JSON(utils.ResponseData{
+			Status:  400,
+			Code:    "BAD_REQUEST",
+			Message: "Invalid request body",
+			Results: nil,
+		})
+	}
+
+	response, err := controller.Service.MarkChatRead(c.UserContext(), request)
+	utils.PanicIfNeeded(err)
+
+	return c.JSON(utils.ResponseData{
+		Status:  200,
+		Code:    "SUCCESS",
+		Message: response.Message,
+		Results: response,
+	})
+}
+
+func (controller *Chat) ClearChat(c *fiber.Ctx) error {
+	var request domainChat.ClearChatRequest
+
+	// Parse path parameter
+	request.ChatJID = c.Params("chat_jid")
+
+	// The body is optional, it only carries delete_starred
+	if len(c.Body()) > 0 {
+		if err := c.BodyParser(&request); err != nil {
+			return c.Status(400).JSON(utils.ResponseData{
+				Status:  400,
+				Code:    "BAD_REQUEST",
+				Message: "Invalid request body",
+				Results: nil,
+			})
+		}
+	}
+
+	response, err := controller.Service.ClearChat(c.UserContext(), request)
+	utils.PanicIfNeeded(err)
+
+	return c.JSON(utils.ResponseData{
+		Status:  200,
+		Code:    "SUCCESS",
+		Message: response.Message,
+		Results: response,
+	})
+}
+
+func (controller *Chat) DeleteChat(c *fiber.Ctx) error {
+	var request domainChat.DeleteChatRequest
+
+	// Parse path parameter
+	request.ChatJID = c.Params("chat_jid")
+
+	response, err := controller.Service.DeleteChat(c.UserContext(), request)
+	utils.PanicIfNeeded(err)
+
+	return c.JSON(utils.ResponseData{
+		Status:  200,
+		Code:    "SUCCESS",
+		Message: response.Message,
+		Results: response,
+	})
+}
+
+// queryBoolPtr returns nil when the query parameter is not set, so filters can tell false from absent
+func queryBoolPtr(c *fiber.Ctx, key string) *bool {
+	if c.Query(key) == "" {
+		return nil
+	}
+	value := c.QueryBool(key)
+	return &value
+}
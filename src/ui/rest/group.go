@@ -4,7 +4,9 @@ import (
 	"bytes"
 	"encoding/csv"
 	"fmt"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/sirupsen/logrus"
 
@@ -28,6 +30,9 @@ func InitRestGroup(app fiber.Router, service domainGroup.IGroupUsecase) Group {
 	app.Get("/group/participants", rest.ListParticipants)
 	app.Get("/group/participants/export", rest.ExportParticipants)
 	app.Post("/group/participants", rest.AddParticipants)
+	app.Post("/group/participants/import", rest.ImportParticipants)
+	app.Get("/group/participants/import/:job_id", rest.GetImportParticipantsJob)
+	app.Get("/group/participants/import/:job_id/results", rest.ExportImportParticipantsResults)
 	app.Post("/group/participants/remove", rest.DeleteParticipants)
 	app.Post("/group/participants/promote", rest.PromoteParticipants)
 	app.Post("/group/participants/demote", rest.DemoteParticipants)
@@ -40,6 +45,11 @@ func InitRestGroup(app fiber.Router, service domainGroup.IGroupUsecase) Group {
 	app.Post("/group/announce", rest.SetGroupAnnounce)
 	app.Post("/group/topic", rest.SetGroupTopic)
 	app.Get("/group/invite-link", rest.GetGroupInviteLink)
+	app.Post("/group/community", rest.CreateCommunity)
+	app.Post("/group/community/link", rest.LinkGroup)
+	app.Post("/group/community/unlink", rest.UnlinkGroup)
+	app.Get("/group/community/groups", rest.GetLinkedGroups)
+	app.Post("/group/community/announce", rest.SendCommunityAnnouncement)
 	return rest
 }
 
@@ -191,6 +201,82 @@ func (controller *Group) ExportParticipants(c *fiber.Ctx) error {
 	return c.Send(buffer.Bytes())
 }
 
+func (controller *Group) ImportParticipants(c *fiber.Ctx) error {
+	var request domainGroup.BulkParticipantJobRequest
+	err := c.BodyParser(&request)
+	utils.PanicIfNeeded(err)
+
+	// A missing file is reported by the usecase validation
+	if file, err := c.FormFile("file"); err == nil {
+		request.File = file
+	}
+
+	response, err := controller.Service.StartBulkParticipantJob(c.UserContext(), request)
+	utils.PanicIfNeeded(err)
+
+	return c.JSON(utils.ResponseData{
+		Status:  200,
+		Code:    "SUCCESS",
+		Message: fmt.Sprintf("Bulk participant job %s started", response.JobID),
+		Results: response,
+	})
+}
+
+func (controller *Group) GetImportParticipantsJob(c *fiber.Ctx) error {
+	var request domainGroup.GetBulkParticipantJobRequest
+	request.JobID = c.Params("job_id")
+
+	response, err := controller.Service.GetBulkParticipantJob(c.UserContext(), request)
+	utils.PanicIfNeeded(err)
+
+	return c.JSON(utils.ResponseData{
+		Status:  200,
+		Code:    "SUCCESS",
+		Message: "Success getting bulk participant job",
+		Results: response,
+	})
+}
+
+func (controller *Group) ExportImportParticipantsResults(c *fiber.Ctx) error {
+	var request domainGroup.GetBulkParticipantJobRequest
+	request.JobID = c.Params("job_id")
+
+	rows, err := controller.Service.GetBulkParticipantJobRows(c.UserContext(), request)
+	utils.PanicIfNeeded(err)
+
+	var buffer bytes.Buffer
+	writer := csv.NewWriter(&buffer)
+
+	utils.PanicIfNeeded(writer.Write([]string{"row", "group_id", "phone", "action", "status", "message", "processed_at"}))
+
+	for _, row := range rows {
+		processedAt := ""
+		if row.ProcessedAt != nil {
+			processedAt = row.ProcessedAt.Format(time.RFC3339)
+		}
+
+		record := []string{
+			strconv.Itoa(row.Row),
+			row.GroupID,
+			row.Phone,
+			row.Action,
+			row.Status,
+			row.Message,
+			processedAt,
+		}
+
+		utils.PanicIfNeeded(writer.Write(record))
+	}
+
+	writer.Flush()
+	utils.PanicIfNeeded(writer.Error())
+
+	c.Type("text/csv; charset=utf-8")
+	c.Attachment(fmt.Sprintf("bulk-job-%s-results.csv", request.JobID))
+
+	return c.Send(buffer.Bytes())
+}
+
 func (controller *Group) AddParticipants(c *fiber.Ctx) error {
 	return controller.manageParticipants(c, whatsmeow.ParticipantChangeAdd, "Success add participants")
 }
@@ -443,3 +529,93 @@ func (controller *Group) GetGroupInviteLink(c *fiber.Ctx) error {
 		Results: response,
 	})
 }
+
+func (controller *Group) CreateCommunity(c *fiber.Ctx) error {
+	var request domainGroup.CreateCommunityRequest
+	err := c.BodyParser(&request)
+	utils.PanicIfNeeded(err)
+
+	communityID, err := controller.Service.CreateCommunity(c.UserContext(), request)
+	utils.PanicIfNeeded(err)
+
+	return c.JSON(utils.ResponseData{
+		Status:  200,
+		Code:    "SUCCESS",
+		Message: fmt.Sprintf("Success created community with id %s", communityID),
+		Results: map[string]string{
+			"community_id": communityID,
+		},
+	})
+}
+
+func (controller *Group) LinkGroup(c *fiber.Ctx) error {
+	var request domainGroup.LinkGroupRequest
+	err := c.BodyParser(&request)
+	utils.PanicIfNeeded(err)
+
+	utils.SanitizePhone(&request.CommunityID)
+	utils.SanitizePhone(&request.GroupID)
+
+	err = controller.Service.LinkGroup(c.UserContext(), request)
+	utils.PanicIfNeeded(err)
+
+	return c.JSON(utils.ResponseData{
+		Status:  200,
+		Code:    "SUCCESS",
+		Message: fmt.Sprintf("Success link group %s to community %s", request.GroupID, request.CommunityID),
+	})
+}
+
+func (controller *Group) UnlinkGroup(c *fiber.Ctx) error {
+	var request domainGroup.UnlinkGroupRequest
+	err := c.BodyParser(&request)
+	utils.PanicIfNeeded(err)
+
+	utils.SanitizePhone(&request.CommunityID)
+	utils.SanitizePhone(&request.GroupID)
+
+	err = controller.Service.UnlinkGroup(c.UserContext(), request)
+	utils.PanicIfNeeded(err)
+
+	return c.JSON(utils.ResponseData{
+		Status:  200,
+		Code:    "SUCCESS",
+		Message: fmt.Sprintf("Success unlink group %s from community %s", request.GroupID, request.CommunityID),
+	})
+}
+
+func (controller *Group) GetLinkedGroups(c *fiber.Ctx) error {
+	var request domainGroup.GetLinkedGroupsRequest
+	err := c.QueryParser(&request)
+	utils.PanicIfNeeded(err)
+
+	utils.SanitizePhone(&request.CommunityID)
+
+	response, err := controller.Service.GetLinkedGroups(c.UserContext(), request)
+	utils.PanicIfNeeded(err)
+
+	return c.JSON(utils.ResponseData{
+		Status:  200,
+		Code:    "SUCCESS",
+		Message: "Success get community groups",
+		Results: response,
+	})
+}
+
+func (controller *Group) SendCommunityAnnouncement(c *fiber.Ctx) error {
+	var request domainGroup.CommunityAnnouncementRequest
+	err := c.BodyParser(&request)
+	utils.PanicIfNeeded(err)
+
+	utils.SanitizePhone(&request.CommunityID)
+
+	response, err := controller.Service.SendCommunityAnnouncement(c.UserContext(), request)
+	utils.PanicIfNeeded(err)
+
+	return c.JSON(utils.ResponseData{
+		Status:  200,
+		Code:    "SUCCESS",
+		Message: response.Status,
+		Results: response,
+	})
+}
@@ -0,0 +1,50 @@
+package rest
+
+import (
+	"github.com/aldinokemal/go-whatsapp-web-multidevice/infrastructure/whatsapp"
+	"github.com/aldinokemal/go-whatsapp-web-multidevice/pkg/utils"
+	"github.com/gofiber/fiber/v2"
+)
+
+// InitRestHealth registers the liveness and readiness probes, which are served without authentication
+func InitRestHealth(app fiber.Router) {
+	app.Get("/healthz", Healthz)
+	app.Get("/readyz", Readyz)
+}
+
+// Healthz reports whether the process is alive, which includes a running connection supervisor
+func Healthz(c *fiber.Ctx) error {
+	if !whatsapp.IsSupervisorAlive() {
+		return c.Status(fiber.StatusServiceUnavailable).JSON(utils.ResponseData{
+			Status:  fiber.StatusServiceUnavailable,
+			Code:    "UNHEALTHY",
+			Message: "Connection supervisor is not running",
+		})
+	}
+
+	return c.JSON(utils.ResponseData{
+		Status:  200,
+		Code:    "SUCCESS",
+		Message: "OK",
+	})
+}
+
+// Readyz reports whether the WhatsApp session is connected and logged in, so traffic can be sent
+func Readyz(c *fiber.Ctx) error {
+	status := whatsapp.GetSupervisorStatus()
+	if status.State != whatsapp.StateConnected || !status.IsConnected || !status.IsLoggedIn {
+		return c.Status(fiber.StatusServiceUnavailable).JSON(utils.ResponseData{
+			Status:  fiber.StatusServiceUnavailable,
+			Code:    "NOT_READY",
+			Message: "WhatsApp session is " + string(status.State),
+			Results: status,
+		})
+	}
+
+	return c.JSON(utils.ResponseData{
+		Status:  200,
+		Code:    "SUCCESS",
+		Message: "WhatsApp session is connected",
+		Results: status,
+	})
+}
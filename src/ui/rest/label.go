@@ -0,0 +1,124 @@
+package rest
+
+import (
+	domainLabel "github.com/aldinokemal/go-whatsapp-web-multidevice/domains/label"
+	"github.com/aldinokemal/go-whatsapp-web-multidevice/pkg/utils"
+	"github.com/gofiber/fiber/v2"
+)
+
+type Label struct {
+	Service domainLabel.ILabelUsecase
+}
+
+func InitRestLabel(app fiber.Router, service domainLabel.ILabelUsecase) Label {
+	rest := Label{Service: service}
+	app.Get("/labels", rest.ListLabels)
+	app.Post("/labels", rest.CreateLabel)
+	app.Post("/label/:label_id/update", rest.UpdateLabel)
+	app.Post("/label/:label_id/delete", rest.DeleteLabel)
+	app.Post("/chat/:chat_jid/label", rest.LabelChat)
+	app.Post("/message/:message_id/label", rest.LabelMessage)
+	return rest
+}
+
+func (controller *Label) ListLabels(c *fiber.Ctx) error {
+	response, err := controller.Service.ListLabels(c.UserContext())
+	utils.PanicIfNeeded(err)
+
+	return c.JSON(utils.ResponseData{
+		Status:  200,
+		Code:    "SUCCESS",
+		Message: "Success get labels",
+		Results: response,
+	})
+}
+
+func (controller *Label) CreateLabel(c *fiber.Ctx) error {
+	var request domainLabel.CreateLabelRequest
+	err := c.BodyParser(&request)
+	utils.PanicIfNeeded(err)
+
+	response, err := controller.Service.CreateLabel(c.UserContext(), request)
+	utils.PanicIfNeeded(err)
+
+	return c.JSON(utils.ResponseData{
+		Status:  200,
+		Code:    "SUCCESS",
+		Message: "Label created successfully",
+		Results: response,
+	})
+}
+
+func (controller *Label) UpdateLabel(c *fiber.Ctx) error {
+	var request domainLabel.UpdateLabelRequest
+	err := c.BodyParser(&request)
+	utils.PanicIfNeeded(err)
+
+	request.LabelID = c.Params("label_id")
+
+	response, err := controller.Service.UpdateLabel(c.UserContext(), request)
+	utils.PanicIfNeeded(err)
+
+	return c.JSON(utils.ResponseData{
+		Status:  200,
+		Code:    "SUCCESS",
+		Message: "Label updated successfully",
+		Results: response,
+	})
+}
+
+func (controller *Label) DeleteLabel(c *fiber.Ctx) error {
+	var request domainLabel.DeleteLabelRequest
+	request.LabelID = c.Params("label_id")
+
+	err := controller.Service.DeleteLabel(c.UserContext(), request)
+	utils.PanicIfNeeded(err)
+
+	return c.JSON(utils.ResponseData{
+		Status:  200,
+		Code:    "SUCCESS",
+		Message: "Label deleted successfully",
+		Results: nil,
+	})
+}
+
+func (controller *Label) LabelChat(c *fiber.Ctx) error {
+	var request domainLabel.LabelChatRequest
+	err := c.BodyParser(&request)
+	utils.PanicIfNeeded(err)
+
+	request.ChatJID = c.Params("chat_jid")
+
+	response, err := controller.Service.LabelChat(c.UserContext(), request)
+	utils.PanicIfNeeded(err)
+
+	return c.JSON(utils.ResponseData{
+		Status:  200,
+		Code:    "SUCCESS",
+		Message: response.Message,
+		Results: response,
+	})
+}
+
+func (controller *Label) LabelMessage(c *fiber.Ctx) error {
+	var request domainLabel.LabelMessageRequest
+	err := c.BodyParser(&request)
+	utils.PanicIfNeeded(err)
+
+	request.MessageID = c.Params("message_id")
+	utils.SanitizePhone(&request.Phone)
+
+	err = controller.Service.LabelMessage(c.UserContext(), request)
+	utils.PanicIfNeeded(err)
+
+	message := "Label removed from message successfully"
+	if request.Labeled {
+		message = "Label applied to message successfully"
+	}
+	return c.JSON(utils.ResponseData{
+		Status:  200,
+		Code:    "SUCCESS",
+		Message: message,
+		Results: nil,
+	})
+}
@@ -0,0 +1,233 @@
+package middleware
+
+import (
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/aldinokemal/go-whatsapp-web-multidevice/config"
+	domainAPIKey "github.com/aldinokemal/go-whatsapp-web-multidevice/domains/apikey"
+	domainAudit "github.com/aldinokemal/go-whatsapp-web-multidevice/domains/audit"
+	pkgError "github.com/aldinokemal/go-whatsapp-web-multidevice/pkg/error"
+	"github.com/aldinokemal/go-whatsapp-web-multidevice/pkg/utils"
+	"github.com/gofiber/fiber/v2"
+	"github.com/sirupsen/logrus"
+)
+
+// LocalsAPIKey is the fiber locals key holding the domainAPIKey.Key of the authenticated request
+const LocalsAPIKey = "api_key"
+
+// routeScopes maps the first path segment of a route to the scope it requires.
+// Routes that only read data use readScopes instead when called with GET.
+var (
+	routeScopes = map[string]domainAPIKey.Scope{
+		"api-keys":   domainAPIKey.ScopeAdmin,
+		"app":        domainAPIKey.ScopeAdmin,
+		"user":       domainAPIKey.ScopeAdmin,
+		"otomax":     domainAPIKey.ScopeOtomax,
+		"send":       domainAPIKey.ScopeSend,
+		"message":    domainAPIKey.ScopeSend,
+		"chat":       domainAPIKey.ScopeSend,
+		"label":      domainAPIKey.ScopeSend,
+		"labels":     domainAPIKey.ScopeSend,
+		"status":     domainAPIKey.ScopeSend,
+		"group":      domainAPIKey.ScopeGroups,
+		"newsletter": domainAPIKey.ScopeGroups,
+		"chats":      domainAPIKey.ScopeReadChats,
+		"calls":      domainAPIKey.ScopeReadChats,
+		"metrics":    domainAPIKey.ScopeMetrics,
+	}
+	// publicPaths are probed by orchestrators and load balancers, or fetched by webhook receivers with
+	// a signed URL, so they never require credentials
+	publicPaths = map[string]bool{
+		"/healthz":       true,
+		"/readyz":        true,
+		"/webhook/media": true,
+	}
+	// sendRoutes are message-sending routes outside the send segments, keyed by path, with the request field
+	// naming their recipient. They need the send scope so recipient restrictions apply to them too.
+	sendRoutes = map[string]string{
+		"/group/community/announcement": "community_id",
+		"/newsletter/send/text":         "newsletter_id",
+		"/newsletter/send/image":        "newsletter_id",
+		"/newsletter/send/video":        "newsletter_id",
+	}
+	readScopes = map[string]domainAPIKey.Scope{
+		"user":    domainAPIKey.ScopeReadChats,
+		"chat":    domainAPIKey.ScopeReadChats,
+		"message": domainAPIKey.ScopeReadChats,
+		"labels":  domainAPIKey.ScopeReadChats,
+		"status":  domainAPIKey.ScopeReadChats,
+	}
+)
+
+// Auth authenticates requests with either the configured basic auth accounts, which have full access,
+// or an API key sent as "X-Api-Key" or "Authorization: Bearer", which is limited to its scopes.
+// Requests without credentials are only allowed while no basic auth account and no active API key exist,
+// except for the public paths.
+func Auth(service domainAPIKey.IAPIKeyAuthorizer, accounts map[string]string) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		if publicPaths[strings.TrimPrefix(c.Path(), config.AppBasePath)] {
+			return c.Next()
+		}
+
+		authorization := c.Get(fiber.HeaderAuthorization)
+
+		rawKey := c.Get("X-Api-Key")
+		if rawKey == "" && strings.HasPrefix(authorization, "Bearer ") {
+			rawKey = strings.TrimSpace(strings.TrimPrefix(authorization, "Bearer "))
+		}
+		if rawKey != "" {
+			return authenticateAPIKey(c, service, rawKey)
+		}
+
+		if len(accounts) > 0 {
+			if !strings.HasPrefix(authorization, "Basic ") {
+				return unauthorized(c, pkgError.ErrAuthRequired, true)
+			}
+			username, ok := validBasicAuth(accounts, strings.TrimPrefix(authorization, "Basic "))
+			if !ok {
+				return unauthorized(c, pkgError.AuthError("invalid basic auth credential"), true)
+			}
+			setOrigin(c, "basic_auth:"+username)
+			return c.Next()
+		}
+
+		hasKeys, err := service.HasActiveKeys(c.UserContext())
+		if err != nil {
+			logrus.Errorf("Failed to check API keys: %v", err)
+			return abort(c, pkgError.InternalServerError("failed to check API keys"))
+		}
+		if hasKeys {
+			return unauthorized(c, pkgError.ErrAuthRequired, false)
+		}
+
+		setOrigin(c, "anonymous")
+		return c.Next()
+	}
+}
+
+// RequiredScope returns the scope needed to call a route, relative to the base path. Unknown routes need admin.
+func RequiredScope(method, path string) domainAPIKey.Scope {
+	if _, ok := sendRoutes[path]; ok && method == fiber.MethodPost {
+		return domainAPIKey.ScopeSend
+	}
+	segment, _, _ := strings.Cut(strings.TrimPrefix(path, "/"), "/")
+	if method == fiber.MethodGet {
+		if scope, ok := readScopes[segment]; ok {
+			return scope
+		}
+	}
+	if scope, ok := routeScopes[segment]; ok {
+		return scope
+	}
+	return domainAPIKey.ScopeAdmin
+}
+
+func authenticateAPIKey(c *fiber.Ctx, service domainAPIKey.IAPIKeyAuthorizer, rawKey string) error {
+	key, err := service.Authenticate(c.UserContext(), rawKey)
+	if err != nil {
+		return abort(c, err)
+	}
+
+	path := strings.TrimPrefix(c.Path(), config.AppBasePath)
+	scope := RequiredScope(c.Method(), path)
+	recipient := ""
+	if scope == domainAPIKey.ScopeSend {
+		if recipient, err = requestRecipient(c, path); err != nil {
+			return abort(c, err)
+		}
+	}
+	if err := service.Authorize(key, scope, recipient); err != nil {
+		return abort(c, err)
+	}
+
+	c.Locals(LocalsAPIKey, key)
+	setOrigin(c, fmt.Sprintf("api_key:%s (%s)", key.Name, key.Prefix))
+	return c.Next()
+}
+
+// requestRecipient reads the recipient of a send request from the same place its handler does: the :chat_jid of
+// /chat routes, or the phone field (the group or newsletter ID field for sendRoutes) of the query, form or JSON
+// body. A request naming different recipients in the query and the body is rejected, so the checked recipient is
+// always the one the message goes to.
+func requestRecipient(c *fiber.Ctx, path string) (string, error) {
+	if segments := strings.Split(strings.TrimPrefix(path, "/"), "/"); len(segments) > 1 && segments[0] == "chat" {
+		// Route params are not unescaped, so this is exactly the value the handler reads
+		return segments[1], nil
+	}
+
+	field := "phone"
+	if sendField, ok := sendRoutes[path]; ok {
+		field = sendField
+	}
+
+	queryValue := c.Query(field)
+	bodyValue := ""
+	contentType := c.Get(fiber.HeaderContentType)
+	switch {
+	case strings.HasPrefix(contentType, fiber.MIMEApplicationJSON):
+		var body map[string]any
+		_ = json.Unmarshal(c.Body(), &body)
+		bodyValue, _ = body[field].(string)
+	case strings.HasPrefix(contentType, fiber.MIMEMultipartForm):
+		if form, err := c.MultipartForm(); err == nil && len(form.Value[field]) > 0 {
+			bodyValue = form.Value[field][0]
+		}
+	case strings.HasPrefix(contentType, fiber.MIMEApplicationForm):
+		bodyValue = string(c.Request().PostArgs().Peek(field))
+	}
+
+	if queryValue != "" && bodyValue != "" && queryValue != bodyValue {
+		return "", pkgError.ValidationError(field + " in the query and in the body must match")
+	}
+	if bodyValue != "" {
+		return bodyValue, nil
+	}
+	return queryValue, nil
+}
+
+// setOrigin records who made the request so usecases can write it to the audit log
+func setOrigin(c *fiber.Ctx, actor string) {
+	ctx := domainAudit.ContextWithOrigin(c.UserContext(), domainAudit.Origin{
+		Actor:    actor,
+		Endpoint: c.Method() + " " + c.Path(),
+	})
+	c.SetUserContext(ctx)
+}
+
+func validBasicAuth(accounts map[string]string, encoded string) (string, bool) {
+	decoded, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil {
+		return "", false
+	}
+	username, password, ok := strings.Cut(string(decoded), ":")
+	if !ok {
+		return "", false
+	}
+	expected, exists := accounts[username]
+	return username, exists && subtle.ConstantTimeCompare([]byte(expected), []byte(password)) == 1
+}
+
+func unauthorized(c *fiber.Ctx, err pkgError.GenericError, challenge bool) error {
+	if challenge {
+		// Let browsers prompt for the basic auth credential used by the web UI
+		c.Set(fiber.HeaderWWWAuthenticate, `Basic realm="Restricted"`)
+	}
+	return abort(c, err)
+}
+
+func abort(c *fiber.Ctx, err error) error {
+	res := utils.ResponseData{
+		Status:  fiber.StatusInternalServerError,
+		Code:    "INTERNAL_SERVER_ERROR",
+		Message: err.Error(),
+	}
+	if genericErr, ok := err.(pkgError.GenericError); ok {
+		res.Status = genericErr.StatusCode()
+		res.Code = genericErr.ErrCode()
+	}
+	return c.Status(res.Status).JSON(res)
+}
@@ -0,0 +1,78 @@
+package middleware
+
+import (
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	domainAPIKey "github.com/aldinokemal/go-whatsapp-web-multidevice/domains/apikey"
+	"github.com/gofiber/fiber/v2"
+)
+
+func TestRequestRecipient(t *testing.T) {
+	tests := []struct {
+		name        string
+		path        string
+		contentType string
+		body        string
+		want        string
+		wantErr     bool
+	}{
+		{name: "JSON body", path: "/send/message", contentType: fiber.MIMEApplicationJSON, body: `{"phone":"6281234567890"}`, want: "6281234567890"},
+		{name: "Query", path: "/send/message?phone=6281234567890", want: "6281234567890"},
+		{name: "Form body", path: "/send/message", contentType: fiber.MIMEApplicationForm, body: "phone=6281234567890", want: "6281234567890"},
+		{name: "Same phone in query and body", path: "/send/message?phone=6281234567890", contentType: fiber.MIMEApplicationJSON, body: `{"phone":"6281234567890"}`, want: "6281234567890"},
+		{name: "Different phones in query and body", path: "/send/message?phone=6281234567890", contentType: fiber.MIMEApplicationJSON, body: `{"phone":"6289900000000"}`, wantErr: true},
+		{name: "Chat route", path: "/chat/120363000000000001@g.us/archive", contentType: fiber.MIMEApplicationJSON, body: `{"phone":"6281234567890"}`, want: "120363000000000001@g.us"},
+		{name: "No recipient", path: "/message/ABC/star", want: ""},
+		{name: "Community announcement", path: "/group/community/announcement", contentType: fiber.MIMEApplicationJSON, body: `{"community_id":"120363000000000001@g.us","phone":"6281234567890"}`, want: "120363000000000001@g.us"},
+		{name: "Newsletter post", path: "/newsletter/send/text", contentType: fiber.MIMEApplicationForm, body: "newsletter_id=120363000000000002@newsletter", want: "120363000000000002@newsletter"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			app := fiber.New()
+			app.Use(func(c *fiber.Ctx) error {
+				got, err := requestRecipient(c, c.Path())
+				if (err != nil) != tt.wantErr {
+					t.Errorf("requestRecipient() error = %v, wantErr %v", err, tt.wantErr)
+				}
+				if got != tt.want {
+					t.Errorf("requestRecipient() = %q, want %q", got, tt.want)
+				}
+				return c.SendStatus(fiber.StatusNoContent)
+			})
+
+			req := httptest.NewRequest(fiber.MethodPost, tt.path, strings.NewReader(tt.body))
+			if tt.contentType != "" {
+				req.Header.Set(fiber.HeaderContentType, tt.contentType)
+			}
+			if _, err := app.Test(req); err != nil {
+				t.Fatal(err)
+			}
+		})
+	}
+}
+
+func TestRequiredScope(t *testing.T) {
+	tests := []struct {
+		method string
+		path   string
+		want   domainAPIKey.Scope
+	}{
+		{method: fiber.MethodGet, path: "/message/ABC/thread", want: domainAPIKey.ScopeReadChats},
+		{method: fiber.MethodGet, path: "/message/ABC/download", want: domainAPIKey.ScopeReadChats},
+		{method: fiber.MethodPost, path: "/message/ABC/star", want: domainAPIKey.ScopeSend},
+		{method: fiber.MethodGet, path: "/chats", want: domainAPIKey.ScopeReadChats},
+		{method: fiber.MethodGet, path: "/unknown", want: domainAPIKey.ScopeAdmin},
+		{method: fiber.MethodPost, path: "/group/community/announcement", want: domainAPIKey.ScopeSend},
+		{method: fiber.MethodPost, path: "/newsletter/send/image", want: domainAPIKey.ScopeSend},
+		{method: fiber.MethodPost, path: "/newsletter/follow", want: domainAPIKey.ScopeGroups},
+	}
+
+	for _, tt := range tests {
+		if got := RequiredScope(tt.method, tt.path); got != tt.want {
+			t.Errorf("RequiredScope(%s, %s) = %s, want %s", tt.method, tt.path, got, tt.want)
+		}
+	}
+}
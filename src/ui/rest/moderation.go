@@ -0,0 +1,79 @@
+package rest
+
+import (
+	domainModeration "github.com/aldinokemal/go-whatsapp-web-multidevice/domains/moderation"
+	"github.com/aldinokemal/go-whatsapp-web-multidevice/pkg/utils"
+	"github.com/gofiber/fiber/v2"
+)
+
+type Moderation struct {
+	Service domainModeration.IModerationUsecase
+}
+
+func InitRestModeration(app fiber.Router, service domainModeration.IModerationUsecase) Moderation {
+	rest := Moderation{Service: service}
+	app.Get("/group/moderation", rest.GetSettings)
+	app.Get("/group/moderations", rest.ListSettings)
+	app.Post("/group/moderation", rest.SetSettings)
+	app.Post("/group/moderation/delete", rest.DeleteSettings)
+	return rest
+}
+
+func (controller *Moderation) GetSettings(c *fiber.Ctx) error {
+	var request domainModeration.GetSettingsRequest
+	err := c.QueryParser(&request)
+	utils.PanicIfNeeded(err)
+
+	response, err := controller.Service.GetSettings(c.UserContext(), request)
+	utils.PanicIfNeeded(err)
+
+	return c.JSON(utils.ResponseData{
+		Status:  200,
+		Code:    "SUCCESS",
+		Message: "Success get group moderation settings",
+		Results: response,
+	})
+}
+
+func (controller *Moderation) ListSettings(c *fiber.Ctx) error {
+	response, err := controller.Service.ListSettings(c.UserContext())
+	utils.PanicIfNeeded(err)
+
+	return c.JSON(utils.ResponseData{
+		Status:  200,
+		Code:    "SUCCESS",
+		Message: "Success get group moderation settings",
+		Results: response,
+	})
+}
+
+func (controller *Moderation) SetSettings(c *fiber.Ctx) error {
+	var request domainModeration.Settings
+	err := c.BodyParser(&request)
+	utils.PanicIfNeeded(err)
+
+	response, err := controller.Service.SetSettings(c.UserContext(), request)
+	utils.PanicIfNeeded(err)
+
+	return c.JSON(utils.ResponseData{
+		Status:  200,
+		Code:    "SUCCESS",
+		Message: "Success update group moderation settings",
+		Results: response,
+	})
+}
+
+func (controller *Moderation) DeleteSettings(c *fiber.Ctx) error {
+	var request domainModeration.DeleteSettingsRequest
+	err := c.BodyParser(&request)
+	utils.PanicIfNeeded(err)
+
+	err = controller.Service.DeleteSettings(c.UserContext(), request)
+	utils.PanicIfNeeded(err)
+
+	return c.JSON(utils.ResponseData{
+		Status:  200,
+		Code:    "SUCCESS",
+		Message: "Success delete group moderation settings",
+	})
+}
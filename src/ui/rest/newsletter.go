@@ -12,10 +12,56 @@ type Newsletter struct {
 
 func InitRestNewsletter(app fiber.Router, service domainNewsletter.INewsletterUsecase) Newsletter {
 	rest := Newsletter{Service: service}
+	app.Post("/newsletter/create", rest.Create)
+	app.Post("/newsletter/follow", rest.Follow)
 	app.Post("/newsletter/unfollow", rest.Unfollow)
+	app.Get("/newsletter/info", rest.GetInfo)
+	app.Get("/newsletter/info-from-link", rest.GetInfoFromLink)
+	app.Post("/newsletter/update", rest.Update)
+	app.Post("/newsletter/mute", rest.Mute)
+	app.Post("/newsletter/send/text", rest.PostText)
+	app.Post("/newsletter/send/image", rest.PostImage)
+	app.Post("/newsletter/send/video", rest.PostVideo)
+	app.Get("/newsletter/messages", rest.GetMessages)
 	return rest
 }
 
+func (controller *Newsletter) Create(c *fiber.Ctx) error {
+	var request domainNewsletter.CreateNewsletterRequest
+	err := c.BodyParser(&request)
+	utils.PanicIfNeeded(err)
+
+	file, err := c.FormFile("picture")
+	if err == nil {
+		request.Picture = file
+	}
+
+	response, err := controller.Service.Create(c.UserContext(), request)
+	utils.PanicIfNeeded(err)
+
+	return c.JSON(utils.ResponseData{
+		Status:  200,
+		Code:    "SUCCESS",
+		Message: "Success create newsletter",
+		Results: response,
+	})
+}
+
+func (controller *Newsletter) Follow(c *fiber.Ctx) error {
+	var request domainNewsletter.FollowRequest
+	err := c.BodyParser(&request)
+	utils.PanicIfNeeded(err)
+
+	err = controller.Service.Follow(c.UserContext(), request)
+	utils.PanicIfNeeded(err)
+
+	return c.JSON(utils.ResponseData{
+		Status:  200,
+		Code:    "SUCCESS",
+		Message: "Success follow newsletter",
+	})
+}
+
 func (controller *Newsletter) Unfollow(c *fiber.Ctx) error {
 	var request domainNewsletter.UnfollowRequest
 	err := c.BodyParser(&request)
@@ -30,3 +76,150 @@ func (controller *Newsletter) Unfollow(c *fiber.Ctx) error {
 		Message: "Success unfollow newsletter",
 	})
 }
+
+func (controller *Newsletter) GetInfo(c *fiber.Ctx) error {
+	var request domainNewsletter.GetInfoRequest
+	err := c.QueryParser(&request)
+	utils.PanicIfNeeded(err)
+
+	response, err := controller.Service.GetInfo(c.UserContext(), request)
+	utils.PanicIfNeeded(err)
+
+	return c.JSON(utils.ResponseData{
+		Status:  200,
+		Code:    "SUCCESS",
+		Message: "Success get newsletter info",
+		Results: response,
+	})
+}
+
+func (controller *Newsletter) GetInfoFromLink(c *fiber.Ctx) error {
+	var request domainNewsletter.GetInfoFromLinkRequest
+	err := c.QueryParser(&request)
+	utils.PanicIfNeeded(err)
+
+	response, err := controller.Service.GetInfoFromLink(c.UserContext(), request)
+	utils.PanicIfNeeded(err)
+
+	return c.JSON(utils.ResponseData{
+		Status:  200,
+		Code:    "SUCCESS",
+		Message: "Success get newsletter info from link",
+		Results: response,
+	})
+}
+
+func (controller *Newsletter) Update(c *fiber.Ctx) error {
+	var request domainNewsletter.UpdateNewsletterRequest
+	err := c.BodyParser(&request)
+	utils.PanicIfNeeded(err)
+
+	file, err := c.FormFile("picture")
+	if err == nil {
+		request.Picture = file
+	}
+
+	response, err := controller.Service.Update(c.UserContext(), request)
+	utils.PanicIfNeeded(err)
+
+	return c.JSON(utils.ResponseData{
+		Status:  200,
+		Code:    "SUCCESS",
+		Message: "Success update newsletter",
+		Results: response,
+	})
+}
+
+func (controller *Newsletter) Mute(c *fiber.Ctx) error {
+	var request domainNewsletter.MuteRequest
+	err := c.BodyParser(&request)
+	utils.PanicIfNeeded(err)
+
+	err = controller.Service.Mute(c.UserContext(), request)
+	utils.PanicIfNeeded(err)
+
+	message := "Success unmute newsletter"
+	if request.Mute {
+		message = "Success mute newsletter"
+	}
+
+	return c.JSON(utils.ResponseData{
+		Status:  200,
+		Code:    "SUCCESS",
+		Message: message,
+	})
+}
+
+func (controller *Newsletter) PostText(c *fiber.Ctx) error {
+	var request domainNewsletter.PostTextRequest
+	err := c.BodyParser(&request)
+	utils.PanicIfNeeded(err)
+
+	response, err := controller.Service.PostText(c.UserContext(), request)
+	utils.PanicIfNeeded(err)
+
+	return c.JSON(utils.ResponseData{
+		Status:  200,
+		Code:    "SUCCESS",
+		Message: response.Status,
+		Results: response,
+	})
+}
+
+func (controller *Newsletter) PostImage(c *fiber.Ctx) error {
+	var request domainNewsletter.PostImageRequest
+	err := c.BodyParser(&request)
+	utils.PanicIfNeeded(err)
+
+	file, err := c.FormFile("image")
+	if err == nil {
+		request.Image = file
+	}
+
+	response, err := controller.Service.PostImage(c.UserContext(), request)
+	utils.PanicIfNeeded(err)
+
+	return c.JSON(utils.ResponseData{
+		Status:  200,
+		Code:    "SUCCESS",
+		Message: response.Status,
+		Results: response,
+	})
+}
+
+func (controller *Newsletter) PostVideo(c *fiber.Ctx) error {
+	var request domainNewsletter.PostVideoRequest
+	err := c.BodyParser(&request)
+	utils.PanicIfNeeded(err)
+
+	file, err := c.FormFile("video")
+	if err == nil {
+		request.Video = file
+	}
+
+	response, err := controller.Service.PostVideo(c.UserContext(), request)
+	utils.PanicIfNeeded(err)
+
+	return c.JSON(utils.ResponseData{
+		Status:  200,
+		Code:    "SUCCESS",
+		Message: response.Status,
+		Results: response,
+	})
+}
+
+func (controller *Newsletter) GetMessages(c *fiber.Ctx) error {
+	var request domainNewsletter.GetMessagesRequest
+	err := c.QueryParser(&request)
+	utils.PanicIfNeeded(err)
+
+	response, err := controller.Service.GetMessages(c.UserContext(), request)
+	utils.PanicIfNeeded(err)
+
+	return c.JSON(utils.ResponseData{
+		Status:  200,
+		Code:    "SUCCESS",
+		Message: "Success get newsletter messages",
+		Results: response,
+	})
+}
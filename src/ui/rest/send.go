@@ -36,6 +36,7 @@ func (controller *Send) SendText(c *fiber.Ctx) error {
 	utils.PanicIfNeeded(err)
 
 	utils.SanitizePhone(&request.Phone)
+	setIdempotencyKey(c, &request.BaseRequest)
 
 	response, err := controller.Service.SendText(c.UserContext(), request)
 	utils.PanicIfNeeded(err)
@@ -99,10 +100,12 @@ func (controller *Send) SendTextGet(c *fiber.Ctx) error {
 	// Create request object
 	request := domainSend.MessageRequest{
 		BaseRequest: domainSend.BaseRequest{
-			Phone: normalizedPhone,
+			Phone:          normalizedPhone,
+			IdempotencyKey: c.Query("idempotency_key"),
 		},
 		Message: message,
 	}
+	setIdempotencyKey(c, &request.BaseRequest)
 
 	// Send message
 	response, err := controller.Service.SendText(c.UserContext(), request)
@@ -129,6 +132,7 @@ func (controller *Send) SendImage(c *fiber.Ctx) error {
 	}
 
 	utils.SanitizePhone(&request.Phone)
+	setIdempotencyKey(c, &request.BaseRequest)
 
 	response, err := controller.Service.SendImage(c.UserContext(), request)
 	utils.PanicIfNeeded(err)
@@ -151,6 +155,7 @@ func (controller *Send) SendFile(c *fiber.Ctx) error {
 
 	request.File = file
 	utils.SanitizePhone(&request.Phone)
+	setIdempotencyKey(c, &request.BaseRequest)
 
 	response, err := controller.Service.SendFile(c.UserContext(), request)
 	utils.PanicIfNeeded(err)
@@ -174,6 +179,7 @@ func (controller *Send) SendVideo(c *fiber.Ctx) error {
 	}
 
 	utils.SanitizePhone(&request.Phone)
+	setIdempotencyKey(c, &request.BaseRequest)
 
 	response, err := controller.Service.SendVideo(c.UserContext(), request)
 	utils.PanicIfNeeded(err)
@@ -197,6 +203,7 @@ func (controller *Send) SendSticker(c *fiber.Ctx) error {
 	}
 
 	utils.SanitizePhone(&request.Phone)
+	setIdempotencyKey(c, &request.BaseRequest)
 
 	response, err := controller.Service.SendSticker(c.UserContext(), request)
 	utils.PanicIfNeeded(err)
@@ -215,6 +222,7 @@ func (controller *Send) SendContact(c *fiber.Ctx) error {
 	utils.PanicIfNeeded(err)
 
 	utils.SanitizePhone(&request.Phone)
+	setIdempotencyKey(c, &request.BaseRequest)
 
 	response, err := controller.Service.SendContact(c.UserContext(), request)
 	utils.PanicIfNeeded(err)
@@ -233,6 +241,7 @@ func (controller *Send) SendLink(c *fiber.Ctx) error {
 	utils.PanicIfNeeded(err)
 
 	utils.SanitizePhone(&request.Phone)
+	setIdempotencyKey(c, &request.BaseRequest)
 
 	response, err := controller.Service.SendLink(c.UserContext(), request)
 	utils.PanicIfNeeded(err)
@@ -251,6 +260,7 @@ func (controller *Send) SendLocation(c *fiber.Ctx) error {
 	utils.PanicIfNeeded(err)
 
 	utils.SanitizePhone(&request.Phone)
+	setIdempotencyKey(c, &request.BaseRequest)
 
 	response, err := controller.Service.SendLocation(c.UserContext(), request)
 	utils.PanicIfNeeded(err)
@@ -274,6 +284,7 @@ func (controller *Send) SendAudio(c *fiber.Ctx) error {
 	}
 
 	utils.SanitizePhone(&request.Phone)
+	setIdempotencyKey(c, &request.BaseRequest)
 
 	response, err := controller.Service.SendAudio(c.UserContext(), request)
 	utils.PanicIfNeeded(err)
@@ -292,6 +303,7 @@ func (controller *Send) SendPoll(c *fiber.Ctx) error {
 	utils.PanicIfNeeded(err)
 
 	utils.SanitizePhone(&request.Phone)
+	setIdempotencyKey(c, &request.BaseRequest)
 
 	response, err := controller.Service.SendPoll(c.UserContext(), request)
 	utils.PanicIfNeeded(err)
@@ -337,3 +349,10 @@ func (controller *Send) SendChatPresence(c *fiber.Ctx) error {
 		Results: response,
 	})
 }
+
+// setIdempotencyKey lets the Idempotency-Key header set the idempotency key of a send request
+func setIdempotencyKey(c *fiber.Ctx, request *domainSend.BaseRequest) {
+	if key := c.Get("Idempotency-Key"); key != "" {
+		request.IdempotencyKey = key
+	}
+}
@@ -0,0 +1,109 @@
+package rest
+
+import (
+	domainStatus "github.com/aldinokemal/go-whatsapp-web-multidevice/domains/status"
+	"github.com/aldinokemal/go-whatsapp-web-multidevice/pkg/utils"
+	"github.com/gofiber/fiber/v2"
+)
+
+type Status struct {
+	Service domainStatus.IStatusUsecase
+}
+
+func InitRestStatus(app fiber.Router, service domainStatus.IStatusUsecase) Status {
+	rest := Status{Service: service}
+	app.Post("/status/text", rest.PostText)
+	app.Post("/status/image", rest.PostImage)
+	app.Post("/status/video", rest.PostVideo)
+	app.Get("/status/privacy", rest.GetPrivacy)
+	app.Get("/statuses", rest.ListStatuses)
+	return rest
+}
+
+func (controller *Status) PostText(c *fiber.Ctx) error {
+	var request domainStatus.TextStatusRequest
+	err := c.BodyParser(&request)
+	utils.PanicIfNeeded(err)
+
+	response, err := controller.Service.PostText(c.UserContext(), request)
+	utils.PanicIfNeeded(err)
+
+	return c.JSON(utils.ResponseData{
+		Status:  200,
+		Code:    "SUCCESS",
+		Message: response.Status,
+		Results: response,
+	})
+}
+
+func (controller *Status) PostImage(c *fiber.Ctx) error {
+	var request domainStatus.ImageStatusRequest
+	err := c.BodyParser(&request)
+	utils.PanicIfNeeded(err)
+
+	file, err := c.FormFile("image")
+	if err == nil {
+		request.Image = file
+	}
+
+	response, err := controller.Service.PostImage(c.UserContext(), request)
+	utils.PanicIfNeeded(err)
+
+	return c.JSON(utils.ResponseData{
+		Status:  200,
+		Code:    "SUCCESS",
+		Message: response.Status,
+		Results: response,
+	})
+}
+
+func (controller *Status) PostVideo(c *fiber.Ctx) error {
+	var request domainStatus.VideoStatusRequest
+	err := c.BodyParser(&request)
+	utils.PanicIfNeeded(err)
+
+	file, err := c.FormFile("video")
+	if err == nil {
+		request.Video = file
+	}
+
+	response, err := controller.Service.PostVideo(c.UserContext(), request)
+	utils.PanicIfNeeded(err)
+
+	return c.JSON(utils.ResponseData{
+		Status:  200,
+		Code:    "SUCCESS",
+		Message: response.Status,
+		Results: response,
+	})
+}
+
+func (controller *Status) GetPrivacy(c *fiber.Ctx) error {
+	response, err := controller.Service.GetPrivacy(c.UserContext())
+	utils.PanicIfNeeded(err)
+
+	return c.JSON(utils.ResponseData{
+		Status:  200,
+		Code:    "SUCCESS",
+		Message: "Success get status privacy",
+		Results: response,
+	})
+}
+
+func (controller *Status) ListStatuses(c *fiber.Ctx) error {
+	var request domainStatus.ListStatusesRequest
+	request.Sender = c.Query("sender", "")
+	request.IncludeExpired = c.QueryBool("include_expired", false)
+	request.Limit = c.QueryInt("limit", 50)
+	request.Offset = c.QueryInt("offset", 0)
+
+	response, err := controller.Service.ListStatuses(c.UserContext(), request)
+	utils.PanicIfNeeded(err)
+
+	return c.JSON(utils.ResponseData{
+		Status:  200,
+		Code:    "SUCCESS",
+		Message: "Success get statuses",
+		Results: response,
+	})
+}
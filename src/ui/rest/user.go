@@ -16,12 +16,18 @@ func InitRestUser(app fiber.Router, service domainUser.IUserUsecase) User {
 	app.Get("/user/avatar", rest.UserAvatar)
 	app.Post("/user/avatar", rest.UserChangeAvatar)
 	app.Post("/user/pushname", rest.UserChangePushName)
+	app.Post("/user/about", rest.UserChangeAbout)
 	app.Get("/user/my/privacy", rest.UserMyPrivacySetting)
+	app.Post("/user/my/privacy", rest.UserChangePrivacySetting)
+	app.Post("/user/my/disappearing", rest.UserChangeDefaultDisappearingTimer)
 	app.Get("/user/my/groups", rest.UserMyListGroups)
 	app.Get("/user/my/newsletters", rest.UserMyListNewsletter)
 	app.Get("/user/my/contacts", rest.UserMyListContacts)
 	app.Get("/user/check", rest.UserCheck)
 	app.Get("/user/business-profile", rest.UserBusinessProfile)
+	app.Post("/user/block", rest.UserBlock)
+	app.Post("/user/unblock", rest.UserUnblock)
+	app.Get("/user/blocklist", rest.UserBlocklist)
 
 	return rest
 }
@@ -92,6 +98,37 @@ func (controller *User) UserMyPrivacySetting(c *fiber.Ctx) error {
 	})
 }
 
+func (controller *User) UserChangePrivacySetting(c *fiber.Ctx) error {
+	var request domainUser.ChangePrivacySettingRequest
+	err := c.BodyParser(&request)
+	utils.PanicIfNeeded(err)
+
+	response, err := controller.Service.ChangePrivacySetting(c.UserContext(), request)
+	utils.PanicIfNeeded(err)
+
+	return c.JSON(utils.ResponseData{
+		Status:  200,
+		Code:    "SUCCESS",
+		Message: "Success change privacy",
+		Results: response,
+	})
+}
+
+func (controller *User) UserChangeDefaultDisappearingTimer(c *fiber.Ctx) error {
+	var request domainUser.ChangeDisappearingTimerRequest
+	err := c.BodyParser(&request)
+	utils.PanicIfNeeded(err)
+
+	err = controller.Service.ChangeDefaultDisappearingTimer(c.UserContext(), request)
+	utils.PanicIfNeeded(err)
+
+	return c.JSON(utils.ResponseData{
+		Status:  200,
+		Code:    "SUCCESS",
+		Message: "Success change default disappearing timer",
+	})
+}
+
 func (controller *User) UserMyListGroups(c *fiber.Ctx) error {
 	response, err := controller.Service.MyListGroups(c.UserContext())
 	utils.PanicIfNeeded(err)
@@ -143,6 +180,21 @@ func (controller *User) UserChangePushName(c *fiber.Ctx) error {
 	})
 }
 
+func (controller *User) UserChangeAbout(c *fiber.Ctx) error {
+	var request domainUser.ChangeAboutRequest
+	err := c.BodyParser(&request)
+	utils.PanicIfNeeded(err)
+
+	err = controller.Service.ChangeAbout(c.UserContext(), request)
+	utils.PanicIfNeeded(err)
+
+	return c.JSON(utils.ResponseData{
+		Status:  200,
+		Code:    "SUCCESS",
+		Message: "Success change about",
+	})
+}
+
 func (controller *User) UserCheck(c *fiber.Ctx) error {
 	var request domainUser.CheckRequest
 	err := c.QueryParser(&request)
@@ -176,3 +228,47 @@ func (controller *User) UserBusinessProfile(c *fiber.Ctx) error {
 		Results: response,
 	})
 }
+
+func (controller *User) UserBlock(c *fiber.Ctx) error {
+	var request domainUser.BlockRequest
+	err := c.BodyParser(&request)
+	utils.PanicIfNeeded(err)
+
+	response, err := controller.Service.Block(c.UserContext(), request)
+	utils.PanicIfNeeded(err)
+
+	return c.JSON(utils.ResponseData{
+		Status:  200,
+		Code:    "SUCCESS",
+		Message: "Success block contact",
+		Results: response,
+	})
+}
+
+func (controller *User) UserUnblock(c *fiber.Ctx) error {
+	var request domainUser.BlockRequest
+	err := c.BodyParser(&request)
+	utils.PanicIfNeeded(err)
+
+	response, err := controller.Service.Unblock(c.UserContext(), request)
+	utils.PanicIfNeeded(err)
+
+	return c.JSON(utils.ResponseData{
+		Status:  200,
+		Code:    "SUCCESS",
+		Message: "Success unblock contact",
+		Results: response,
+	})
+}
+
+func (controller *User) UserBlocklist(c *fiber.Ctx) error {
+	response, err := controller.Service.Blocklist(c.UserContext())
+	utils.PanicIfNeeded(err)
+
+	return c.JSON(utils.ResponseData{
+		Status:  200,
+		Code:    "SUCCESS",
+		Message: "Success get blocklist",
+		Results: response,
+	})
+}
@@ -0,0 +1,54 @@
+package rest
+
+import (
+	"mime"
+	"path/filepath"
+
+	"github.com/aldinokemal/go-whatsapp-web-multidevice/config"
+	"github.com/aldinokemal/go-whatsapp-web-multidevice/infrastructure/whatsapp"
+	"github.com/aldinokemal/go-whatsapp-web-multidevice/pkg/mediastorage"
+	"github.com/aldinokemal/go-whatsapp-web-multidevice/pkg/utils"
+	"github.com/gofiber/fiber/v2"
+	"github.com/sirupsen/logrus"
+)
+
+// InitRestWebhookMedia serves the media linked from v2 webhook payloads. Requests are authorized by the
+// signature in the URL instead of credentials, so webhook receivers can download the media directly.
+// The endpoint only exists when a public media base URL is configured.
+func InitRestWebhookMedia(app fiber.Router) {
+	if config.WhatsappWebhookMediaBaseURL == "" {
+		return
+	}
+	if config.WhatsappWebhookSecret == "" || config.WhatsappWebhookSecret == config.DefaultWebhookSecret {
+		logrus.Warn("Webhook media URLs are disabled, set --webhook-secret to a value other than the default")
+	}
+	app.Get("/webhook/media", WebhookMedia)
+}
+
+func WebhookMedia(c *fiber.Ctx) error {
+	key, err := whatsapp.CleanMediaKey(c.Query("key"))
+	if err != nil || !whatsapp.VerifyMediaSignature(key, c.Query("expires"), c.Query("signature")) {
+		return c.Status(fiber.StatusForbidden).JSON(utils.ResponseData{
+			Status:  fiber.StatusForbidden,
+			Code:    "INVALID_SIGNATURE",
+			Message: "Media URL is invalid or expired",
+		})
+	}
+
+	file, err := mediastorage.Open(c.UserContext(), key)
+	if err != nil {
+		logrus.Warnf("Failed to open webhook media %s: %v", key, err)
+		return c.Status(fiber.StatusNotFound).JSON(utils.ResponseData{
+			Status:  fiber.StatusNotFound,
+			Code:    "NOT_FOUND",
+			Message: "Media not found",
+		})
+	}
+
+	if contentType := mime.TypeByExtension(filepath.Ext(key)); contentType != "" {
+		c.Set(fiber.HeaderContentType, contentType)
+	} else {
+		c.Set(fiber.HeaderContentType, fiber.MIMEOctetStream)
+	}
+	return c.SendStream(file)
+}
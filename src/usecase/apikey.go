@@ -0,0 +1,237 @@
+package usecase
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"path"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+
+	domainAPIKey "github.com/aldinokemal/go-whatsapp-web-multidevice/domains/apikey"
+	domainChatStorage "github.com/aldinokemal/go-whatsapp-web-multidevice/domains/chatstorage"
+	pkgError "github.com/aldinokemal/go-whatsapp-web-multidevice/pkg/error"
+	"github.com/aldinokemal/go-whatsapp-web-multidevice/validations"
+)
+
+const (
+	apiKeySecretPrefix = "gowa_"
+	apiKeyPrefixLength = 12
+	// apiKeyLastUsedInterval limits how often last_used_at is written for a busy key
+	apiKeyLastUsedInterval = time.Minute
+)
+
+type serviceAPIKey struct {
+	chatStorageRepo domainChatStorage.IChatStorageRepository
+
+	mu      sync.Mutex
+	windows map[string]*apiKeyRateWindow
+}
+
+// apiKeyRateWindow counts the requests of a key in the current one minute window
+type apiKeyRateWindow struct {
+	start time.Time
+	count int
+}
+
+func NewAPIKeyService(chatStorageRepo domainChatStorage.IChatStorageRepository) domainAPIKey.IAPIKeyUsecase {
+	return &serviceAPIKey{
+		chatStorageRepo: chatStorageRepo,
+		windows:         make(map[string]*apiKeyRateWindow),
+	}
+}
+
+func (service *serviceAPIKey) CreateKey(ctx context.Context, request domainAPIKey.CreateKeyRequest) (response domainAPIKey.CreateKeyResponse, err error) {
+	if err = validations.ValidateCreateAPIKey(ctx, request); err != nil {
+		return response, err
+	}
+
+	random := make([]byte, 32)
+	if _, err = rand.Read(random); err != nil {
+		return response, err
+	}
+	secret := apiKeySecretPrefix + hex.EncodeToString(random)
+
+	stored := &domainChatStorage.APIKey{
+		ID:                uuid.NewString(),
+		Name:              request.Name,
+		KeyHash:           hashAPIKey(secret),
+		Prefix:            secret[:apiKeyPrefixLength],
+		AllowedRecipients: request.AllowedRecipients,
+		RateLimit:         request.RateLimit,
+	}
+	for _, scope := range request.Scopes {
+		stored.Scopes = append(stored.Scopes, string(scope))
+	}
+	if request.ExpiresAt != "" {
+		expiresAt, _ := time.Parse(time.RFC3339, request.ExpiresAt)
+		stored.ExpiresAt = &expiresAt
+	}
+
+	if err = service.chatStorageRepo.StoreAPIKey(stored); err != nil {
+		return response, err
+	}
+
+	logrus.Infof("API key %s (%s) created with scopes %v", stored.Name, stored.Prefix, stored.Scopes)
+
+	response.Key = toAPIKey(stored)
+	response.Secret = secret
+	return response, nil
+}
+
+func (service *serviceAPIKey) ListKeys(_ context.Context) (response []domainAPIKey.Key, err error) {
+	keys, err := service.chatStorageRepo.GetAPIKeys()
+	if err != nil {
+		return response, err
+	}
+
+	response = make([]domainAPIKey.Key, 0, len(keys))
+	for _, key := range keys {
+		response = append(response, toAPIKey(key))
+	}
+	return response, nil
+}
+
+func (service *serviceAPIKey) RevokeKey(ctx context.Context, request domainAPIKey.RevokeKeyRequest) (err error) {
+	if err = validations.ValidateRevokeAPIKey(ctx, request); err != nil {
+		return err
+	}
+
+	found, err := service.chatStorageRepo.RevokeAPIKey(request.ID, time.Now())
+	if err != nil {
+		return err
+	}
+	if !found {
+		return pkgError.ValidationError(fmt.Sprintf("active API key %s not found", request.ID))
+	}
+
+	service.mu.Lock()
+	delete(service.windows, request.ID)
+	service.mu.Unlock()
+
+	logrus.Infof("API key %s revoked", request.ID)
+	return nil
+}
+
+func (service *serviceAPIKey) HasActiveKeys(_ context.Context) (bool, error) {
+	count, err := service.chatStorageRepo.CountActiveAPIKeys(time.Now())
+	return count > 0, err
+}
+
+func (service *serviceAPIKey) Authenticate(_ context.Context, rawKey string) (key domainAPIKey.Key, err error) {
+	stored, err := service.chatStorageRepo.GetAPIKeyByHash(hashAPIKey(rawKey))
+	if err != nil {
+		return key, err
+	}
+
+	now := time.Now()
+	if stored == nil || stored.RevokedAt != nil || (stored.ExpiresAt != nil && !stored.ExpiresAt.After(now)) {
+		return key, pkgError.ErrInvalidAPIKey
+	}
+
+	if !service.allowRequest(stored.ID, stored.RateLimit, now) {
+		return key, pkgError.ErrRateLimited
+	}
+
+	if stored.LastUsedAt == nil || now.Sub(*stored.LastUsedAt) >= apiKeyLastUsedInterval {
+		if err := service.chatStorageRepo.UpdateAPIKeyLastUsed(stored.ID, now); err != nil {
+			logrus.Warnf("Failed to update last use of API key %s: %v", stored.Prefix, err)
+		}
+		stored.LastUsedAt = &now
+	}
+
+	return toAPIKey(stored), nil
+}
+
+func (service *serviceAPIKey) Authorize(key domainAPIKey.Key, scope domainAPIKey.Scope, recipient string) error {
+	// The admin scope grants access to every route
+	if !slices.Contains(key.Scopes, domainAPIKey.ScopeAdmin) && !slices.Contains(key.Scopes, scope) {
+		return pkgError.ForbiddenError(fmt.Sprintf("API key %s is missing the %s scope", key.Prefix, scope))
+	}
+
+	if len(key.AllowedRecipients) > 0 && scope == domainAPIKey.ScopeSend {
+		// A restricted key can only be used where the recipient is known
+		if recipient == "" {
+			return pkgError.ForbiddenError(fmt.Sprintf("API key %s is restricted to some recipients and this request has none", key.Prefix))
+		}
+		if !recipientAllowed(key.AllowedRecipients, recipient) {
+			return pkgError.ForbiddenError(fmt.Sprintf("API key %s is not allowed to send to %s", key.Prefix, recipient))
+		}
+	}
+
+	return nil
+}
+
+// allowRequest applies a fixed one minute window rate limit; a limit of zero means unlimited
+func (service *serviceAPIKey) allowRequest(keyID string, limit int, now time.Time) bool {
+	if limit <= 0 {
+		return true
+	}
+
+	service.mu.Lock()
+	defer service.mu.Unlock()
+
+	window, ok := service.windows[keyID]
+	if !ok || now.Sub(window.start) >= time.Minute {
+		service.windows[keyID] = &apiKeyRateWindow{start: now, count: 1}
+		return true
+	}
+	if window.count >= limit {
+		return false
+	}
+	window.count++
+	return true
+}
+
+// recipientAllowed matches the recipient, and its phone number without server and plus sign, against the patterns
+func recipientAllowed(patterns []string, recipient string) bool {
+	candidates := []string{recipient}
+	user, _, _ := strings.Cut(recipient, "@")
+	user = strings.TrimPrefix(user, "+")
+	if user != recipient {
+		candidates = append(candidates, user)
+	}
+
+	for _, pattern := range patterns {
+		for _, candidate := range candidates {
+			if matched, _ := path.Match(pattern, candidate); matched {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func hashAPIKey(rawKey string) string {
+	sum := sha256.Sum256([]byte(rawKey))
+	return hex.EncodeToString(sum[:])
+}
+
+func toAPIKey(stored *domainChatStorage.APIKey) domainAPIKey.Key {
+	key := domainAPIKey.Key{
+		ID:                stored.ID,
+		Name:              stored.Name,
+		Prefix:            stored.Prefix,
+		Scopes:            make([]domainAPIKey.Scope, 0, len(stored.Scopes)),
+		AllowedRecipients: stored.AllowedRecipients,
+		RateLimit:         stored.RateLimit,
+		ExpiresAt:         stored.ExpiresAt,
+		LastUsedAt:        stored.LastUsedAt,
+		RevokedAt:         stored.RevokedAt,
+		CreatedAt:         stored.CreatedAt,
+	}
+	if key.AllowedRecipients == nil {
+		key.AllowedRecipients = []string{}
+	}
+	for _, scope := range stored.Scopes {
+		key.Scopes = append(key.Scopes, domainAPIKey.Scope(scope))
+	}
+	return key
+}
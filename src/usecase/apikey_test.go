@@ -0,0 +1,76 @@
+package usecase
+
+import (
+	"testing"
+	"time"
+
+	domainAPIKey "github.com/aldinokemal/go-whatsapp-web-multidevice/domains/apikey"
+)
+
+func TestRecipientAllowed(t *testing.T) {
+	patterns := []string{"62812*", "*@g.us"}
+
+	tests := []struct {
+		name      string
+		recipient string
+		want      bool
+	}{
+		{name: "Phone number", recipient: "6281234567890", want: true},
+		{name: "Phone number with plus", recipient: "+6281234567890", want: true},
+		{name: "User JID", recipient: "6281234567890@s.whatsapp.net", want: true},
+		{name: "Group JID", recipient: "120363000000000001@g.us", want: true},
+		{name: "Other phone number", recipient: "6289900000000", want: false},
+		{name: "Other user JID", recipient: "6289900000000@s.whatsapp.net", want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := recipientAllowed(patterns, tt.recipient); got != tt.want {
+				t.Fatalf("recipientAllowed(%q) = %v, want %v", tt.recipient, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAuthorizeAPIKey(t *testing.T) {
+	service := &serviceAPIKey{windows: make(map[string]*apiKeyRateWindow)}
+
+	sendKey := domainAPIKey.Key{Prefix: "gowa_1234567", Scopes: []domainAPIKey.Scope{domainAPIKey.ScopeSend}, AllowedRecipients: []string{"62812*"}}
+	if err := service.Authorize(sendKey, domainAPIKey.ScopeSend, "6281234567890"); err != nil {
+		t.Fatalf("expected send to allowed recipient to pass, got %v", err)
+	}
+	if err := service.Authorize(sendKey, domainAPIKey.ScopeSend, "6289900000000"); err == nil {
+		t.Fatal("expected send to other recipient to be rejected")
+	}
+	if err := service.Authorize(sendKey, domainAPIKey.ScopeSend, ""); err == nil {
+		t.Fatal("expected a restricted key to be rejected without a recipient")
+	}
+	if err := service.Authorize(sendKey, domainAPIKey.ScopeGroups, ""); err == nil {
+		t.Fatal("expected missing scope to be rejected")
+	}
+
+	adminKey := domainAPIKey.Key{Prefix: "gowa_7654321", Scopes: []domainAPIKey.Scope{domainAPIKey.ScopeAdmin}}
+	if err := service.Authorize(adminKey, domainAPIKey.ScopeOtomax, ""); err != nil {
+		t.Fatalf("expected admin scope to grant every scope, got %v", err)
+	}
+}
+
+func TestAPIKeyRateLimit(t *testing.T) {
+	service := &serviceAPIKey{windows: make(map[string]*apiKeyRateWindow)}
+	now := time.Now()
+
+	for i := 0; i < 3; i++ {
+		if !service.allowRequest("key", 3, now) {
+			t.Fatalf("request %d should be allowed", i+1)
+		}
+	}
+	if service.allowRequest("key", 3, now.Add(time.Second)) {
+		t.Fatal("fourth request in the same minute should be rejected")
+	}
+	if !service.allowRequest("key", 3, now.Add(time.Minute)) {
+		t.Fatal("request in the next window should be allowed")
+	}
+	if !service.allowRequest("unlimited", 0, now) {
+		t.Fatal("key without limit should always be allowed")
+	}
+}
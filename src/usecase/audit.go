@@ -0,0 +1,123 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	domainAudit "github.com/aldinokemal/go-whatsapp-web-multidevice/domains/audit"
+	domainChatStorage "github.com/aldinokemal/go-whatsapp-web-multidevice/domains/chatstorage"
+	"github.com/aldinokemal/go-whatsapp-web-multidevice/validations"
+)
+
+const defaultAuditLimit = 100
+
+type serviceAudit struct {
+	chatStorageRepo domainChatStorage.IChatStorageRepository
+}
+
+func NewAuditService(chatStorageRepo domainChatStorage.IChatStorageRepository) domainAudit.IAuditUsecase {
+	return &serviceAudit{
+		chatStorageRepo: chatStorageRepo,
+	}
+}
+
+func (service serviceAudit) List(ctx context.Context, request domainAudit.ListRequest) (response domainAudit.ListResponse, err error) {
+	if err = validations.ValidateListAudit(ctx, request); err != nil {
+		return response, err
+	}
+
+	if request.Limit == 0 {
+		request.Limit = defaultAuditLimit
+	}
+
+	filter := &domainChatStorage.AuditLogFilter{
+		Actor:     request.Actor,
+		Action:    request.Action,
+		TargetJID: request.TargetJID,
+		Result:    request.Result,
+		Limit:     request.Limit,
+		Offset:    request.Offset,
+	}
+	if request.From != "" {
+		from, _ := time.Parse(time.RFC3339, request.From)
+		filter.From = &from
+	}
+	if request.To != "" {
+		to, _ := time.Parse(time.RFC3339, request.To)
+		filter.To = &to
+	}
+
+	entries, err := service.chatStorageRepo.GetAuditLogs(filter)
+	if err != nil {
+		return response, err
+	}
+	total, err := service.chatStorageRepo.CountAuditLogs(filter)
+	if err != nil {
+		return response, err
+	}
+
+	response.Data = make([]domainAudit.Entry, 0, len(entries))
+	for _, entry := range entries {
+		response.Data = append(response.Data, domainAudit.Entry{
+			ID:        entry.ID,
+			Actor:     entry.Actor,
+			Endpoint:  entry.Endpoint,
+			Action:    entry.Action,
+			TargetJID: entry.TargetJID,
+			MessageID: entry.MessageID,
+			Details:   entry.Details,
+			Result:    entry.Result,
+			Error:     entry.Error,
+			CreatedAt: entry.CreatedAt,
+		})
+	}
+	response.Pagination = domainAudit.PaginationResponse{
+		Limit:  request.Limit,
+		Offset: request.Offset,
+		Total:  int(total),
+	}
+
+	return response, nil
+}
+
+// auditRecord describes a state-changing action to append to the audit log
+type auditRecord struct {
+	action    string
+	target    string
+	messageID string
+	details   string
+}
+
+// recordAudit appends the outcome of an action to the audit log. It is meant to be deferred with the
+// named error result and recover(), so panics such as utils.MustLogin are recorded as failures and re-raised.
+func recordAudit(ctx context.Context, repo domainChatStorage.IChatStorageRepository, record auditRecord, err error, recovered any) {
+	if recovered != nil {
+		defer panic(recovered)
+		err = fmt.Errorf("%v", recovered)
+	}
+	if repo == nil {
+		return
+	}
+
+	origin := domainAudit.OriginFromContext(ctx)
+	entry := &domainChatStorage.AuditLog{
+		Actor:     origin.Actor,
+		Endpoint:  origin.Endpoint,
+		Action:    record.action,
+		TargetJID: record.target,
+		MessageID: record.messageID,
+		Details:   record.details,
+		Result:    domainAudit.ResultSuccess,
+	}
+	if err != nil {
+		entry.Result = domainAudit.ResultFailed
+		entry.Error = err.Error()
+	}
+
+	if storeErr := repo.StoreAuditLog(entry); storeErr != nil {
+		logrus.Warnf("Failed to store audit log for %s: %v", record.action, storeErr)
+	}
+}
@@ -0,0 +1,31 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	domainAudit "github.com/aldinokemal/go-whatsapp-web-multidevice/domains/audit"
+)
+
+func TestAuditOriginFromContext(t *testing.T) {
+	if origin := domainAudit.OriginFromContext(context.Background()); origin.Actor != domainAudit.ActorSystem {
+		t.Fatalf("expected system actor without origin, got %q", origin.Actor)
+	}
+
+	ctx := domainAudit.ContextWithOrigin(context.Background(), domainAudit.Origin{Actor: "basic_auth:admin", Endpoint: "POST /send/message"})
+	if origin := domainAudit.OriginFromContext(ctx); origin.Actor != "basic_auth:admin" || origin.Endpoint != "POST /send/message" {
+		t.Fatalf("unexpected origin: %+v", origin)
+	}
+}
+
+func TestRecordAuditRepanics(t *testing.T) {
+	defer func() {
+		if recovered := recover(); recovered != "not logged in" {
+			t.Fatalf("expected original panic to be re-raised, got %v", recovered)
+		}
+	}()
+
+	recordAudit(context.Background(), nil, auditRecord{action: domainAudit.ActionSendText}, errors.New("ignored"), "not logged in")
+	t.Fatal("recordAudit should re-panic")
+}
@@ -0,0 +1,71 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	domainCall "github.com/aldinokemal/go-whatsapp-web-multidevice/domains/call"
+	domainChatStorage "github.com/aldinokemal/go-whatsapp-web-multidevice/domains/chatstorage"
+	"github.com/aldinokemal/go-whatsapp-web-multidevice/pkg/utils"
+	"github.com/aldinokemal/go-whatsapp-web-multidevice/validations"
+	"github.com/sirupsen/logrus"
+)
+
+type serviceCall struct {
+	chatStorageRepo domainChatStorage.IChatStorageRepository
+}
+
+func NewCallService(chatStorageRepo domainChatStorage.IChatStorageRepository) domainCall.ICallUsecase {
+	return &serviceCall{
+		chatStorageRepo: chatStorageRepo,
+	}
+}
+
+func (service serviceCall) ListCalls(ctx context.Context, request domainCall.ListCallsRequest) (response domainCall.ListCallsResponse, err error) {
+	if err = validations.ValidateListCalls(ctx, &request); err != nil {
+		return response, err
+	}
+
+	if request.Phone != "" {
+		utils.SanitizePhone(&request.Phone)
+	}
+
+	filter := &domainChatStorage.CallFilter{
+		CallerJID: request.Phone,
+		Outcome:   request.Outcome,
+		Limit:     request.Limit,
+		Offset:    request.Offset,
+	}
+
+	calls, err := service.chatStorageRepo.GetCalls(filter)
+	if err != nil {
+		logrus.WithError(err).Error("Failed to get calls from storage")
+		return response, err
+	}
+	total, err := service.chatStorageRepo.CountCalls(filter)
+	if err != nil {
+		return response, err
+	}
+
+	response.Data = make([]domainCall.CallInfo, 0, len(calls))
+	for _, call := range calls {
+		response.Data = append(response.Data, domainCall.CallInfo{
+			ID:        call.ID,
+			From:      call.CallerJID,
+			GroupJID:  call.GroupJID,
+			IsVideo:   call.IsVideo,
+			IsGroup:   call.IsGroup,
+			Outcome:   call.Outcome,
+			Reason:    call.Reason,
+			Timestamp: call.Timestamp.Format(time.RFC3339),
+			UpdatedAt: call.UpdatedAt.Format(time.RFC3339),
+		})
+	}
+	response.Pagination = domainCall.PaginationResponse{
+		Limit:  request.Limit,
+		Offset: request.Offset,
+		Total:  int(total),
+	}
+
+	return response, nil
+}
@@ -3,15 +3,23 @@ package usecase
 import (
 	"context"
 	"fmt"
+	"sort"
 	"time"
 
+	domainAudit "github.com/aldinokemal/go-whatsapp-web-multidevice/domains/audit"
 	domainChat "github.com/aldinokemal/go-whatsapp-web-multidevice/domains/chat"
 	domainChatStorage "github.com/aldinokemal/go-whatsapp-web-multidevice/domains/chatstorage"
 	"github.com/aldinokemal/go-whatsapp-web-multidevice/infrastructure/whatsapp"
+	pkgError "github.com/aldinokemal/go-whatsapp-web-multidevice/pkg/error"
 	"github.com/aldinokemal/go-whatsapp-web-multidevice/pkg/utils"
 	"github.com/aldinokemal/go-whatsapp-web-multidevice/validations"
 	"github.com/sirupsen/logrus"
+	"go.mau.fi/whatsmeow"
 	"go.mau.fi/whatsmeow/appstate"
+	"go.mau.fi/whatsmeow/proto/waCommon"
+	"go.mau.fi/whatsmeow/proto/waSyncAction"
+	"go.mau.fi/whatsmeow/types"
+	"google.golang.org/protobuf/proto"
 )
 
 type serviceChat struct {
@@ -35,6 +43,11 @@ func (service serviceChat) ListChats(ctx context.Context, request domainChat.Lis
 		Offset:     request.Offset,
 		SearchName: request.Search,
 		HasMedia:   request.HasMedia,
+		Pinned:     request.Pinned,
+		Archived:   request.Archived,
+		Muted:      request.Muted,
+		Unread:     request.Unread,
+		LabelID:    request.LabelID,
 	}
 
 	// Get chats from storage
@@ -55,15 +68,7 @@ func (service serviceChat) ListChats(ctx context.Context, request domainChat.Lis
 	// Convert entities to domain objects
 	chatInfos := make([]domainChat.ChatInfo, 0, len(chats))
 	for _, chat := range chats {
-		chatInfo := domainChat.ChatInfo{
-			JID:                 chat.JID,
-			Name:                chat.Name,
-			LastMessageTime:     chat.LastMessageTime.Format(time.RFC3339),
-			EphemeralExpiration: chat.EphemeralExpiration,
-			CreatedAt:           chat.CreatedAt.Format(time.RFC3339),
-			UpdatedAt:           chat.UpdatedAt.Format(time.RFC3339),
-		}
-		chatInfos = append(chatInfos, chatInfo)
+		chatInfos = append(chatInfos, toChatInfo(chat))
 	}
 
 	// Create pagination response
@@ -153,34 +158,10 @@ func (service serviceChat) GetChatMessages(ctx context.Context, request domainCh
 	}
 
 	// Convert entities to domain objects
-	messageInfos := make([]domainChat.MessageInfo, 0, len(messages))
-	for _, message := range messages {
-		messageInfo := domainChat.MessageInfo{
-			ID:         message.ID,
-			ChatJID:    message.ChatJID,
-			SenderJID:  message.Sender,
-			Content:    message.Content,
-			Timestamp:  message.Timestamp.Format(time.RFC3339),
-			IsFromMe:   message.IsFromMe,
-			MediaType:  message.MediaType,
-			Filename:   message.Filename,
-			URL:        message.URL,
-			FileLength: message.FileLength,
-			CreatedAt:  message.CreatedAt.Format(time.RFC3339),
-			UpdatedAt:  message.UpdatedAt.Format(time.RFC3339),
-		}
-		messageInfos = append(messageInfos, messageInfo)
-	}
+	messageInfos := service.toMessageInfos(messages, request.IncludeEdits)
 
 	// Create chat info for response
-	chatInfo := domainChat.ChatInfo{
-		JID:                 chat.JID,
-		Name:                chat.Name,
-		LastMessageTime:     chat.LastMessageTime.Format(time.RFC3339),
-		EphemeralExpiration: chat.EphemeralExpiration,
-		CreatedAt:           chat.CreatedAt.Format(time.RFC3339),
-		UpdatedAt:           chat.UpdatedAt.Format(time.RFC3339),
-	}
+	chatInfo := toChatInfo(chat)
 
 	// Create pagination response
 	pagination := domainChat.PaginationResponse{
@@ -204,6 +185,9 @@ func (service serviceChat) GetChatMessages(ctx context.Context, request domainCh
 }
 
 func (service serviceChat) PinChat(ctx context.Context, request domainChat.PinChatRequest) (response domainChat.PinChatResponse, err error) {
+	defer func() {
+		recordAudit(ctx, service.chatStorageRepo, auditRecord{action: domainAudit.ActionChatPin, target: request.ChatJID, details: fmt.Sprintf("pinned=%t", request.Pinned)}, err, recover())
+	}()
 	if err = validations.ValidatePinChat(ctx, &request); err != nil {
 		return response, err
 	}
@@ -226,6 +210,8 @@ func (service serviceChat) PinChat(ctx context.Context, request domainChat.PinCh
 		return response, err
 	}
 
+	service.updateChatState(targetJID, &domainChatStorage.ChatStateUpdate{Pinned: &request.Pinned})
+
 	// Build response
 	response.Status = "success"
 	response.ChatJID = request.ChatJID
@@ -244,3 +230,531 @@ func (service serviceChat) PinChat(ctx context.Context, request domainChat.PinCh
 
 	return response, nil
 }
+
+func (service serviceChat) ArchiveChat(ctx context.Context, request domainChat.ArchiveChatRequest) (response domainChat.ArchiveChatResponse, err error) {
+	defer func() {
+		recordAudit(ctx, service.chatStorageRepo, auditRecord{action: domainAudit.ActionChatArchive, target: request.ChatJID, details: fmt.Sprintf("archived=%t", request.Archived)}, err, recover())
+	}()
+	if err = validations.ValidateArchiveChat(ctx, &request); err != nil {
+		return response, err
+	}
+
+	targetJID, err := utils.ValidateJidWithLogin(whatsapp.GetClient(), request.ChatJID)
+	if err != nil {
+		return response, err
+	}
+
+	lastMessageTime, lastMessageKey := service.lastMessageRange(targetJID)
+	patchInfo := appstate.BuildArchive(targetJID, request.Archived, lastMessageTime, lastMessageKey)
+
+	if err = whatsapp.GetClient().SendAppState(ctx, patchInfo); err != nil {
+		logrus.WithError(err).WithFields(logrus.Fields{
+			"chat_jid": request.ChatJID,
+			"archived": request.Archived,
+		}).Error("Failed to send archive chat app state")
+		return response, err
+	}
+
+	// Archiving also unpins the chat
+	update := &domainChatStorage.ChatStateUpdate{Archived: &request.Archived}
+	if request.Archived {
+		update.Pinned = new(bool)
+	}
+	service.updateChatState(targetJID, update)
+
+	response.Status = "success"
+	response.ChatJID = request.ChatJID
+	response.Archived = request.Archived
+
+	if request.Archived {
+		response.Message = "Chat archived successfully"
+	} else {
+		response.Message = "Chat unarchived successfully"
+	}
+
+	return response, nil
+}
+
+func (service serviceChat) MuteChat(ctx context.Context, request domainChat.MuteChatRequest) (response domainChat.MuteChatResponse, err error) {
+	defer func() {
+		recordAudit(ctx, service.chatStorageRepo, auditRecord{action: domainAudit.ActionChatMute, target: request.ChatJID, details: fmt.Sprintf("muted=%t duration=%d", request.Muted, request.Duration)}, err, recover())
+	}()
+	if err = validations.ValidateMuteChat(ctx, &request); err != nil {
+		return response, err
+	}
+
+	targetJID, err := utils.ValidateJidWithLogin(whatsapp.GetClient(), request.ChatJID)
+	if err != nil {
+		return response, err
+	}
+
+	var mutedUntil int64
+	var muteEndTimestamp *int64
+	if request.Muted {
+		mutedUntil = domainChatStorage.MutedForever
+		if request.Duration > 0 {
+			end := time.Now().Add(time.Duration(request.Duration) * time.Second)
+			mutedUntil = end.Unix()
+			muteEndTimestamp = proto.Int64(end.UnixMilli())
+		}
+	}
+	patchInfo := appstate.BuildMuteAbs(targetJID, request.Muted, muteEndTimestamp)
+
+	if err = whatsapp.GetClient().SendAppState(ctx, patchInfo); err != nil {
+		logrus.WithError(err).WithFields(logrus.Fields{
+			"chat_jid": request.ChatJID,
+			"muted":    request.Muted,
+		}).Error("Failed to send mute chat app state")
+		return response, err
+	}
+
+	service.updateChatState(targetJID, &domainChatStorage.ChatStateUpdate{MutedUntil: &mutedUntil})
+
+	response.Status = "success"
+	response.ChatJID = request.ChatJID
+	response.Muted = request.Muted
+
+	switch {
+	case !request.Muted:
+		response.Message = "Chat unmuted successfully"
+	case mutedUntil == domainChatStorage.MutedForever:
+		response.Message = "Chat muted successfully"
+	default:
+		response.MutedUntil = time.Unix(mutedUntil, 0).Format(time.RFC3339)
+		response.Message = fmt.Sprintf("Chat muted until %s", response.MutedUntil)
+	}
+
+	return response, nil
+}
+
+func (service serviceChat) SetDisappearingTimer(ctx context.Context, request domainChat.SetDisappearingTimerRequest) (response domainChat.SetDisappearingTimerResponse, err error) {
+	defer func() {
+		recordAudit(ctx, service.chatStorageRepo, auditRecord{action: domainAudit.ActionChatDisappearing, target: request.ChatJID, details: request.Timer}, err, recover())
+	}()
+	if err = validations.ValidateSetDisappearingTimer(ctx, &request); err != nil {
+		return response, err
+	}
+
+	targetJID, err := utils.ValidateJidWithLogin(whatsapp.GetClient(), request.ChatJID)
+	if err != nil {
+		return response, err
+	}
+
+	timer, _ := whatsmeow.ParseDisappearingTimerString(request.Timer)
+	if err = whatsapp.GetClient().SetDisappearingTimer(ctx, targetJID, timer, time.Time{}); err != nil {
+		logrus.WithError(err).WithFields(logrus.Fields{
+			"chat_jid": request.ChatJID,
+			"timer":    request.Timer,
+		}).Error("Failed to set disappearing timer")
+		return response, err
+	}
+
+	expiration := uint32(timer.Seconds())
+	service.updateChatState(targetJID, &domainChatStorage.ChatStateUpdate{EphemeralExpiration: &expiration})
+
+	response.Status = "success"
+	response.ChatJID = request.ChatJID
+	response.Timer = request.Timer
+	response.EphemeralExpiration = expiration
+	if expiration == 0 {
+		response.Message = "Disappearing messages turned off"
+	} else {
+		response.Message = fmt.Sprintf("Disappearing messages set to %s", request.Timer)
+	}
+
+	return response, nil
+}
+
+// RequestHistory asks the primary device for messages older than the oldest stored one of a chat. They arrive as an
+// ON_DEMAND history sync and are stored like any other synced message.
+func (service serviceChat) RequestHistory(ctx context.Context, request domainChat.RequestHistoryRequest) (response domainChat.RequestHistoryResponse, err error) {
+	defer func() {
+		recordAudit(ctx, service.chatStorageRepo, auditRecord{action: domainAudit.ActionChatHistory, target: request.ChatJID, details: fmt.Sprintf("count=%d", request.Count)}, err, recover())
+	}()
+	if err = validations.ValidateRequestHistory(ctx, &request); err != nil {
+		return response, err
+	}
+	if request.Count == 0 {
+		request.Count = domainChat.DefaultHistoryRequestCount
+	}
+
+	targetJID, err := utils.ValidateJidWithLogin(whatsapp.GetClient(), request.ChatJID)
+	if err != nil {
+		return response, err
+	}
+
+	// The phone only sends history before a message it knows about
+	oldest, err := service.chatStorageRepo.GetOldestMessage(targetJID.String())
+	if err != nil {
+		return response, err
+	}
+	if oldest == nil {
+		return response, pkgError.ValidationError(fmt.Sprintf("chat %s has no stored messages to request older history from", targetJID.String()))
+	}
+
+	client := whatsapp.GetClient()
+	historyRequest := client.BuildHistorySyncRequest(&types.MessageInfo{
+		MessageSource: types.MessageSource{Chat: targetJID, IsFromMe: oldest.IsFromMe},
+		ID:            oldest.ID,
+		Timestamp:     oldest.Timestamp,
+	}, request.Count)
+	if _, err = client.SendMessage(ctx, client.Store.ID.ToNonAD(), historyRequest, whatsmeow.SendRequestExtra{Peer: true}); err != nil {
+		logrus.WithError(err).WithField("chat_jid", request.ChatJID).Error("Failed to request chat history")
+		return response, err
+	}
+
+	response.Status = "success"
+	response.Message = fmt.Sprintf("Requested %d older messages, they are stored once the phone sends them", request.Count)
+	response.ChatJID = targetJID.String()
+	response.OldestMessageID = oldest.ID
+	response.OldestTimestamp = oldest.Timestamp.Format(time.RFC3339)
+	response.Count = request.Count
+
+	return response, nil
+}
+
+func (service serviceChat) MarkChatRead(ctx context.Context, request domainChat.MarkChatReadRequest) (response domainChat.MarkChatReadResponse, err error) {
+	defer func() {
+		recordAudit(ctx, service.chatStorageRepo, auditRecord{action: domainAudit.ActionChatRead, target: request.ChatJID, details: fmt.Sprintf("read=%t", request.Read)}, err, recover())
+	}()
+	if err = validations.ValidateMarkChatRead(ctx, &request); err != nil {
+		return response, err
+	}
+
+	targetJID, err := utils.ValidateJidWithLogin(whatsapp.GetClient(), request.ChatJID)
+	if err != nil {
+		return response, err
+	}
+
+	lastMessageTime, lastMessageKey := service.lastMessageRange(targetJID)
+	patchInfo := appstate.BuildMarkChatAsRead(targetJID, request.Read, lastMessageTime, lastMessageKey)
+
+	if err = whatsapp.GetClient().SendAppState(ctx, patchInfo); err != nil {
+		logrus.WithError(err).WithFields(logrus.Fields{
+			"chat_jid": request.ChatJID,
+			"read":     request.Read,
+		}).Error("Failed to send mark chat as read app state")
+		return response, err
+	}
+
+	unread := !request.Read
+	service.updateChatState(targetJID, &domainChatStorage.ChatStateUpdate{Unread: &unread})
+
+	response.Status = "success"
+	response.ChatJID = request.ChatJID
+	response.Unread = unread
+
+	if request.Read {
+		response.Message = "Chat marked as read successfully"
+	} else {
+		response.Message = "Chat marked as unread successfully"
+	}
+
+	return response, nil
+}
+
+func (service serviceChat) ClearChat(ctx context.Context, request domainChat.ClearChatRequest) (response domainChat.ChatActionResponse, err error) {
+	defer func() {
+		recordAudit(ctx, service.chatStorageRepo, auditRecord{action: domainAudit.ActionChatClear, target: request.ChatJID, details: fmt.Sprintf("delete_starred=%t", request.DeleteStarred)}, err, recover())
+	}()
+	if err = validations.ValidateClearChat(ctx, &request); err != nil {
+		return response, err
+	}
+
+	targetJID, err := utils.ValidateJidWithLogin(whatsapp.GetClient(), request.ChatJID)
+	if err != nil {
+		return response, err
+	}
+
+	lastMessageTime, lastMessageKey := service.lastMessageRange(targetJID)
+	patchInfo := buildClearChat(targetJID, request.DeleteStarred, lastMessageTime, lastMessageKey)
+
+	if err = whatsapp.GetClient().SendAppState(ctx, patchInfo); err != nil {
+		logrus.WithError(err).WithField("chat_jid", request.ChatJID).Error("Failed to send clear chat app state")
+		return response, err
+	}
+
+	if err := service.chatStorageRepo.ClearChatMessages(targetJID.String()); err != nil {
+		logrus.WithError(err).WithField("chat_jid", request.ChatJID).Warn("Failed to clear stored chat messages")
+	}
+
+	response.Status = "success"
+	response.Message = "Chat cleared successfully"
+	response.ChatJID = request.ChatJID
+
+	return response, nil
+}
+
+func (service serviceChat) DeleteChat(ctx context.Context, request domainChat.DeleteChatRequest) (response domainChat.ChatActionResponse, err error) {
+	defer func() {
+		recordAudit(ctx, service.chatStorageRepo, auditRecord{action: domainAudit.ActionChatDelete, target: request.ChatJID}, err, recover())
+	}()
+	if err = validations.ValidateDeleteChat(ctx, &request); err != nil {
+		return response, err
+	}
+
+	targetJID, err := utils.ValidateJidWithLogin(whatsapp.GetClient(), request.ChatJID)
+	if err != nil {
+		return response, err
+	}
+
+	lastMessageTime, lastMessageKey := service.lastMessageRange(targetJID)
+	patchInfo := appstate.BuildDeleteChat(targetJID, lastMessageTime, lastMessageKey)
+
+	if err = whatsapp.GetClient().SendAppState(ctx, patchInfo); err != nil {
+		logrus.WithError(err).WithField("chat_jid", request.ChatJID).Error("Failed to send delete chat app state")
+		return response, err
+	}
+
+	if err := service.chatStorageRepo.DeleteChat(targetJID.String()); err != nil {
+		logrus.WithError(err).WithField("chat_jid", request.ChatJID).Warn("Failed to delete stored chat")
+	}
+
+	response.Status = "success"
+	response.Message = "Chat deleted successfully"
+	response.ChatJID = request.ChatJID
+
+	return response, nil
+}
+
+// maxThreadMessages limits how many messages a thread walks through in either direction
+const maxThreadMessages = 200
+
+func (service serviceChat) GetMessageThread(ctx context.Context, request domainChat.GetMessageThreadRequest) (response domainChat.GetMessageThreadResponse, err error) {
+	if err = validations.ValidateGetMessageThread(ctx, &request); err != nil {
+		return response, err
+	}
+
+	message, err := service.findMessage(request.ChatJID, request.MessageID)
+	if err != nil {
+		return response, err
+	}
+	if message == nil {
+		return response, fmt.Errorf("message with ID %s not found", request.MessageID)
+	}
+
+	// Walk up to the root of the reply chain, quoted messages that are not stored end the chain
+	thread := []*domainChatStorage.Message{message}
+	seen := map[string]bool{message.ID: true}
+	for parent := message; parent.QuotedMessageID != "" && len(thread) < maxThreadMessages; {
+		if seen[parent.QuotedMessageID] {
+			break
+		}
+		parent, err = service.findMessage(message.ChatJID, parent.QuotedMessageID)
+		if err != nil {
+			return response, err
+		}
+		if parent == nil {
+			break
+		}
+		seen[parent.ID] = true
+		thread = append([]*domainChatStorage.Message{parent}, thread...)
+	}
+
+	// Collect every reply to the message, breadth first
+	var replies []*domainChatStorage.Message
+	for queue := []string{message.ID}; len(queue) > 0 && len(replies) < maxThreadMessages; queue = queue[1:] {
+		children, err := service.chatStorageRepo.GetMessageReplies(message.ChatJID, queue[0])
+		if err != nil {
+			return response, err
+		}
+		for _, child := range children {
+			if seen[child.ID] {
+				continue
+			}
+			seen[child.ID] = true
+			replies = append(replies, child)
+			queue = append(queue, child.ID)
+		}
+	}
+	sort.SliceStable(replies, func(i, j int) bool { return replies[i].Timestamp.Before(replies[j].Timestamp) })
+
+	response.ChatJID = message.ChatJID
+	response.MessageID = message.ID
+	response.RootID = thread[0].ID
+	response.Data = service.toMessageInfos(append(thread, replies...), false)
+	return response, nil
+}
+
+// findMessage returns a stored message by ID, or nil when it is not stored in the chat given by chatJID
+func (service serviceChat) findMessage(chatJID, messageID string) (*domainChatStorage.Message, error) {
+	message, err := service.chatStorageRepo.GetMessageByID(messageID)
+	if err != nil || message == nil {
+		return nil, err
+	}
+	if chatJID != "" && message.ChatJID != chatJID {
+		return nil, nil
+	}
+	return message, nil
+}
+
+// toMessageInfos converts stored messages with their reactions and the messages they reply to
+func (service serviceChat) toMessageInfos(messages []*domainChatStorage.Message, includeEdits bool) []domainChat.MessageInfo {
+	byChat := make(map[string][]string)
+	stored := make(map[string]*domainChatStorage.Message, len(messages))
+	for _, message := range messages {
+		byChat[message.ChatJID] = append(byChat[message.ChatJID], message.ID)
+		stored[message.ChatJID+"/"+message.ID] = message
+	}
+
+	reactions := make(map[string][]*domainChatStorage.MessageReaction)
+	for chatJID, ids := range byChat {
+		chatReactions, err := service.chatStorageRepo.GetMessageReactions(chatJID, ids)
+		if err != nil {
+			logrus.WithError(err).WithField("chat_jid", chatJID).Warn("Failed to get message reactions")
+			continue
+		}
+		for id, list := range chatReactions {
+			reactions[chatJID+"/"+id] = list
+		}
+	}
+
+	messageInfos := make([]domainChat.MessageInfo, 0, len(messages))
+	for _, message := range messages {
+		messageInfo := domainChat.MessageInfo{
+			ID:         message.ID,
+			ChatJID:    message.ChatJID,
+			SenderJID:  message.Sender,
+			Content:    message.Content,
+			Timestamp:  message.Timestamp.Format(time.RFC3339),
+			IsFromMe:   message.IsFromMe,
+			MediaType:  message.MediaType,
+			Filename:   message.Filename,
+			URL:        message.URL,
+			FileLength: message.FileLength,
+			Starred:    message.Starred,
+			Revoked:    message.Revoked,
+			Mentions:   message.Mentions,
+			CreatedAt:  message.CreatedAt.Format(time.RFC3339),
+			UpdatedAt:  message.UpdatedAt.Format(time.RFC3339),
+		}
+		if message.EditedAt != nil {
+			messageInfo.EditedAt = message.EditedAt.Format(time.RFC3339)
+			if includeEdits {
+				messageInfo.Edits = service.messageEdits(message)
+			}
+		}
+		if message.RevokedAt != nil {
+			messageInfo.RevokedAt = message.RevokedAt.Format(time.RFC3339)
+		}
+		if message.QuotedMessageID != "" {
+			messageInfo.ReplyTo = &domainChat.MessageReference{ID: message.QuotedMessageID}
+			quoted, ok := stored[message.ChatJID+"/"+message.QuotedMessageID]
+			if !ok {
+				quoted, _ = service.findMessage(message.ChatJID, message.QuotedMessageID)
+			}
+			if quoted != nil {
+				messageInfo.ReplyTo.SenderJID = quoted.Sender
+				messageInfo.ReplyTo.Content = quoted.Content
+			}
+		}
+		for _, reaction := range reactions[message.ChatJID+"/"+message.ID] {
+			messageInfo.Reactions = append(messageInfo.Reactions, domainChat.MessageReaction{
+				SenderJID: reaction.SenderJID,
+				Emoji:     reaction.Emoji,
+				Timestamp: reaction.Timestamp.Format(time.RFC3339),
+			})
+		}
+		messageInfos = append(messageInfos, messageInfo)
+	}
+	return messageInfos
+}
+
+// messageEdits lists the revisions of an edited message, an empty history is returned when they cannot be read
+func (service serviceChat) messageEdits(message *domainChatStorage.Message) []domainChat.MessageEdit {
+	edits, err := service.chatStorageRepo.GetMessageEdits(message.ChatJID, message.ID)
+	if err != nil {
+		logrus.WithError(err).WithField("message_id", message.ID).Warn("Failed to get message edits")
+		return nil
+	}
+
+	revisions := make([]domainChat.MessageEdit, 0, len(edits))
+	for _, edit := range edits {
+		revisions = append(revisions, domainChat.MessageEdit{
+			Content:  edit.Content,
+			EditedAt: edit.EditedAt.Format(time.RFC3339),
+		})
+	}
+	return revisions
+}
+
+// updateChatState persists the app state sent for a chat. WhatsApp already accepted the change,
+// so a storage failure is only logged.
+func (service serviceChat) updateChatState(chatJID types.JID, update *domainChatStorage.ChatStateUpdate) {
+	if err := service.chatStorageRepo.UpdateChatState(chatJID.String(), update); err != nil {
+		logrus.WithError(err).WithField("chat_jid", chatJID.String()).Warn("Failed to store chat state")
+	}
+}
+
+// lastMessageRange returns the timestamp and key of the newest stored message of a chat, which tells WhatsApp
+// which messages an archive, read, clear or delete covers. Zero values are returned when none is stored.
+func (service serviceChat) lastMessageRange(chatJID types.JID) (time.Time, *waCommon.MessageKey) {
+	messages, err := service.chatStorageRepo.GetMessages(&domainChatStorage.MessageFilter{ChatJID: chatJID.String(), Limit: 1})
+	if err != nil || len(messages) == 0 {
+		return time.Time{}, nil
+	}
+
+	last := messages[0]
+	key := &waCommon.MessageKey{
+		RemoteJID: proto.String(chatJID.String()),
+		FromMe:    proto.Bool(last.IsFromMe),
+		ID:        proto.String(last.ID),
+	}
+	if chatJID.Server == types.GroupServer && !last.IsFromMe {
+		key.Participant = proto.String(last.Sender)
+	}
+	return last.Timestamp, key
+}
+
+// buildClearChat builds the app state patch clearing the messages of a chat, which whatsmeow has no builder for.
+// Starred messages are kept unless deleteStarred is set.
+func buildClearChat(target types.JID, deleteStarred bool, lastMessageTimestamp time.Time, lastMessageKey *waCommon.MessageKey) appstate.PatchInfo {
+	if lastMessageTimestamp.IsZero() {
+		lastMessageTimestamp = time.Now()
+	}
+	messageRange := &waSyncAction.SyncActionMessageRange{
+		LastMessageTimestamp: proto.Int64(lastMessageTimestamp.Unix()),
+	}
+	if lastMessageKey != nil {
+		messageRange.Messages = []*waSyncAction.SyncActionMessage{{
+			Key:       lastMessageKey,
+			Timestamp: proto.Int64(lastMessageTimestamp.Unix()),
+		}}
+	}
+
+	starred := "0"
+	if deleteStarred {
+		starred = "1"
+	}
+
+	return appstate.PatchInfo{
+		Type: appstate.WAPatchRegularHigh,
+		Mutations: []appstate.MutationInfo{{
+			Index:   []string{appstate.IndexClearChat, target.String(), starred, "0"},
+			Version: 6,
+			Value: &waSyncAction.SyncActionValue{
+				ClearChatAction: &waSyncAction.ClearChatAction{MessageRange: messageRange},
+			},
+		}},
+	}
+}
+
+func toChatInfo(chat *domainChatStorage.Chat) domainChat.ChatInfo {
+	info := domainChat.ChatInfo{
+		JID:                 chat.JID,
+		Name:                chat.Name,
+		LastMessageTime:     chat.LastMessageTime.Format(time.RFC3339),
+		EphemeralExpiration: chat.EphemeralExpiration,
+		Pinned:              chat.Pinned,
+		Archived:            chat.Archived,
+		Muted:               chat.IsMuted(time.Now()),
+		Unread:              chat.Unread,
+		LabelIDs:            append([]string{}, chat.LabelIDs...),
+		CreatedAt:           chat.CreatedAt.Format(time.RFC3339),
+		UpdatedAt:           chat.UpdatedAt.Format(time.RFC3339),
+	}
+	if info.Muted && chat.MutedUntil != domainChatStorage.MutedForever {
+		info.MutedUntil = time.Unix(chat.MutedUntil, 0).Format(time.RFC3339)
+	}
+	return info
+}
@@ -0,0 +1,276 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	domainChat "github.com/aldinokemal/go-whatsapp-web-multidevice/domains/chat"
+	domainChatStorage "github.com/aldinokemal/go-whatsapp-web-multidevice/domains/chatstorage"
+	"go.mau.fi/whatsmeow/appstate"
+	"go.mau.fi/whatsmeow/proto/waCommon"
+	"go.mau.fi/whatsmeow/proto/waE2E"
+	"go.mau.fi/whatsmeow/types"
+	"go.mau.fi/whatsmeow/types/events"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestListChatsFiltersOnChatState(t *testing.T) {
+	repo := newSQLiteTestRepo(t)
+	now := time.Now()
+	for _, jid := range []string{"1@s.whatsapp.net", "2@s.whatsapp.net", "3@g.us"} {
+		if err := repo.StoreChat(&domainChatStorage.Chat{JID: jid, Name: jid, LastMessageTime: now}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	yes, no := true, false
+	expired := now.Add(-time.Hour).Unix()
+	forever := domainChatStorage.MutedForever
+	updates := map[string]*domainChatStorage.ChatStateUpdate{
+		"1@s.whatsapp.net": {Pinned: &yes, MutedUntil: &forever},
+		"2@s.whatsapp.net": {Archived: &yes, Unread: &yes, MutedUntil: &expired},
+		"3@g.us":           {Pinned: &no},
+	}
+	for jid, update := range updates {
+		if err := repo.UpdateChatState(jid, update); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// A new message must not reset the stored state
+	if err := repo.StoreChat(&domainChatStorage.Chat{JID: "1@s.whatsapp.net", Name: "renamed", LastMessageTime: now.Add(time.Minute)}); err != nil {
+		t.Fatal(err)
+	}
+
+	service := serviceChat{chatStorageRepo: repo}
+	tests := []struct {
+		name    string
+		request domainChat.ListChatsRequest
+		want    []string
+	}{
+		{name: "pinned", request: domainChat.ListChatsRequest{Pinned: &yes}, want: []string{"1@s.whatsapp.net"}},
+		{name: "archived", request: domainChat.ListChatsRequest{Archived: &yes}, want: []string{"2@s.whatsapp.net"}},
+		{name: "unarchived", request: domainChat.ListChatsRequest{Archived: &no}, want: []string{"1@s.whatsapp.net", "3@g.us"}},
+		{name: "muted ignores expired mutes", request: domainChat.ListChatsRequest{Muted: &yes}, want: []string{"1@s.whatsapp.net"}},
+		{name: "unmuted", request: domainChat.ListChatsRequest{Muted: &no}, want: []string{"2@s.whatsapp.net", "3@g.us"}},
+		{name: "unread", request: domainChat.ListChatsRequest{Unread: &yes}, want: []string{"2@s.whatsapp.net"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			response, err := service.ListChats(context.Background(), tt.request)
+			if err != nil {
+				t.Fatal(err)
+			}
+			got := map[string]bool{}
+			for _, chat := range response.Data {
+				got[chat.JID] = true
+			}
+			if len(got) != len(tt.want) {
+				t.Fatalf("got chats %v, want %v", got, tt.want)
+			}
+			for _, jid := range tt.want {
+				if !got[jid] {
+					t.Fatalf("got chats %v, want %v", got, tt.want)
+				}
+			}
+		})
+	}
+
+	chat, err := repo.GetChat("1@s.whatsapp.net")
+	if err != nil {
+		t.Fatal(err)
+	}
+	info := toChatInfo(chat)
+	if !info.Pinned || !info.Muted || info.MutedUntil != "" || info.Name != "renamed" {
+		t.Fatalf("unexpected chat info %+v", info)
+	}
+}
+
+func TestGetChatMessagesEditsAndRevokes(t *testing.T) {
+	repo := newSQLiteTestRepo(t)
+	ctx := context.Background()
+	chat := types.NewJID("628111", types.DefaultUserServer)
+	sent := time.Now().Add(-time.Hour).Truncate(time.Second)
+
+	incoming := func(id string, at time.Time, msg *waE2E.Message) {
+		t.Helper()
+		evt := &events.Message{
+			Info:    types.MessageInfo{MessageSource: types.MessageSource{Chat: chat, Sender: chat}, ID: id, Timestamp: at},
+			Message: msg,
+		}
+		if err := repo.CreateMessage(ctx, evt); err != nil {
+			t.Fatal(err)
+		}
+	}
+	edit := func(id, target, text string, at time.Time) {
+		incoming(id, at, &waE2E.Message{ProtocolMessage: &waE2E.ProtocolMessage{
+			Type:          waE2E.ProtocolMessage_MESSAGE_EDIT.Enum(),
+			Key:           &waCommon.MessageKey{ID: proto.String(target)},
+			EditedMessage: &waE2E.Message{Conversation: proto.String(text)},
+		}})
+	}
+
+	incoming("MSG1", sent, &waE2E.Message{Conversation: proto.String("helo")})
+	incoming("MSG2", sent.Add(time.Second), &waE2E.Message{Conversation: proto.String("secret")})
+	edit("EDIT1", "MSG1", "hello", sent.Add(time.Minute))
+	edit("EDIT2", "MSG1", "hello there", sent.Add(2*time.Minute))
+	edit("EDIT1", "MSG1", "hello", sent.Add(time.Minute))
+	edit("EDIT3", "MSG2", "still secret", sent.Add(time.Minute))
+	incoming("REVOKE1", sent.Add(3*time.Minute), &waE2E.Message{ProtocolMessage: &waE2E.ProtocolMessage{
+		Type: waE2E.ProtocolMessage_REVOKE.Enum(),
+		Key:  &waCommon.MessageKey{ID: proto.String("MSG2")},
+	}})
+
+	// The original delivered again must not undo the edit
+	incoming("MSG1", sent, &waE2E.Message{Conversation: proto.String("helo")})
+
+	service := serviceChat{chatStorageRepo: repo}
+	response, err := service.GetChatMessages(ctx, domainChat.GetChatMessagesRequest{ChatJID: chat.String(), Limit: 10, IncludeEdits: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(response.Data) != 2 {
+		t.Fatalf("expected only the 2 original messages, got %+v", response.Data)
+	}
+
+	messages := map[string]domainChat.MessageInfo{}
+	for _, message := range response.Data {
+		messages[message.ID] = message
+	}
+
+	edited := messages["MSG1"]
+	if edited.Content != "hello there" || edited.EditedAt == "" || edited.Revoked {
+		t.Fatalf("unexpected edited message %+v", edited)
+	}
+	var history []string
+	for _, revision := range edited.Edits {
+		history = append(history, revision.Content)
+	}
+	if len(history) != 3 || history[0] != "helo" || history[1] != "hello" || history[2] != "hello there" {
+		t.Fatalf("unexpected revisions %v", history)
+	}
+
+	revoked := messages["MSG2"]
+	if !revoked.Revoked || revoked.RevokedAt == "" || revoked.Content != "" || len(revoked.Edits) != 0 {
+		t.Fatalf("unexpected revoked message %+v", revoked)
+	}
+}
+
+func TestMessageRepliesMentionsAndReactions(t *testing.T) {
+	repo := newSQLiteTestRepo(t)
+	ctx := context.Background()
+	group := types.NewJID("120363024512399999", types.GroupServer)
+	alice := types.NewJID("628111", types.DefaultUserServer)
+	bob := types.NewJID("628222", types.DefaultUserServer)
+	sent := time.Now().Add(-time.Hour).Truncate(time.Second)
+
+	incoming := func(id string, sender types.JID, at time.Time, msg *waE2E.Message) {
+		t.Helper()
+		evt := &events.Message{
+			Info:    types.MessageInfo{MessageSource: types.MessageSource{Chat: group, Sender: sender, IsGroup: true}, ID: id, Timestamp: at},
+			Message: msg,
+		}
+		if err := repo.CreateMessage(ctx, evt); err != nil {
+			t.Fatal(err)
+		}
+	}
+	reply := func(id string, sender types.JID, at time.Time, text, quotedID string, mentions ...string) {
+		incoming(id, sender, at, &waE2E.Message{ExtendedTextMessage: &waE2E.ExtendedTextMessage{
+			Text:        proto.String(text),
+			ContextInfo: &waE2E.ContextInfo{StanzaID: proto.String(quotedID), MentionedJID: mentions},
+		}})
+	}
+	react := func(id string, sender types.JID, at time.Time, target, emoji string) {
+		incoming(id, sender, at, &waE2E.Message{ReactionMessage: &waE2E.ReactionMessage{
+			Key:  &waCommon.MessageKey{ID: proto.String(target)},
+			Text: proto.String(emoji),
+		}})
+	}
+
+	incoming("ROOT", alice, sent, &waE2E.Message{Conversation: proto.String("lunch?")})
+	reply("R1", bob, sent.Add(time.Minute), "sure @628111", "ROOT", alice.String())
+	reply("R2", alice, sent.Add(2*time.Minute), "12:30", "R1")
+	reply("R3", bob, sent.Add(3*time.Minute), "ok", "R2")
+	reply("OTHER", bob, sent.Add(4*time.Minute), "unrelated", "")
+
+	react("X1", alice, sent.Add(5*time.Minute), "R1", "👍")
+	react("X2", alice, sent.Add(6*time.Minute), "R1", "❤️")
+	react("X3", alice, sent.Add(time.Minute), "R1", "😂")
+	react("X4", bob, sent.Add(5*time.Minute), "ROOT", "👍")
+	react("X5", bob, sent.Add(6*time.Minute), "ROOT", "")
+
+	service := serviceChat{chatStorageRepo: repo}
+	response, err := service.GetChatMessages(ctx, domainChat.GetChatMessagesRequest{ChatJID: group.String(), Limit: 10})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(response.Data) != 5 {
+		t.Fatalf("expected reactions not to be stored as messages, got %+v", response.Data)
+	}
+	messages := map[string]domainChat.MessageInfo{}
+	for _, message := range response.Data {
+		messages[message.ID] = message
+	}
+
+	r1 := messages["R1"]
+	if r1.ReplyTo == nil || r1.ReplyTo.ID != "ROOT" || r1.ReplyTo.Content != "lunch?" || r1.ReplyTo.SenderJID != alice.String() {
+		t.Fatalf("unexpected reply_to %+v", r1.ReplyTo)
+	}
+	if len(r1.Mentions) != 1 || r1.Mentions[0] != alice.String() {
+		t.Fatalf("unexpected mentions %v", r1.Mentions)
+	}
+	if len(r1.Reactions) != 1 || r1.Reactions[0].Emoji != "❤️" || r1.Reactions[0].SenderJID != alice.String() {
+		t.Fatalf("expected the latest reaction of alice, got %+v", r1.Reactions)
+	}
+	if root := messages["ROOT"]; root.ReplyTo != nil || len(root.Reactions) != 0 {
+		t.Fatalf("expected removed reaction and no reply_to on the root, got %+v", root)
+	}
+
+	thread, err := service.GetMessageThread(ctx, domainChat.GetMessageThreadRequest{MessageID: "R1", ChatJID: group.String()})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var ids []string
+	for _, message := range thread.Data {
+		ids = append(ids, message.ID)
+	}
+	if thread.RootID != "ROOT" || len(ids) != 4 || ids[0] != "ROOT" || ids[1] != "R1" || ids[2] != "R2" || ids[3] != "R3" {
+		t.Fatalf("unexpected thread root %s with %v", thread.RootID, ids)
+	}
+
+	if _, err := service.GetMessageThread(ctx, domainChat.GetMessageThreadRequest{MessageID: "R1", ChatJID: alice.String()}); err == nil {
+		t.Fatal("expected an error for a message of another chat")
+	}
+}
+
+func TestToChatInfoMutedUntil(t *testing.T) {
+	until := time.Now().Add(time.Hour).Truncate(time.Second)
+	info := toChatInfo(&domainChatStorage.Chat{JID: "1@s.whatsapp.net", MutedUntil: until.Unix()})
+	if !info.Muted || info.MutedUntil != until.Format(time.RFC3339) {
+		t.Fatalf("got muted=%t until=%q, want muted until %s", info.Muted, info.MutedUntil, until.Format(time.RFC3339))
+	}
+}
+
+func TestBuildClearChat(t *testing.T) {
+	target := types.NewJID("628123456789", types.DefaultUserServer)
+
+	patch := buildClearChat(target, false, time.Unix(1700000000, 0), nil)
+	if patch.Type != appstate.WAPatchRegularHigh || len(patch.Mutations) != 1 {
+		t.Fatalf("unexpected patch %+v", patch)
+	}
+	mutation := patch.Mutations[0]
+	wantIndex := []string{appstate.IndexClearChat, target.String(), "0", "0"}
+	for i, part := range wantIndex {
+		if mutation.Index[i] != part {
+			t.Fatalf("got index %v, want %v", mutation.Index, wantIndex)
+		}
+	}
+	if got := mutation.Value.GetClearChatAction().GetMessageRange().GetLastMessageTimestamp(); got != 1700000000 {
+		t.Fatalf("got last message timestamp %d, want 1700000000", got)
+	}
+
+	if patch := buildClearChat(target, true, time.Time{}, nil); patch.Mutations[0].Index[2] != "1" {
+		t.Fatalf("delete starred flag not set in index %v", patch.Mutations[0].Index)
+	}
+}
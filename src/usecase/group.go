@@ -3,26 +3,38 @@ package usecase
 import (
 	"context"
 	"fmt"
+	"strings"
 
 	"github.com/sirupsen/logrus"
 
 	"github.com/aldinokemal/go-whatsapp-web-multidevice/config"
+	domainAudit "github.com/aldinokemal/go-whatsapp-web-multidevice/domains/audit"
+	domainChatStorage "github.com/aldinokemal/go-whatsapp-web-multidevice/domains/chatstorage"
 	domainGroup "github.com/aldinokemal/go-whatsapp-web-multidevice/domains/group"
 	"github.com/aldinokemal/go-whatsapp-web-multidevice/infrastructure/whatsapp"
 	pkgError "github.com/aldinokemal/go-whatsapp-web-multidevice/pkg/error"
 	"github.com/aldinokemal/go-whatsapp-web-multidevice/pkg/utils"
 	"github.com/aldinokemal/go-whatsapp-web-multidevice/validations"
 	"go.mau.fi/whatsmeow"
+	"go.mau.fi/whatsmeow/proto/waE2E"
 	"go.mau.fi/whatsmeow/types"
+	"google.golang.org/protobuf/proto"
 )
 
-type serviceGroup struct{}
+type serviceGroup struct {
+	chatStorageRepo domainChatStorage.IChatStorageRepository
+}
 
-func NewGroupService() domainGroup.IGroupUsecase {
-	return &serviceGroup{}
+func NewGroupService(chatStorageRepo domainChatStorage.IChatStorageRepository) domainGroup.IGroupUsecase {
+	return &serviceGroup{
+		chatStorageRepo: chatStorageRepo,
+	}
 }
 
 func (service serviceGroup) JoinGroupWithLink(ctx context.Context, request domainGroup.JoinGroupWithLinkRequest) (groupID string, err error) {
+	defer func() {
+		recordAudit(ctx, service.chatStorageRepo, auditRecord{action: domainAudit.ActionGroupJoin, target: groupID, details: request.Link}, err, recover())
+	}()
 	if err = validations.ValidateJoinGroupWithLink(ctx, request); err != nil {
 		return groupID, err
 	}
@@ -36,6 +48,9 @@ func (service serviceGroup) JoinGroupWithLink(ctx context.Context, request domai
 }
 
 func (service serviceGroup) LeaveGroup(ctx context.Context, request domainGroup.LeaveGroupRequest) (err error) {
+	defer func() {
+		recordAudit(ctx, service.chatStorageRepo, auditRecord{action: domainAudit.ActionGroupLeave, target: request.GroupID}, err, recover())
+	}()
 	if err = validations.ValidateLeaveGroup(ctx, request); err != nil {
 		return err
 	}
@@ -49,6 +64,9 @@ func (service serviceGroup) LeaveGroup(ctx context.Context, request domainGroup.
 }
 
 func (service serviceGroup) CreateGroup(ctx context.Context, request domainGroup.CreateGroupRequest) (groupID string, err error) {
+	defer func() {
+		recordAudit(ctx, service.chatStorageRepo, auditRecord{action: domainAudit.ActionGroupCreate, target: groupID, details: strings.Join(request.Participants, ",")}, err, recover())
+	}()
 	if err = validations.ValidateCreateGroup(ctx, request); err != nil {
 		return groupID, err
 	}
@@ -101,6 +119,9 @@ func (service serviceGroup) GetGroupInfoFromLink(ctx context.Context, request do
 }
 
 func (service serviceGroup) ManageParticipant(ctx context.Context, request domainGroup.ParticipantRequest) (result []domainGroup.ParticipantStatus, err error) {
+	defer func() {
+		recordAudit(ctx, service.chatStorageRepo, auditRecord{action: domainAudit.ActionGroupParticipants, target: request.GroupID, details: fmt.Sprintf("%s: %s", request.Action, strings.Join(request.Participants, ","))}, err, recover())
+	}()
 	if err = validations.ValidateParticipant(ctx, request); err != nil {
 		return result, err
 	}
@@ -202,6 +223,9 @@ func (service serviceGroup) GetGroupRequestParticipants(ctx context.Context, req
 }
 
 func (service serviceGroup) ManageGroupRequestParticipants(ctx context.Context, request domainGroup.GroupRequestParticipantsRequest) (result []domainGroup.ParticipantStatus, err error) {
+	defer func() {
+		recordAudit(ctx, service.chatStorageRepo, auditRecord{action: domainAudit.ActionGroupParticipantRequests, target: request.GroupID, details: fmt.Sprintf("%s: %s", request.Action, strings.Join(request.Participants, ","))}, err, recover())
+	}()
 	if err = validations.ValidateManageGroupRequestParticipants(ctx, request); err != nil {
 		return result, err
 	}
@@ -257,6 +281,9 @@ func (service serviceGroup) participantToJID(participants []string) ([]types.JID
 }
 
 func (service serviceGroup) SetGroupPhoto(ctx context.Context, request domainGroup.SetGroupPhotoRequest) (pictureID string, err error) {
+	defer func() {
+		recordAudit(ctx, service.chatStorageRepo, auditRecord{action: domainAudit.ActionGroupPhoto, target: request.GroupID, details: pictureID}, err, recover())
+	}()
 	if err = validations.ValidateSetGroupPhoto(ctx, request); err != nil {
 		return pictureID, err
 	}
@@ -294,6 +321,9 @@ func (service serviceGroup) SetGroupPhoto(ctx context.Context, request domainGro
 }
 
 func (service serviceGroup) SetGroupName(ctx context.Context, request domainGroup.SetGroupNameRequest) (err error) {
+	defer func() {
+		recordAudit(ctx, service.chatStorageRepo, auditRecord{action: domainAudit.ActionGroupName, target: request.GroupID, details: request.Name}, err, recover())
+	}()
 	if err = validations.ValidateSetGroupName(ctx, request); err != nil {
 		return err
 	}
@@ -307,6 +337,9 @@ func (service serviceGroup) SetGroupName(ctx context.Context, request domainGrou
 }
 
 func (service serviceGroup) SetGroupLocked(ctx context.Context, request domainGroup.SetGroupLockedRequest) (err error) {
+	defer func() {
+		recordAudit(ctx, service.chatStorageRepo, auditRecord{action: domainAudit.ActionGroupLocked, target: request.GroupID, details: fmt.Sprintf("locked=%t", request.Locked)}, err, recover())
+	}()
 	if err = validations.ValidateSetGroupLocked(ctx, request); err != nil {
 		return err
 	}
@@ -320,6 +353,9 @@ func (service serviceGroup) SetGroupLocked(ctx context.Context, request domainGr
 }
 
 func (service serviceGroup) SetGroupAnnounce(ctx context.Context, request domainGroup.SetGroupAnnounceRequest) (err error) {
+	defer func() {
+		recordAudit(ctx, service.chatStorageRepo, auditRecord{action: domainAudit.ActionGroupAnnounce, target: request.GroupID, details: fmt.Sprintf("announce=%t", request.Announce)}, err, recover())
+	}()
 	if err = validations.ValidateSetGroupAnnounce(ctx, request); err != nil {
 		return err
 	}
@@ -333,6 +369,9 @@ func (service serviceGroup) SetGroupAnnounce(ctx context.Context, request domain
 }
 
 func (service serviceGroup) SetGroupTopic(ctx context.Context, request domainGroup.SetGroupTopicRequest) (err error) {
+	defer func() {
+		recordAudit(ctx, service.chatStorageRepo, auditRecord{action: domainAudit.ActionGroupTopic, target: request.GroupID, details: request.Topic}, err, recover())
+	}()
 	if err = validations.ValidateSetGroupTopic(ctx, request); err != nil {
 		return err
 	}
@@ -370,13 +409,22 @@ func (service serviceGroup) GroupInfo(ctx context.Context, request domainGroup.G
 
 	// Map the response
 	if groupInfo != nil {
-		response.Data = *groupInfo
+		response.Data = domainGroup.GroupInfoData{
+			GroupInfo: *groupInfo,
+			Community: communityMembership(groupInfo),
+		}
 	}
 
 	return response, nil
 }
 
 func (service serviceGroup) GetGroupInviteLink(ctx context.Context, request domainGroup.GetGroupInviteLinkRequest) (response domainGroup.GetGroupInviteLinkResponse, err error) {
+	// Only resetting the link changes state
+	if request.Reset {
+		defer func() {
+			recordAudit(ctx, service.chatStorageRepo, auditRecord{action: domainAudit.ActionGroupResetInviteLink, target: request.GroupID}, err, recover())
+		}()
+	}
 	if err = validations.ValidateGetGroupInviteLink(ctx, request); err != nil {
 		return response, err
 	}
@@ -399,3 +447,163 @@ func (service serviceGroup) GetGroupInviteLink(ctx context.Context, request doma
 
 	return response, nil
 }
+
+func (service serviceGroup) CreateCommunity(ctx context.Context, request domainGroup.CreateCommunityRequest) (communityID string, err error) {
+	defer func() {
+		recordAudit(ctx, service.chatStorageRepo, auditRecord{action: domainAudit.ActionCommunityCreate, target: communityID, details: request.Name}, err, recover())
+	}()
+	if err = validations.ValidateCreateCommunity(ctx, request); err != nil {
+		return communityID, err
+	}
+	utils.MustLogin(whatsapp.GetClient())
+
+	groupInfo, err := whatsapp.GetClient().CreateGroup(ctx, whatsmeow.ReqCreateGroup{
+		Name: request.Name,
+		GroupParent: types.GroupParent{
+			IsParent:                      true,
+			DefaultMembershipApprovalMode: "request_required",
+		},
+	})
+	if err != nil {
+		return communityID, err
+	}
+
+	if request.Description != "" {
+		if err = whatsapp.GetClient().SetGroupTopic(ctx, groupInfo.JID, "", "", request.Description); err != nil {
+			logrus.Warnf("Community %s created but failed to set description: %v", groupInfo.JID.String(), err)
+		}
+	}
+
+	return groupInfo.JID.String(), nil
+}
+
+func (service serviceGroup) LinkGroup(ctx context.Context, request domainGroup.LinkGroupRequest) (err error) {
+	defer func() {
+		recordAudit(ctx, service.chatStorageRepo, auditRecord{action: domainAudit.ActionCommunityLink, target: request.GroupID, details: "community " + request.CommunityID}, err, recover())
+	}()
+	if err = validations.ValidateLinkGroup(ctx, request); err != nil {
+		return err
+	}
+
+	communityJID, err := utils.ValidateJidWithLogin(whatsapp.GetClient(), request.CommunityID)
+	if err != nil {
+		return err
+	}
+	groupJID, err := utils.ValidateJidWithLogin(whatsapp.GetClient(), request.GroupID)
+	if err != nil {
+		return err
+	}
+
+	return whatsapp.GetClient().LinkGroup(ctx, communityJID, groupJID)
+}
+
+func (service serviceGroup) UnlinkGroup(ctx context.Context, request domainGroup.UnlinkGroupRequest) (err error) {
+	defer func() {
+		recordAudit(ctx, service.chatStorageRepo, auditRecord{action: domainAudit.ActionCommunityUnlink, target: request.GroupID, details: "community " + request.CommunityID}, err, recover())
+	}()
+	if err = validations.ValidateUnlinkGroup(ctx, request); err != nil {
+		return err
+	}
+
+	communityJID, err := utils.ValidateJidWithLogin(whatsapp.GetClient(), request.CommunityID)
+	if err != nil {
+		return err
+	}
+	groupJID, err := utils.ValidateJidWithLogin(whatsapp.GetClient(), request.GroupID)
+	if err != nil {
+		return err
+	}
+
+	return whatsapp.GetClient().UnlinkGroup(ctx, communityJID, groupJID)
+}
+
+func (service serviceGroup) GetLinkedGroups(ctx context.Context, request domainGroup.GetLinkedGroupsRequest) (response domainGroup.GetLinkedGroupsResponse, err error) {
+	if err = validations.ValidateGetLinkedGroups(ctx, request); err != nil {
+		return response, err
+	}
+
+	communityJID, err := utils.ValidateJidWithLogin(whatsapp.GetClient(), request.CommunityID)
+	if err != nil {
+		return response, err
+	}
+
+	subGroups, err := whatsapp.GetClient().GetSubGroups(ctx, communityJID)
+	if err != nil {
+		return response, err
+	}
+
+	response.CommunityID = communityJID.String()
+	response.Groups = make([]domainGroup.LinkedGroup, 0, len(subGroups))
+	for _, subGroup := range subGroups {
+		response.Groups = append(response.Groups, domainGroup.LinkedGroup{
+			JID:                 subGroup.JID.String(),
+			Name:                subGroup.Name,
+			IsAnnouncementGroup: subGroup.IsDefaultSubGroup,
+		})
+	}
+
+	return response, nil
+}
+
+func (service serviceGroup) SendCommunityAnnouncement(ctx context.Context, request domainGroup.CommunityAnnouncementRequest) (response domainGroup.CommunityAnnouncementResponse, err error) {
+	defer func() {
+		recordAudit(ctx, service.chatStorageRepo, auditRecord{action: domainAudit.ActionCommunityAnnouncement, target: request.CommunityID, messageID: response.MessageID}, err, recover())
+	}()
+	if err = validations.ValidateCommunityAnnouncement(ctx, request); err != nil {
+		return response, err
+	}
+
+	communityJID, err := utils.ValidateJidWithLogin(whatsapp.GetClient(), request.CommunityID)
+	if err != nil {
+		return response, err
+	}
+
+	// Announcements are posted to the community's default sub-group, which only admins can write to
+	subGroups, err := whatsapp.GetClient().GetSubGroups(ctx, communityJID)
+	if err != nil {
+		return response, err
+	}
+	var announcementJID types.JID
+	for _, subGroup := range subGroups {
+		if subGroup.IsDefaultSubGroup {
+			announcementJID = subGroup.JID
+			break
+		}
+	}
+	if announcementJID.IsEmpty() {
+		return response, pkgError.ValidationError(fmt.Sprintf("community %s has no announcement group", communityJID.String()))
+	}
+
+	msg := &waE2E.Message{ExtendedTextMessage: &waE2E.ExtendedTextMessage{
+		Text: proto.String(request.Message),
+	}}
+	ts, err := whatsapp.SendMessage(ctx, announcementJID, msg)
+	if err != nil {
+		return response, err
+	}
+
+	senderJID := ""
+	if whatsapp.GetClient().Store.ID != nil {
+		senderJID = whatsapp.GetClient().Store.ID.String()
+	}
+	if err := service.chatStorageRepo.StoreSentMessageWithContext(ctx, ts.ID, senderJID, announcementJID.String(), request.Message, ts.Timestamp, "", nil); err != nil {
+		logrus.Warnf("Failed to store community announcement %s: %v", ts.ID, err)
+	}
+
+	response.MessageID = ts.ID
+	response.GroupID = announcementJID.String()
+	response.Status = fmt.Sprintf("Announcement sent to %s (server timestamp: %s)", announcementJID.String(), ts.Timestamp.String())
+	return response, nil
+}
+
+// communityMembership describes how a group relates to a WhatsApp Community
+func communityMembership(groupInfo *types.GroupInfo) (membership domainGroup.CommunityMembership) {
+	membership.IsCommunity = groupInfo.IsParent
+	membership.IsAnnouncementGroup = groupInfo.IsDefaultSubGroup
+	if groupInfo.IsParent {
+		membership.CommunityID = groupInfo.JID.String()
+	} else if !groupInfo.LinkedParentJID.IsEmpty() {
+		membership.CommunityID = groupInfo.LinkedParentJID.String()
+	}
+	return membership
+}
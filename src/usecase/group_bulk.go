@@ -0,0 +1,523 @@
+package usecase
+
+import (
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+
+	domainAudit "github.com/aldinokemal/go-whatsapp-web-multidevice/domains/audit"
+	domainChatStorage "github.com/aldinokemal/go-whatsapp-web-multidevice/domains/chatstorage"
+	domainGroup "github.com/aldinokemal/go-whatsapp-web-multidevice/domains/group"
+	"github.com/aldinokemal/go-whatsapp-web-multidevice/infrastructure/whatsapp"
+	pkgError "github.com/aldinokemal/go-whatsapp-web-multidevice/pkg/error"
+	"github.com/aldinokemal/go-whatsapp-web-multidevice/pkg/metrics"
+	"github.com/aldinokemal/go-whatsapp-web-multidevice/pkg/utils"
+	"github.com/aldinokemal/go-whatsapp-web-multidevice/validations"
+	"go.mau.fi/whatsmeow"
+	"go.mau.fi/whatsmeow/proto/waE2E"
+	"go.mau.fi/whatsmeow/types"
+	"google.golang.org/protobuf/proto"
+)
+
+const (
+	bulkJobStatusPending     = "pending"
+	bulkJobStatusRunning     = "running"
+	bulkJobStatusCompleted   = "completed"
+	bulkJobStatusInterrupted = "interrupted" // Still pending or running when the app stopped
+
+	bulkRowStatusPending = "pending"
+	bulkRowStatusSuccess = "success"
+	bulkRowStatusFailed  = "failed"
+	bulkRowStatusInvited = "invited"
+
+	defaultBulkBatchSize    = 10
+	defaultBulkDelaySeconds = 5
+	maxBulkRows             = 5000
+)
+
+// bulkJobLock makes bulk jobs run one after another, so parallel jobs cannot bypass the throttling
+var bulkJobLock sync.Mutex
+
+var bulkActions = map[string]whatsmeow.ParticipantChange{
+	string(whatsmeow.ParticipantChangeAdd):     whatsmeow.ParticipantChangeAdd,
+	string(whatsmeow.ParticipantChangeRemove):  whatsmeow.ParticipantChangeRemove,
+	string(whatsmeow.ParticipantChangePromote): whatsmeow.ParticipantChangePromote,
+	string(whatsmeow.ParticipantChangeDemote):  whatsmeow.ParticipantChangeDemote,
+}
+
+func (service serviceGroup) StartBulkParticipantJob(ctx context.Context, request domainGroup.BulkParticipantJobRequest) (response domainGroup.BulkParticipantJobResponse, err error) {
+	defer func() {
+		recordAudit(ctx, service.chatStorageRepo, auditRecord{action: domainAudit.ActionGroupBulkParticipants, details: "job " + response.JobID}, err, recover())
+	}()
+	if err = validations.ValidateBulkParticipantJob(ctx, request); err != nil {
+		return response, err
+	}
+	utils.MustLogin(whatsapp.GetClient())
+
+	file, err := request.File.Open()
+	if err != nil {
+		return response, err
+	}
+	defer file.Close()
+
+	rows, err := parseBulkParticipantCSV(file)
+	if err != nil {
+		return response, err
+	}
+
+	job := &domainChatStorage.GroupBulkJob{
+		ID:           uuid.NewString(),
+		Status:       bulkJobStatusPending,
+		BatchSize:    request.BatchSize,
+		DelaySeconds: request.DelaySeconds,
+		Total:        len(rows),
+	}
+	if job.BatchSize == 0 {
+		job.BatchSize = defaultBulkBatchSize
+	}
+	if job.DelaySeconds == 0 {
+		job.DelaySeconds = defaultBulkDelaySeconds
+	}
+	for _, row := range rows {
+		row.JobID = job.ID
+		// Rows rejected while parsing are already final
+		if row.Status == bulkRowStatusFailed {
+			job.Processed++
+			job.Failed++
+		}
+	}
+
+	if err = service.chatStorageRepo.CreateGroupBulkJob(job, rows); err != nil {
+		return response, err
+	}
+
+	// The job outlives the request, keep only its origin for the audit log
+	jobCtx := domainAudit.ContextWithOrigin(context.Background(), domainAudit.OriginFromContext(ctx))
+	metrics.BulkParticipantRowsPending.Add(float64(job.Total - job.Processed))
+	// The job is owned by its goroutine from here on, build the response before it starts
+	response = toBulkParticipantJobResponse(job)
+	go service.runBulkParticipantJob(jobCtx, job, rows)
+
+	return response, nil
+}
+
+// FailInterruptedBulkJobs finishes the bulk jobs left pending or running by a previous run. Bulk jobs run in memory,
+// so none can still be running at startup. Their remaining rows are marked failed instead of resumed, as the session
+// may not be connected yet and the CSV may no longer be wanted.
+func (service serviceGroup) FailInterruptedBulkJobs() error {
+	jobs, err := service.chatStorageRepo.GetUnfinishedGroupBulkJobs()
+	if err != nil {
+		return fmt.Errorf("failed to load unfinished bulk jobs: %w", err)
+	}
+
+	var errs []error
+	for _, job := range jobs {
+		rows, err := service.chatStorageRepo.GetGroupBulkJobRows(job.ID)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("failed to load rows of bulk job %s: %w", job.ID, err))
+			continue
+		}
+
+		now := time.Now()
+		job.Processed, job.Succeeded, job.Invited, job.Failed = 0, 0, 0, 0
+		for _, row := range rows {
+			if row.Status == bulkRowStatusPending {
+				row.Status = bulkRowStatusFailed
+				row.Message = "interrupted by a restart"
+				row.ProcessedAt = &now
+				if err := service.chatStorageRepo.UpdateGroupBulkJobRow(row); err != nil {
+					errs = append(errs, fmt.Errorf("failed to update row %d of bulk job %s: %w", row.RowNumber, job.ID, err))
+				}
+			}
+
+			job.Processed++
+			switch row.Status {
+			case bulkRowStatusSuccess:
+				job.Succeeded++
+			case bulkRowStatusInvited:
+				job.Invited++
+			default:
+				job.Failed++
+			}
+		}
+
+		job.Status = bulkJobStatusInterrupted
+		job.FinishedAt = &now
+		if err := service.chatStorageRepo.UpdateGroupBulkJob(job); err != nil {
+			errs = append(errs, fmt.Errorf("failed to update bulk job %s: %w", job.ID, err))
+			continue
+		}
+		logrus.Warnf("Bulk job %s was interrupted by a restart: %d succeeded, %d invited, %d failed", job.ID, job.Succeeded, job.Invited, job.Failed)
+	}
+
+	return errors.Join(errs...)
+}
+
+func (service serviceGroup) GetBulkParticipantJob(ctx context.Context, request domainGroup.GetBulkParticipantJobRequest) (response domainGroup.BulkParticipantJobResponse, err error) {
+	if err = validations.ValidateGetBulkParticipantJob(ctx, request); err != nil {
+		return response, err
+	}
+
+	job, err := service.chatStorageRepo.GetGroupBulkJob(request.JobID)
+	if err != nil {
+		return response, err
+	}
+	if job == nil {
+		return response, pkgError.ValidationError(fmt.Sprintf("bulk job %s not found", request.JobID))
+	}
+
+	return toBulkParticipantJobResponse(job), nil
+}
+
+func (service serviceGroup) GetBulkParticipantJobRows(ctx context.Context, request domainGroup.GetBulkParticipantJobRequest) (rows []domainGroup.BulkParticipantJobRow, err error) {
+	if _, err = service.GetBulkParticipantJob(ctx, request); err != nil {
+		return rows, err
+	}
+
+	jobRows, err := service.chatStorageRepo.GetGroupBulkJobRows(request.JobID)
+	if err != nil {
+		return rows, err
+	}
+
+	rows = make([]domainGroup.BulkParticipantJobRow, 0, len(jobRows))
+	for _, row := range jobRows {
+		rows = append(rows, domainGroup.BulkParticipantJobRow{
+			Row:         row.RowNumber,
+			GroupID:     row.GroupJID,
+			Phone:       row.Phone,
+			Action:      row.Action,
+			Status:      row.Status,
+			Message:     row.Message,
+			ProcessedAt: row.ProcessedAt,
+		})
+	}
+
+	return rows, nil
+}
+
+// runBulkParticipantJob processes the rows of a job in throttled batches per group and action
+func (service serviceGroup) runBulkParticipantJob(ctx context.Context, job *domainChatStorage.GroupBulkJob, rows []*domainChatStorage.GroupBulkJobRow) {
+	bulkJobLock.Lock()
+	defer bulkJobLock.Unlock()
+
+	job.Status = bulkJobStatusRunning
+	if err := service.chatStorageRepo.UpdateGroupBulkJob(job); err != nil {
+		logrus.Errorf("Failed to update bulk job %s: %v", job.ID, err)
+	}
+
+	inviteLinks := make(map[string]string)
+	first := true
+	for _, batch := range bulkParticipantBatches(rows, job.BatchSize) {
+		if !first {
+			time.Sleep(time.Duration(job.DelaySeconds) * time.Second)
+		}
+		first = false
+
+		service.processBulkParticipantBatch(ctx, batch, inviteLinks)
+
+		now := time.Now()
+		for _, row := range batch {
+			row.ProcessedAt = &now
+			if err := service.chatStorageRepo.UpdateGroupBulkJobRow(row); err != nil {
+				logrus.Errorf("Failed to update row %d of bulk job %s: %v", row.RowNumber, job.ID, err)
+			}
+
+			var rowErr error
+			if row.Status == bulkRowStatusFailed {
+				rowErr = errors.New(row.Message)
+			}
+			recordAudit(ctx, service.chatStorageRepo, auditRecord{
+				action:  domainAudit.ActionGroupParticipants,
+				target:  row.GroupJID,
+				details: fmt.Sprintf("%s: %s (job %s row %d, %s)", row.Action, row.Phone, job.ID, row.RowNumber, row.Status),
+			}, rowErr, nil)
+
+			job.Processed++
+			metrics.BulkParticipantRowsPending.Dec()
+			switch row.Status {
+			case bulkRowStatusSuccess:
+				job.Succeeded++
+			case bulkRowStatusInvited:
+				job.Invited++
+			default:
+				job.Failed++
+			}
+		}
+		if err := service.chatStorageRepo.UpdateGroupBulkJob(job); err != nil {
+			logrus.Errorf("Failed to update bulk job %s: %v", job.ID, err)
+		}
+	}
+
+	finishedAt := time.Now()
+	job.Status = bulkJobStatusCompleted
+	job.FinishedAt = &finishedAt
+	if err := service.chatStorageRepo.UpdateGroupBulkJob(job); err != nil {
+		logrus.Errorf("Failed to update bulk job %s: %v", job.ID, err)
+	}
+
+	logrus.Infof("Bulk job %s completed: %d succeeded, %d invited, %d failed", job.ID, job.Succeeded, job.Invited, job.Failed)
+}
+
+// processBulkParticipantBatch applies one batch of rows sharing the same group and action
+func (service serviceGroup) processBulkParticipantBatch(ctx context.Context, batch []*domainChatStorage.GroupBulkJobRow, inviteLinks map[string]string) {
+	client := whatsapp.GetClient()
+	if client == nil || !client.IsLoggedIn() {
+		markBulkRows(batch, bulkRowStatusFailed, pkgError.ErrNotLoggedIn.Error())
+		return
+	}
+
+	groupJID, err := types.ParseJID(batch[0].GroupJID)
+	if err != nil {
+		markBulkRows(batch, bulkRowStatusFailed, fmt.Sprintf("invalid group id: %v", err))
+		return
+	}
+	action := bulkActions[batch[0].Action]
+
+	queries := make([]string, 0, len(batch))
+	for _, row := range batch {
+		queries = append(queries, "+"+row.Phone)
+	}
+	registered, err := client.IsOnWhatsApp(ctx, queries)
+	if err != nil {
+		markBulkRows(batch, bulkRowStatusFailed, fmt.Sprintf("failed to check WhatsApp registration: %v", err))
+		return
+	}
+
+	registeredJIDs := make(map[string]types.JID)
+	for _, result := range registered {
+		if result.IsIn {
+			registeredJIDs[strings.TrimPrefix(result.Query, "+")] = result.JID
+		}
+	}
+
+	var participants []types.JID
+	var pending []*domainChatStorage.GroupBulkJobRow
+	for _, row := range batch {
+		jid, ok := registeredJIDs[row.Phone]
+		if !ok {
+			row.Status = bulkRowStatusFailed
+			row.Message = pkgError.ErrUserNotRegistered.Error()
+			continue
+		}
+		participants = append(participants, jid)
+		pending = append(pending, row)
+	}
+	if len(participants) == 0 {
+		return
+	}
+
+	results, err := client.UpdateGroupParticipants(ctx, groupJID, participants, action)
+	if err != nil {
+		markBulkRows(pending, bulkRowStatusFailed, err.Error())
+		return
+	}
+
+	resultsByUser := make(map[string]types.GroupParticipant)
+	for _, result := range results {
+		resultsByUser[result.JID.User] = result
+		if !result.PhoneNumber.IsEmpty() {
+			resultsByUser[result.PhoneNumber.User] = result
+		}
+	}
+
+	for _, row := range pending {
+		result, ok := resultsByUser[registeredJIDs[row.Phone].User]
+		switch {
+		case !ok:
+			row.Status = bulkRowStatusFailed
+			row.Message = "no result returned by WhatsApp"
+		case result.Error == 0:
+			row.Status = bulkRowStatusSuccess
+			row.Message = fmt.Sprintf("Action %s success", action)
+		case result.Error == 403 && result.AddRequest != nil:
+			// The user's privacy settings block being added directly, invite them instead
+			if err := service.sendBulkInviteLink(ctx, client, groupJID, registeredJIDs[row.Phone], inviteLinks); err != nil {
+				row.Status = bulkRowStatusFailed
+				row.Message = fmt.Sprintf("adding blocked by privacy settings and sending invite link failed: %v", err)
+			} else {
+				row.Status = bulkRowStatusInvited
+				row.Message = "adding blocked by privacy settings, invite link sent"
+			}
+		default:
+			row.Status = bulkRowStatusFailed
+			row.Message = fmt.Sprintf("Action %s failed (code %d)", action, result.Error)
+		}
+	}
+}
+
+// sendBulkInviteLink sends the group invite link to a user, caching the link per group
+func (service serviceGroup) sendBulkInviteLink(ctx context.Context, client *whatsmeow.Client, groupJID, recipient types.JID, inviteLinks map[string]string) error {
+	link, ok := inviteLinks[groupJID.String()]
+	if !ok {
+		var err error
+		link, err = client.GetGroupInviteLink(ctx, groupJID, false)
+		if err != nil {
+			return err
+		}
+		inviteLinks[groupJID.String()] = link
+	}
+
+	text := fmt.Sprintf("You are invited to join our WhatsApp group: %s", link)
+	ts, err := whatsapp.SendMessage(ctx, recipient, &waE2E.Message{Conversation: proto.String(text)})
+	if err != nil {
+		return err
+	}
+
+	senderJID := ""
+	if client.Store.ID != nil {
+		senderJID = client.Store.ID.String()
+	}
+	if err := service.chatStorageRepo.StoreSentMessageWithContext(ctx, ts.ID, senderJID, recipient.String(), text, ts.Timestamp, "", nil); err != nil {
+		logrus.Warnf("Failed to store group invite message %s: %v", ts.ID, err)
+	}
+
+	return nil
+}
+
+// parseBulkParticipantCSV reads group_id, phone and action columns; rows that cannot be processed are marked failed
+func parseBulkParticipantCSV(reader io.Reader) ([]*domainChatStorage.GroupBulkJobRow, error) {
+	csvReader := csv.NewReader(reader)
+	csvReader.FieldsPerRecord = -1
+	csvReader.TrimLeadingSpace = true
+
+	header, err := csvReader.Read()
+	if errors.Is(err, io.EOF) {
+		return nil, pkgError.ValidationError("csv file is empty")
+	}
+	if err != nil {
+		return nil, pkgError.ValidationError(fmt.Sprintf("invalid csv file: %v", err))
+	}
+
+	columns := make(map[string]int)
+	for i, column := range header {
+		columns[strings.ToLower(strings.TrimSpace(strings.TrimPrefix(column, "\ufeff")))] = i
+	}
+	for _, column := range []string{"group_id", "phone", "action"} {
+		if _, ok := columns[column]; !ok {
+			return nil, pkgError.ValidationError(fmt.Sprintf("csv header must contain %s column", column))
+		}
+	}
+
+	var rows []*domainChatStorage.GroupBulkJobRow
+	for line := 2; ; line++ {
+		record, err := csvReader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, pkgError.ValidationError(fmt.Sprintf("invalid csv file: %v", err))
+		}
+
+		field := func(name string) string {
+			if index := columns[name]; index < len(record) {
+				return strings.TrimSpace(record[index])
+			}
+			return ""
+		}
+
+		row := &domainChatStorage.GroupBulkJobRow{
+			RowNumber: line,
+			GroupJID:  field("group_id"),
+			Phone:     normalizeBulkPhone(field("phone")),
+			Action:    strings.ToLower(field("action")),
+			Status:    bulkRowStatusPending,
+		}
+		if row.GroupJID == "" && row.Phone == "" && row.Action == "" {
+			continue
+		}
+		if row.GroupJID != "" && !strings.Contains(row.GroupJID, "@") {
+			row.GroupJID += "@" + types.GroupServer
+		}
+
+		switch {
+		case row.GroupJID == "":
+			row.Status, row.Message = bulkRowStatusFailed, "group_id is required"
+		case row.Phone == "":
+			row.Status, row.Message = bulkRowStatusFailed, "phone is required"
+		case bulkActions[row.Action] == "":
+			row.Status, row.Message = bulkRowStatusFailed, fmt.Sprintf("invalid action %q, must be add, remove, promote or demote", row.Action)
+		}
+
+		rows = append(rows, row)
+		if len(rows) > maxBulkRows {
+			return nil, pkgError.ValidationError(fmt.Sprintf("csv file exceeds the maximum of %d rows", maxBulkRows))
+		}
+	}
+
+	if len(rows) == 0 {
+		return nil, pkgError.ValidationError("csv file has no rows")
+	}
+
+	return rows, nil
+}
+
+// bulkParticipantBatches groups pending rows by group and action, in CSV order, and splits them into batches
+func bulkParticipantBatches(rows []*domainChatStorage.GroupBulkJobRow, batchSize int) [][]*domainChatStorage.GroupBulkJobRow {
+	var keys []string
+	grouped := make(map[string][]*domainChatStorage.GroupBulkJobRow)
+	for _, row := range rows {
+		if row.Status != bulkRowStatusPending {
+			continue
+		}
+		key := row.GroupJID + "|" + row.Action
+		if _, ok := grouped[key]; !ok {
+			keys = append(keys, key)
+		}
+		grouped[key] = append(grouped[key], row)
+	}
+
+	var batches [][]*domainChatStorage.GroupBulkJobRow
+	for _, key := range keys {
+		group := grouped[key]
+		for start := 0; start < len(group); start += batchSize {
+			end := min(start+batchSize, len(group))
+			batches = append(batches, group[start:end])
+		}
+	}
+	return batches
+}
+
+// normalizeBulkPhone keeps only the digits of a phone number
+func normalizeBulkPhone(phone string) string {
+	if at := strings.Index(phone, "@"); at >= 0 {
+		phone = phone[:at]
+	}
+	return strings.Map(func(r rune) rune {
+		if r >= '0' && r <= '9' {
+			return r
+		}
+		return -1
+	}, phone)
+}
+
+func markBulkRows(rows []*domainChatStorage.GroupBulkJobRow, status, message string) {
+	for _, row := range rows {
+		row.Status = status
+		row.Message = message
+	}
+}
+
+func toBulkParticipantJobResponse(job *domainChatStorage.GroupBulkJob) domainGroup.BulkParticipantJobResponse {
+	return domainGroup.BulkParticipantJobResponse{
+		JobID:        job.ID,
+		Status:       job.Status,
+		BatchSize:    job.BatchSize,
+		DelaySeconds: job.DelaySeconds,
+		Total:        job.Total,
+		Processed:    job.Processed,
+		Succeeded:    job.Succeeded,
+		Failed:       job.Failed,
+		Invited:      job.Invited,
+		CreatedAt:    job.CreatedAt,
+		UpdatedAt:    job.UpdatedAt,
+		FinishedAt:   job.FinishedAt,
+	}
+}
@@ -0,0 +1,118 @@
+package usecase
+
+import (
+	"strings"
+	"testing"
+
+	domainChatStorage "github.com/aldinokemal/go-whatsapp-web-multidevice/domains/chatstorage"
+)
+
+func TestParseBulkParticipantCSV(t *testing.T) {
+	input := "Phone,Group_ID,Action\n" +
+		"+62 812-3456-7890,120363000000000001,add\n" +
+		"6281200000001,120363000000000001@g.us,PROMOTE\n" +
+		",,\n" +
+		"6281200000002,120363000000000001,kick\n" +
+		"6281200000003,,remove\n"
+
+	rows, err := parseBulkParticipantCSV(strings.NewReader(input))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(rows) != 4 {
+		t.Fatalf("expected 4 rows, got %d", len(rows))
+	}
+
+	first := rows[0]
+	if first.RowNumber != 2 || first.Phone != "6281234567890" || first.GroupJID != "120363000000000001@g.us" || first.Action != "add" {
+		t.Fatalf("unexpected first row: %+v", first)
+	}
+	if first.Status != bulkRowStatusPending {
+		t.Fatalf("expected first row to be pending, got %s", first.Status)
+	}
+	if rows[1].Action != "promote" || rows[1].Status != bulkRowStatusPending {
+		t.Fatalf("unexpected second row: %+v", rows[1])
+	}
+	if rows[2].RowNumber != 5 || rows[2].Status != bulkRowStatusFailed {
+		t.Fatalf("expected row with invalid action to fail, got %+v", rows[2])
+	}
+	if rows[3].Status != bulkRowStatusFailed || rows[3].Message != "group_id is required" {
+		t.Fatalf("expected row without group to fail, got %+v", rows[3])
+	}
+}
+
+func TestParseBulkParticipantCSVInvalidHeader(t *testing.T) {
+	if _, err := parseBulkParticipantCSV(strings.NewReader("group,phone\n1,2\n")); err == nil {
+		t.Fatal("expected error for missing columns")
+	}
+	if _, err := parseBulkParticipantCSV(strings.NewReader("")); err == nil {
+		t.Fatal("expected error for empty file")
+	}
+	if _, err := parseBulkParticipantCSV(strings.NewReader("group_id,phone,action\n")); err == nil {
+		t.Fatal("expected error for file without rows")
+	}
+}
+
+func TestBulkParticipantBatches(t *testing.T) {
+	input := "group_id,phone,action\n" +
+		"1,621,add\n" +
+		"2,622,add\n" +
+		"1,623,add\n" +
+		"1,624,remove\n" +
+		"1,625,add\n" +
+		"1,626,invalid\n"
+
+	rows, err := parseBulkParticipantCSV(strings.NewReader(input))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	batches := bulkParticipantBatches(rows, 2)
+	var got []string
+	for _, batch := range batches {
+		var phones []string
+		for _, row := range batch {
+			phones = append(phones, row.Phone)
+		}
+		got = append(got, strings.Join(phones, ","))
+	}
+
+	want := []string{"621,623", "625", "622", "624"}
+	if strings.Join(got, "|") != strings.Join(want, "|") {
+		t.Fatalf("batches = %v, want %v", got, want)
+	}
+}
+
+func TestFailInterruptedBulkJobs(t *testing.T) {
+	repo := newSQLiteTestRepo(t)
+
+	job := &domainChatStorage.GroupBulkJob{ID: "job-1", Status: bulkJobStatusRunning, BatchSize: 10, DelaySeconds: 5, Total: 2}
+	rows := []*domainChatStorage.GroupBulkJobRow{
+		{JobID: job.ID, RowNumber: 2, GroupJID: "120363000000000001@g.us", Phone: "6281200000001", Action: "add", Status: bulkRowStatusSuccess},
+		{JobID: job.ID, RowNumber: 3, GroupJID: "120363000000000001@g.us", Phone: "6281200000002", Action: "add", Status: bulkRowStatusPending},
+	}
+	if err := repo.CreateGroupBulkJob(job, rows); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := NewGroupService(repo).FailInterruptedBulkJobs(); err != nil {
+		t.Fatal(err)
+	}
+
+	stored, err := repo.GetGroupBulkJob(job.ID)
+	if err != nil || stored == nil {
+		t.Fatalf("failed to get bulk job: %v", err)
+	}
+	if stored.Status != bulkJobStatusInterrupted || stored.FinishedAt == nil ||
+		stored.Processed != 2 || stored.Succeeded != 1 || stored.Failed != 1 {
+		t.Fatalf("expected the job to be interrupted with its pending row failed, got %+v", stored)
+	}
+
+	storedRows, err := repo.GetGroupBulkJobRows(job.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if storedRows[1].Status != bulkRowStatusFailed || storedRows[1].ProcessedAt == nil {
+		t.Fatalf("expected the pending row to be failed, got %+v", storedRows[1])
+	}
+}
@@ -0,0 +1,86 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/aldinokemal/go-whatsapp-web-multidevice/config"
+	domainAudit "github.com/aldinokemal/go-whatsapp-web-multidevice/domains/audit"
+	domainChatStorage "github.com/aldinokemal/go-whatsapp-web-multidevice/domains/chatstorage"
+	domainSend "github.com/aldinokemal/go-whatsapp-web-multidevice/domains/send"
+	pkgError "github.com/aldinokemal/go-whatsapp-web-multidevice/pkg/error"
+	"github.com/sirupsen/logrus"
+)
+
+const maxIdempotencyKeyLength = 255
+
+// idempotencyClaim is an idempotency key claimed by a send request, nil when the request has none
+type idempotencyClaim struct {
+	repo  domainChatStorage.IChatStorageRepository
+	actor string
+	key   string
+}
+
+// claimIdempotencyKey claims the idempotency key of a send request. Keys are scoped to the caller, so two
+// API keys cannot see each other's results. When the key was already used within config.AppIdempotencyWindow,
+// done is true and the response of the first request, or the reason it cannot be replayed, is returned.
+func claimIdempotencyKey(ctx context.Context, repo domainChatStorage.IChatStorageRepository, action string, request domainSend.BaseRequest) (claim *idempotencyClaim, response domainSend.GenericResponse, done bool, err error) {
+	if request.IdempotencyKey == "" || repo == nil {
+		return nil, response, false, nil
+	}
+	if len(request.IdempotencyKey) > maxIdempotencyKeyLength {
+		return nil, response, true, pkgError.ValidationError(fmt.Sprintf("idempotency key must be at most %d characters", maxIdempotencyKeyLength))
+	}
+
+	claim = &idempotencyClaim{
+		repo:  repo,
+		actor: domainAudit.OriginFromContext(ctx).Actor,
+		key:   request.IdempotencyKey,
+	}
+	existing, err := repo.ClaimIdempotencyKey(&domainChatStorage.IdempotencyKey{
+		Actor:     claim.actor,
+		Key:       claim.key,
+		Action:    action,
+		Recipient: request.Phone,
+	}, time.Now().Add(-config.AppIdempotencyWindow))
+	if err != nil {
+		logrus.Errorf("Failed to claim idempotency key %s: %v", claim.key, err)
+		return nil, response, true, pkgError.InternalServerError("failed to check the idempotency key")
+	}
+	if existing == nil {
+		return claim, response, false, nil
+	}
+
+	switch {
+	case existing.Action != action || existing.Recipient != request.Phone:
+		return nil, response, true, pkgError.ConflictError(fmt.Sprintf("idempotency key %s was already used for %s to %s", claim.key, existing.Action, existing.Recipient))
+	case existing.CompletedAt == nil:
+		return nil, response, true, pkgError.ConflictError(fmt.Sprintf("a request with idempotency key %s is still in progress", claim.key))
+	}
+
+	logrus.Infof("Replaying %s for idempotency key %s: %s", action, claim.key, existing.MessageID)
+	return nil, domainSend.GenericResponse{MessageID: existing.MessageID, Status: existing.Status}, true, nil
+}
+
+// release records the response of a successful request, or frees the key of a failed one so it can be retried.
+// It is meant to be deferred with the named results and recover(); panics are re-raised.
+func (claim *idempotencyClaim) release(response domainSend.GenericResponse, err error, recovered any) {
+	if recovered != nil {
+		defer panic(recovered)
+		err = fmt.Errorf("%v", recovered)
+	}
+	if claim == nil {
+		return
+	}
+
+	if err != nil {
+		if deleteErr := claim.repo.DeleteIdempotencyKey(claim.actor, claim.key); deleteErr != nil {
+			logrus.Warnf("Failed to free idempotency key %s: %v", claim.key, deleteErr)
+		}
+		return
+	}
+	if completeErr := claim.repo.CompleteIdempotencyKey(claim.actor, claim.key, response.MessageID, response.Status, time.Now()); completeErr != nil {
+		logrus.Warnf("Failed to store the result of idempotency key %s: %v", claim.key, completeErr)
+	}
+}
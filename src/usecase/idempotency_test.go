@@ -0,0 +1,125 @@
+package usecase
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+
+	domainAudit "github.com/aldinokemal/go-whatsapp-web-multidevice/domains/audit"
+	domainChatStorage "github.com/aldinokemal/go-whatsapp-web-multidevice/domains/chatstorage"
+	domainSend "github.com/aldinokemal/go-whatsapp-web-multidevice/domains/send"
+	"github.com/aldinokemal/go-whatsapp-web-multidevice/infrastructure/chatstorage"
+	pkgError "github.com/aldinokemal/go-whatsapp-web-multidevice/pkg/error"
+	_ "github.com/mattn/go-sqlite3"
+)
+
+func newSQLiteTestRepo(t *testing.T) domainChatStorage.IChatStorageRepository {
+	t.Helper()
+	db, err := sql.Open("sqlite3", ":memory:")
+	if err != nil {
+		t.Fatal(err)
+	}
+	db.SetMaxOpenConns(1)
+	t.Cleanup(func() { db.Close() })
+
+	repo := chatstorage.NewStorageRepository(db)
+	if err := repo.InitializeSchema(); err != nil {
+		t.Fatal(err)
+	}
+	return repo
+}
+
+// sendWithKey mimics a send usecase method guarded by an idempotency key
+func sendWithKey(ctx context.Context, repo domainChatStorage.IChatStorageRepository, request domainSend.BaseRequest, send func() (domainSend.GenericResponse, error)) (response domainSend.GenericResponse, err error) {
+	claim, response, done, err := claimIdempotencyKey(ctx, repo, domainAudit.ActionSendText, request)
+	if done {
+		return response, err
+	}
+	defer func() { claim.release(response, err, recover()) }()
+	return send()
+}
+
+func TestIdempotencyKeyReplaysResult(t *testing.T) {
+	repo := newSQLiteTestRepo(t)
+	ctx := domainAudit.ContextWithOrigin(context.Background(), domainAudit.Origin{Actor: "api_key:crm"})
+	request := domainSend.BaseRequest{Phone: "6281234567890@s.whatsapp.net", IdempotencyKey: "order-42"}
+
+	sends := 0
+	send := func() (domainSend.GenericResponse, error) {
+		sends++
+		return domainSend.GenericResponse{MessageID: "3EB0MSG", Status: "Message sent"}, nil
+	}
+
+	for i := 0; i < 2; i++ {
+		response, err := sendWithKey(ctx, repo, request, send)
+		if err != nil {
+			t.Fatalf("attempt %d: unexpected error %v", i+1, err)
+		}
+		if response.MessageID != "3EB0MSG" || response.Status != "Message sent" {
+			t.Fatalf("attempt %d: unexpected response %+v", i+1, response)
+		}
+	}
+	if sends != 1 {
+		t.Fatalf("expected the message to be sent once, got %d", sends)
+	}
+
+	// Keys are scoped to the caller
+	otherCtx := domainAudit.ContextWithOrigin(context.Background(), domainAudit.Origin{Actor: "api_key:billing"})
+	if _, err := sendWithKey(otherCtx, repo, request, send); err != nil || sends != 2 {
+		t.Fatalf("expected another caller to send with the same key, got %d sends and error %v", sends, err)
+	}
+}
+
+func TestIdempotencyKeyFreedOnFailure(t *testing.T) {
+	repo := newSQLiteTestRepo(t)
+	ctx := context.Background()
+	request := domainSend.BaseRequest{Phone: "6281234567890@s.whatsapp.net", IdempotencyKey: "order-43"}
+
+	_, err := sendWithKey(ctx, repo, request, func() (domainSend.GenericResponse, error) {
+		return domainSend.GenericResponse{}, errors.New("not connected")
+	})
+	if err == nil {
+		t.Fatal("expected the send error")
+	}
+
+	response, err := sendWithKey(ctx, repo, request, func() (domainSend.GenericResponse, error) {
+		return domainSend.GenericResponse{MessageID: "3EB0RETRY"}, nil
+	})
+	if err != nil || response.MessageID != "3EB0RETRY" {
+		t.Fatalf("expected the retry to send, got %+v and error %v", response, err)
+	}
+}
+
+func TestIdempotencyKeyConflicts(t *testing.T) {
+	repo := newSQLiteTestRepo(t)
+	ctx := context.Background()
+	request := domainSend.BaseRequest{Phone: "6281234567890@s.whatsapp.net", IdempotencyKey: "order-44"}
+
+	claim, _, done, err := claimIdempotencyKey(ctx, repo, domainAudit.ActionSendText, request)
+	if err != nil || done || claim == nil {
+		t.Fatalf("expected the key to be claimed, got done=%v error=%v", done, err)
+	}
+
+	_, _, done, err = claimIdempotencyKey(ctx, repo, domainAudit.ActionSendText, request)
+	var conflict pkgError.ConflictError
+	if !done || !errors.As(err, &conflict) {
+		t.Fatalf("expected a conflict while the first request is in progress, got done=%v error=%v", done, err)
+	}
+
+	claim.release(domainSend.GenericResponse{MessageID: "3EB0MSG"}, nil, nil)
+	other := request
+	other.Phone = "6289876543210@s.whatsapp.net"
+	_, _, done, err = claimIdempotencyKey(ctx, repo, domainAudit.ActionSendText, other)
+	if !done || !errors.As(err, &conflict) {
+		t.Fatalf("expected a conflict when the key is reused for another recipient, got done=%v error=%v", done, err)
+	}
+}
+
+func TestIdempotencyKeyOptional(t *testing.T) {
+	claim, _, done, err := claimIdempotencyKey(context.Background(), nil, domainAudit.ActionSendText, domainSend.BaseRequest{Phone: "6281234567890"})
+	if claim != nil || done || err != nil {
+		t.Fatalf("expected requests without a key to pass through, got claim=%v done=%v error=%v", claim, done, err)
+	}
+	claim.release(domainSend.GenericResponse{}, nil, nil)
+}
@@ -0,0 +1,247 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"sync"
+	"time"
+
+	domainAudit "github.com/aldinokemal/go-whatsapp-web-multidevice/domains/audit"
+	domainChatStorage "github.com/aldinokemal/go-whatsapp-web-multidevice/domains/chatstorage"
+	domainLabel "github.com/aldinokemal/go-whatsapp-web-multidevice/domains/label"
+	"github.com/aldinokemal/go-whatsapp-web-multidevice/infrastructure/whatsapp"
+	pkgError "github.com/aldinokemal/go-whatsapp-web-multidevice/pkg/error"
+	"github.com/aldinokemal/go-whatsapp-web-multidevice/pkg/utils"
+	"github.com/aldinokemal/go-whatsapp-web-multidevice/validations"
+	"github.com/sirupsen/logrus"
+	"go.mau.fi/whatsmeow/appstate"
+)
+
+var (
+	// labelCreateLock makes label creation run one at a time, so parallel requests cannot pick the same ID
+	labelCreateLock sync.Mutex
+	// labelsResynced is set once the labels were reloaded from a full app state sync in this process
+	labelsResynced bool
+)
+
+type serviceLabel struct {
+	chatStorageRepo domainChatStorage.IChatStorageRepository
+}
+
+func NewLabelService(chatStorageRepo domainChatStorage.IChatStorageRepository) domainLabel.ILabelUsecase {
+	return &serviceLabel{
+		chatStorageRepo: chatStorageRepo,
+	}
+}
+
+// ListLabels returns the labels synced from WhatsApp and the ones created through this app
+func (service serviceLabel) ListLabels(_ context.Context) (response domainLabel.ListLabelsResponse, err error) {
+	labels, err := service.chatStorageRepo.GetLabels()
+	if err != nil {
+		return response, err
+	}
+
+	response.Data = make([]domainLabel.Label, 0, len(labels))
+	for _, label := range labels {
+		response.Data = append(response.Data, toLabel(label))
+	}
+	return response, nil
+}
+
+func (service serviceLabel) CreateLabel(ctx context.Context, request domainLabel.CreateLabelRequest) (response domainLabel.Label, err error) {
+	defer func() {
+		recordAudit(ctx, service.chatStorageRepo, auditRecord{action: domainAudit.ActionLabelCreate, target: response.ID, details: fmt.Sprintf("name=%s color=%d", request.Name, request.Color)}, err, recover())
+	}()
+	if err = validations.ValidateCreateLabel(ctx, &request); err != nil {
+		return response, err
+	}
+	utils.MustLogin(whatsapp.GetClient())
+
+	labelCreateLock.Lock()
+	defer labelCreateLock.Unlock()
+
+	labelID, err := service.nextLabelID(ctx)
+	if err != nil {
+		return response, err
+	}
+
+	if err = whatsapp.GetClient().SendAppState(ctx, appstate.BuildLabelEdit(labelID, request.Name, request.Color, false)); err != nil {
+		return response, err
+	}
+
+	label := &domainChatStorage.Label{ID: labelID, Name: request.Name, Color: request.Color}
+	if err := service.chatStorageRepo.StoreLabel(label); err != nil {
+		logrus.WithError(err).WithField("label_id", labelID).Warn("Failed to store label")
+	}
+
+	return toLabel(label), nil
+}
+
+func (service serviceLabel) UpdateLabel(ctx context.Context, request domainLabel.UpdateLabelRequest) (response domainLabel.Label, err error) {
+	defer func() {
+		recordAudit(ctx, service.chatStorageRepo, auditRecord{action: domainAudit.ActionLabelUpdate, target: request.LabelID, details: fmt.Sprintf("name=%s color=%d", request.Name, request.Color)}, err, recover())
+	}()
+	if err = validations.ValidateUpdateLabel(ctx, &request); err != nil {
+		return response, err
+	}
+	utils.MustLogin(whatsapp.GetClient())
+
+	label, err := service.getLabel(request.LabelID)
+	if err != nil {
+		return response, err
+	}
+
+	if err = whatsapp.GetClient().SendAppState(ctx, appstate.BuildLabelEdit(label.ID, request.Name, request.Color, false)); err != nil {
+		return response, err
+	}
+
+	label.Name = request.Name
+	label.Color = request.Color
+	if err := service.chatStorageRepo.StoreLabel(label); err != nil {
+		logrus.WithError(err).WithField("label_id", label.ID).Warn("Failed to store label")
+	}
+
+	return toLabel(label), nil
+}
+
+func (service serviceLabel) DeleteLabel(ctx context.Context, request domainLabel.DeleteLabelRequest) (err error) {
+	defer func() {
+		recordAudit(ctx, service.chatStorageRepo, auditRecord{action: domainAudit.ActionLabelDelete, target: request.LabelID}, err, recover())
+	}()
+	if err = validations.ValidateDeleteLabel(ctx, &request); err != nil {
+		return err
+	}
+	utils.MustLogin(whatsapp.GetClient())
+
+	label, err := service.getLabel(request.LabelID)
+	if err != nil {
+		return err
+	}
+
+	if err = whatsapp.GetClient().SendAppState(ctx, appstate.BuildLabelEdit(label.ID, label.Name, label.Color, true)); err != nil {
+		return err
+	}
+
+	if err := service.chatStorageRepo.DeleteLabel(label.ID); err != nil {
+		logrus.WithError(err).WithField("label_id", label.ID).Warn("Failed to delete stored label")
+	}
+	return nil
+}
+
+func (service serviceLabel) LabelChat(ctx context.Context, request domainLabel.LabelChatRequest) (response domainLabel.LabelChatResponse, err error) {
+	defer func() {
+		recordAudit(ctx, service.chatStorageRepo, auditRecord{action: domainAudit.ActionLabelChat, target: request.ChatJID, details: fmt.Sprintf("label_id=%s labeled=%t", request.LabelID, request.Labeled)}, err, recover())
+	}()
+	if err = validations.ValidateLabelChat(ctx, &request); err != nil {
+		return response, err
+	}
+
+	targetJID, err := utils.ValidateJidWithLogin(whatsapp.GetClient(), request.ChatJID)
+	if err != nil {
+		return response, err
+	}
+
+	if err = whatsapp.GetClient().SendAppState(ctx, appstate.BuildLabelChat(targetJID, request.LabelID, request.Labeled)); err != nil {
+		return response, err
+	}
+
+	if err := service.chatStorageRepo.SetChatLabel(targetJID.String(), request.LabelID, request.Labeled); err != nil {
+		logrus.WithError(err).WithField("chat_jid", request.ChatJID).Warn("Failed to store chat label")
+	}
+
+	labelName := request.LabelID
+	if label, err := service.chatStorageRepo.GetLabel(request.LabelID); err == nil && label != nil {
+		labelName = label.Name
+	}
+
+	response.Status = "success"
+	response.ChatJID = request.ChatJID
+	response.LabelID = request.LabelID
+	response.Labeled = request.Labeled
+	if request.Labeled {
+		response.Message = fmt.Sprintf("Chat labeled successfully with label '%s'", labelName)
+	} else {
+		response.Message = fmt.Sprintf("Label '%s' removed from chat successfully", labelName)
+	}
+
+	return response, nil
+}
+
+func (service serviceLabel) LabelMessage(ctx context.Context, request domainLabel.LabelMessageRequest) (err error) {
+	defer func() {
+		recordAudit(ctx, service.chatStorageRepo, auditRecord{action: domainAudit.ActionLabelMessage, target: request.Phone, messageID: request.MessageID, details: fmt.Sprintf("label_id=%s labeled=%t", request.LabelID, request.Labeled)}, err, recover())
+	}()
+	if err = validations.ValidateLabelMessage(ctx, &request); err != nil {
+		return err
+	}
+
+	targetJID, err := utils.ValidateJidWithLogin(whatsapp.GetClient(), request.Phone)
+	if err != nil {
+		return err
+	}
+
+	if err = whatsapp.GetClient().SendAppState(ctx, appstate.BuildLabelMessage(targetJID, request.LabelID, request.MessageID, request.Labeled)); err != nil {
+		return err
+	}
+
+	if err := service.chatStorageRepo.SetMessageLabel(targetJID.String(), request.MessageID, request.LabelID, request.Labeled); err != nil {
+		logrus.WithError(err).WithField("message_id", request.MessageID).Warn("Failed to store message label")
+	}
+	return nil
+}
+
+func (service serviceLabel) getLabel(labelID string) (*domainChatStorage.Label, error) {
+	label, err := service.chatStorageRepo.GetLabel(labelID)
+	if err != nil {
+		return nil, err
+	}
+	if label == nil {
+		return nil, pkgError.ValidationError(fmt.Sprintf("label %s not found", labelID))
+	}
+	return label, nil
+}
+
+// nextLabelID returns the ID of a new label. WhatsApp numbers labels sequentially, so it follows the highest
+// ID in the label app state. The first call reloads the labels from a full sync of that app state, later calls
+// rely on the label events stored since. Deleted labels stay in storage, so their IDs are never reused.
+// Callers must hold labelCreateLock.
+func (service serviceLabel) nextLabelID(ctx context.Context) (string, error) {
+	client := whatsapp.GetClient()
+	version, _, err := client.Store.AppState.GetAppStateVersion(ctx, string(appstate.WAPatchRegular))
+	if err != nil {
+		return "", pkgError.InternalServerError(fmt.Sprintf("failed to get label app state version %v", err))
+	}
+	if version == 0 {
+		return "", pkgError.ConflictError("labels have not synced from the phone yet, try again later")
+	}
+
+	if !labelsResynced {
+		// The full sync emits a LabelEdit event for every label, which the event handler stores
+		if err = client.FetchAppState(ctx, appstate.WAPatchRegular, true, false); err != nil {
+			return "", pkgError.ConflictError(fmt.Sprintf("failed to sync labels from the phone, try again later: %v", err))
+		}
+		labelsResynced = true
+	}
+
+	return service.labelIDAfterHighest()
+}
+
+// labelIDAfterHighest returns the ID following the highest stored label ID, deleted labels included
+func (service serviceLabel) labelIDAfterHighest() (string, error) {
+	highest, err := service.chatStorageRepo.GetHighestLabelID()
+	if err != nil {
+		return "", err
+	}
+	return strconv.Itoa(highest + 1), nil
+}
+
+func toLabel(label *domainChatStorage.Label) domainLabel.Label {
+	return domainLabel.Label{
+		ID:           label.ID,
+		Name:         label.Name,
+		Color:        label.Color,
+		PredefinedID: label.PredefinedID,
+		UpdatedAt:    label.UpdatedAt.Format(time.RFC3339),
+	}
+}
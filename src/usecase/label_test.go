@@ -0,0 +1,97 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	domainChat "github.com/aldinokemal/go-whatsapp-web-multidevice/domains/chat"
+	domainChatStorage "github.com/aldinokemal/go-whatsapp-web-multidevice/domains/chatstorage"
+)
+
+func TestLabelIDAfterHighestSkipsDeletedLabels(t *testing.T) {
+	repo := newSQLiteTestRepo(t)
+	service := serviceLabel{chatStorageRepo: repo}
+
+	if id, err := service.labelIDAfterHighest(); err != nil || id != "1" {
+		t.Fatalf("got %q, %v, want the first label ID", id, err)
+	}
+
+	for _, label := range []*domainChatStorage.Label{{ID: "5", Name: "Paid"}, {ID: "12", Name: "Pending payment"}, {ID: "custom", Name: "Custom"}} {
+		if err := repo.StoreLabel(label); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if id, err := service.labelIDAfterHighest(); err != nil || id != "13" {
+		t.Fatalf("got %q, %v, want 13", id, err)
+	}
+
+	// Deleting the highest label, or a label that was never stored, keeps its ID taken
+	for _, labelID := range []string{"12", "20"} {
+		if err := repo.DeleteLabel(labelID); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if id, err := service.labelIDAfterHighest(); err != nil || id != "21" {
+		t.Fatalf("got %q, %v, want 21", id, err)
+	}
+
+	labels, err := repo.GetLabels()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(labels) != 2 {
+		t.Fatalf("expected deleted labels to be hidden, got %d labels", len(labels))
+	}
+	if label, err := repo.GetLabel("12"); err != nil || label != nil {
+		t.Fatalf("got %+v, %v, want no deleted label", label, err)
+	}
+
+	// A label edited again from the phone comes back
+	if err := repo.StoreLabel(&domainChatStorage.Label{ID: "12", Name: "Pending payment"}); err != nil {
+		t.Fatal(err)
+	}
+	if label, err := repo.GetLabel("12"); err != nil || label == nil {
+		t.Fatalf("got %+v, %v, want the restored label", label, err)
+	}
+}
+
+func TestListChatsFiltersOnLabel(t *testing.T) {
+	repo := newSQLiteTestRepo(t)
+	for _, jid := range []string{"1@s.whatsapp.net", "2@s.whatsapp.net"} {
+		if err := repo.StoreChat(&domainChatStorage.Chat{JID: jid, Name: jid, LastMessageTime: time.Now()}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	for _, labelID := range []string{"3", "4"} {
+		if err := repo.SetChatLabel("1@s.whatsapp.net", labelID, true); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := repo.SetChatLabel("2@s.whatsapp.net", "4", true); err != nil {
+		t.Fatal(err)
+	}
+	if err := repo.SetChatLabel("2@s.whatsapp.net", "4", false); err != nil {
+		t.Fatal(err)
+	}
+
+	service := serviceChat{chatStorageRepo: repo}
+	response, err := service.ListChats(context.Background(), domainChat.ListChatsRequest{LabelID: "4"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(response.Data) != 1 || response.Data[0].JID != "1@s.whatsapp.net" || len(response.Data[0].LabelIDs) != 2 {
+		t.Fatalf("unexpected chats %+v", response.Data)
+	}
+
+	if err := repo.DeleteLabel("4"); err != nil {
+		t.Fatal(err)
+	}
+	response, err = service.ListChats(context.Background(), domainChat.ListChatsRequest{LabelID: "4"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(response.Data) != 0 {
+		t.Fatalf("expected no chat after deleting the label, got %+v", response.Data)
+	}
+}
@@ -3,14 +3,15 @@ package usecase
 import (
 	"context"
 	"fmt"
-	"os"
 	"path/filepath"
 	"time"
 
 	"github.com/aldinokemal/go-whatsapp-web-multidevice/config"
+	domainAudit "github.com/aldinokemal/go-whatsapp-web-multidevice/domains/audit"
 	domainChatStorage "github.com/aldinokemal/go-whatsapp-web-multidevice/domains/chatstorage"
 	domainMessage "github.com/aldinokemal/go-whatsapp-web-multidevice/domains/message"
 	"github.com/aldinokemal/go-whatsapp-web-multidevice/infrastructure/whatsapp"
+	pkgError "github.com/aldinokemal/go-whatsapp-web-multidevice/pkg/error"
 	"github.com/aldinokemal/go-whatsapp-web-multidevice/pkg/utils"
 	"github.com/aldinokemal/go-whatsapp-web-multidevice/validations"
 	"github.com/sirupsen/logrus"
@@ -34,6 +35,9 @@ func NewMessageService(chatStorageRepo domainChatStorage.IChatStorageRepository)
 }
 
 func (service serviceMessage) MarkAsRead(ctx context.Context, request domainMessage.MarkAsReadRequest) (response domainMessage.GenericResponse, err error) {
+	defer func() {
+		recordAudit(ctx, service.chatStorageRepo, auditRecord{action: domainAudit.ActionMessageRead, target: request.Phone, messageID: request.MessageID}, err, recover())
+	}()
 	if err = validations.ValidateMarkAsRead(ctx, request); err != nil {
 		return response, err
 	}
@@ -60,6 +64,9 @@ func (service serviceMessage) MarkAsRead(ctx context.Context, request domainMess
 }
 
 func (service serviceMessage) ReactMessage(ctx context.Context, request domainMessage.ReactionRequest) (response domainMessage.GenericResponse, err error) {
+	defer func() {
+		recordAudit(ctx, service.chatStorageRepo, auditRecord{action: domainAudit.ActionMessageReact, target: request.Phone, messageID: request.MessageID, details: request.Emoji}, err, recover())
+	}()
 	if err = validations.ValidateReactMessage(ctx, request); err != nil {
 		return response, err
 	}
@@ -79,17 +86,31 @@ func (service serviceMessage) ReactMessage(ctx context.Context, request domainMe
 			SenderTimestampMS: proto.Int64(time.Now().UnixMilli()),
 		},
 	}
-	ts, err := whatsapp.GetClient().SendMessage(ctx, dataWaRecipient, msg)
+	ts, err := whatsapp.SendMessage(ctx, dataWaRecipient, msg)
 	if err != nil {
 		return response, err
 	}
 
+	reaction := &domainChatStorage.MessageReaction{
+		ChatJID:   dataWaRecipient.String(),
+		MessageID: request.MessageID,
+		SenderJID: whatsapp.GetClient().Store.ID.ToNonAD().String(),
+		Emoji:     request.Emoji,
+		Timestamp: ts.Timestamp,
+	}
+	if err := service.chatStorageRepo.StoreReaction(reaction); err != nil {
+		logrus.Warnf("Failed to store reaction to message %s: %v", request.MessageID, err)
+	}
+
 	response.MessageID = ts.ID
 	response.Status = fmt.Sprintf("Reaction sent to %s (server timestamp: %s)", request.Phone, ts.Timestamp)
 	return response, nil
 }
 
 func (service serviceMessage) RevokeMessage(ctx context.Context, request domainMessage.RevokeRequest) (response domainMessage.GenericResponse, err error) {
+	defer func() {
+		recordAudit(ctx, service.chatStorageRepo, auditRecord{action: domainAudit.ActionMessageRevoke, target: request.Phone, messageID: request.MessageID}, err, recover())
+	}()
 	if err = validations.ValidateRevokeMessage(ctx, request); err != nil {
 		return response, err
 	}
@@ -98,17 +119,32 @@ func (service serviceMessage) RevokeMessage(ctx context.Context, request domainM
 		return response, err
 	}
 
-	ts, err := whatsapp.GetClient().SendMessage(context.Background(), dataWaRecipient, whatsapp.GetClient().BuildRevoke(dataWaRecipient, types.EmptyJID, request.MessageID))
+	sender := types.EmptyJID
+	if request.Sender != "" {
+		sender, err = types.ParseJID(request.Sender)
+		if err != nil {
+			return response, pkgError.InvalidJID(fmt.Sprintf("invalid sender %s: %v", request.Sender, err))
+		}
+	}
+
+	ts, err := whatsapp.SendMessage(context.WithoutCancel(ctx), dataWaRecipient, whatsapp.GetClient().BuildRevoke(dataWaRecipient, sender, request.MessageID))
 	if err != nil {
 		return response, err
 	}
 
+	if err := service.chatStorageRepo.RevokeMessage(dataWaRecipient.String(), request.MessageID, ts.Timestamp); err != nil {
+		logrus.Warnf("Failed to store revoke of message %s: %v", request.MessageID, err)
+	}
+
 	response.MessageID = ts.ID
 	response.Status = fmt.Sprintf("Revoke success %s (server timestamp: %s)", request.Phone, ts.Timestamp)
 	return response, nil
 }
 
 func (service serviceMessage) DeleteMessage(ctx context.Context, request domainMessage.DeleteRequest) (err error) {
+	defer func() {
+		recordAudit(ctx, service.chatStorageRepo, auditRecord{action: domainAudit.ActionMessageDelete, target: request.Phone, messageID: request.MessageID}, err, recover())
+	}()
 	if err = validations.ValidateDeleteMessage(ctx, request); err != nil {
 		return err
 	}
@@ -143,6 +179,9 @@ func (service serviceMessage) DeleteMessage(ctx context.Context, request domainM
 }
 
 func (service serviceMessage) UpdateMessage(ctx context.Context, request domainMessage.UpdateMessageRequest) (response domainMessage.GenericResponse, err error) {
+	defer func() {
+		recordAudit(ctx, service.chatStorageRepo, auditRecord{action: domainAudit.ActionMessageUpdate, target: request.Phone, messageID: request.MessageID}, err, recover())
+	}()
 	if err = validations.ValidateUpdateMessage(ctx, request); err != nil {
 		return response, err
 	}
@@ -153,11 +192,15 @@ func (service serviceMessage) UpdateMessage(ctx context.Context, request domainM
 	}
 
 	msg := &waE2E.Message{Conversation: proto.String(request.Message)}
-	ts, err := whatsapp.GetClient().SendMessage(context.Background(), dataWaRecipient, whatsapp.GetClient().BuildEdit(dataWaRecipient, request.MessageID, msg))
+	ts, err := whatsapp.SendMessage(context.WithoutCancel(ctx), dataWaRecipient, whatsapp.GetClient().BuildEdit(dataWaRecipient, request.MessageID, msg))
 	if err != nil {
 		return response, err
 	}
 
+	if err := service.chatStorageRepo.EditMessage(dataWaRecipient.String(), request.MessageID, ts.ID, request.Message, ts.Timestamp); err != nil {
+		logrus.Warnf("Failed to store edit of message %s: %v", request.MessageID, err)
+	}
+
 	response.MessageID = ts.ID
 	response.Status = fmt.Sprintf("Update message success %s (server timestamp: %s)", request.Phone, ts.Timestamp)
 	return response, nil
@@ -165,6 +208,9 @@ func (service serviceMessage) UpdateMessage(ctx context.Context, request domainM
 
 // StarMessage implements message.IMessageService.
 func (service serviceMessage) StarMessage(ctx context.Context, request domainMessage.StarRequest) (err error) {
+	defer func() {
+		recordAudit(ctx, service.chatStorageRepo, auditRecord{action: domainAudit.ActionMessageStar, target: request.Phone, messageID: request.MessageID, details: fmt.Sprintf("starred=%t", request.IsStarred)}, err, recover())
+	}()
 	if err = validations.ValidateStarMessage(ctx, request); err != nil {
 		return err
 	}
@@ -218,15 +264,10 @@ func (service serviceMessage) DownloadMedia(ctx context.Context, request domainM
 		return response, fmt.Errorf("message %s does not belong to chat %s", request.MessageID, dataWaRecipient.String())
 	}
 
-	// Create directory structure for organized storage
+	// Organize downloads by chat and date, the local backend creates the directories
 	chatDir := filepath.Join(config.PathMedia, utils.ExtractPhoneNumber(message.ChatJID))
 	dateDir := filepath.Join(chatDir, message.Timestamp.Format("2006-01-02"))
 
-	err = os.MkdirAll(dateDir, 0755)
-	if err != nil {
-		return response, fmt.Errorf("failed to create directory: %v", err)
-	}
-
 	// Create a downloadable message interface based on media type
 	var downloadableMsg interface{}
 
@@ -282,21 +323,14 @@ func (service serviceMessage) DownloadMedia(ctx context.Context, request domainM
 		return response, fmt.Errorf("failed to download media: %v", err)
 	}
 
-	// Get file size
-	fileInfo, err := os.Stat(extractedMedia.MediaPath)
-	if err != nil {
-		logrus.Warnf("Could not get file size for %s: %v", extractedMedia.MediaPath, err)
-	}
-
 	// Build response
 	response.MessageID = request.MessageID
 	response.Status = fmt.Sprintf("Media downloaded successfully to %s", extractedMedia.MediaPath)
 	response.MediaType = message.MediaType
 	response.Filename = filepath.Base(extractedMedia.MediaPath)
 	response.FilePath = extractedMedia.MediaPath
-	if fileInfo != nil {
-		response.FileSize = fileInfo.Size()
-	}
+	response.FileURL = extractedMedia.MediaURL
+	response.FileSize = extractedMedia.FileSize
 
 	logrus.Info(map[string]any{
 		"message_id": request.MessageID,
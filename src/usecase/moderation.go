@@ -0,0 +1,478 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+	"unicode"
+
+	domainChatStorage "github.com/aldinokemal/go-whatsapp-web-multidevice/domains/chatstorage"
+	domainGroup "github.com/aldinokemal/go-whatsapp-web-multidevice/domains/group"
+	domainMessage "github.com/aldinokemal/go-whatsapp-web-multidevice/domains/message"
+	domainModeration "github.com/aldinokemal/go-whatsapp-web-multidevice/domains/moderation"
+	domainSend "github.com/aldinokemal/go-whatsapp-web-multidevice/domains/send"
+	"github.com/aldinokemal/go-whatsapp-web-multidevice/infrastructure/whatsapp"
+	pkgError "github.com/aldinokemal/go-whatsapp-web-multidevice/pkg/error"
+	"github.com/aldinokemal/go-whatsapp-web-multidevice/pkg/utils"
+	"github.com/aldinokemal/go-whatsapp-web-multidevice/validations"
+	"github.com/sirupsen/logrus"
+	"go.mau.fi/whatsmeow"
+	"go.mau.fi/whatsmeow/types"
+	"go.mau.fi/whatsmeow/types/events"
+)
+
+// inviteLinkPattern matches WhatsApp group and channel invite links
+var inviteLinkPattern = regexp.MustCompile(`(?i)(chat\.whatsapp\.com|whatsapp\.com/channel)/[A-Za-z0-9]+`)
+
+// activityRetention is the longest flood window the settings allow. Activity without warnings
+// and without messages in this window is dropped, it cannot count towards a flood anymore.
+const activityRetention = time.Hour
+
+// participantActivity tracks recent messages and warnings of a participant in a group
+type participantActivity struct {
+	messages []time.Time
+	warnings int
+}
+
+type serviceModeration struct {
+	chatStorageRepo domainChatStorage.IChatStorageRepository
+	groupService    domainGroup.IGroupUsecase
+	messageService  domainMessage.IMessageUsecase
+	sendService     domainSend.ISendUsecase
+
+	mu       sync.Mutex
+	activity map[string]*participantActivity
+	prunedAt time.Time
+}
+
+func NewModerationService(
+	chatStorageRepo domainChatStorage.IChatStorageRepository,
+	groupService domainGroup.IGroupUsecase,
+	messageService domainMessage.IMessageUsecase,
+	sendService domainSend.ISendUsecase,
+) domainModeration.IModerationUsecase {
+	return &serviceModeration{
+		chatStorageRepo: chatStorageRepo,
+		groupService:    groupService,
+		messageService:  messageService,
+		sendService:     sendService,
+		activity:        make(map[string]*participantActivity),
+	}
+}
+
+func (service *serviceModeration) GetSettings(ctx context.Context, request domainModeration.GetSettingsRequest) (response domainModeration.Settings, err error) {
+	if err = validations.ValidateGetModerationSettings(ctx, request); err != nil {
+		return response, err
+	}
+
+	groupJID, err := parseGroupJID(request.GroupID)
+	if err != nil {
+		return response, err
+	}
+
+	moderation, err := service.chatStorageRepo.GetGroupModeration(groupJID.String())
+	if err != nil {
+		return response, err
+	}
+	if moderation == nil {
+		// Groups without settings are not moderated, which matches the zero value
+		return domainModeration.Settings{GroupID: groupJID.String(), BannedWords: []string{}}, nil
+	}
+
+	return toModerationSettings(moderation), nil
+}
+
+func (service *serviceModeration) ListSettings(_ context.Context) (response []domainModeration.Settings, err error) {
+	moderations, err := service.chatStorageRepo.GetGroupModerations()
+	if err != nil {
+		return response, err
+	}
+
+	response = make([]domainModeration.Settings, 0, len(moderations))
+	for _, moderation := range moderations {
+		response = append(response, toModerationSettings(moderation))
+	}
+
+	return response, nil
+}
+
+func (service *serviceModeration) SetSettings(ctx context.Context, request domainModeration.Settings) (response domainModeration.Settings, err error) {
+	if err = validations.ValidateSetModerationSettings(ctx, request); err != nil {
+		return response, err
+	}
+
+	groupJID, err := parseGroupJID(request.GroupID)
+	if err != nil {
+		return response, err
+	}
+
+	bannedWords := make([]string, 0, len(request.BannedWords))
+	for _, word := range request.BannedWords {
+		bannedWords = append(bannedWords, strings.ToLower(strings.TrimSpace(word)))
+	}
+
+	err = service.chatStorageRepo.StoreGroupModeration(&domainChatStorage.GroupModeration{
+		GroupJID:           groupJID.String(),
+		WelcomeMessage:     request.WelcomeMessage,
+		GoodbyeMessage:     request.GoodbyeMessage,
+		AntiLink:           request.AntiLink,
+		BannedWords:        bannedWords,
+		FloodLimit:         request.FloodLimit,
+		FloodWindowSeconds: request.FloodWindowSeconds,
+		MaxWarnings:        request.MaxWarnings,
+	})
+	if err != nil {
+		return response, err
+	}
+
+	return service.GetSettings(ctx, domainModeration.GetSettingsRequest{GroupID: groupJID.String()})
+}
+
+func (service *serviceModeration) DeleteSettings(ctx context.Context, request domainModeration.DeleteSettingsRequest) (err error) {
+	if err = validations.ValidateDeleteModerationSettings(ctx, request); err != nil {
+		return err
+	}
+
+	groupJID, err := parseGroupJID(request.GroupID)
+	if err != nil {
+		return err
+	}
+
+	if err = service.chatStorageRepo.DeleteGroupModeration(groupJID.String()); err != nil {
+		return err
+	}
+
+	service.resetGroupActivity(groupJID.String())
+	return nil
+}
+
+// HandleGroupMessage deletes messages that break the group rules and escalates repeated offences
+func (service *serviceModeration) HandleGroupMessage(ctx context.Context, evt *events.Message) {
+	if evt.Info.IsFromMe || !evt.Info.IsGroup {
+		return
+	}
+
+	chatJID := evt.Info.Chat.String()
+	settings, err := service.chatStorageRepo.GetGroupModeration(chatJID)
+	if err != nil {
+		logrus.Errorf("Failed to load moderation settings for %s: %v", chatJID, err)
+		return
+	}
+	if settings == nil {
+		return
+	}
+
+	text := utils.ExtractMessageTextFromProto(evt.Message)
+	reason := ""
+	switch {
+	case settings.AntiLink && inviteLinkPattern.MatchString(text):
+		reason = "invite links are not allowed"
+	case containsBannedWord(text, settings.BannedWords):
+		reason = "your message contains a banned word"
+	}
+
+	flooded := service.recordMessage(activityKey(chatJID, evt.Info.Sender), settings)
+	if reason == "" && !flooded {
+		return
+	}
+
+	// Moderation only applies to regular members in groups where we are admin
+	canModerate, err := service.canModerate(ctx, evt.Info.Chat, evt.Info.Sender)
+	if err != nil {
+		logrus.Errorf("Failed to check moderation rights in %s: %v", chatJID, err)
+		return
+	}
+	if !canModerate {
+		return
+	}
+
+	if reason != "" {
+		_, err := service.messageService.RevokeMessage(ctx, domainMessage.RevokeRequest{
+			MessageID: evt.Info.ID,
+			Phone:     chatJID,
+			Sender:    evt.Info.Sender.String(),
+		})
+		if err != nil {
+			logrus.Errorf("Failed to delete message %s in %s: %v", evt.Info.ID, chatJID, err)
+		}
+	} else {
+		reason = "you are sending messages too fast"
+	}
+
+	service.warn(ctx, evt.Info.Chat, evt.Info.Sender, reason, settings)
+}
+
+// HandleParticipantsChange sends the welcome and goodbye messages of a group
+func (service *serviceModeration) HandleParticipantsChange(ctx context.Context, evt *events.GroupInfo) {
+	if len(evt.Join) == 0 && len(evt.Leave) == 0 {
+		return
+	}
+
+	settings, err := service.chatStorageRepo.GetGroupModeration(evt.JID.String())
+	if err != nil {
+		logrus.Errorf("Failed to load moderation settings for %s: %v", evt.JID.String(), err)
+		return
+	}
+	if settings == nil {
+		return
+	}
+
+	for _, jid := range evt.Leave {
+		service.resetActivity(activityKey(evt.JID.String(), jid))
+	}
+
+	if settings.WelcomeMessage != "" {
+		for _, jid := range evt.Join {
+			service.greet(ctx, evt.JID, jid, settings.WelcomeMessage)
+		}
+	}
+	if settings.GoodbyeMessage != "" {
+		for _, jid := range evt.Leave {
+			service.greet(ctx, evt.JID, jid, settings.GoodbyeMessage)
+		}
+	}
+}
+
+// greet renders a welcome or goodbye template for a participant and sends it to the group
+func (service *serviceModeration) greet(ctx context.Context, groupJID types.JID, participant types.JID, template string) {
+	if isOwnJID(participant) {
+		return
+	}
+
+	message := strings.ReplaceAll(template, domainModeration.PlaceholderUser, "@"+participantPhone(ctx, participant))
+	if strings.Contains(message, domainModeration.PlaceholderGroup) {
+		groupName := groupJID.User
+		if info, err := whatsapp.GetClient().GetGroupInfo(ctx, groupJID); err == nil && info != nil {
+			groupName = info.Name
+		}
+		message = strings.ReplaceAll(message, domainModeration.PlaceholderGroup, groupName)
+	}
+
+	service.sendToGroup(ctx, groupJID, message)
+}
+
+// warn sends a warning to the participant and removes them once the warning limit is reached
+func (service *serviceModeration) warn(ctx context.Context, groupJID types.JID, sender types.JID, reason string, settings *domainChatStorage.GroupModeration) {
+	phone := participantPhone(ctx, sender)
+	key := activityKey(groupJID.String(), sender)
+
+	service.mu.Lock()
+	activity := service.activityFor(key)
+	activity.warnings++
+	warnings := activity.warnings
+	service.mu.Unlock()
+
+	if settings.MaxWarnings <= 0 || warnings < settings.MaxWarnings {
+		limit := ""
+		if settings.MaxWarnings > 0 {
+			limit = fmt.Sprintf(" (%d/%d)", warnings, settings.MaxWarnings)
+		}
+		service.sendToGroup(ctx, groupJID, fmt.Sprintf("@%s warning%s: %s", phone, limit, reason))
+		return
+	}
+
+	_, err := service.groupService.ManageParticipant(ctx, domainGroup.ParticipantRequest{
+		GroupID:      groupJID.String(),
+		Participants: []string{phone},
+		Action:       whatsmeow.ParticipantChangeRemove,
+	})
+	if err != nil {
+		logrus.Errorf("Failed to remove %s from %s: %v", phone, groupJID.String(), err)
+		return
+	}
+
+	service.resetActivity(key)
+	service.sendToGroup(ctx, groupJID, fmt.Sprintf("@%s was removed after %d warnings: %s", phone, warnings, reason))
+}
+
+func (service *serviceModeration) sendToGroup(ctx context.Context, groupJID types.JID, message string) {
+	_, err := service.sendService.SendText(ctx, domainSend.MessageRequest{
+		BaseRequest: domainSend.BaseRequest{Phone: groupJID.String()},
+		Message:     message,
+	})
+	if err != nil {
+		logrus.Errorf("Failed to send moderation message to %s: %v", groupJID.String(), err)
+	}
+}
+
+// canModerate reports whether we are admin of the group and the sender is not
+func (service *serviceModeration) canModerate(ctx context.Context, groupJID types.JID, sender types.JID) (bool, error) {
+	info, err := whatsapp.GetClient().GetGroupInfo(ctx, groupJID)
+	if err != nil {
+		return false, err
+	}
+
+	weAreAdmin := false
+	for _, participant := range info.Participants {
+		isAdmin := participant.IsAdmin || participant.IsSuperAdmin
+		if isOwnJID(participant.JID) || isOwnJID(participant.LID) || isOwnJID(participant.PhoneNumber) {
+			weAreAdmin = isAdmin
+		}
+		if isAdmin && (participant.JID.User == sender.User || participant.LID.User == sender.User || participant.PhoneNumber.User == sender.User) {
+			return false, nil
+		}
+	}
+
+	return weAreAdmin, nil
+}
+
+// recordMessage tracks the message in the flood window and reports whether the limit was exceeded
+func (service *serviceModeration) recordMessage(key string, settings *domainChatStorage.GroupModeration) bool {
+	if settings.FloodLimit <= 0 || settings.FloodWindowSeconds <= 0 {
+		return false
+	}
+
+	service.mu.Lock()
+	defer service.mu.Unlock()
+
+	now := time.Now()
+	service.pruneActivity(now)
+	cutoff := now.Add(-time.Duration(settings.FloodWindowSeconds) * time.Second)
+	activity := service.activityFor(key)
+
+	recent := activity.messages[:0]
+	for _, sentAt := range activity.messages {
+		if sentAt.After(cutoff) {
+			recent = append(recent, sentAt)
+		}
+	}
+	activity.messages = append(recent, now)
+
+	if len(activity.messages) > settings.FloodLimit {
+		// Start a new window so a single burst only counts as one offence
+		activity.messages = nil
+		return true
+	}
+	return false
+}
+
+// activityFor returns the activity of a participant; the caller must hold the lock
+func (service *serviceModeration) activityFor(key string) *participantActivity {
+	activity, ok := service.activity[key]
+	if !ok {
+		activity = &participantActivity{}
+		service.activity[key] = activity
+	}
+	return activity
+}
+
+// pruneActivity drops the activity that expired, at most once per retention period; the caller must hold the lock
+func (service *serviceModeration) pruneActivity(now time.Time) {
+	if now.Sub(service.prunedAt) < activityRetention {
+		return
+	}
+	service.prunedAt = now
+
+	cutoff := now.Add(-activityRetention)
+	for key, activity := range service.activity {
+		if activity.warnings > 0 {
+			continue
+		}
+		if len(activity.messages) == 0 || activity.messages[len(activity.messages)-1].Before(cutoff) {
+			delete(service.activity, key)
+		}
+	}
+}
+
+func (service *serviceModeration) resetActivity(key string) {
+	service.mu.Lock()
+	defer service.mu.Unlock()
+	delete(service.activity, key)
+}
+
+func (service *serviceModeration) resetGroupActivity(groupJID string) {
+	service.mu.Lock()
+	defer service.mu.Unlock()
+	for key := range service.activity {
+		if strings.HasPrefix(key, groupJID+"|") {
+			delete(service.activity, key)
+		}
+	}
+}
+
+func activityKey(groupJID string, sender types.JID) string {
+	return groupJID + "|" + sender.User
+}
+
+func containsBannedWord(text string, bannedWords []string) bool {
+	if text == "" || len(bannedWords) == 0 {
+		return false
+	}
+
+	words := strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	for _, word := range words {
+		for _, banned := range bannedWords {
+			if word == banned {
+				return true
+			}
+		}
+	}
+
+	// Banned phrases with spaces are matched on the whole text
+	lower := strings.ToLower(text)
+	for _, banned := range bannedWords {
+		if strings.Contains(banned, " ") && strings.Contains(lower, banned) {
+			return true
+		}
+	}
+	return false
+}
+
+// participantPhone returns the phone number of a participant, resolving hidden (LID) identities
+func participantPhone(ctx context.Context, jid types.JID) string {
+	if jid.Server == types.HiddenUserServer {
+		if pn, err := whatsapp.GetClient().Store.LIDs.GetPNForLID(ctx, jid); err == nil && !pn.IsEmpty() {
+			return pn.User
+		}
+	}
+	return jid.User
+}
+
+func isOwnJID(jid types.JID) bool {
+	if jid.IsEmpty() {
+		return false
+	}
+	store := whatsapp.GetClient().Store
+	if store.ID != nil && jid.User == store.ID.User {
+		return true
+	}
+	return !store.LID.IsEmpty() && jid.User == store.LID.User
+}
+
+// parseGroupJID accepts a group JID or its numeric part
+func parseGroupJID(groupID string) (types.JID, error) {
+	groupID = strings.TrimSpace(groupID)
+	if !strings.Contains(groupID, "@") {
+		groupID = groupID + "@" + types.GroupServer
+	}
+
+	jid, err := types.ParseJID(groupID)
+	if err != nil || jid.Server != types.GroupServer {
+		return jid, pkgError.InvalidJID(fmt.Sprintf("%s is not a group id", groupID))
+	}
+	return jid, nil
+}
+
+func toModerationSettings(moderation *domainChatStorage.GroupModeration) domainModeration.Settings {
+	bannedWords := moderation.BannedWords
+	if bannedWords == nil {
+		bannedWords = []string{}
+	}
+
+	return domainModeration.Settings{
+		GroupID:            moderation.GroupJID,
+		WelcomeMessage:     moderation.WelcomeMessage,
+		GoodbyeMessage:     moderation.GoodbyeMessage,
+		AntiLink:           moderation.AntiLink,
+		BannedWords:        bannedWords,
+		FloodLimit:         moderation.FloodLimit,
+		FloodWindowSeconds: moderation.FloodWindowSeconds,
+		MaxWarnings:        moderation.MaxWarnings,
+		UpdatedAt:          moderation.UpdatedAt.Format(time.RFC3339),
+	}
+}
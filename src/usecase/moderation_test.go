@@ -0,0 +1,86 @@
+package usecase
+
+import (
+	"testing"
+	"time"
+
+	domainChatStorage "github.com/aldinokemal/go-whatsapp-web-multidevice/domains/chatstorage"
+)
+
+func TestContainsBannedWord(t *testing.T) {
+	bannedWords := []string{"scam", "free money"}
+
+	tests := []struct {
+		name string
+		text string
+		want bool
+	}{
+		{name: "Exact word", text: "this is a scam", want: true},
+		{name: "Different case", text: "SCAM alert!", want: true},
+		{name: "Word inside another word", text: "scampi for dinner", want: false},
+		{name: "Phrase", text: "get FREE MONEY now", want: true},
+		{name: "Clean text", text: "see you tomorrow", want: false},
+		{name: "Empty text", text: "", want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := containsBannedWord(tt.text, bannedWords); got != tt.want {
+				t.Fatalf("containsBannedWord(%q) = %v, want %v", tt.text, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestInviteLinkPattern(t *testing.T) {
+	if !inviteLinkPattern.MatchString("join us https://chat.whatsapp.com/AbCdEf123") {
+		t.Fatal("expected group invite link to match")
+	}
+	if !inviteLinkPattern.MatchString("follow https://whatsapp.com/channel/0029VaAbCdEf") {
+		t.Fatal("expected channel invite link to match")
+	}
+	if inviteLinkPattern.MatchString("see https://example.com/chat") {
+		t.Fatal("expected unrelated link not to match")
+	}
+}
+
+func TestRecordMessageFloodLimit(t *testing.T) {
+	service := &serviceModeration{activity: make(map[string]*participantActivity)}
+	settings := &domainChatStorage.GroupModeration{FloodLimit: 3, FloodWindowSeconds: 60}
+
+	for i := 1; i <= 3; i++ {
+		if service.recordMessage("group|user", settings) {
+			t.Fatalf("message %d should be within the flood limit", i)
+		}
+	}
+	if !service.recordMessage("group|user", settings) {
+		t.Fatal("fourth message should exceed the flood limit")
+	}
+	if service.recordMessage("group|user", settings) {
+		t.Fatal("flood window should restart after an offence")
+	}
+	if service.recordMessage("group|other", &domainChatStorage.GroupModeration{}) {
+		t.Fatal("flood limit should be disabled without settings")
+	}
+}
+
+func TestRecordMessagePrunesExpiredActivity(t *testing.T) {
+	expired := time.Now().Add(-2 * activityRetention)
+	service := &serviceModeration{activity: map[string]*participantActivity{
+		"group|idle":   {messages: []time.Time{expired}},
+		"group|warned": {messages: []time.Time{expired}, warnings: 1},
+	}}
+	settings := &domainChatStorage.GroupModeration{FloodLimit: 3, FloodWindowSeconds: 60}
+
+	service.recordMessage("group|user", settings)
+
+	if _, ok := service.activity["group|idle"]; ok {
+		t.Fatal("expired activity without warnings should be pruned")
+	}
+	if _, ok := service.activity["group|warned"]; !ok {
+		t.Fatal("activity with warnings should be kept")
+	}
+	if _, ok := service.activity["group|user"]; !ok {
+		t.Fatal("current activity should be kept")
+	}
+}
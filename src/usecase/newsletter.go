@@ -1,29 +1,416 @@
 package usecase
 
 import (
+	"bytes"
 	"context"
+	"encoding/base64"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
 
+	domainChatStorage "github.com/aldinokemal/go-whatsapp-web-multidevice/domains/chatstorage"
 	domainNewsletter "github.com/aldinokemal/go-whatsapp-web-multidevice/domains/newsletter"
 	"github.com/aldinokemal/go-whatsapp-web-multidevice/infrastructure/whatsapp"
+	pkgError "github.com/aldinokemal/go-whatsapp-web-multidevice/pkg/error"
 	"github.com/aldinokemal/go-whatsapp-web-multidevice/pkg/utils"
+	"github.com/aldinokemal/go-whatsapp-web-multidevice/ui/rest/helpers"
 	"github.com/aldinokemal/go-whatsapp-web-multidevice/validations"
+	"github.com/disintegration/imaging"
+	"github.com/sirupsen/logrus"
+	"go.mau.fi/whatsmeow"
+	"go.mau.fi/whatsmeow/proto/waE2E"
+	"go.mau.fi/whatsmeow/types"
+	"google.golang.org/protobuf/proto"
 )
 
-type serviceNewsletter struct{}
+// WhatsApp identifiers whatsmeow knows but does not expose, copied from newsletter.go of go.mau.fi/whatsmeow
+// (the mutationUpdateNewsletter constant and the AcceptTOSNotice doc example). Check them when bumping whatsmeow.
+const (
+	// mutationUpdateNewsletter is the GraphQL mutation that changes channel metadata
+	mutationUpdateNewsletter = "7150902998257522"
+	// newsletterTOSNoticeID and newsletterTOSNoticeStage identify the channel terms of service notice
+	newsletterTOSNoticeID    = "20601218"
+	newsletterTOSNoticeStage = "5"
+)
 
-func NewNewsletterService() domainNewsletter.INewsletterUsecase {
-	return &serviceNewsletter{}
+type serviceNewsletter struct {
+	chatStorageRepo domainChatStorage.IChatStorageRepository
+}
+
+func NewNewsletterService(chatStorageRepo domainChatStorage.IChatStorageRepository) domainNewsletter.INewsletterUsecase {
+	return &serviceNewsletter{
+		chatStorageRepo: chatStorageRepo,
+	}
+}
+
+func (service serviceNewsletter) Create(ctx context.Context, request domainNewsletter.CreateNewsletterRequest) (response domainNewsletter.NewsletterInfo, err error) {
+	if err = validations.ValidateCreateNewsletter(ctx, request); err != nil {
+		return response, err
+	}
+	utils.MustLogin(whatsapp.GetClient())
+
+	params := whatsmeow.CreateNewsletterParams{
+		Name:        request.Name,
+		Description: request.Description,
+	}
+	if request.Picture != nil {
+		picture, err := utils.ProcessGroupPhoto(request.Picture)
+		if err != nil {
+			return response, err
+		}
+		params.Picture = picture.Bytes()
+	}
+
+	// Creating channels requires the newsletter terms of service to be accepted once per account
+	if err = whatsapp.GetClient().AcceptTOSNotice(ctx, newsletterTOSNoticeID, newsletterTOSNoticeStage); err != nil {
+		logrus.Warnf("Failed to accept newsletter terms of service: %v", err)
+	}
+
+	metadata, err := whatsapp.GetClient().CreateNewsletter(ctx, params)
+	if err != nil {
+		return response, err
+	}
+
+	return toNewsletterInfo(metadata), nil
+}
+
+func (service serviceNewsletter) Follow(ctx context.Context, request domainNewsletter.FollowRequest) (err error) {
+	if err = validations.ValidateFollowNewsletter(ctx, request); err != nil {
+		return err
+	}
+	utils.MustLogin(whatsapp.GetClient())
+
+	JID, err := parseNewsletterJID(request.NewsletterID)
+	if err != nil {
+		return err
+	}
+
+	return whatsapp.GetClient().FollowNewsletter(ctx, JID)
 }
 
 func (service serviceNewsletter) Unfollow(ctx context.Context, request domainNewsletter.UnfollowRequest) (err error) {
 	if err = validations.ValidateUnfollowNewsletter(ctx, request); err != nil {
 		return err
 	}
+	utils.MustLogin(whatsapp.GetClient())
 
-	JID, err := utils.ValidateJidWithLogin(whatsapp.GetClient(), request.NewsletterID)
+	JID, err := parseNewsletterJID(request.NewsletterID)
 	if err != nil {
 		return err
 	}
 
 	return whatsapp.GetClient().UnfollowNewsletter(ctx, JID)
 }
+
+func (service serviceNewsletter) GetInfo(ctx context.Context, request domainNewsletter.GetInfoRequest) (response domainNewsletter.NewsletterInfo, err error) {
+	if err = validations.ValidateGetNewsletterInfo(ctx, request); err != nil {
+		return response, err
+	}
+	utils.MustLogin(whatsapp.GetClient())
+
+	JID, err := parseNewsletterJID(request.NewsletterID)
+	if err != nil {
+		return response, err
+	}
+
+	metadata, err := whatsapp.GetClient().GetNewsletterInfo(ctx, JID)
+	if err != nil {
+		return response, err
+	}
+
+	return toNewsletterInfo(metadata), nil
+}
+
+func (service serviceNewsletter) GetInfoFromLink(ctx context.Context, request domainNewsletter.GetInfoFromLinkRequest) (response domainNewsletter.NewsletterInfo, err error) {
+	if err = validations.ValidateGetNewsletterInfoFromLink(ctx, request); err != nil {
+		return response, err
+	}
+	utils.MustLogin(whatsapp.GetClient())
+
+	metadata, err := whatsapp.GetClient().GetNewsletterInfoWithInvite(ctx, strings.TrimSpace(request.Link))
+	if err != nil {
+		return response, err
+	}
+
+	return toNewsletterInfo(metadata), nil
+}
+
+func (service serviceNewsletter) Update(ctx context.Context, request domainNewsletter.UpdateNewsletterRequest) (response domainNewsletter.NewsletterInfo, err error) {
+	if err = validations.ValidateUpdateNewsletter(ctx, request); err != nil {
+		return response, err
+	}
+	utils.MustLogin(whatsapp.GetClient())
+
+	JID, err := parseNewsletterJID(request.NewsletterID)
+	if err != nil {
+		return response, err
+	}
+
+	updates := map[string]any{"settings": nil}
+	if request.Name != nil {
+		updates["name"] = *request.Name
+	}
+	if request.Description != nil {
+		updates["description"] = *request.Description
+	}
+	if request.Picture != nil {
+		picture, err := utils.ProcessGroupPhoto(request.Picture)
+		if err != nil {
+			return response, err
+		}
+		updates["picture"] = base64.StdEncoding.EncodeToString(picture.Bytes())
+	}
+
+	//lint:ignore SA1019 whatsmeow has no public helper for the update mutation
+	_, err = whatsapp.GetClient().DangerousInternals().SendMexIQ(ctx, mutationUpdateNewsletter, map[string]any{
+		"newsletter_id": JID.String(),
+		"updates":       updates,
+	})
+	if err != nil {
+		return response, err
+	}
+
+	metadata, err := whatsapp.GetClient().GetNewsletterInfo(ctx, JID)
+	if err != nil {
+		return response, err
+	}
+
+	return toNewsletterInfo(metadata), nil
+}
+
+func (service serviceNewsletter) Mute(ctx context.Context, request domainNewsletter.MuteRequest) (err error) {
+	if err = validations.ValidateMuteNewsletter(ctx, request); err != nil {
+		return err
+	}
+	utils.MustLogin(whatsapp.GetClient())
+
+	JID, err := parseNewsletterJID(request.NewsletterID)
+	if err != nil {
+		return err
+	}
+
+	return whatsapp.GetClient().NewsletterToggleMute(ctx, JID, request.Mute)
+}
+
+func (service serviceNewsletter) PostText(ctx context.Context, request domainNewsletter.PostTextRequest) (response domainNewsletter.PostResponse, err error) {
+	if err = validations.ValidatePostNewsletterText(ctx, request); err != nil {
+		return response, err
+	}
+	utils.MustLogin(whatsapp.GetClient())
+
+	JID, err := parseNewsletterJID(request.NewsletterID)
+	if err != nil {
+		return response, err
+	}
+
+	msg := &waE2E.Message{ExtendedTextMessage: &waE2E.ExtendedTextMessage{
+		Text: proto.String(request.Message),
+	}}
+
+	return service.post(ctx, JID, msg, request.Message, whatsmeow.SendRequestExtra{})
+}
+
+func (service serviceNewsletter) PostImage(ctx context.Context, request domainNewsletter.PostImageRequest) (response domainNewsletter.PostResponse, err error) {
+	if err = validations.ValidatePostNewsletterImage(ctx, request); err != nil {
+		return response, err
+	}
+	utils.MustLogin(whatsapp.GetClient())
+
+	JID, err := parseNewsletterJID(request.NewsletterID)
+	if err != nil {
+		return response, err
+	}
+
+	var imageBytes []byte
+	if request.ImageURL != nil && *request.ImageURL != "" {
+		imageBytes, _, err = utils.DownloadImageFromURL(*request.ImageURL)
+		if err != nil {
+			return response, pkgError.InternalServerError(fmt.Sprintf("failed to download image from URL %v", err))
+		}
+	} else {
+		imageBytes = helpers.MultipartFormFileHeaderToBytes(request.Image)
+	}
+
+	srcImage, err := imaging.Decode(bytes.NewReader(imageBytes))
+	if err != nil {
+		return response, pkgError.InternalServerError(fmt.Sprintf("failed to decode image %v", err))
+	}
+	var thumbnail bytes.Buffer
+	if err = imaging.Encode(&thumbnail, imaging.Resize(srcImage, 100, 0, imaging.Lanczos), imaging.JPEG); err != nil {
+		return response, pkgError.InternalServerError(fmt.Sprintf("failed to create thumbnail %v", err))
+	}
+
+	// Newsletter media is uploaded unencrypted, so there is no media key
+	uploaded, err := whatsapp.GetClient().UploadNewsletter(ctx, imageBytes, whatsmeow.MediaImage)
+	if err != nil {
+		return response, pkgError.WaUploadMediaError(fmt.Sprintf("failed to upload image %v", err))
+	}
+
+	msg := &waE2E.Message{ImageMessage: &waE2E.ImageMessage{
+		JPEGThumbnail: thumbnail.Bytes(),
+		Caption:       proto.String(request.Caption),
+		URL:           proto.String(uploaded.URL),
+		DirectPath:    proto.String(uploaded.DirectPath),
+		Mimetype:      proto.String(http.DetectContentType(imageBytes)),
+		FileSHA256:    uploaded.FileSHA256,
+		FileLength:    proto.Uint64(uploaded.FileLength),
+	}}
+
+	content := "🖼️ Image"
+	if request.Caption != "" {
+		content = "🖼️ " + request.Caption
+	}
+	return service.post(ctx, JID, msg, content, whatsmeow.SendRequestExtra{MediaHandle: uploaded.Handle})
+}
+
+func (service serviceNewsletter) PostVideo(ctx context.Context, request domainNewsletter.PostVideoRequest) (response domainNewsletter.PostResponse, err error) {
+	if err = validations.ValidatePostNewsletterVideo(ctx, request); err != nil {
+		return response, err
+	}
+	utils.MustLogin(whatsapp.GetClient())
+
+	JID, err := parseNewsletterJID(request.NewsletterID)
+	if err != nil {
+		return response, err
+	}
+
+	var videoBytes []byte
+	if request.VideoURL != nil && *request.VideoURL != "" {
+		videoBytes, _, err = utils.DownloadVideoFromURL(*request.VideoURL)
+		if err != nil {
+			return response, pkgError.InternalServerError(fmt.Sprintf("failed to download video from URL %v", err))
+		}
+	} else {
+		videoBytes = helpers.MultipartFormFileHeaderToBytes(request.Video)
+	}
+
+	uploaded, err := whatsapp.GetClient().UploadNewsletter(ctx, videoBytes, whatsmeow.MediaVideo)
+	if err != nil {
+		return response, pkgError.WaUploadMediaError(fmt.Sprintf("failed to upload video %v", err))
+	}
+
+	msg := &waE2E.Message{VideoMessage: &waE2E.VideoMessage{
+		Caption:    proto.String(request.Caption),
+		URL:        proto.String(uploaded.URL),
+		DirectPath: proto.String(uploaded.DirectPath),
+		Mimetype:   proto.String(http.DetectContentType(videoBytes)),
+		FileSHA256: uploaded.FileSHA256,
+		FileLength: proto.Uint64(uploaded.FileLength),
+	}}
+
+	content := "🎥 Video"
+	if request.Caption != "" {
+		content = "🎥 " + request.Caption
+	}
+	return service.post(ctx, JID, msg, content, whatsmeow.SendRequestExtra{MediaHandle: uploaded.Handle})
+}
+
+func (service serviceNewsletter) GetMessages(ctx context.Context, request domainNewsletter.GetMessagesRequest) (response domainNewsletter.GetMessagesResponse, err error) {
+	if err = validations.ValidateGetNewsletterMessages(ctx, &request); err != nil {
+		return response, err
+	}
+	utils.MustLogin(whatsapp.GetClient())
+
+	JID, err := parseNewsletterJID(request.NewsletterID)
+	if err != nil {
+		return response, err
+	}
+
+	messages, err := whatsapp.GetClient().GetNewsletterMessages(ctx, JID, &whatsmeow.GetNewsletterMessagesParams{
+		Count:  request.Count,
+		Before: types.MessageServerID(request.Before),
+	})
+	if err != nil {
+		return response, err
+	}
+
+	response.Data = make([]domainNewsletter.NewsletterMessage, 0, len(messages))
+	for _, message := range messages {
+		mediaType, _, _, _, _, _, _ := utils.ExtractMediaInfo(message.Message)
+		reactions := message.ReactionCounts
+		if reactions == nil {
+			reactions = map[string]int{}
+		}
+		response.Data = append(response.Data, domainNewsletter.NewsletterMessage{
+			ServerID:  int(message.MessageServerID),
+			MessageID: message.MessageID,
+			Type:      message.Type,
+			Content:   utils.ExtractMessageTextFromProto(message.Message),
+			MediaType: mediaType,
+			Views:     message.ViewsCount,
+			Reactions: reactions,
+			Timestamp: message.Timestamp.Format(time.RFC3339),
+		})
+	}
+
+	return response, nil
+}
+
+// post sends a message to the channel and keeps a copy in chat storage
+func (service serviceNewsletter) post(ctx context.Context, JID types.JID, msg *waE2E.Message, content string, extra whatsmeow.SendRequestExtra) (response domainNewsletter.PostResponse, err error) {
+	ts, err := whatsapp.SendMessage(ctx, JID, msg, extra)
+	if err != nil {
+		return response, err
+	}
+
+	senderJID := ""
+	if whatsapp.GetClient().Store.ID != nil {
+		senderJID = whatsapp.GetClient().Store.ID.String()
+	}
+	if err := service.chatStorageRepo.StoreSentMessageWithContext(ctx, ts.ID, senderJID, JID.String(), content, ts.Timestamp, "", nil); err != nil {
+		logrus.Warnf("Failed to store newsletter post %s: %v", ts.ID, err)
+	}
+
+	response.MessageID = ts.ID
+	response.ServerID = int(ts.ServerID)
+	response.Status = fmt.Sprintf("Posted to newsletter %s (server timestamp: %s)", JID.String(), ts.Timestamp.String())
+	return response, nil
+}
+
+// parseNewsletterJID accepts either a full newsletter JID or its numeric part
+func parseNewsletterJID(newsletterID string) (types.JID, error) {
+	newsletterID = strings.TrimSpace(newsletterID)
+	if !strings.Contains(newsletterID, "@") {
+		newsletterID = newsletterID + "@" + types.NewsletterServer
+	}
+
+	JID, err := types.ParseJID(newsletterID)
+	if err != nil {
+		return JID, pkgError.InvalidJID(fmt.Sprintf("invalid newsletter id %s: %v", newsletterID, err))
+	}
+	if JID.Server != types.NewsletterServer {
+		return JID, pkgError.InvalidJID(fmt.Sprintf("%s is not a newsletter id", newsletterID))
+	}
+
+	return JID, nil
+}
+
+func toNewsletterInfo(metadata *types.NewsletterMetadata) (info domainNewsletter.NewsletterInfo) {
+	if metadata == nil {
+		return info
+	}
+
+	info = domainNewsletter.NewsletterInfo{
+		ID:              metadata.ID.String(),
+		Name:            metadata.ThreadMeta.Name.Text,
+		Description:     metadata.ThreadMeta.Description.Text,
+		InviteCode:      metadata.ThreadMeta.InviteCode,
+		SubscriberCount: metadata.ThreadMeta.SubscriberCount,
+		Verification:    string(metadata.ThreadMeta.VerificationState),
+		State:           string(metadata.State.Type),
+		CreatedAt:       metadata.ThreadMeta.CreationTime.Time.Format(time.RFC3339),
+	}
+	if metadata.ThreadMeta.InviteCode != "" {
+		info.InviteLink = whatsmeow.NewsletterLinkPrefix + metadata.ThreadMeta.InviteCode
+	}
+	if metadata.ThreadMeta.Picture != nil {
+		info.PictureURL = metadata.ThreadMeta.Picture.URL
+	}
+	if metadata.ViewerMeta != nil {
+		info.Role = string(metadata.ViewerMeta.Role)
+		info.Mute = string(metadata.ViewerMeta.Mute)
+	}
+
+	return info
+}
@@ -0,0 +1,26 @@
+package usecase
+
+import "testing"
+
+func TestParseNewsletterJID(t *testing.T) {
+	tests := []struct {
+		input   string
+		want    string
+		wantErr bool
+	}{
+		{input: "120363144038483540", want: "120363144038483540@newsletter"},
+		{input: " 120363144038483540@newsletter ", want: "120363144038483540@newsletter"},
+		{input: "120363144038483540@g.us", wantErr: true},
+	}
+
+	// Parsing must not need a logged in client
+	for _, tt := range tests {
+		got, err := parseNewsletterJID(tt.input)
+		if (err != nil) != tt.wantErr {
+			t.Fatalf("parseNewsletterJID(%q) error = %v, wantErr %v", tt.input, err, tt.wantErr)
+		}
+		if !tt.wantErr && got.String() != tt.want {
+			t.Errorf("parseNewsletterJID(%q) = %s, want %s", tt.input, got, tt.want)
+		}
+	}
+}
@@ -15,6 +15,7 @@ import (
 
 	"github.com/aldinokemal/go-whatsapp-web-multidevice/config"
 	"github.com/aldinokemal/go-whatsapp-web-multidevice/domains/app"
+	domainAudit "github.com/aldinokemal/go-whatsapp-web-multidevice/domains/audit"
 	domainChatStorage "github.com/aldinokemal/go-whatsapp-web-multidevice/domains/chatstorage"
 	domainSend "github.com/aldinokemal/go-whatsapp-web-multidevice/domains/send"
 	"github.com/aldinokemal/go-whatsapp-web-multidevice/infrastructure/whatsapp"
@@ -46,7 +47,7 @@ func NewSendService(appService app.IAppUsecase, chatStorageRepo domainChatStorag
 
 // wrapSendMessage wraps the message sending process with message ID saving
 func (service serviceSend) wrapSendMessage(ctx context.Context, recipient types.JID, msg *waE2E.Message, content string) (whatsmeow.SendResponse, error) {
-	ts, err := whatsapp.GetClient().SendMessage(ctx, recipient, msg)
+	ts, err := whatsapp.SendMessage(ctx, recipient, msg)
 	if err != nil {
 		return whatsmeow.SendResponse{}, err
 	}
@@ -60,10 +61,11 @@ func (service serviceSend) wrapSendMessage(ctx context.Context, recipient types.
 	// Store message asynchronously with timeout
 	// Use a goroutine to avoid blocking the send operation
 	go func() {
-		storeCtx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+		storeCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), 2*time.Second)
 		defer cancel()
 
-		if err := service.chatStorageRepo.StoreSentMessageWithContext(storeCtx, ts.ID, senderJID, recipient.String(), content, ts.Timestamp); err != nil {
+		contextInfo := utils.ExtractContextInfo(msg)
+		if err := service.chatStorageRepo.StoreSentMessageWithContext(storeCtx, ts.ID, senderJID, recipient.String(), content, ts.Timestamp, contextInfo.GetStanzaID(), contextInfo.GetMentionedJID()); err != nil {
 			if errors.Is(err, context.DeadlineExceeded) {
 				logrus.Warn("Timeout storing sent message")
 			} else {
@@ -76,6 +78,14 @@ func (service serviceSend) wrapSendMessage(ctx context.Context, recipient types.
 }
 
 func (service serviceSend) SendText(ctx context.Context, request domainSend.MessageRequest) (response domainSend.GenericResponse, err error) {
+	claim, response, done, err := claimIdempotencyKey(ctx, service.chatStorageRepo, domainAudit.ActionSendText, request.BaseRequest)
+	if done {
+		return response, err
+	}
+	defer func() { claim.release(response, err, recover()) }()
+	defer func() {
+		recordAudit(ctx, service.chatStorageRepo, auditRecord{action: domainAudit.ActionSendText, target: request.Phone, messageID: response.MessageID}, err, recover())
+	}()
 	err = validations.ValidateSendMessage(ctx, request)
 	if err != nil {
 		return response, err
@@ -170,6 +180,14 @@ func (service serviceSend) SendText(ctx context.Context, request domainSend.Mess
 }
 
 func (service serviceSend) SendImage(ctx context.Context, request domainSend.ImageRequest) (response domainSend.GenericResponse, err error) {
+	claim, response, done, err := claimIdempotencyKey(ctx, service.chatStorageRepo, domainAudit.ActionSendImage, request.BaseRequest)
+	if done {
+		return response, err
+	}
+	defer func() { claim.release(response, err, recover()) }()
+	defer func() {
+		recordAudit(ctx, service.chatStorageRepo, auditRecord{action: domainAudit.ActionSendImage, target: request.Phone, messageID: response.MessageID}, err, recover())
+	}()
 	err = validations.ValidateSendImage(ctx, request)
 	if err != nil {
 		return response, err
@@ -332,6 +350,14 @@ func (service serviceSend) SendImage(ctx context.Context, request domainSend.Ima
 }
 
 func (service serviceSend) SendFile(ctx context.Context, request domainSend.FileRequest) (response domainSend.GenericResponse, err error) {
+	claim, response, done, err := claimIdempotencyKey(ctx, service.chatStorageRepo, domainAudit.ActionSendFile, request.BaseRequest)
+	if done {
+		return response, err
+	}
+	defer func() { claim.release(response, err, recover()) }()
+	defer func() {
+		recordAudit(ctx, service.chatStorageRepo, auditRecord{action: domainAudit.ActionSendFile, target: request.Phone, messageID: response.MessageID}, err, recover())
+	}()
 	err = validations.ValidateSendFile(ctx, request)
 	if err != nil {
 		return response, err
@@ -408,6 +434,14 @@ func resolveDocumentMIME(filename string, fileBytes []byte) string {
 }
 
 func (service serviceSend) SendVideo(ctx context.Context, request domainSend.VideoRequest) (response domainSend.GenericResponse, err error) {
+	claim, response, done, err := claimIdempotencyKey(ctx, service.chatStorageRepo, domainAudit.ActionSendVideo, request.BaseRequest)
+	if done {
+		return response, err
+	}
+	defer func() { claim.release(response, err, recover()) }()
+	defer func() {
+		recordAudit(ctx, service.chatStorageRepo, auditRecord{action: domainAudit.ActionSendVideo, target: request.Phone, messageID: response.MessageID}, err, recover())
+	}()
 	err = validations.ValidateSendVideo(ctx, request)
 	if err != nil {
 		return response, err
@@ -583,6 +617,14 @@ func (service serviceSend) SendVideo(ctx context.Context, request domainSend.Vid
 }
 
 func (service serviceSend) SendContact(ctx context.Context, request domainSend.ContactRequest) (response domainSend.GenericResponse, err error) {
+	claim, response, done, err := claimIdempotencyKey(ctx, service.chatStorageRepo, domainAudit.ActionSendContact, request.BaseRequest)
+	if done {
+		return response, err
+	}
+	defer func() { claim.release(response, err, recover()) }()
+	defer func() {
+		recordAudit(ctx, service.chatStorageRepo, auditRecord{action: domainAudit.ActionSendContact, target: request.Phone, messageID: response.MessageID}, err, recover())
+	}()
 	err = validations.ValidateSendContact(ctx, request)
 	if err != nil {
 		return response, err
@@ -626,6 +668,14 @@ func (service serviceSend) SendContact(ctx context.Context, request domainSend.C
 }
 
 func (service serviceSend) SendLink(ctx context.Context, request domainSend.LinkRequest) (response domainSend.GenericResponse, err error) {
+	claim, response, done, err := claimIdempotencyKey(ctx, service.chatStorageRepo, domainAudit.ActionSendLink, request.BaseRequest)
+	if done {
+		return response, err
+	}
+	defer func() { claim.release(response, err, recover()) }()
+	defer func() {
+		recordAudit(ctx, service.chatStorageRepo, auditRecord{action: domainAudit.ActionSendLink, target: request.Phone, messageID: response.MessageID}, err, recover())
+	}()
 	err = validations.ValidateSendLink(ctx, request)
 	if err != nil {
 		return response, err
@@ -701,6 +751,14 @@ func (service serviceSend) SendLink(ctx context.Context, request domainSend.Link
 }
 
 func (service serviceSend) SendLocation(ctx context.Context, request domainSend.LocationRequest) (response domainSend.GenericResponse, err error) {
+	claim, response, done, err := claimIdempotencyKey(ctx, service.chatStorageRepo, domainAudit.ActionSendLocation, request.BaseRequest)
+	if done {
+		return response, err
+	}
+	defer func() { claim.release(response, err, recover()) }()
+	defer func() {
+		recordAudit(ctx, service.chatStorageRepo, auditRecord{action: domainAudit.ActionSendLocation, target: request.Phone, messageID: response.MessageID}, err, recover())
+	}()
 	err = validations.ValidateSendLocation(ctx, request)
 	if err != nil {
 		return response, err
@@ -746,6 +804,14 @@ func (service serviceSend) SendLocation(ctx context.Context, request domainSend.
 }
 
 func (service serviceSend) SendAudio(ctx context.Context, request domainSend.AudioRequest) (response domainSend.GenericResponse, err error) {
+	claim, response, done, err := claimIdempotencyKey(ctx, service.chatStorageRepo, domainAudit.ActionSendAudio, request.BaseRequest)
+	if done {
+		return response, err
+	}
+	defer func() { claim.release(response, err, recover()) }()
+	defer func() {
+		recordAudit(ctx, service.chatStorageRepo, auditRecord{action: domainAudit.ActionSendAudio, target: request.Phone, messageID: response.MessageID}, err, recover())
+	}()
 	// Validate request
 	err = validations.ValidateSendAudio(ctx, request)
 	if err != nil {
@@ -820,6 +886,14 @@ func (service serviceSend) SendAudio(ctx context.Context, request domainSend.Aud
 }
 
 func (service serviceSend) SendPoll(ctx context.Context, request domainSend.PollRequest) (response domainSend.GenericResponse, err error) {
+	claim, response, done, err := claimIdempotencyKey(ctx, service.chatStorageRepo, domainAudit.ActionSendPoll, request.BaseRequest)
+	if done {
+		return response, err
+	}
+	defer func() { claim.release(response, err, recover()) }()
+	defer func() {
+		recordAudit(ctx, service.chatStorageRepo, auditRecord{action: domainAudit.ActionSendPoll, target: request.Phone, messageID: response.MessageID}, err, recover())
+	}()
 	err = validations.ValidateSendPoll(ctx, request)
 	if err != nil {
 		return response, err
@@ -851,6 +925,9 @@ func (service serviceSend) SendPoll(ctx context.Context, request domainSend.Poll
 }
 
 func (service serviceSend) SendPresence(ctx context.Context, request domainSend.PresenceRequest) (response domainSend.GenericResponse, err error) {
+	defer func() {
+		recordAudit(ctx, service.chatStorageRepo, auditRecord{action: domainAudit.ActionSendPresence, details: request.Type}, err, recover())
+	}()
 	err = validations.ValidateSendPresence(ctx, request)
 	if err != nil {
 		return response, err
@@ -867,6 +944,9 @@ func (service serviceSend) SendPresence(ctx context.Context, request domainSend.
 }
 
 func (service serviceSend) SendChatPresence(ctx context.Context, request domainSend.ChatPresenceRequest) (response domainSend.GenericResponse, err error) {
+	defer func() {
+		recordAudit(ctx, service.chatStorageRepo, auditRecord{action: domainAudit.ActionSendChatPresence, target: request.Phone, details: request.Action}, err, recover())
+	}()
 	err = validations.ValidateSendChatPresence(ctx, request)
 	if err != nil {
 		return response, err
@@ -916,6 +996,14 @@ func (service serviceSend) getMentionFromText(_ context.Context, messages string
 }
 
 func (service serviceSend) SendSticker(ctx context.Context, request domainSend.StickerRequest) (response domainSend.GenericResponse, err error) {
+	claim, response, done, err := claimIdempotencyKey(ctx, service.chatStorageRepo, domainAudit.ActionSendSticker, request.BaseRequest)
+	if done {
+		return response, err
+	}
+	defer func() { claim.release(response, err, recover()) }()
+	defer func() {
+		recordAudit(ctx, service.chatStorageRepo, auditRecord{action: domainAudit.ActionSendSticker, target: request.Phone, messageID: response.MessageID}, err, recover())
+	}()
 	// Validate request
 	err = validations.ValidateSendSticker(ctx, request)
 	if err != nil {
@@ -0,0 +1,285 @@
+package usecase
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	domainChatStorage "github.com/aldinokemal/go-whatsapp-web-multidevice/domains/chatstorage"
+	domainStatus "github.com/aldinokemal/go-whatsapp-web-multidevice/domains/status"
+	"github.com/aldinokemal/go-whatsapp-web-multidevice/infrastructure/whatsapp"
+	pkgError "github.com/aldinokemal/go-whatsapp-web-multidevice/pkg/error"
+	"github.com/aldinokemal/go-whatsapp-web-multidevice/pkg/mediastorage"
+	"github.com/aldinokemal/go-whatsapp-web-multidevice/pkg/utils"
+	"github.com/aldinokemal/go-whatsapp-web-multidevice/ui/rest/helpers"
+	"github.com/aldinokemal/go-whatsapp-web-multidevice/validations"
+	"github.com/disintegration/imaging"
+	"github.com/sirupsen/logrus"
+	"go.mau.fi/whatsmeow"
+	"go.mau.fi/whatsmeow/proto/waE2E"
+	"go.mau.fi/whatsmeow/types"
+	"google.golang.org/protobuf/proto"
+)
+
+type serviceStatus struct {
+	chatStorageRepo domainChatStorage.IChatStorageRepository
+}
+
+func NewStatusService(chatStorageRepo domainChatStorage.IChatStorageRepository) domainStatus.IStatusUsecase {
+	return &serviceStatus{
+		chatStorageRepo: chatStorageRepo,
+	}
+}
+
+func (service serviceStatus) PostText(ctx context.Context, request domainStatus.TextStatusRequest) (response domainStatus.StatusResponse, err error) {
+	if err = validations.ValidatePostTextStatus(ctx, request); err != nil {
+		return response, err
+	}
+
+	privacy, err := service.resolveAudience(ctx, request.Audience)
+	if err != nil {
+		return response, err
+	}
+
+	msg := &waE2E.Message{ExtendedTextMessage: &waE2E.ExtendedTextMessage{
+		Text:     proto.String(request.Message),
+		TextArgb: proto.Uint32(0xFFFFFFFF),
+		Font:     waE2E.ExtendedTextMessage_FontType(request.Font).Enum(),
+	}}
+	if request.BackgroundColor != "" {
+		rgb, _ := strconv.ParseUint(strings.TrimPrefix(request.BackgroundColor, "#"), 16, 32)
+		msg.ExtendedTextMessage.BackgroundArgb = proto.Uint32(0xFF000000 | uint32(rgb))
+	}
+
+	return service.postStatus(ctx, msg, privacy, &domainChatStorage.Status{Content: request.Message})
+}
+
+func (service serviceStatus) PostImage(ctx context.Context, request domainStatus.ImageStatusRequest) (response domainStatus.StatusResponse, err error) {
+	if err = validations.ValidatePostImageStatus(ctx, request); err != nil {
+		return response, err
+	}
+
+	privacy, err := service.resolveAudience(ctx, request.Audience)
+	if err != nil {
+		return response, err
+	}
+
+	var imageBytes []byte
+	if request.ImageURL != nil && *request.ImageURL != "" {
+		imageBytes, _, err = utils.DownloadImageFromURL(*request.ImageURL)
+		if err != nil {
+			return response, pkgError.InternalServerError(fmt.Sprintf("failed to download image from URL %v", err))
+		}
+	} else {
+		imageBytes = helpers.MultipartFormFileHeaderToBytes(request.Image)
+	}
+
+	// Generate thumbnail with smaller image size
+	srcImage, err := imaging.Decode(bytes.NewReader(imageBytes))
+	if err != nil {
+		return response, pkgError.InternalServerError(fmt.Sprintf("failed to decode image %v", err))
+	}
+	var thumbnail bytes.Buffer
+	if err = imaging.Encode(&thumbnail, imaging.Resize(srcImage, 100, 0, imaging.Lanczos), imaging.JPEG); err != nil {
+		return response, pkgError.InternalServerError(fmt.Sprintf("failed to create thumbnail %v", err))
+	}
+
+	uploaded, err := whatsapp.GetClient().Upload(ctx, imageBytes, whatsmeow.MediaImage)
+	if err != nil {
+		return response, pkgError.WaUploadMediaError(fmt.Sprintf("failed to upload image %v", err))
+	}
+
+	mimeType := http.DetectContentType(imageBytes)
+	msg := &waE2E.Message{ImageMessage: &waE2E.ImageMessage{
+		JPEGThumbnail: thumbnail.Bytes(),
+		Caption:       proto.String(request.Caption),
+		URL:           proto.String(uploaded.URL),
+		DirectPath:    proto.String(uploaded.DirectPath),
+		MediaKey:      uploaded.MediaKey,
+		Mimetype:      proto.String(mimeType),
+		FileEncSHA256: uploaded.FileEncSHA256,
+		FileSHA256:    uploaded.FileSHA256,
+		FileLength:    proto.Uint64(uint64(len(imageBytes))),
+	}}
+
+	return service.postStatus(ctx, msg, privacy, &domainChatStorage.Status{
+		Content:   request.Caption,
+		MediaType: "image",
+		MimeType:  mimeType,
+	})
+}
+
+func (service serviceStatus) PostVideo(ctx context.Context, request domainStatus.VideoStatusRequest) (response domainStatus.StatusResponse, err error) {
+	if err = validations.ValidatePostVideoStatus(ctx, request); err != nil {
+		return response, err
+	}
+
+	privacy, err := service.resolveAudience(ctx, request.Audience)
+	if err != nil {
+		return response, err
+	}
+
+	var videoBytes []byte
+	if request.VideoURL != nil && *request.VideoURL != "" {
+		videoBytes, _, err = utils.DownloadVideoFromURL(*request.VideoURL)
+		if err != nil {
+			return response, pkgError.InternalServerError(fmt.Sprintf("failed to download video from URL %v", err))
+		}
+	} else {
+		videoBytes = helpers.MultipartFormFileHeaderToBytes(request.Video)
+	}
+
+	uploaded, err := whatsapp.GetClient().Upload(ctx, videoBytes, whatsmeow.MediaVideo)
+	if err != nil {
+		return response, pkgError.WaUploadMediaError(fmt.Sprintf("failed to upload video %v", err))
+	}
+
+	mimeType := http.DetectContentType(videoBytes)
+	msg := &waE2E.Message{VideoMessage: &waE2E.VideoMessage{
+		URL:           proto.String(uploaded.URL),
+		Mimetype:      proto.String(mimeType),
+		Caption:       proto.String(request.Caption),
+		FileLength:    proto.Uint64(uploaded.FileLength),
+		FileSHA256:    uploaded.FileSHA256,
+		FileEncSHA256: uploaded.FileEncSHA256,
+		MediaKey:      uploaded.MediaKey,
+		DirectPath:    proto.String(uploaded.DirectPath),
+	}}
+
+	return service.postStatus(ctx, msg, privacy, &domainChatStorage.Status{
+		Content:   request.Caption,
+		MediaType: "video",
+		MimeType:  mimeType,
+	})
+}
+
+func (service serviceStatus) ListStatuses(ctx context.Context, request domainStatus.ListStatusesRequest) (response domainStatus.ListStatusesResponse, err error) {
+	if err = validations.ValidateListStatuses(ctx, &request); err != nil {
+		return response, err
+	}
+
+	if request.Sender != "" {
+		utils.SanitizePhone(&request.Sender)
+	}
+
+	statuses, err := service.chatStorageRepo.GetStatuses(&domainChatStorage.StatusFilter{
+		Sender:         request.Sender,
+		IncludeExpired: request.IncludeExpired,
+		Limit:          request.Limit,
+		Offset:         request.Offset,
+	})
+	if err != nil {
+		logrus.WithError(err).Error("Failed to get statuses from storage")
+		return response, err
+	}
+
+	response.Data = make([]domainStatus.StatusInfo, 0, len(statuses))
+	for _, status := range statuses {
+		info := domainStatus.StatusInfo{
+			ID:        status.ID,
+			Sender:    status.Sender,
+			PushName:  status.PushName,
+			Content:   status.Content,
+			MediaType: status.MediaType,
+			MediaPath: status.MediaPath,
+			MimeType:  status.MimeType,
+			IsFromMe:  status.IsFromMe,
+			Timestamp: status.Timestamp.Format(time.RFC3339),
+			ExpiresAt: status.ExpiresAt.Format(time.RFC3339),
+		}
+		if status.MediaPath != "" && !mediastorage.IsLocal() {
+			if info.MediaURL, err = mediastorage.URL(ctx, status.MediaPath); err != nil {
+				logrus.WithError(err).Warnf("Failed to sign URL for status media %s", status.ID)
+			}
+		}
+		response.Data = append(response.Data, info)
+	}
+	response.Limit = request.Limit
+	response.Offset = request.Offset
+
+	return response, nil
+}
+
+func (service serviceStatus) GetPrivacy(ctx context.Context) (response []domainStatus.StatusPrivacyResponse, err error) {
+	utils.MustLogin(whatsapp.GetClient())
+
+	privacies, err := whatsapp.GetClient().GetStatusPrivacy(ctx)
+	if err != nil {
+		return response, err
+	}
+
+	for _, privacy := range privacies {
+		list := make([]string, 0, len(privacy.List))
+		for _, jid := range privacy.List {
+			list = append(list, jid.String())
+		}
+		response = append(response, domainStatus.StatusPrivacyResponse{
+			Type:      string(privacy.Type),
+			List:      list,
+			IsDefault: privacy.IsDefault,
+		})
+	}
+
+	return response, nil
+}
+
+// statusAudiences maps the status privacy lists stored on the account to the request audiences
+var statusAudiences = map[types.StatusPrivacyType]string{
+	types.StatusPrivacyTypeContacts:  domainStatus.AudienceContacts,
+	types.StatusPrivacyTypeBlacklist: domainStatus.AudienceContactsExcept,
+	types.StatusPrivacyTypeWhitelist: domainStatus.AudienceOnlyShareWith,
+}
+
+// resolveAudience returns the audience a status post will reach. whatsmeow always sends status posts to
+// the account's default status privacy list and cannot change that list (the "status" privacy setting is
+// the About visibility), so a requested audience other than the default is rejected instead of ignored.
+func (service serviceStatus) resolveAudience(ctx context.Context, requested string) (string, error) {
+	utils.MustLogin(whatsapp.GetClient())
+
+	privacies, err := whatsapp.GetClient().GetStatusPrivacy(ctx)
+	if err != nil {
+		return "", pkgError.InternalServerError(fmt.Sprintf("failed to get status privacy %v", err))
+	}
+
+	// whatsmeow returns the default list first
+	current := domainStatus.AudienceContacts
+	if len(privacies) > 0 {
+		if audience, ok := statusAudiences[privacies[0].Type]; ok {
+			current = audience
+		}
+	}
+
+	if requested != "" && requested != current {
+		return "", pkgError.ValidationError(fmt.Sprintf("audience %s is not the default status audience (%s) of the account, change it from the WhatsApp app first", requested, current))
+	}
+
+	return current, nil
+}
+
+// postStatus sends the message to the status broadcast and keeps a copy in chat storage
+func (service serviceStatus) postStatus(ctx context.Context, msg *waE2E.Message, privacy string, status *domainChatStorage.Status) (response domainStatus.StatusResponse, err error) {
+	ts, err := whatsapp.SendMessage(ctx, types.StatusBroadcastJID, msg)
+	if err != nil {
+		return response, err
+	}
+
+	status.ID = ts.ID
+	status.IsFromMe = true
+	status.Timestamp = ts.Timestamp
+	status.ExpiresAt = ts.Timestamp.Add(domainStatus.Lifetime)
+	if whatsapp.GetClient().Store.ID != nil {
+		status.Sender = whatsapp.GetClient().Store.ID.ToNonAD().String()
+	}
+	if err := service.chatStorageRepo.StoreStatus(status); err != nil {
+		logrus.Warnf("Failed to store posted status %s: %v", ts.ID, err)
+	}
+
+	response.MessageID = ts.ID
+	response.Privacy = privacy
+	response.Status = fmt.Sprintf("Status posted to %s (server timestamp: %s)", privacy, ts.Timestamp.String())
+	return response, nil
+}
@@ -8,6 +8,8 @@ import (
 	"image"
 	"time"
 
+	domainAudit "github.com/aldinokemal/go-whatsapp-web-multidevice/domains/audit"
+	domainChatStorage "github.com/aldinokemal/go-whatsapp-web-multidevice/domains/chatstorage"
 	domainUser "github.com/aldinokemal/go-whatsapp-web-multidevice/domains/user"
 	"github.com/aldinokemal/go-whatsapp-web-multidevice/infrastructure/whatsapp"
 	pkgError "github.com/aldinokemal/go-whatsapp-web-multidevice/pkg/error"
@@ -17,14 +19,17 @@ import (
 	"go.mau.fi/whatsmeow"
 	"go.mau.fi/whatsmeow/appstate"
 	"go.mau.fi/whatsmeow/types"
+	"go.mau.fi/whatsmeow/types/events"
 )
 
 type serviceUser struct {
-	// Remove the WaCli field - we'll use the global client instead
+	chatStorageRepo domainChatStorage.IChatStorageRepository
 }
 
-func NewUserService() domainUser.IUserUsecase {
-	return &serviceUser{}
+func NewUserService(chatStorageRepo domainChatStorage.IChatStorageRepository) domainUser.IUserUsecase {
+	return &serviceUser{
+		chatStorageRepo: chatStorageRepo,
+	}
 }
 
 func (service serviceUser) Info(ctx context.Context, request domainUser.InfoRequest) (response domainUser.InfoResponse, err error) {
@@ -153,11 +158,48 @@ func (service serviceUser) MyPrivacySetting(ctx context.Context) (response domai
 		return
 	}
 
-	response.GroupAdd = string(resp.GroupAdd)
-	response.Status = string(resp.Status)
-	response.ReadReceipts = string(resp.ReadReceipts)
-	response.Profile = string(resp.Profile)
-	return response, nil
+	return toPrivacySettingResponse(*resp), nil
+}
+
+// privacySettingTypes maps the privacy settings accepted by the API to the names WhatsApp uses
+var privacySettingTypes = map[string]types.PrivacySettingType{
+	domainUser.PrivacySettingLastSeen:     types.PrivacySettingTypeLastSeen,
+	domainUser.PrivacySettingOnline:       types.PrivacySettingTypeOnline,
+	domainUser.PrivacySettingProfile:      types.PrivacySettingTypeProfile,
+	domainUser.PrivacySettingAbout:        types.PrivacySettingTypeStatus,
+	domainUser.PrivacySettingGroupAdd:     types.PrivacySettingTypeGroupAdd,
+	domainUser.PrivacySettingReadReceipts: types.PrivacySettingTypeReadReceipts,
+	domainUser.PrivacySettingCallAdd:      types.PrivacySettingTypeCallAdd,
+}
+
+func (service serviceUser) ChangePrivacySetting(ctx context.Context, request domainUser.ChangePrivacySettingRequest) (response domainUser.MyPrivacySettingResponse, err error) {
+	defer func() {
+		details := fmt.Sprintf("%s=%s", request.Setting, request.Value)
+		recordAudit(ctx, service.chatStorageRepo, auditRecord{action: domainAudit.ActionUserPrivacy, details: details}, err, recover())
+	}()
+	if err = validations.ValidateChangePrivacySetting(ctx, request); err != nil {
+		return response, err
+	}
+	utils.MustLogin(whatsapp.GetClient())
+
+	settings, err := whatsapp.GetClient().SetPrivacySetting(ctx, privacySettingTypes[request.Setting], types.PrivacySetting(request.Value))
+	if err != nil {
+		return response, err
+	}
+
+	return toPrivacySettingResponse(settings), nil
+}
+
+func toPrivacySettingResponse(settings types.PrivacySettings) domainUser.MyPrivacySettingResponse {
+	return domainUser.MyPrivacySettingResponse{
+		GroupAdd:     string(settings.GroupAdd),
+		LastSeen:     string(settings.LastSeen),
+		Status:       string(settings.Status),
+		Profile:      string(settings.Profile),
+		ReadReceipts: string(settings.ReadReceipts),
+		Online:       string(settings.Online),
+		CallAdd:      string(settings.CallAdd),
+	}
 }
 
 func (service serviceUser) MyListContacts(ctx context.Context) (response domainUser.MyListContactsResponse, err error) {
@@ -179,6 +221,9 @@ func (service serviceUser) MyListContacts(ctx context.Context) (response domainU
 }
 
 func (service serviceUser) ChangeAvatar(ctx context.Context, request domainUser.ChangeAvatarRequest) (err error) {
+	defer func() {
+		recordAudit(ctx, service.chatStorageRepo, auditRecord{action: domainAudit.ActionUserAvatar}, err, recover())
+	}()
 	utils.MustLogin(whatsapp.GetClient())
 
 	file, err := request.Avatar.Open()
@@ -233,6 +278,9 @@ func (service serviceUser) ChangeAvatar(ctx context.Context, request domainUser.
 }
 
 func (service serviceUser) ChangePushName(ctx context.Context, request domainUser.ChangePushNameRequest) (err error) {
+	defer func() {
+		recordAudit(ctx, service.chatStorageRepo, auditRecord{action: domainAudit.ActionUserPushName, details: request.PushName}, err, recover())
+	}()
 	utils.MustLogin(whatsapp.GetClient())
 
 	err = whatsapp.GetClient().SendAppState(ctx, appstate.BuildSettingPushName(request.PushName))
@@ -242,6 +290,31 @@ func (service serviceUser) ChangePushName(ctx context.Context, request domainUse
 	return nil
 }
 
+func (service serviceUser) ChangeAbout(ctx context.Context, request domainUser.ChangeAboutRequest) (err error) {
+	defer func() {
+		recordAudit(ctx, service.chatStorageRepo, auditRecord{action: domainAudit.ActionUserAbout, details: request.About}, err, recover())
+	}()
+	if err = validations.ValidateChangeAbout(ctx, request); err != nil {
+		return err
+	}
+	utils.MustLogin(whatsapp.GetClient())
+
+	return whatsapp.GetClient().SetStatusMessage(ctx, request.About)
+}
+
+func (service serviceUser) ChangeDefaultDisappearingTimer(ctx context.Context, request domainUser.ChangeDisappearingTimerRequest) (err error) {
+	defer func() {
+		recordAudit(ctx, service.chatStorageRepo, auditRecord{action: domainAudit.ActionUserDisappearing, details: request.Timer}, err, recover())
+	}()
+	if err = validations.ValidateChangeDisappearingTimer(ctx, request); err != nil {
+		return err
+	}
+	utils.MustLogin(whatsapp.GetClient())
+
+	timer, _ := whatsmeow.ParseDisappearingTimerString(request.Timer)
+	return whatsapp.GetClient().SetDefaultDisappearingTimer(ctx, timer)
+}
+
 func (service serviceUser) IsOnWhatsApp(ctx context.Context, request domainUser.CheckRequest) (response domainUser.CheckResponse, err error) {
 	utils.MustLogin(whatsapp.GetClient())
 
@@ -303,3 +376,67 @@ func (service serviceUser) BusinessProfile(ctx context.Context, request domainUs
 
 	return response, nil
 }
+
+func (service serviceUser) Block(ctx context.Context, request domainUser.BlockRequest) (response domainUser.BlocklistResponse, err error) {
+	return service.updateBlocklist(ctx, request, events.BlocklistChangeActionBlock, domainAudit.ActionUserBlock)
+}
+
+func (service serviceUser) Unblock(ctx context.Context, request domainUser.BlockRequest) (response domainUser.BlocklistResponse, err error) {
+	return service.updateBlocklist(ctx, request, events.BlocklistChangeActionUnblock, domainAudit.ActionUserUnblock)
+}
+
+func (service serviceUser) updateBlocklist(ctx context.Context, request domainUser.BlockRequest, action events.BlocklistChangeAction, auditAction string) (response domainUser.BlocklistResponse, err error) {
+	defer func() {
+		recordAudit(ctx, service.chatStorageRepo, auditRecord{action: auditAction, target: request.Phone}, err, recover())
+	}()
+	if err = validations.ValidateBlockContact(ctx, request); err != nil {
+		return response, err
+	}
+
+	utils.SanitizePhone(&request.Phone)
+	dataWaRecipient, err := utils.ValidateJidWithLogin(whatsapp.GetClient(), request.Phone)
+	if err != nil {
+		return response, err
+	}
+
+	blocklist, err := whatsapp.GetClient().UpdateBlocklist(ctx, dataWaRecipient, action)
+	if err != nil {
+		return response, err
+	}
+
+	return service.toBlocklistResponse(blocklist)
+}
+
+func (service serviceUser) Blocklist(ctx context.Context) (response domainUser.BlocklistResponse, err error) {
+	utils.MustLogin(whatsapp.GetClient())
+
+	blocklist, err := whatsapp.GetClient().GetBlocklist(ctx)
+	if err != nil {
+		return response, err
+	}
+
+	return service.toBlocklistResponse(blocklist)
+}
+
+// toBlocklistResponse stores the blocklist returned by WhatsApp and lists it with the time each contact was blocked
+func (service serviceUser) toBlocklistResponse(blocklist *types.Blocklist) (response domainUser.BlocklistResponse, err error) {
+	whatsapp.StoreBlocklist(service.chatStorageRepo, blocklist.JIDs)
+
+	blockedAt := make(map[string]time.Time)
+	if stored, err := service.chatStorageRepo.GetBlocklist(); err == nil {
+		for _, contact := range stored {
+			blockedAt[contact.JID] = contact.BlockedAt
+		}
+	}
+
+	response.Data = make([]domainUser.BlockedContact, 0, len(blocklist.JIDs))
+	for _, jid := range blocklist.JIDs {
+		contact := domainUser.BlockedContact{JID: jid.ToNonAD().String()}
+		if at, ok := blockedAt[contact.JID]; ok {
+			contact.BlockedAt = at.Format(time.RFC3339)
+		}
+		response.Data = append(response.Data, contact)
+	}
+
+	return response, nil
+}
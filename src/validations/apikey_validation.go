@@ -0,0 +1,59 @@
+package validations
+
+import (
+	"context"
+	"path"
+	"time"
+
+	domainAPIKey "github.com/aldinokemal/go-whatsapp-web-multidevice/domains/apikey"
+	pkgError "github.com/aldinokemal/go-whatsapp-web-multidevice/pkg/error"
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+)
+
+func ValidateCreateAPIKey(ctx context.Context, request domainAPIKey.CreateKeyRequest) error {
+	scopes := make([]any, 0, len(domainAPIKey.Scopes))
+	for _, scope := range domainAPIKey.Scopes {
+		scopes = append(scopes, scope)
+	}
+
+	err := validation.ValidateStructWithContext(ctx, &request,
+		validation.Field(&request.Name, validation.Required, validation.Length(1, 100)),
+		validation.Field(&request.Scopes, validation.Required, validation.Each(validation.Required, validation.In(scopes...))),
+		validation.Field(&request.AllowedRecipients, validation.Each(validation.Required, validation.By(validateRecipientPattern))),
+		validation.Field(&request.RateLimit, validation.Min(0), validation.Max(100000)),
+		validation.Field(&request.ExpiresAt, validation.Date(time.RFC3339)),
+	)
+
+	if err != nil {
+		return pkgError.ValidationError(err.Error())
+	}
+
+	if request.ExpiresAt != "" {
+		expiresAt, _ := time.Parse(time.RFC3339, request.ExpiresAt)
+		if !expiresAt.After(time.Now()) {
+			return pkgError.ValidationError("expires_at: must be in the future.")
+		}
+	}
+
+	return nil
+}
+
+func ValidateRevokeAPIKey(ctx context.Context, request domainAPIKey.RevokeKeyRequest) error {
+	err := validation.ValidateStructWithContext(ctx, &request,
+		validation.Field(&request.ID, validation.Required),
+	)
+
+	if err != nil {
+		return pkgError.ValidationError(err.Error())
+	}
+
+	return nil
+}
+
+func validateRecipientPattern(value any) error {
+	pattern, _ := value.(string)
+	if _, err := path.Match(pattern, ""); err != nil {
+		return validation.NewError("validation_recipient_pattern", "must be a valid glob pattern")
+	}
+	return nil
+}
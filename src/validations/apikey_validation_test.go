@@ -0,0 +1,72 @@
+package validations
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	domainAPIKey "github.com/aldinokemal/go-whatsapp-web-multidevice/domains/apikey"
+	pkgError "github.com/aldinokemal/go-whatsapp-web-multidevice/pkg/error"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateCreateAPIKey(t *testing.T) {
+	type args struct {
+		request domainAPIKey.CreateKeyRequest
+	}
+	tests := []struct {
+		name string
+		args args
+		err  any
+	}{
+		{
+			name: "should success with name and scopes",
+			args: args{request: domainAPIKey.CreateKeyRequest{
+				Name:              "crm",
+				Scopes:            []domainAPIKey.Scope{domainAPIKey.ScopeSend, domainAPIKey.ScopeReadChats},
+				AllowedRecipients: []string{"62812*", "*@g.us"},
+				RateLimit:         60,
+				ExpiresAt:         time.Now().Add(24 * time.Hour).Format(time.RFC3339),
+			}},
+			err: nil,
+		},
+		{
+			name: "should error without scopes",
+			args: args{request: domainAPIKey.CreateKeyRequest{Name: "crm"}},
+			err:  pkgError.ValidationError("scopes: cannot be blank."),
+		},
+		{
+			name: "should error with unknown scope",
+			args: args{request: domainAPIKey.CreateKeyRequest{
+				Name:   "crm",
+				Scopes: []domainAPIKey.Scope{"everything"},
+			}},
+			err: pkgError.ValidationError("scopes: (0: must be a valid value.)."),
+		},
+		{
+			name: "should error with invalid recipient pattern",
+			args: args{request: domainAPIKey.CreateKeyRequest{
+				Name:              "crm",
+				Scopes:            []domainAPIKey.Scope{domainAPIKey.ScopeSend},
+				AllowedRecipients: []string{"[62"},
+			}},
+			err: pkgError.ValidationError("allowed_recipients: (0: must be a valid glob pattern.)."),
+		},
+		{
+			name: "should error with expiry in the past",
+			args: args{request: domainAPIKey.CreateKeyRequest{
+				Name:      "crm",
+				Scopes:    []domainAPIKey.Scope{domainAPIKey.ScopeAdmin},
+				ExpiresAt: "2020-01-01T00:00:00Z",
+			}},
+			err: pkgError.ValidationError("expires_at: must be in the future."),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateCreateAPIKey(context.Background(), tt.args.request)
+			assert.Equal(t, tt.err, err)
+		})
+	}
+}
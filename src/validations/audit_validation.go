@@ -0,0 +1,26 @@
+package validations
+
+import (
+	"context"
+	"time"
+
+	domainAudit "github.com/aldinokemal/go-whatsapp-web-multidevice/domains/audit"
+	pkgError "github.com/aldinokemal/go-whatsapp-web-multidevice/pkg/error"
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+)
+
+func ValidateListAudit(ctx context.Context, request domainAudit.ListRequest) error {
+	err := validation.ValidateStructWithContext(ctx, &request,
+		validation.Field(&request.Result, validation.In(domainAudit.ResultSuccess, domainAudit.ResultFailed)),
+		validation.Field(&request.From, validation.Date(time.RFC3339)),
+		validation.Field(&request.To, validation.Date(time.RFC3339)),
+		validation.Field(&request.Limit, validation.Min(0), validation.Max(1000)),
+		validation.Field(&request.Offset, validation.Min(0)),
+	)
+
+	if err != nil {
+		return pkgError.ValidationError(err.Error())
+	}
+
+	return nil
+}
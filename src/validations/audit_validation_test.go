@@ -0,0 +1,68 @@
+package validations
+
+import (
+	"context"
+	"testing"
+
+	domainAudit "github.com/aldinokemal/go-whatsapp-web-multidevice/domains/audit"
+	pkgError "github.com/aldinokemal/go-whatsapp-web-multidevice/pkg/error"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateListAudit(t *testing.T) {
+	type args struct {
+		request domainAudit.ListRequest
+	}
+	tests := []struct {
+		name string
+		args args
+		err  any
+	}{
+		{
+			name: "should success without filters",
+			args: args{request: domainAudit.ListRequest{}},
+			err:  nil,
+		},
+		{
+			name: "should success with filters",
+			args: args{request: domainAudit.ListRequest{
+				Actor:     "basic_auth:admin",
+				Action:    domainAudit.ActionSendText,
+				TargetJID: "6289685028129@s.whatsapp.net",
+				Result:    domainAudit.ResultFailed,
+				From:      "2026-01-01T00:00:00Z",
+				To:        "2026-01-31T23:59:59+07:00",
+				Limit:     1000,
+				Offset:    100,
+			}},
+			err: nil,
+		},
+		{
+			name: "should error with unknown result",
+			args: args{request: domainAudit.ListRequest{Result: "pending"}},
+			err:  pkgError.ValidationError("result: must be a valid value."),
+		},
+		{
+			name: "should error with invalid date",
+			args: args{request: domainAudit.ListRequest{From: "2026-01-01"}},
+			err:  pkgError.ValidationError("from: must be a valid date."),
+		},
+		{
+			name: "should error with limit above maximum",
+			args: args{request: domainAudit.ListRequest{Limit: 1001}},
+			err:  pkgError.ValidationError("limit: must be no greater than 1000."),
+		},
+		{
+			name: "should error with negative offset",
+			args: args{request: domainAudit.ListRequest{Offset: -1}},
+			err:  pkgError.ValidationError("offset: must be no less than 0."),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateListAudit(context.Background(), tt.args.request)
+			assert.Equal(t, tt.err, err)
+		})
+	}
+}
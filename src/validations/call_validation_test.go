@@ -0,0 +1,67 @@
+package validations
+
+import (
+	"context"
+	"testing"
+
+	domainCall "github.com/aldinokemal/go-whatsapp-web-multidevice/domains/call"
+	domainChatStorage "github.com/aldinokemal/go-whatsapp-web-multidevice/domains/chatstorage"
+	pkgError "github.com/aldinokemal/go-whatsapp-web-multidevice/pkg/error"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateListCalls(t *testing.T) {
+	type args struct {
+		request domainCall.ListCallsRequest
+	}
+	tests := []struct {
+		name  string
+		args  args
+		err   any
+		limit int
+	}{
+		{
+			name:  "should success and default the limit",
+			args:  args{request: domainCall.ListCallsRequest{}},
+			err:   nil,
+			limit: 50,
+		},
+		{
+			name: "should success with filters",
+			args: args{request: domainCall.ListCallsRequest{
+				Phone:   "6289685028129",
+				Outcome: domainChatStorage.CallOutcomeMissed,
+				Limit:   100,
+				Offset:  20,
+			}},
+			err:   nil,
+			limit: 100,
+		},
+		{
+			name:  "should error with unknown outcome",
+			args:  args{request: domainCall.ListCallsRequest{Outcome: "busy"}},
+			err:   pkgError.ValidationError("outcome: must be a valid value."),
+			limit: 50,
+		},
+		{
+			name:  "should error with limit above maximum",
+			args:  args{request: domainCall.ListCallsRequest{Limit: 101}},
+			err:   pkgError.ValidationError("limit: must be no greater than 100."),
+			limit: 101,
+		},
+		{
+			name:  "should error with negative offset",
+			args:  args{request: domainCall.ListCallsRequest{Offset: -1}},
+			err:   pkgError.ValidationError("offset: must be no less than 0."),
+			limit: 50,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateListCalls(context.Background(), &tt.args.request)
+			assert.Equal(t, tt.err, err)
+			assert.Equal(t, tt.limit, tt.args.request.Limit)
+		})
+	}
+}
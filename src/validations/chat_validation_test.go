@@ -183,3 +183,176 @@ func TestValidatePinChat(t *testing.T) {
 		})
 	}
 }
+
+func TestValidateMuteChat(t *testing.T) {
+	type args struct {
+		request domainChat.MuteChatRequest
+	}
+	tests := []struct {
+		name string
+		args args
+		err  any
+	}{
+		{
+			name: "should success muting without duration",
+			args: args{request: domainChat.MuteChatRequest{
+				ChatJID: "6289685028129@s.whatsapp.net",
+				Muted:   true,
+			}},
+			err: nil,
+		},
+		{
+			name: "should success muting for 8 hours",
+			args: args{request: domainChat.MuteChatRequest{
+				ChatJID:  "6289685028129@s.whatsapp.net",
+				Muted:    true,
+				Duration: 28800,
+			}},
+			err: nil,
+		},
+		{
+			name: "should error with negative duration",
+			args: args{request: domainChat.MuteChatRequest{
+				ChatJID:  "6289685028129@s.whatsapp.net",
+				Muted:    true,
+				Duration: -1,
+			}},
+			err: pkgError.ValidationError("duration: must be no less than 0."),
+		},
+		{
+			name: "should error with empty chat_jid",
+			args: args{request: domainChat.MuteChatRequest{
+				ChatJID: "",
+				Muted:   true,
+			}},
+			err: pkgError.ValidationError("chat_jid: cannot be blank."),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateMuteChat(context.Background(), &tt.args.request)
+			assert.Equal(t, tt.err, err)
+		})
+	}
+}
+
+func TestValidateSetDisappearingTimer(t *testing.T) {
+	type args struct {
+		request domainChat.SetDisappearingTimerRequest
+	}
+	tests := []struct {
+		name string
+		args args
+		err  any
+	}{
+		{
+			name: "should success for a private chat",
+			args: args{request: domainChat.SetDisappearingTimerRequest{
+				ChatJID: "6289685028129@s.whatsapp.net",
+				Timer:   "24h",
+			}},
+			err: nil,
+		},
+		{
+			name: "should success turning it off in a group",
+			args: args{request: domainChat.SetDisappearingTimerRequest{
+				ChatJID: "120363024512399999@g.us",
+				Timer:   "off",
+			}},
+			err: nil,
+		},
+		{
+			name: "should error with unsupported timer",
+			args: args{request: domainChat.SetDisappearingTimerRequest{
+				ChatJID: "6289685028129@s.whatsapp.net",
+				Timer:   "30d",
+			}},
+			err: pkgError.ValidationError("timer: must be a valid value."),
+		},
+		{
+			name: "should error with empty timer",
+			args: args{request: domainChat.SetDisappearingTimerRequest{
+				ChatJID: "6289685028129@s.whatsapp.net",
+			}},
+			err: pkgError.ValidationError("timer: cannot be blank."),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateSetDisappearingTimer(context.Background(), &tt.args.request)
+			assert.Equal(t, tt.err, err)
+		})
+	}
+}
+
+func TestValidateRequestHistory(t *testing.T) {
+	tests := []struct {
+		name    string
+		request domainChat.RequestHistoryRequest
+		err     any
+	}{
+		{
+			name:    "should success with the default count",
+			request: domainChat.RequestHistoryRequest{ChatJID: "6289685028129@s.whatsapp.net"},
+			err:     nil,
+		},
+		{
+			name:    "should success with a count",
+			request: domainChat.RequestHistoryRequest{ChatJID: "120363024512399999@g.us", Count: 100},
+			err:     nil,
+		},
+		{
+			name:    "should error with a count above the maximum",
+			request: domainChat.RequestHistoryRequest{ChatJID: "6289685028129@s.whatsapp.net", Count: 101},
+			err:     pkgError.ValidationError("count: must be no greater than 100."),
+		},
+		{
+			name:    "should error with empty chat_jid",
+			request: domainChat.RequestHistoryRequest{Count: 50},
+			err:     pkgError.ValidationError("chat_jid: cannot be blank."),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateRequestHistory(context.Background(), &tt.request)
+			assert.Equal(t, tt.err, err)
+		})
+	}
+}
+
+func TestValidateGetMessageThread(t *testing.T) {
+	tests := []struct {
+		name    string
+		request domainChat.GetMessageThreadRequest
+		err     any
+	}{
+		{
+			name:    "should success without chat_jid",
+			request: domainChat.GetMessageThreadRequest{MessageID: "3EB0B430B6F8F1D0E053AC120E0A9E5C"},
+			err:     nil,
+		},
+		{
+			name:    "should error with empty message_id",
+			request: domainChat.GetMessageThreadRequest{ChatJID: "6289685028129@s.whatsapp.net"},
+			err:     pkgError.ValidationError("message_id: cannot be blank."),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateGetMessageThread(context.Background(), &tt.request)
+			assert.Equal(t, tt.err, err)
+		})
+	}
+}
+
+func TestValidateDeleteChat(t *testing.T) {
+	err := ValidateDeleteChat(context.Background(), &domainChat.DeleteChatRequest{})
+	assert.Equal(t, pkgError.ValidationError("chat_jid: cannot be blank."), err)
+
+	err = ValidateDeleteChat(context.Background(), &domainChat.DeleteChatRequest{ChatJID: "120363025246125486@g.us"})
+	assert.NoError(t, err)
+}
@@ -2,6 +2,8 @@ package validations
 
 import (
 	"context"
+	"path/filepath"
+	"strings"
 
 	domainGroup "github.com/aldinokemal/go-whatsapp-web-multidevice/domains/group"
 	pkgError "github.com/aldinokemal/go-whatsapp-web-multidevice/pkg/error"
@@ -228,3 +230,97 @@ func ValidateGetGroupInviteLink(ctx context.Context, request domainGroup.GetGrou
 
 	return nil
 }
+
+func ValidateCreateCommunity(ctx context.Context, request domainGroup.CreateCommunityRequest) error {
+	err := validation.ValidateStructWithContext(ctx, &request,
+		validation.Field(&request.Name, validation.Required, validation.Length(1, 100)),
+		validation.Field(&request.Description, validation.Length(0, 2048)),
+	)
+
+	if err != nil {
+		return pkgError.ValidationError(err.Error())
+	}
+
+	return nil
+}
+
+func ValidateLinkGroup(ctx context.Context, request domainGroup.LinkGroupRequest) error {
+	err := validation.ValidateStructWithContext(ctx, &request,
+		validation.Field(&request.CommunityID, validation.Required),
+		validation.Field(&request.GroupID, validation.Required),
+	)
+
+	if err != nil {
+		return pkgError.ValidationError(err.Error())
+	}
+
+	return nil
+}
+
+func ValidateUnlinkGroup(ctx context.Context, request domainGroup.UnlinkGroupRequest) error {
+	err := validation.ValidateStructWithContext(ctx, &request,
+		validation.Field(&request.CommunityID, validation.Required),
+		validation.Field(&request.GroupID, validation.Required),
+	)
+
+	if err != nil {
+		return pkgError.ValidationError(err.Error())
+	}
+
+	return nil
+}
+
+func ValidateGetLinkedGroups(ctx context.Context, request domainGroup.GetLinkedGroupsRequest) error {
+	err := validation.ValidateStructWithContext(ctx, &request,
+		validation.Field(&request.CommunityID, validation.Required),
+	)
+
+	if err != nil {
+		return pkgError.ValidationError(err.Error())
+	}
+
+	return nil
+}
+
+func ValidateCommunityAnnouncement(ctx context.Context, request domainGroup.CommunityAnnouncementRequest) error {
+	err := validation.ValidateStructWithContext(ctx, &request,
+		validation.Field(&request.CommunityID, validation.Required),
+		validation.Field(&request.Message, validation.Required),
+	)
+
+	if err != nil {
+		return pkgError.ValidationError(err.Error())
+	}
+
+	return nil
+}
+
+func ValidateBulkParticipantJob(ctx context.Context, request domainGroup.BulkParticipantJobRequest) error {
+	err := validation.ValidateStructWithContext(ctx, &request,
+		validation.Field(&request.File, validation.Required),
+		validation.Field(&request.BatchSize, validation.Min(0), validation.Max(50)),
+		validation.Field(&request.DelaySeconds, validation.Min(0), validation.Max(300)),
+	)
+
+	if err != nil {
+		return pkgError.ValidationError(err.Error())
+	}
+
+	if !strings.EqualFold(filepath.Ext(request.File.Filename), ".csv") {
+		return pkgError.ValidationError("file must be a CSV file")
+	}
+
+	return nil
+}
+
+func ValidateGetBulkParticipantJob(ctx context.Context, request domainGroup.GetBulkParticipantJobRequest) error {
+	err := validation.ValidateStructWithContext(ctx, &request,
+		validation.Field(&request.JobID, validation.Required),
+	)
+
+	if err != nil {
+		return pkgError.ValidationError(err.Error())
+	}
+
+	return nil
+}
@@ -2,6 +2,7 @@ package validations
 
 import (
 	"context"
+	"mime/multipart"
 	"testing"
 
 	domainGroup "github.com/aldinokemal/go-whatsapp-web-multidevice/domains/group"
@@ -706,3 +707,160 @@ func TestValidateGroupInfo(t *testing.T) {
 		})
 	}
 }
+
+func TestValidateCreateCommunity(t *testing.T) {
+	type args struct {
+		request domainGroup.CreateCommunityRequest
+	}
+	tests := []struct {
+		name string
+		args args
+		err  any
+	}{
+		{
+			name: "should success with name and description",
+			args: args{request: domainGroup.CreateCommunityRequest{
+				Name:        "Neighbourhood",
+				Description: "All groups of our neighbourhood",
+			}},
+			err: nil,
+		},
+		{
+			name: "should error with empty name",
+			args: args{request: domainGroup.CreateCommunityRequest{
+				Description: "All groups of our neighbourhood",
+			}},
+			err: pkgError.ValidationError("name: cannot be blank."),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateCreateCommunity(context.Background(), tt.args.request)
+			assert.Equal(t, tt.err, err)
+		})
+	}
+}
+
+func TestValidateLinkGroup(t *testing.T) {
+	type args struct {
+		request domainGroup.LinkGroupRequest
+	}
+	tests := []struct {
+		name string
+		args args
+		err  any
+	}{
+		{
+			name: "should success with community and group id",
+			args: args{request: domainGroup.LinkGroupRequest{
+				CommunityID: "120363000000000001@g.us",
+				GroupID:     "120363000000000002@g.us",
+			}},
+			err: nil,
+		},
+		{
+			name: "should error with empty community id",
+			args: args{request: domainGroup.LinkGroupRequest{
+				GroupID: "120363000000000002@g.us",
+			}},
+			err: pkgError.ValidationError("community_id: cannot be blank."),
+		},
+		{
+			name: "should error with empty group id",
+			args: args{request: domainGroup.LinkGroupRequest{
+				CommunityID: "120363000000000001@g.us",
+			}},
+			err: pkgError.ValidationError("group_id: cannot be blank."),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateLinkGroup(context.Background(), tt.args.request)
+			assert.Equal(t, tt.err, err)
+		})
+	}
+}
+
+func TestValidateCommunityAnnouncement(t *testing.T) {
+	type args struct {
+		request domainGroup.CommunityAnnouncementRequest
+	}
+	tests := []struct {
+		name string
+		args args
+		err  any
+	}{
+		{
+			name: "should success with community id and message",
+			args: args{request: domainGroup.CommunityAnnouncementRequest{
+				CommunityID: "120363000000000001@g.us",
+				Message:     "Meeting tonight at 8",
+			}},
+			err: nil,
+		},
+		{
+			name: "should error with empty message",
+			args: args{request: domainGroup.CommunityAnnouncementRequest{
+				CommunityID: "120363000000000001@g.us",
+			}},
+			err: pkgError.ValidationError("message: cannot be blank."),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateCommunityAnnouncement(context.Background(), tt.args.request)
+			assert.Equal(t, tt.err, err)
+		})
+	}
+}
+
+func TestValidateBulkParticipantJob(t *testing.T) {
+	type args struct {
+		request domainGroup.BulkParticipantJobRequest
+	}
+	tests := []struct {
+		name string
+		args args
+		err  any
+	}{
+		{
+			name: "should success with csv file",
+			args: args{request: domainGroup.BulkParticipantJobRequest{
+				File:         &multipart.FileHeader{Filename: "participants.csv"},
+				BatchSize:    10,
+				DelaySeconds: 5,
+			}},
+			err: nil,
+		},
+		{
+			name: "should error without file",
+			args: args{request: domainGroup.BulkParticipantJobRequest{}},
+			err:  pkgError.ValidationError("file: cannot be blank."),
+		},
+		{
+			name: "should error with non csv file",
+			args: args{request: domainGroup.BulkParticipantJobRequest{
+				File: &multipart.FileHeader{Filename: "participants.xlsx"},
+			}},
+			err: pkgError.ValidationError("file must be a CSV file"),
+		},
+		{
+			name: "should error with batch size too large",
+			args: args{request: domainGroup.BulkParticipantJobRequest{
+				File:      &multipart.FileHeader{Filename: "participants.csv"},
+				BatchSize: 100,
+			}},
+			err: pkgError.ValidationError("batch_size: must be no greater than 50."),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateBulkParticipantJob(context.Background(), tt.args.request)
+			assert.Equal(t, tt.err, err)
+		})
+	}
+}
@@ -0,0 +1,78 @@
+package validations
+
+import (
+	"context"
+
+	domainLabel "github.com/aldinokemal/go-whatsapp-web-multidevice/domains/label"
+	pkgError "github.com/aldinokemal/go-whatsapp-web-multidevice/pkg/error"
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+)
+
+// maxLabelColor is the last color of the WhatsApp Business label palette
+const maxLabelColor = 19
+
+func ValidateCreateLabel(ctx context.Context, request *domainLabel.CreateLabelRequest) error {
+	err := validation.ValidateStructWithContext(ctx, request,
+		validation.Field(&request.Name, validation.Required, validation.Length(1, 100)),
+		validation.Field(&request.Color, validation.Min(int32(0)), validation.Max(int32(maxLabelColor))),
+	)
+
+	if err != nil {
+		return pkgError.ValidationError(err.Error())
+	}
+
+	return nil
+}
+
+func ValidateUpdateLabel(ctx context.Context, request *domainLabel.UpdateLabelRequest) error {
+	err := validation.ValidateStructWithContext(ctx, request,
+		validation.Field(&request.LabelID, validation.Required),
+		validation.Field(&request.Name, validation.Required, validation.Length(1, 100)),
+		validation.Field(&request.Color, validation.Min(int32(0)), validation.Max(int32(maxLabelColor))),
+	)
+
+	if err != nil {
+		return pkgError.ValidationError(err.Error())
+	}
+
+	return nil
+}
+
+func ValidateDeleteLabel(ctx context.Context, request *domainLabel.DeleteLabelRequest) error {
+	err := validation.ValidateStructWithContext(ctx, request,
+		validation.Field(&request.LabelID, validation.Required),
+	)
+
+	if err != nil {
+		return pkgError.ValidationError(err.Error())
+	}
+
+	return nil
+}
+
+func ValidateLabelChat(ctx context.Context, request *domainLabel.LabelChatRequest) error {
+	err := validation.ValidateStructWithContext(ctx, request,
+		validation.Field(&request.ChatJID, validation.Required),
+		validation.Field(&request.LabelID, validation.Required),
+	)
+
+	if err != nil {
+		return pkgError.ValidationError(err.Error())
+	}
+
+	return nil
+}
+
+func ValidateLabelMessage(ctx context.Context, request *domainLabel.LabelMessageRequest) error {
+	err := validation.ValidateStructWithContext(ctx, request,
+		validation.Field(&request.MessageID, validation.Required),
+		validation.Field(&request.Phone, validation.Required),
+		validation.Field(&request.LabelID, validation.Required),
+	)
+
+	if err != nil {
+		return pkgError.ValidationError(err.Error())
+	}
+
+	return nil
+}
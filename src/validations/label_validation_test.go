@@ -0,0 +1,67 @@
+package validations
+
+import (
+	"context"
+	"testing"
+
+	domainLabel "github.com/aldinokemal/go-whatsapp-web-multidevice/domains/label"
+	pkgError "github.com/aldinokemal/go-whatsapp-web-multidevice/pkg/error"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateCreateLabel(t *testing.T) {
+	tests := []struct {
+		name    string
+		request domainLabel.CreateLabelRequest
+		err     any
+	}{
+		{
+			name:    "should success with name and color",
+			request: domainLabel.CreateLabelRequest{Name: "Pending payment", Color: 3},
+			err:     nil,
+		},
+		{
+			name:    "should error with empty name",
+			request: domainLabel.CreateLabelRequest{Color: 3},
+			err:     pkgError.ValidationError("name: cannot be blank."),
+		},
+		{
+			name:    "should error with color outside the palette",
+			request: domainLabel.CreateLabelRequest{Name: "Paid", Color: 20},
+			err:     pkgError.ValidationError("color: must be no greater than 19."),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateCreateLabel(context.Background(), &tt.request)
+			assert.Equal(t, tt.err, err)
+		})
+	}
+}
+
+func TestValidateLabelChat(t *testing.T) {
+	tests := []struct {
+		name    string
+		request domainLabel.LabelChatRequest
+		err     any
+	}{
+		{
+			name:    "should success with chat and label",
+			request: domainLabel.LabelChatRequest{ChatJID: "6289685028129@s.whatsapp.net", LabelID: "3", Labeled: true},
+			err:     nil,
+		},
+		{
+			name:    "should error without label",
+			request: domainLabel.LabelChatRequest{ChatJID: "6289685028129@s.whatsapp.net", Labeled: true},
+			err:     pkgError.ValidationError("label_id: cannot be blank."),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateLabelChat(context.Background(), &tt.request)
+			assert.Equal(t, tt.err, err)
+		})
+	}
+}
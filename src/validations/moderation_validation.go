@@ -0,0 +1,54 @@
+package validations
+
+import (
+	"context"
+
+	domainModeration "github.com/aldinokemal/go-whatsapp-web-multidevice/domains/moderation"
+	pkgError "github.com/aldinokemal/go-whatsapp-web-multidevice/pkg/error"
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+)
+
+func ValidateGetModerationSettings(ctx context.Context, request domainModeration.GetSettingsRequest) error {
+	err := validation.ValidateStructWithContext(ctx, &request,
+		validation.Field(&request.GroupID, validation.Required),
+	)
+
+	if err != nil {
+		return pkgError.ValidationError(err.Error())
+	}
+
+	return nil
+}
+
+func ValidateSetModerationSettings(ctx context.Context, request domainModeration.Settings) error {
+	err := validation.ValidateStructWithContext(ctx, &request,
+		validation.Field(&request.GroupID, validation.Required),
+		validation.Field(&request.WelcomeMessage, validation.Length(0, 1024)),
+		validation.Field(&request.GoodbyeMessage, validation.Length(0, 1024)),
+		validation.Field(&request.BannedWords, validation.Each(validation.Required, validation.Length(1, 100))),
+		validation.Field(&request.FloodLimit, validation.Min(0), validation.Max(1000)),
+		validation.Field(&request.FloodWindowSeconds,
+			validation.When(request.FloodLimit > 0, validation.Required),
+			validation.Min(0), validation.Max(3600),
+		),
+		validation.Field(&request.MaxWarnings, validation.Min(0), validation.Max(20)),
+	)
+
+	if err != nil {
+		return pkgError.ValidationError(err.Error())
+	}
+
+	return nil
+}
+
+func ValidateDeleteModerationSettings(ctx context.Context, request domainModeration.DeleteSettingsRequest) error {
+	err := validation.ValidateStructWithContext(ctx, &request,
+		validation.Field(&request.GroupID, validation.Required),
+	)
+
+	if err != nil {
+		return pkgError.ValidationError(err.Error())
+	}
+
+	return nil
+}
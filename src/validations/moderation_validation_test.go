@@ -0,0 +1,88 @@
+package validations
+
+import (
+	"context"
+	"testing"
+
+	domainModeration "github.com/aldinokemal/go-whatsapp-web-multidevice/domains/moderation"
+	pkgError "github.com/aldinokemal/go-whatsapp-web-multidevice/pkg/error"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateSetModerationSettings(t *testing.T) {
+	type args struct {
+		request domainModeration.Settings
+	}
+	tests := []struct {
+		name string
+		args args
+		err  any
+	}{
+		{
+			name: "should success with welcome message only",
+			args: args{request: domainModeration.Settings{
+				GroupID:        "120363024512399999@g.us",
+				WelcomeMessage: "Welcome {user} to {group}!",
+			}},
+			err: nil,
+		},
+		{
+			name: "should success with all rules",
+			args: args{request: domainModeration.Settings{
+				GroupID:            "120363024512399999@g.us",
+				AntiLink:           true,
+				BannedWords:        []string{"spam", "scam"},
+				FloodLimit:         5,
+				FloodWindowSeconds: 10,
+				MaxWarnings:        3,
+			}},
+			err: nil,
+		},
+		{
+			name: "should error with empty group id",
+			args: args{request: domainModeration.Settings{
+				AntiLink: true,
+			}},
+			err: pkgError.ValidationError("group_id: cannot be blank."),
+		},
+		{
+			name: "should error with flood limit but no window",
+			args: args{request: domainModeration.Settings{
+				GroupID:    "120363024512399999@g.us",
+				FloodLimit: 5,
+			}},
+			err: pkgError.ValidationError("flood_window_seconds: cannot be blank."),
+		},
+		{
+			name: "should error with empty banned word",
+			args: args{request: domainModeration.Settings{
+				GroupID:     "120363024512399999@g.us",
+				BannedWords: []string{"spam", ""},
+			}},
+			err: pkgError.ValidationError("banned_words: (1: cannot be blank.)."),
+		},
+		{
+			name: "should error with negative max warnings",
+			args: args{request: domainModeration.Settings{
+				GroupID:     "120363024512399999@g.us",
+				MaxWarnings: -1,
+			}},
+			err: pkgError.ValidationError("max_warnings: must be no less than 0."),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateSetModerationSettings(context.Background(), tt.args.request)
+			assert.Equal(t, tt.err, err)
+		})
+	}
+}
+
+func TestValidateGetModerationSettings(t *testing.T) {
+	err := ValidateGetModerationSettings(context.Background(), domainModeration.GetSettingsRequest{})
+	assert.Equal(t, pkgError.ValidationError("group_id: cannot be blank."), err)
+
+	err = ValidateGetModerationSettings(context.Background(), domainModeration.GetSettingsRequest{GroupID: "120363024512399999@g.us"})
+	assert.NoError(t, err)
+}
@@ -2,9 +2,12 @@ package validations
 
 import (
 	"context"
+
 	domainNewsletter "github.com/aldinokemal/go-whatsapp-web-multidevice/domains/newsletter"
 	pkgError "github.com/aldinokemal/go-whatsapp-web-multidevice/pkg/error"
+	"github.com/aldinokemal/go-whatsapp-web-multidevice/pkg/utils"
 	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/go-ozzo/ozzo-validation/v4/is"
 )
 
 func ValidateUnfollowNewsletter(ctx context.Context, request domainNewsletter.UnfollowRequest) error {
@@ -18,3 +21,182 @@ func ValidateUnfollowNewsletter(ctx context.Context, request domainNewsletter.Un
 
 	return nil
 }
+
+func ValidateFollowNewsletter(ctx context.Context, request domainNewsletter.FollowRequest) error {
+	err := validation.ValidateStructWithContext(ctx, &request,
+		validation.Field(&request.NewsletterID, validation.Required),
+	)
+
+	if err != nil {
+		return pkgError.ValidationError(err.Error())
+	}
+
+	return nil
+}
+
+func ValidateCreateNewsletter(ctx context.Context, request domainNewsletter.CreateNewsletterRequest) error {
+	err := validation.ValidateStructWithContext(ctx, &request,
+		validation.Field(&request.Name, validation.Required, validation.Length(1, 100)),
+		validation.Field(&request.Description, validation.Length(0, 2048)),
+	)
+
+	if err != nil {
+		return pkgError.ValidationError(err.Error())
+	}
+
+	if request.Picture != nil {
+		if err := utils.ValidateGroupPhotoFormat(request.Picture); err != nil {
+			return pkgError.ValidationError(err.Error())
+		}
+	}
+
+	return nil
+}
+
+func ValidateGetNewsletterInfo(ctx context.Context, request domainNewsletter.GetInfoRequest) error {
+	err := validation.ValidateStructWithContext(ctx, &request,
+		validation.Field(&request.NewsletterID, validation.Required),
+	)
+
+	if err != nil {
+		return pkgError.ValidationError(err.Error())
+	}
+
+	return nil
+}
+
+func ValidateGetNewsletterInfoFromLink(ctx context.Context, request domainNewsletter.GetInfoFromLinkRequest) error {
+	err := validation.ValidateStructWithContext(ctx, &request,
+		validation.Field(&request.Link, validation.Required),
+	)
+
+	if err != nil {
+		return pkgError.ValidationError(err.Error())
+	}
+
+	return nil
+}
+
+func ValidateUpdateNewsletter(ctx context.Context, request domainNewsletter.UpdateNewsletterRequest) error {
+	err := validation.ValidateStructWithContext(ctx, &request,
+		validation.Field(&request.NewsletterID, validation.Required),
+		validation.Field(&request.Name, validation.NilOrNotEmpty, validation.Length(1, 100)),
+		validation.Field(&request.Description, validation.Length(0, 2048)),
+	)
+
+	if err != nil {
+		return pkgError.ValidationError(err.Error())
+	}
+
+	if request.Name == nil && request.Description == nil && request.Picture == nil {
+		return pkgError.ValidationError("at least one of name, description or picture must be provided")
+	}
+
+	if request.Picture != nil {
+		if err := utils.ValidateGroupPhotoFormat(request.Picture); err != nil {
+			return pkgError.ValidationError(err.Error())
+		}
+	}
+
+	return nil
+}
+
+func ValidateMuteNewsletter(ctx context.Context, request domainNewsletter.MuteRequest) error {
+	err := validation.ValidateStructWithContext(ctx, &request,
+		validation.Field(&request.NewsletterID, validation.Required),
+	)
+
+	if err != nil {
+		return pkgError.ValidationError(err.Error())
+	}
+
+	return nil
+}
+
+func ValidatePostNewsletterText(ctx context.Context, request domainNewsletter.PostTextRequest) error {
+	err := validation.ValidateStructWithContext(ctx, &request,
+		validation.Field(&request.NewsletterID, validation.Required),
+		validation.Field(&request.Message, validation.Required),
+	)
+
+	if err != nil {
+		return pkgError.ValidationError(err.Error())
+	}
+
+	return nil
+}
+
+func ValidatePostNewsletterImage(ctx context.Context, request domainNewsletter.PostImageRequest) error {
+	err := validation.ValidateStructWithContext(ctx, &request,
+		validation.Field(&request.NewsletterID, validation.Required),
+	)
+
+	if err != nil {
+		return pkgError.ValidationError(err.Error())
+	}
+
+	if request.Image == nil && (request.ImageURL == nil || *request.ImageURL == "") {
+		return pkgError.ValidationError("either Image or ImageURL must be provided")
+	}
+
+	if request.Image != nil {
+		availableMimes := map[string]bool{
+			"image/jpeg": true,
+			"image/jpg":  true,
+			"image/png":  true,
+		}
+
+		if !availableMimes[request.Image.Header.Get("Content-Type")] {
+			return pkgError.ValidationError("your image is not allowed. please use jpg/jpeg/png")
+		}
+	}
+
+	if request.ImageURL != nil && *request.ImageURL != "" {
+		if err := validation.Validate(*request.ImageURL, is.URL); err != nil {
+			return pkgError.ValidationError("ImageURL must be a valid URL")
+		}
+	}
+
+	return nil
+}
+
+func ValidatePostNewsletterVideo(ctx context.Context, request domainNewsletter.PostVideoRequest) error {
+	err := validation.ValidateStructWithContext(ctx, &request,
+		validation.Field(&request.NewsletterID, validation.Required),
+	)
+
+	if err != nil {
+		return pkgError.ValidationError(err.Error())
+	}
+
+	if request.Video == nil && (request.VideoURL == nil || *request.VideoURL == "") {
+		return pkgError.ValidationError("either Video or VideoURL must be provided")
+	}
+
+	if request.VideoURL != nil && *request.VideoURL != "" {
+		if err := validation.Validate(*request.VideoURL, is.URL); err != nil {
+			return pkgError.ValidationError("VideoURL must be a valid URL")
+		}
+	}
+
+	return nil
+}
+
+func ValidateGetNewsletterMessages(ctx context.Context, request *domainNewsletter.GetMessagesRequest) error {
+	// Set default count if not provided
+	if request.Count == 0 {
+		request.Count = 20
+	}
+
+	err := validation.ValidateStructWithContext(ctx, request,
+		validation.Field(&request.NewsletterID, validation.Required),
+		validation.Field(&request.Count, validation.Min(1), validation.Max(100)),
+		validation.Field(&request.Before, validation.Min(0)),
+	)
+
+	if err != nil {
+		return pkgError.ValidationError(err.Error())
+	}
+
+	return nil
+}
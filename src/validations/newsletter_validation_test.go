@@ -48,3 +48,165 @@ func TestValidateUnfollowNewsletter(t *testing.T) {
 		})
 	}
 }
+
+func TestValidateCreateNewsletter(t *testing.T) {
+	type args struct {
+		request domainNewsletter.CreateNewsletterRequest
+	}
+	tests := []struct {
+		name string
+		args args
+		err  any
+	}{
+		{
+			name: "should success with name only",
+			args: args{request: domainNewsletter.CreateNewsletterRequest{
+				Name: "My Channel",
+			}},
+			err: nil,
+		},
+		{
+			name: "should success with name and description",
+			args: args{request: domainNewsletter.CreateNewsletterRequest{
+				Name:        "My Channel",
+				Description: "Daily updates",
+			}},
+			err: nil,
+		},
+		{
+			name: "should error with empty name",
+			args: args{request: domainNewsletter.CreateNewsletterRequest{
+				Description: "Daily updates",
+			}},
+			err: pkgError.ValidationError("name: cannot be blank."),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateCreateNewsletter(context.Background(), tt.args.request)
+			assert.Equal(t, tt.err, err)
+		})
+	}
+}
+
+func TestValidateUpdateNewsletter(t *testing.T) {
+	name := "New Name"
+	emptyName := ""
+	description := "New description"
+
+	type args struct {
+		request domainNewsletter.UpdateNewsletterRequest
+	}
+	tests := []struct {
+		name string
+		args args
+		err  any
+	}{
+		{
+			name: "should success with name",
+			args: args{request: domainNewsletter.UpdateNewsletterRequest{
+				NewsletterID: "120363123456789@newsletter",
+				Name:         &name,
+			}},
+			err: nil,
+		},
+		{
+			name: "should success with description only",
+			args: args{request: domainNewsletter.UpdateNewsletterRequest{
+				NewsletterID: "120363123456789@newsletter",
+				Description:  &description,
+			}},
+			err: nil,
+		},
+		{
+			name: "should error with empty name",
+			args: args{request: domainNewsletter.UpdateNewsletterRequest{
+				NewsletterID: "120363123456789@newsletter",
+				Name:         &emptyName,
+			}},
+			err: pkgError.ValidationError("name: cannot be blank."),
+		},
+		{
+			name: "should error without any update",
+			args: args{request: domainNewsletter.UpdateNewsletterRequest{
+				NewsletterID: "120363123456789@newsletter",
+			}},
+			err: pkgError.ValidationError("at least one of name, description or picture must be provided"),
+		},
+		{
+			name: "should error with empty newsletter id",
+			args: args{request: domainNewsletter.UpdateNewsletterRequest{
+				Name: &name,
+			}},
+			err: pkgError.ValidationError("newsletter_id: cannot be blank."),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateUpdateNewsletter(context.Background(), tt.args.request)
+			assert.Equal(t, tt.err, err)
+		})
+	}
+}
+
+func TestValidatePostNewsletterImage(t *testing.T) {
+	imageURL := "https://example.com/image.jpg"
+	invalidURL := "not-a-url"
+
+	type args struct {
+		request domainNewsletter.PostImageRequest
+	}
+	tests := []struct {
+		name string
+		args args
+		err  any
+	}{
+		{
+			name: "should success with image url",
+			args: args{request: domainNewsletter.PostImageRequest{
+				NewsletterID: "120363123456789@newsletter",
+				ImageURL:     &imageURL,
+			}},
+			err: nil,
+		},
+		{
+			name: "should error without image",
+			args: args{request: domainNewsletter.PostImageRequest{
+				NewsletterID: "120363123456789@newsletter",
+			}},
+			err: pkgError.ValidationError("either Image or ImageURL must be provided"),
+		},
+		{
+			name: "should error with invalid image url",
+			args: args{request: domainNewsletter.PostImageRequest{
+				NewsletterID: "120363123456789@newsletter",
+				ImageURL:     &invalidURL,
+			}},
+			err: pkgError.ValidationError("ImageURL must be a valid URL"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidatePostNewsletterImage(context.Background(), tt.args.request)
+			assert.Equal(t, tt.err, err)
+		})
+	}
+}
+
+func TestValidateGetNewsletterMessages(t *testing.T) {
+	t.Run("should default count to 20", func(t *testing.T) {
+		request := domainNewsletter.GetMessagesRequest{NewsletterID: "120363123456789@newsletter"}
+		err := ValidateGetNewsletterMessages(context.Background(), &request)
+		assert.NoError(t, err)
+		assert.Equal(t, 20, request.Count)
+	})
+
+	t.Run("should error when count exceeds maximum", func(t *testing.T) {
+		request := domainNewsletter.GetMessagesRequest{NewsletterID: "120363123456789@newsletter", Count: 101}
+		err := ValidateGetNewsletterMessages(context.Background(), &request)
+		assert.Equal(t, pkgError.ValidationError("count: must be no greater than 100."), err)
+	})
+}
@@ -0,0 +1,123 @@
+package validations
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+
+	"github.com/aldinokemal/go-whatsapp-web-multidevice/config"
+	domainStatus "github.com/aldinokemal/go-whatsapp-web-multidevice/domains/status"
+	pkgError "github.com/aldinokemal/go-whatsapp-web-multidevice/pkg/error"
+	"github.com/dustin/go-humanize"
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/go-ozzo/ozzo-validation/v4/is"
+)
+
+var statusColorPattern = regexp.MustCompile(`^#[0-9A-Fa-f]{6}$`)
+
+func validateStatusAudience(ctx context.Context, request *domainStatus.BaseStatusRequest) error {
+	err := validation.ValidateStructWithContext(ctx, request,
+		validation.Field(&request.Audience, validation.In(
+			domainStatus.AudienceContacts,
+			domainStatus.AudienceContactsExcept,
+			domainStatus.AudienceOnlyShareWith,
+		)),
+	)
+	if err != nil {
+		return pkgError.ValidationError(err.Error())
+	}
+	return nil
+}
+
+func ValidatePostTextStatus(ctx context.Context, request domainStatus.TextStatusRequest) error {
+	err := validation.ValidateStructWithContext(ctx, &request,
+		validation.Field(&request.Message, validation.Required),
+		validation.Field(&request.BackgroundColor, validation.Match(statusColorPattern).Error("must be a hex color like #RRGGBB")),
+		validation.Field(&request.Font, validation.Min(0), validation.Max(10)),
+	)
+	if err != nil {
+		return pkgError.ValidationError(err.Error())
+	}
+
+	return validateStatusAudience(ctx, &request.BaseStatusRequest)
+}
+
+func ValidatePostImageStatus(ctx context.Context, request domainStatus.ImageStatusRequest) error {
+	if request.Image == nil && (request.ImageURL == nil || *request.ImageURL == "") {
+		return pkgError.ValidationError("either Image or ImageURL must be provided")
+	}
+
+	if request.Image != nil {
+		availableMimes := map[string]bool{
+			"image/jpeg": true,
+			"image/jpg":  true,
+			"image/png":  true,
+		}
+
+		if !availableMimes[request.Image.Header.Get("Content-Type")] {
+			return pkgError.ValidationError("your image is not allowed. please use jpg/jpeg/png")
+		}
+
+		if request.Image.Size > config.WhatsappSettingMaxImageSize {
+			maxSizeString := humanize.Bytes(uint64(config.WhatsappSettingMaxImageSize))
+			return pkgError.ValidationError(fmt.Sprintf("max image upload is %s", maxSizeString))
+		}
+	}
+
+	if request.ImageURL != nil && *request.ImageURL != "" {
+		if err := validation.Validate(*request.ImageURL, is.URL); err != nil {
+			return pkgError.ValidationError("ImageURL must be a valid URL")
+		}
+	}
+
+	return validateStatusAudience(ctx, &request.BaseStatusRequest)
+}
+
+func ValidatePostVideoStatus(ctx context.Context, request domainStatus.VideoStatusRequest) error {
+	if request.Video == nil && (request.VideoURL == nil || *request.VideoURL == "") {
+		return pkgError.ValidationError("either Video or VideoURL must be provided")
+	}
+
+	if request.Video != nil {
+		availableMimes := map[string]bool{
+			"video/mp4":        true,
+			"video/x-matroska": true,
+			"video/avi":        true,
+			"video/x-msvideo":  true,
+		}
+
+		if !availableMimes[request.Video.Header.Get("Content-Type")] {
+			return pkgError.ValidationError("your video type is not allowed. please use mp4/mkv/avi/x-msvideo")
+		}
+
+		if request.Video.Size > config.WhatsappSettingMaxVideoSize {
+			maxSizeString := humanize.Bytes(uint64(config.WhatsappSettingMaxVideoSize))
+			return pkgError.ValidationError(fmt.Sprintf("max video upload is %s", maxSizeString))
+		}
+	}
+
+	if request.VideoURL != nil && *request.VideoURL != "" {
+		if err := validation.Validate(*request.VideoURL, is.URL); err != nil {
+			return pkgError.ValidationError("VideoURL must be a valid URL")
+		}
+	}
+
+	return validateStatusAudience(ctx, &request.BaseStatusRequest)
+}
+
+func ValidateListStatuses(ctx context.Context, request *domainStatus.ListStatusesRequest) error {
+	// Set default limit if not provided
+	if request.Limit == 0 {
+		request.Limit = 50
+	}
+
+	err := validation.ValidateStructWithContext(ctx, request,
+		validation.Field(&request.Limit, validation.Min(1), validation.Max(100)),
+		validation.Field(&request.Offset, validation.Min(0)),
+	)
+	if err != nil {
+		return pkgError.ValidationError(err.Error())
+	}
+
+	return nil
+}
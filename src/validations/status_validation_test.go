@@ -0,0 +1,112 @@
+package validations
+
+import (
+	"context"
+	"testing"
+
+	domainStatus "github.com/aldinokemal/go-whatsapp-web-multidevice/domains/status"
+	pkgError "github.com/aldinokemal/go-whatsapp-web-multidevice/pkg/error"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidatePostTextStatus(t *testing.T) {
+	type args struct {
+		request domainStatus.TextStatusRequest
+	}
+	tests := []struct {
+		name string
+		args args
+		err  any
+	}{
+		{
+			name: "should success with message only",
+			args: args{request: domainStatus.TextStatusRequest{
+				Message: "Hello status",
+			}},
+			err: nil,
+		},
+		{
+			name: "should success with color, font and audience",
+			args: args{request: domainStatus.TextStatusRequest{
+				BaseStatusRequest: domainStatus.BaseStatusRequest{Audience: domainStatus.AudienceOnlyShareWith},
+				Message:           "Hello status",
+				BackgroundColor:   "#1E90FF",
+				Font:              2,
+			}},
+			err: nil,
+		},
+		{
+			name: "should error with empty message",
+			args: args{request: domainStatus.TextStatusRequest{}},
+			err:  pkgError.ValidationError("message: cannot be blank."),
+		},
+		{
+			name: "should error with invalid background color",
+			args: args{request: domainStatus.TextStatusRequest{
+				Message:         "Hello status",
+				BackgroundColor: "blue",
+			}},
+			err: pkgError.ValidationError("background_color: must be a hex color like #RRGGBB."),
+		},
+		{
+			name: "should error with unknown audience",
+			args: args{request: domainStatus.TextStatusRequest{
+				BaseStatusRequest: domainStatus.BaseStatusRequest{Audience: "everyone"},
+				Message:           "Hello status",
+			}},
+			err: pkgError.ValidationError("audience: must be a valid value."),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidatePostTextStatus(context.Background(), tt.args.request)
+			assert.Equal(t, tt.err, err)
+		})
+	}
+}
+
+func TestValidatePostImageStatus(t *testing.T) {
+	imageURL := "https://example.com/image.jpg"
+	invalidURL := "not a url"
+
+	tests := []struct {
+		name    string
+		request domainStatus.ImageStatusRequest
+		err     any
+	}{
+		{
+			name:    "should success with image url",
+			request: domainStatus.ImageStatusRequest{ImageURL: &imageURL},
+			err:     nil,
+		},
+		{
+			name:    "should error without image",
+			request: domainStatus.ImageStatusRequest{},
+			err:     pkgError.ValidationError("either Image or ImageURL must be provided"),
+		},
+		{
+			name:    "should error with invalid image url",
+			request: domainStatus.ImageStatusRequest{ImageURL: &invalidURL},
+			err:     pkgError.ValidationError("ImageURL must be a valid URL"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidatePostImageStatus(context.Background(), tt.request)
+			assert.Equal(t, tt.err, err)
+		})
+	}
+}
+
+func TestValidateListStatuses(t *testing.T) {
+	request := domainStatus.ListStatusesRequest{}
+	err := ValidateListStatuses(context.Background(), &request)
+	assert.Nil(t, err)
+	assert.Equal(t, 50, request.Limit)
+
+	request = domainStatus.ListStatusesRequest{Limit: 500}
+	err = ValidateListStatuses(context.Background(), &request)
+	assert.Equal(t, pkgError.ValidationError("limit: must be no greater than 100."), err)
+}
@@ -44,3 +44,68 @@ func ValidateBusinessProfile(ctx context.Context, request domainUser.BusinessPro
 
 	return nil
 }
+
+func ValidateBlockContact(ctx context.Context, request domainUser.BlockRequest) error {
+	err := validation.ValidateStructWithContext(ctx, &request,
+		validation.Field(&request.Phone, validation.Required),
+	)
+
+	if err != nil {
+		return pkgError.ValidationError(err.Error())
+	}
+
+	return nil
+}
+
+// privacySettingValues lists the values WhatsApp accepts for each privacy setting
+var privacySettingValues = map[string][]any{
+	domainUser.PrivacySettingLastSeen:     {"all", "contacts", "contact_blacklist", "none"},
+	domainUser.PrivacySettingOnline:       {"all", "match_last_seen"},
+	domainUser.PrivacySettingProfile:      {"all", "contacts", "contact_blacklist", "none"},
+	domainUser.PrivacySettingAbout:        {"all", "contacts", "contact_blacklist", "none"},
+	domainUser.PrivacySettingGroupAdd:     {"all", "contacts", "contact_blacklist", "none"},
+	domainUser.PrivacySettingReadReceipts: {"all", "none"},
+	domainUser.PrivacySettingCallAdd:      {"all", "known"},
+}
+
+func ValidateChangePrivacySetting(ctx context.Context, request domainUser.ChangePrivacySettingRequest) error {
+	settings := make([]any, 0, len(privacySettingValues))
+	for setting := range privacySettingValues {
+		settings = append(settings, setting)
+	}
+
+	err := validation.ValidateStructWithContext(ctx, &request,
+		validation.Field(&request.Setting, validation.Required, validation.In(settings...)),
+		validation.Field(&request.Value, validation.Required, validation.In(privacySettingValues[request.Setting]...)),
+	)
+
+	if err != nil {
+		return pkgError.ValidationError(err.Error())
+	}
+
+	return nil
+}
+
+func ValidateChangeAbout(ctx context.Context, request domainUser.ChangeAboutRequest) error {
+	err := validation.ValidateStructWithContext(ctx, &request,
+		validation.Field(&request.About, validation.Required, validation.RuneLength(1, 139)),
+	)
+
+	if err != nil {
+		return pkgError.ValidationError(err.Error())
+	}
+
+	return nil
+}
+
+func ValidateChangeDisappearingTimer(ctx context.Context, request domainUser.ChangeDisappearingTimerRequest) error {
+	err := validation.ValidateStructWithContext(ctx, &request,
+		validation.Field(&request.Timer, validation.Required, validation.In("off", "24h", "7d", "90d")),
+	)
+
+	if err != nil {
+		return pkgError.ValidationError(err.Error())
+	}
+
+	return nil
+}
@@ -5,6 +5,7 @@ import (
 	domainUser "github.com/aldinokemal/go-whatsapp-web-multidevice/domains/user"
 	pkgError "github.com/aldinokemal/go-whatsapp-web-multidevice/pkg/error"
 	"github.com/stretchr/testify/assert"
+	"strings"
 	"testing"
 )
 
@@ -110,3 +111,138 @@ func TestValidateBusinessProfile(t *testing.T) {
 		})
 	}
 }
+
+func TestValidateBlockContact(t *testing.T) {
+	type args struct {
+		request domainUser.BlockRequest
+	}
+	tests := []struct {
+		name string
+		args args
+		err  any
+	}{
+		{
+			name: "should success",
+			args: args{request: domainUser.BlockRequest{Phone: "6289685028129@s.whatsapp.net"}},
+			err:  nil,
+		},
+		{
+			name: "should error with empty phone",
+			args: args{request: domainUser.BlockRequest{Phone: ""}},
+			err:  pkgError.ValidationError("phone: cannot be blank."),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateBlockContact(context.Background(), tt.args.request)
+			assert.Equal(t, tt.err, err)
+		})
+	}
+}
+
+func TestValidateChangePrivacySetting(t *testing.T) {
+	type args struct {
+		request domainUser.ChangePrivacySettingRequest
+	}
+	tests := []struct {
+		name string
+		args args
+		err  any
+	}{
+		{
+			name: "should success",
+			args: args{request: domainUser.ChangePrivacySettingRequest{Setting: domainUser.PrivacySettingLastSeen, Value: "contacts"}},
+			err:  nil,
+		},
+		{
+			name: "should success with setting specific value",
+			args: args{request: domainUser.ChangePrivacySettingRequest{Setting: domainUser.PrivacySettingOnline, Value: "match_last_seen"}},
+			err:  nil,
+		},
+		{
+			name: "should error with unknown setting",
+			args: args{request: domainUser.ChangePrivacySettingRequest{Setting: "typing", Value: "all"}},
+			err:  pkgError.ValidationError("setting: must be a valid value; value: must be a valid value."),
+		},
+		{
+			name: "should error with value not allowed for the setting",
+			args: args{request: domainUser.ChangePrivacySettingRequest{Setting: domainUser.PrivacySettingReadReceipts, Value: "contacts"}},
+			err:  pkgError.ValidationError("value: must be a valid value."),
+		},
+		{
+			name: "should error with empty value",
+			args: args{request: domainUser.ChangePrivacySettingRequest{Setting: domainUser.PrivacySettingCallAdd}},
+			err:  pkgError.ValidationError("value: cannot be blank."),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateChangePrivacySetting(context.Background(), tt.args.request)
+			assert.Equal(t, tt.err, err)
+		})
+	}
+}
+
+func TestValidateChangeAbout(t *testing.T) {
+	tests := []struct {
+		name    string
+		request domainUser.ChangeAboutRequest
+		err     any
+	}{
+		{
+			name:    "should success",
+			request: domainUser.ChangeAboutRequest{About: "Available 9am - 5pm"},
+			err:     nil,
+		},
+		{
+			name:    "should error with empty about",
+			request: domainUser.ChangeAboutRequest{},
+			err:     pkgError.ValidationError("about: cannot be blank."),
+		},
+		{
+			name:    "should error with about too long",
+			request: domainUser.ChangeAboutRequest{About: strings.Repeat("a", 140)},
+			err:     pkgError.ValidationError("about: the length must be between 1 and 139."),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateChangeAbout(context.Background(), tt.request)
+			assert.Equal(t, tt.err, err)
+		})
+	}
+}
+
+func TestValidateChangeDisappearingTimer(t *testing.T) {
+	tests := []struct {
+		name    string
+		request domainUser.ChangeDisappearingTimerRequest
+		err     any
+	}{
+		{
+			name:    "should success",
+			request: domainUser.ChangeDisappearingTimerRequest{Timer: "7d"},
+			err:     nil,
+		},
+		{
+			name:    "should success turning it off",
+			request: domainUser.ChangeDisappearingTimerRequest{Timer: "off"},
+			err:     nil,
+		},
+		{
+			name:    "should error with unsupported timer",
+			request: domainUser.ChangeDisappearingTimerRequest{Timer: "1h"},
+			err:     pkgError.ValidationError("timer: must be a valid value."),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateChangeDisappearingTimer(context.Background(), tt.request)
+			assert.Equal(t, tt.err, err)
+		})
+	}
+}